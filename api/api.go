@@ -23,21 +23,35 @@ package api
 // API Layer
 
 import (
+	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/nuclio/logger"
 	"github.com/pkg/errors"
 	"github.com/v3io/frames"
+	"github.com/v3io/frames/authz"
 	"github.com/v3io/frames/backends"
 	// Load backends (make sure they register)
 	_ "github.com/v3io/frames/backends/csv"
+	"github.com/v3io/frames/backends/join"
 	_ "github.com/v3io/frames/backends/kv"
+	_ "github.com/v3io/frames/backends/memory"
+	_ "github.com/v3io/frames/backends/parquet"
+	_ "github.com/v3io/frames/backends/plugin"
+	"github.com/v3io/frames/backends/query"
 	_ "github.com/v3io/frames/backends/stream"
 	_ "github.com/v3io/frames/backends/tsdb"
 	"github.com/v3io/frames/backends/utils"
+	"github.com/v3io/frames/metrics"
+	"github.com/v3io/frames/pb"
+	"github.com/v3io/frames/tracing"
+	"github.com/v3io/frames/v3ioutils"
+	v3io "github.com/v3io/v3io-go/pkg/dataplane"
 	v3iohttp "github.com/v3io/v3io-go/pkg/dataplane/http"
 )
 
@@ -45,6 +59,17 @@ const (
 	missingMsg = "missing parameters"
 )
 
+// Operations in metrics
+const (
+	readOperation     = "read"
+	writeOperation    = "write"
+	createOperation   = "create"
+	deleteOperation   = "delete"
+	execOperation     = "exec"
+	tablesOperation   = "tables"
+	describeOperation = "describe"
+)
+
 // API layer, implements common CRUD operations
 // TODO: Call it DAL? (data access layer)
 type API struct {
@@ -52,6 +77,7 @@ type API struct {
 	backends      map[string]frames.DataBackend
 	config        *frames.Config
 	historyServer *utils.HistoryServer
+	policy        *authz.Policy // nil if everything is allowed
 }
 
 // New returns a new API layer struct
@@ -64,10 +90,16 @@ func New(logger logger.Logger, config *frames.Config, historyServer *utils.Histo
 		}
 	}
 
+	policy, err := authz.New(config.Authorization)
+	if err != nil {
+		return nil, errors.Wrap(err, "bad authorization policy")
+	}
+
 	api := &API{
 		logger:        logger,
 		config:        config,
 		historyServer: historyServer,
+		policy:        policy,
 	}
 
 	if err := api.createBackends(config); err != nil {
@@ -79,8 +111,11 @@ func New(logger logger.Logger, config *frames.Config, historyServer *utils.Histo
 	return api, nil
 }
 
-// Read reads from database, emitting results to wf
-func (api *API) Read(request *frames.ReadRequest, out chan frames.Frame) error {
+// Read reads from database, emitting results to out. Reading stops once ctx
+// is done
+func (api *API) Read(ctx context.Context, request *frames.ReadRequest, out chan frames.Frame) (err error) {
+	ctx, done := api.start(ctx, readOperation, request.Proto.Backend, request.Proto.Table)
+	defer done(&err)
 	api.logger.DebugWith("read request", "request", request)
 
 	backend, ok := api.backends[request.Proto.Backend]
@@ -90,15 +125,56 @@ func (api *API) Read(request *frames.ReadRequest, out chan frames.Frame) error {
 		return fmt.Errorf("unknown backend - %q", request.Proto.Backend)
 	}
 
+	if err := api.authorizeRead(request); err != nil {
+		return err
+	}
+
+	inflight := metrics.InflightStreams.WithLabelValues(readOperation)
+	inflight.Inc()
+	defer inflight.Dec()
+	frameCount := metrics.Frames.WithLabelValues(readOperation, request.Proto.Backend)
+	rowCount := metrics.Rows.WithLabelValues(readOperation, request.Proto.Backend)
+
+	read := backends.ReadContext
+	if request.Proto.Query != "" && !backends.SupportsReadField(backend.Capabilities(), "Query") {
+		// Backends that don't run queries read the query columns and filter
+		read = query.Read
+	}
+
 	queryStartTime := time.Now()
-	iter, err := backend.Read(request)
+	backendRequest, tables, err := api.readJoins(ctx, request)
+	if err != nil {
+		api.logger.ErrorWith("can't read join", "error", err)
+		return err
+	}
+
+	iter, err := read(ctx, backend, backendRequest)
 	if err != nil {
 		api.logger.ErrorWith("can't query", "error", err)
 		return errors.Wrap(err, "can't query")
 	}
 
+	for i, table := range tables {
+		iter = join.NewIterator(iter, table, request.Proto.Join[i])
+	}
+
 	for iter.Next() {
-		out <- iter.At()
+		frame := iter.At()
+		if !request.Proto.ExtendedDtypes {
+			if frame, err = frames.LegacyFrame(frame); err != nil {
+				api.logger.ErrorWith("can't convert to legacy dtypes", "error", err)
+				return errors.Wrap(err, "can't convert to legacy dtypes")
+			}
+		}
+
+		select {
+		case out <- frame:
+			frameCount.Inc()
+			rowCount.Add(float64(frame.Len()))
+		case <-ctx.Done():
+			api.logger.WarnWith("read cancelled", "error", ctx.Err(), "table", request.Proto.Table)
+			return errors.Wrap(ctx.Err(), "read cancelled")
+		}
 	}
 
 	queryDuration := time.Since(queryStartTime)
@@ -115,8 +191,14 @@ func (api *API) Read(request *frames.ReadRequest, out chan frames.Frame) error {
 	return nil
 }
 
-// Write write data to backend, returns num_frames, num_rows, error
-func (api *API) Write(request *frames.WriteRequest, in chan frames.Frame) (int, int, error) {
+// Write write data to backend, returns num_frames, num_rows, error. Writing
+// stops once ctx is done
+func (api *API) Write(ctx context.Context, request *frames.WriteRequest, in chan frames.Frame) (nFrames int, nRows int, err error) {
+	// Frames left after an error are drained so the sender won't block
+	defer func() { go drainFrames(in) }()
+	ctx, done := api.start(ctx, writeOperation, request.Backend, request.Table)
+	defer done(&err)
+
 	if request.Backend == "" || request.Table == "" {
 		api.logger.ErrorWith(missingMsg, "request", request)
 		return -1, -1, fmt.Errorf(missingMsg)
@@ -129,20 +211,45 @@ func (api *API) Write(request *frames.WriteRequest, in chan frames.Frame) (int,
 		return -1, -1, fmt.Errorf("unknown backend - %s", request.Backend)
 	}
 
+	authzRequest := authz.Request{Identity: request.Identity, Action: authz.WriteAction, Backend: request.Backend, Table: request.Table}
+	if err := api.authorize(authzRequest, request.Session); err != nil {
+		return -1, -1, err
+	}
+
+	inflight := metrics.InflightStreams.WithLabelValues(writeOperation)
+	inflight.Inc()
+	defer inflight.Dec()
+	frameCount := metrics.Frames.WithLabelValues(writeOperation, request.Backend)
+	rowCount := metrics.Rows.WithLabelValues(writeOperation, request.Backend)
+
 	ingestStartTime := time.Now()
-	appender, err := backend.Write(request)
+	appender, err := backends.WriteContext(ctx, backend, request)
 	if err != nil {
 		msg := "backend Write failed"
 		api.logger.ErrorWith(msg, "error", err)
 		return -1, -1, errors.Wrap(err, msg)
 	}
 	defer appender.Close()
-	nFrames, nRows := 0, 0
 	if request.ImmidiateData != nil {
 		nFrames, nRows = 1, request.ImmidiateData.Len()
+		frameCount.Inc()
+		rowCount.Add(float64(nRows))
 	}
 
-	for frame := range in {
+	for {
+		var frame frames.Frame
+		var ok bool
+		select {
+		case frame, ok = <-in:
+		case <-ctx.Done():
+			api.logger.WarnWith("write cancelled", "error", ctx.Err(), "table", request.Table)
+			return nFrames, nRows, errors.Wrap(ctx.Err(), "write cancelled")
+		}
+
+		if !ok {
+			break
+		}
+
 		api.logger.DebugWith("frame to write", "size", frame.Len())
 		if err := appender.Add(frame); err != nil {
 			msg := "can't add frame"
@@ -155,14 +262,23 @@ func (api *API) Write(request *frames.WriteRequest, in chan frames.Frame) (int,
 
 		nFrames++
 		nRows += frame.Len()
+		frameCount.Inc()
+		rowCount.Add(float64(frame.Len()))
 		api.logger.DebugWith("write", "numFrames", nFrames, "numRows", nRows)
 	}
 
+	// A cancel and the end of input can arrive together, don't complete
+	// cancelled writes
+	if err := ctx.Err(); err != nil {
+		api.logger.WarnWith("write cancelled", "error", err, "table", request.Table)
+		return nFrames, nRows, errors.Wrap(err, "write cancelled")
+	}
+
 	api.logger.Debug("write done")
 
 	// TODO: Specify timeout in request?
 	if nRows > 0 {
-		if err := appender.WaitForComplete(time.Duration(api.config.DefaultTimeout) * time.Second); err != nil {
+		if err := appender.WaitForComplete(api.completionTimeout()); err != nil {
 			msg := "can't wait for completion"
 			api.logger.ErrorWith(msg, "error", err)
 			return nFrames, nRows, errors.Wrap(err, msg)
@@ -180,7 +296,9 @@ func (api *API) Write(request *frames.WriteRequest, in chan frames.Frame) (int,
 }
 
 // Create will create a new table
-func (api *API) Create(request *frames.CreateRequest) error {
+func (api *API) Create(ctx context.Context, request *frames.CreateRequest) (err error) {
+	_, done := api.start(ctx, createOperation, request.Proto.Backend, request.Proto.Table)
+	defer done(&err)
 	if request.Proto.Backend == "" || request.Proto.Table == "" {
 		api.logger.ErrorWith(missingMsg, "request", request)
 		return fmt.Errorf(missingMsg)
@@ -193,6 +311,11 @@ func (api *API) Create(request *frames.CreateRequest) error {
 		return fmt.Errorf("unknown backend - %s", request.Proto.Backend)
 	}
 
+	authzRequest := authz.Request{Identity: request.Identity, Action: authz.CreateAction, Backend: request.Proto.Backend, Table: request.Proto.Table}
+	if err := api.authorize(authzRequest, request.Proto.Session); err != nil {
+		return err
+	}
+
 	createStartTime := time.Now()
 	if err := backend.Create(request); err != nil {
 		api.logger.ErrorWith("error creating table", "error", err, "request", request)
@@ -208,7 +331,9 @@ func (api *API) Create(request *frames.CreateRequest) error {
 }
 
 // Delete deletes a table or part of it
-func (api *API) Delete(request *frames.DeleteRequest) error {
+func (api *API) Delete(ctx context.Context, request *frames.DeleteRequest) (err error) {
+	_, done := api.start(ctx, deleteOperation, request.Proto.Backend, request.Proto.Table)
+	defer done(&err)
 	if request.Proto.Backend == "" || request.Proto.Table == "" {
 		api.logger.ErrorWith(missingMsg, "request", request)
 		return fmt.Errorf(missingMsg)
@@ -221,6 +346,11 @@ func (api *API) Delete(request *frames.DeleteRequest) error {
 		return fmt.Errorf("unknown backend - %s", request.Proto.Backend)
 	}
 
+	authzRequest := authz.Request{Identity: request.Identity, Action: authz.DeleteAction, Backend: request.Proto.Backend, Table: request.Proto.Table}
+	if err := api.authorize(authzRequest, request.Proto.Session); err != nil {
+		return err
+	}
+
 	deleteStartTime := time.Now()
 
 	if err := backend.Delete(request); err != nil {
@@ -236,8 +366,11 @@ func (api *API) Delete(request *frames.DeleteRequest) error {
 	return nil
 }
 
-// Exec executes a command on the backend
-func (api *API) Exec(request *frames.ExecRequest) (frames.Frame, error) {
+// Exec executes a command on the backend, the command is cancelled once ctx
+// is done
+func (api *API) Exec(ctx context.Context, request *frames.ExecRequest) (_ frames.Frame, err error) {
+	ctx, done := api.start(ctx, execOperation, request.Proto.Backend, request.Proto.Table)
+	defer done(&err)
 	if request.Proto.Backend == "" || request.Proto.Table == "" {
 		api.logger.ErrorWith(missingMsg, "request", request)
 		return nil, fmt.Errorf(missingMsg)
@@ -251,8 +384,22 @@ func (api *API) Exec(request *frames.ExecRequest) (frames.Frame, error) {
 		return nil, fmt.Errorf("unknown backend - %s", request.Proto.Backend)
 	}
 
+	authzRequest := authz.Request{
+		Identity: request.Identity,
+		Action:   authz.ExecAction,
+		Backend:  request.Proto.Backend,
+		Table:    request.Proto.Table,
+		Command:  request.Proto.Command,
+	}
+	if err := api.authorize(authzRequest, request.Proto.Session); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := api.withTimeout(ctx)
+	defer cancel()
+
 	executeStartTime := time.Now()
-	frame, err := backend.Exec(request)
+	frame, err := backends.ExecContext(ctx, backend, request)
 	if err != nil {
 		api.logger.ErrorWith("error in exec", "error", err, "request", request)
 		return nil, errors.Wrap(err, "can't exec")
@@ -265,6 +412,100 @@ func (api *API) Exec(request *frames.ExecRequest) (frames.Frame, error) {
 	return frame, nil
 }
 
+// ListTables lists the tables in a directory of the backend
+func (api *API) ListTables(ctx context.Context, request *frames.ListTablesRequest) (_ []string, err error) {
+	_, done := api.start(ctx, tablesOperation, request.Proto.Backend, "")
+	defer done(&err)
+	if request.Proto.Backend == "" {
+		api.logger.ErrorWith(missingMsg, "request", request)
+		return nil, fmt.Errorf(missingMsg)
+	}
+
+	catalog, err := api.catalogBackend(request.Proto.Backend)
+	if err != nil {
+		return nil, err
+	}
+
+	tables, err := catalog.ListTables(request)
+	if err != nil {
+		api.logger.ErrorWith("error listing tables", "error", err, "request", request)
+		return nil, errors.Wrap(err, "can't list tables")
+	}
+
+	return api.readableTables(request, tables), nil
+}
+
+// DescribeTable returns the schema of a table
+func (api *API) DescribeTable(ctx context.Context, request *frames.DescribeTableRequest) (_ *pb.DescribeTableResponse, err error) {
+	_, done := api.start(ctx, describeOperation, request.Proto.Backend, request.Proto.Table)
+	defer done(&err)
+	if request.Proto.Backend == "" || request.Proto.Table == "" {
+		api.logger.ErrorWith(missingMsg, "request", request)
+		return nil, fmt.Errorf(missingMsg)
+	}
+
+	catalog, err := api.catalogBackend(request.Proto.Backend)
+	if err != nil {
+		return nil, err
+	}
+
+	authzRequest := authz.Request{Identity: request.Identity, Action: authz.ReadAction, Backend: request.Proto.Backend, Table: request.Proto.Table}
+	if err := api.authorize(authzRequest, request.Proto.Session); err != nil {
+		return nil, err
+	}
+
+	description, err := catalog.DescribeTable(request)
+	if err != nil {
+		api.logger.ErrorWith("error describing table", "error", err, "request", request)
+		return nil, errors.Wrap(err, "can't describe table")
+	}
+
+	return description, nil
+}
+
+// Capabilities returns the capabilities of a backend, or of all backends
+// (sorted by name) if request.Backend is empty
+func (api *API) Capabilities(request *pb.CapabilitiesRequest) ([]*frames.Capabilities, error) {
+	names := []string{request.Backend}
+	if request.Backend == "" {
+		names = make([]string, 0, len(api.backends))
+		for name := range api.backends {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	caps := make([]*frames.Capabilities, len(names))
+	for i, name := range names {
+		backend, ok := api.backends[name]
+		if !ok {
+			api.logger.ErrorWith("unknown backend", "name", name)
+			return nil, fmt.Errorf("unknown backend - %s", name)
+		}
+
+		// Copy since backends share their capabilities between instances
+		caps[i] = proto.Clone(backend.Capabilities()).(*frames.Capabilities)
+		caps[i].Name = name
+	}
+
+	return caps, nil
+}
+
+func (api *API) catalogBackend(name string) (frames.CatalogBackend, error) {
+	backend, ok := api.backends[name]
+	if !ok {
+		api.logger.ErrorWith("unknown backend", "name", name)
+		return nil, fmt.Errorf("unknown backend - %s", name)
+	}
+
+	catalog, ok := backend.(frames.CatalogBackend)
+	if !ok {
+		return nil, fmt.Errorf("backend %s doesn't support listing and describing tables", name)
+	}
+
+	return catalog, nil
+}
+
 func (api *API) History(request *frames.HistoryRequest, out chan frames.Frame) error {
 	if api.historyServer == nil {
 		return errors.New("history server was not initialized properly. To enable this feature, please contact the system administrator")
@@ -272,29 +513,230 @@ func (api *API) History(request *frames.HistoryRequest, out chan frames.Frame) e
 	return api.historyServer.GetLogs(request, out)
 }
 
+// AuthorizeRead returns an error if the authorization policy denies request.
+// It's called by Read, servers call it to fail before they start replying
+func (api *API) AuthorizeRead(request *frames.ReadRequest) (err error) {
+	defer func(start time.Time) {
+		// Read isn't called for denied requests
+		if err != nil {
+			api.observe(readOperation, request.Proto.Backend, start, &err)
+		}
+	}(time.Now())
+
+	return api.authorizeRead(request)
+}
+
+func (api *API) authorizeRead(request *frames.ReadRequest) error {
+	// Queries read the table in FROM
+	table := request.Proto.Table
+	if request.Proto.Query != "" && api.policy != nil {
+		var err error
+		if _, table, err = frames.ParseSelect(request.Proto.Query); err != nil {
+			return errors.Wrap(err, "bad query")
+		}
+	}
+
+	authzRequest := authz.Request{Identity: request.Identity, Action: authz.ReadAction, Backend: request.Proto.Backend, Table: table}
+	return api.authorize(authzRequest, request.Proto.Session)
+}
+
+// readJoins reads the right sides of the joins in request, they're joined in
+// order with the result of the returned request which has no joins
+func (api *API) readJoins(ctx context.Context, request *frames.ReadRequest) (*frames.ReadRequest, []*join.Table, error) {
+	if len(request.Proto.Join) == 0 {
+		return request, nil, nil
+	}
+
+	var tables []*join.Table
+	for _, spec := range request.Proto.Join {
+		if err := join.Validate(spec); err != nil {
+			return nil, nil, err
+		}
+
+		rightRequest := join.Request(request, spec)
+		rightBackend := rightRequest.Proto.Backend
+		backend, ok := api.backends[rightBackend]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown join backend - %q", rightBackend)
+		}
+
+		authzRequest := authz.Request{Identity: request.Identity, Action: authz.ReadAction, Backend: rightBackend, Table: spec.Table}
+		if err := api.authorize(authzRequest, rightRequest.Proto.Session); err != nil {
+			return nil, nil, err
+		}
+
+		it, err := backends.ReadContext(ctx, backend, rightRequest)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "can't read join table %q", spec.Table)
+		}
+
+		table, err := join.Load(spec.Table, it, join.RightKeys(spec), api.config.JoinMaxBytes)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "can't read join table %q", spec.Table)
+		}
+		tables = append(tables, table)
+	}
+
+	msg := proto.Clone(request.Proto).(*pb.ReadRequest)
+	msg.Join = nil
+	backendRequest := *request
+	backendRequest.Proto = msg
+	return &backendRequest, tables, nil
+}
+
+// authorize checks request with the authorization policy, denials are logged
+// to history
+func (api *API) authorize(request authz.Request, session *frames.Session) error {
+	if api.policy == nil {
+		return nil
+	}
+
+	request.Container = api.container(session)
+	err := api.policy.Authorize(request)
+	if denied, ok := err.(*authz.DeniedError); ok {
+		api.logger.WarnWith("request denied", "error", err.Error())
+		if api.historyServer != nil {
+			api.historyServer.AddDeniedLog(denied, session)
+		}
+	}
+
+	return err
+}
+
+// start starts the span of an operation, the returned function ends it with
+// the error of the operation and records the request metrics
+func (api *API) start(ctx context.Context, operation, backend, table string) (context.Context, func(*error)) {
+	start := time.Now()
+	ctx, span := tracing.Start(ctx, "api."+operation, tracing.BackendKey.String(backend), tracing.TableKey.String(table))
+	done := func(err *error) {
+		tracing.End(span, *err)
+		api.observe(operation, backend, start, err)
+	}
+
+	return ctx, done
+}
+
+// observe records the metrics of a request that returned *err
+func (api *API) observe(operation, backend string, start time.Time, err *error) {
+	// Unknown backends are grouped together to limit the number of series
+	if _, ok := api.backends[backend]; !ok {
+		backend = ""
+	}
+
+	status := metrics.StatusOK
+	switch {
+	case authz.IsDenied(*err):
+		status = metrics.StatusDenied
+	case *err != nil:
+		status = metrics.StatusError
+	}
+
+	metrics.ObserveRequest(operation, backend, status, start)
+}
+
+// readableTables returns the tables the authorization policy allows to read
+func (api *API) readableTables(request *frames.ListTablesRequest, tables []string) []string {
+	if api.policy == nil {
+		return tables
+	}
+
+	authzRequest := authz.Request{
+		Identity:  request.Identity,
+		Action:    authz.ReadAction,
+		Backend:   request.Proto.Backend,
+		Container: api.container(request.Proto.Session),
+	}
+
+	var readable []string
+	for _, table := range tables {
+		authzRequest.Table = table
+		if api.policy.Authorize(authzRequest) == nil {
+			readable = append(readable, table)
+		}
+	}
+
+	return readable
+}
+
+// container returns the session container, backends use the configured
+// container if it's empty
+func (api *API) container(session *frames.Session) string {
+	if session != nil && session.Container != "" {
+		return session.Container
+	}
+
+	return api.config.Container
+}
+
+// completionTimeout returns the time writes wait for completion, 0 (no limit)
+// if the default timeout is negative
+func (api *API) completionTimeout() time.Duration {
+	if api.config.DefaultTimeout <= 0 {
+		return 0
+	}
+
+	return time.Duration(api.config.DefaultTimeout) * time.Second
+}
+
+// withTimeout returns ctx with the configured default timeout as deadline.
+// Read and write streams (e.g. long exports) have no deadline
+func (api *API) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if api.config.DefaultTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	return context.WithTimeout(ctx, time.Duration(api.config.DefaultTimeout)*time.Second)
+}
+
+// drainFrames reads the frames left in ch
+func drainFrames(ch chan frames.Frame) {
+	for range ch {
+	}
+}
+
 func (api *API) createBackends(config *frames.Config) error {
 	api.backends = make(map[string]frames.DataBackend)
+	fakeContexts := make(map[string]v3io.Context)
 
 	for _, backendConfig := range config.Backends {
-		newClient := v3iohttp.NewClient(&v3iohttp.NewClientInput{DialTimeout: time.Duration(backendConfig.DialTimeoutSeconds) * time.Second, MaxConnsPerHost: math.MaxInt64})
-
-		api.logger.InfoWith("Creating v3io context for backend",
-			"backend", backendConfig.Name,
-			"workers", backendConfig.V3ioGoWorkers,
-			"requestChanLength", backendConfig.V3ioGoRequestChanLength,
-			"maxConns", backendConfig.MaxConnections)
-
-		newContextInput := &v3iohttp.NewContextInput{
-			HTTPClient:     newClient,
-			NumWorkers:     backendConfig.V3ioGoWorkers,
-			RequestChanLen: backendConfig.V3ioGoRequestChanLength,
-			MaxConns:       backendConfig.MaxConnections,
-		}
-		// create a context for the backend
-		v3ioContext, err := v3iohttp.NewContext(api.logger, newContextInput)
+		var v3ioContext v3io.Context
+		var err error
 
-		if err != nil {
-			return errors.Wrap(err, "Failed to create v3io context for backend")
+		if backendConfig.FakeV3ioRoot != "" {
+			// Backends with the same fake root share storage
+			v3ioContext = fakeContexts[backendConfig.FakeV3ioRoot]
+			if v3ioContext == nil {
+				api.logger.InfoWith("Creating fake v3io context for backend",
+					"backend", backendConfig.Name,
+					"root", backendConfig.FakeV3ioRoot)
+
+				v3ioContext, err = v3ioutils.NewFakeContext(backendConfig.FakeV3ioRoot)
+				if err != nil {
+					return errors.Wrap(err, "Failed to create fake v3io context for backend")
+				}
+				fakeContexts[backendConfig.FakeV3ioRoot] = v3ioContext
+			}
+		} else {
+			newClient := v3iohttp.NewClient(&v3iohttp.NewClientInput{DialTimeout: time.Duration(backendConfig.DialTimeoutSeconds) * time.Second, MaxConnsPerHost: math.MaxInt64})
+
+			api.logger.InfoWith("Creating v3io context for backend",
+				"backend", backendConfig.Name,
+				"workers", backendConfig.V3ioGoWorkers,
+				"requestChanLength", backendConfig.V3ioGoRequestChanLength,
+				"maxConns", backendConfig.MaxConnections)
+
+			newContextInput := &v3iohttp.NewContextInput{
+				HTTPClient:     newClient,
+				NumWorkers:     backendConfig.V3ioGoWorkers,
+				RequestChanLen: backendConfig.V3ioGoRequestChanLength,
+				MaxConns:       backendConfig.MaxConnections,
+			}
+			// create a context for the backend
+			v3ioContext, err = v3iohttp.NewContext(api.logger, newContextInput)
+
+			if err != nil {
+				return errors.Wrap(err, "Failed to create v3io context for backend")
+			}
 		}
 
 		factory := backends.GetFactory(backendConfig.Type)
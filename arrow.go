@@ -0,0 +1,597 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+// Conversion of frames to Apache Arrow record batches and IPC streams
+
+package frames
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/apache/arrow/go/arrow"
+	"github.com/apache/arrow/go/arrow/array"
+	"github.com/apache/arrow/go/arrow/decimal128"
+	"github.com/apache/arrow/go/arrow/ipc"
+	"github.com/apache/arrow/go/arrow/memory"
+	"github.com/pkg/errors"
+	"github.com/v3io/frames/pb"
+)
+
+// Arrow metadata keys
+const (
+	arrowIndexKey  = "frames.index"  // Field metadata of index columns
+	arrowLabelsKey = "frames.labels" // Schema metadata, labels as JSON object
+	arrowErrorKey  = "frames.error"  // Schema metadata of error streams
+	arrowListKey   = "frames.list"   // Field metadata of list columns, sent as JSON strings
+)
+
+// Decimals are int64 values, which have up to 19 digits
+const arrowDecimalPrecision = 19
+
+var arrowAllocator = memory.NewGoAllocator()
+
+// FrameToArrow converts a frame to an Arrow record. Index columns come after
+// the frame columns and have "frames.index" in their field metadata. The
+// caller should Release the record
+func FrameToArrow(frame Frame) (array.Record, error) {
+	var (
+		fields []arrow.Field
+		arrays []array.Interface
+	)
+
+	defer func() {
+		for _, arr := range arrays {
+			arr.Release()
+		}
+	}()
+
+	for _, name := range frame.Names() {
+		col, err := frame.Column(name)
+		if err != nil {
+			return nil, err
+		}
+
+		isNull := func(i int) bool { return frame.IsNull(i, name) }
+		arr, err := arrowArray(col, isNull)
+		if err != nil {
+			return nil, err
+		}
+
+		arrays = append(arrays, arr)
+		fields = append(fields, arrow.Field{Name: name, Type: arr.DataType(), Nullable: true, Metadata: arrowMetadata(col, false)})
+	}
+
+	for _, col := range frame.Indices() {
+		arr, err := arrowArray(col, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		arrays = append(arrays, arr)
+		fields = append(fields, arrow.Field{Name: col.Name(), Type: arr.DataType(), Metadata: arrowMetadata(col, true)})
+	}
+
+	var metadata arrow.Metadata
+	if labels := frame.Labels(); len(labels) > 0 {
+		values, err := pb.FromGoMap(labels)
+		if err != nil {
+			return nil, errors.Wrap(err, "bad labels")
+		}
+
+		data, err := json.Marshal(values)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't encode labels")
+		}
+		metadata = arrow.NewMetadata([]string{arrowLabelsKey}, []string{string(data)})
+	}
+
+	schema := arrow.NewSchema(fields, &metadata)
+	return array.NewRecord(schema, arrays, int64(frame.Len())), nil
+}
+
+// arrowMetadata returns the field metadata of col
+func arrowMetadata(col Column, isIndex bool) arrow.Metadata {
+	var keys []string
+	if isIndex {
+		keys = append(keys, arrowIndexKey)
+	}
+
+	if col.DType() == ListType {
+		keys = append(keys, arrowListKey)
+	}
+
+	if len(keys) == 0 {
+		return arrow.Metadata{}
+	}
+
+	values := make([]string, len(keys))
+	for i := range values {
+		values[i] = "true"
+	}
+	return arrow.NewMetadata(keys, values)
+}
+
+// arrowArray converts a column to an Arrow array, isNull can be nil for
+// columns without nulls
+func arrowArray(col Column, isNull func(int) bool) (array.Interface, error) {
+	var valid []bool
+	if isNull != nil {
+		for i := 0; i < col.Len(); i++ {
+			if !isNull(i) {
+				continue
+			}
+
+			if valid == nil {
+				valid = make([]bool, col.Len())
+				for j := range valid {
+					valid[j] = true
+				}
+			}
+			valid[i] = false
+		}
+	}
+
+	switch col.DType() {
+	case IntType:
+		data, err := col.Ints()
+		if err != nil {
+			return nil, err
+		}
+
+		builder := array.NewInt64Builder(arrowAllocator)
+		defer builder.Release()
+		builder.AppendValues(data, valid)
+		return builder.NewArray(), nil
+	case FloatType:
+		data, err := col.Floats()
+		if err != nil {
+			return nil, err
+		}
+
+		builder := array.NewFloat64Builder(arrowAllocator)
+		defer builder.Release()
+		builder.AppendValues(data, valid)
+		return builder.NewArray(), nil
+	case StringType:
+		builder := array.NewStringBuilder(arrowAllocator)
+		defer builder.Release()
+		builder.AppendValues(col.Strings(), valid)
+		return builder.NewArray(), nil
+	case TimeType:
+		times, err := col.Times()
+		if err != nil {
+			return nil, err
+		}
+
+		data := make([]arrow.Timestamp, len(times))
+		for i, t := range times {
+			data[i] = arrow.Timestamp(t.UnixNano())
+		}
+
+		dtype := arrow.FixedWidthTypes.Timestamp_ns.(*arrow.TimestampType)
+		builder := array.NewTimestampBuilder(arrowAllocator, dtype)
+		defer builder.Release()
+		builder.AppendValues(data, valid)
+		return builder.NewArray(), nil
+	case BoolType:
+		data, err := col.Bools()
+		if err != nil {
+			return nil, err
+		}
+
+		builder := array.NewBooleanBuilder(arrowAllocator)
+		defer builder.Release()
+		builder.AppendValues(data, valid)
+		return builder.NewArray(), nil
+	case Int32Type:
+		data, err := col.Int32s()
+		if err != nil {
+			return nil, err
+		}
+
+		builder := array.NewInt32Builder(arrowAllocator)
+		defer builder.Release()
+		builder.AppendValues(data, valid)
+		return builder.NewArray(), nil
+	case Float32Type:
+		data, err := col.Float32s()
+		if err != nil {
+			return nil, err
+		}
+
+		builder := array.NewFloat32Builder(arrowAllocator)
+		defer builder.Release()
+		builder.AppendValues(data, valid)
+		return builder.NewArray(), nil
+	case BytesType:
+		data, err := col.Bytes()
+		if err != nil {
+			return nil, err
+		}
+
+		builder := array.NewBinaryBuilder(arrowAllocator, arrow.BinaryTypes.Binary)
+		defer builder.Release()
+		builder.AppendValues(data, valid)
+		return builder.NewArray(), nil
+	case DecimalType:
+		decimals, err := col.Decimals()
+		if err != nil {
+			return nil, err
+		}
+
+		dtype := &arrow.Decimal128Type{Precision: arrowDecimalPrecision}
+		data := make([]decimal128.Num, len(decimals))
+		for i, d := range decimals {
+			data[i] = decimal128.FromI64(d.Value)
+			dtype.Scale = d.Scale
+		}
+
+		builder := array.NewDecimal128Builder(arrowAllocator, dtype)
+		defer builder.Release()
+		builder.AppendValues(data, valid)
+		return builder.NewArray(), nil
+	case ListType:
+		// Lists are sent as JSON, see arrowListKey
+		builder := array.NewStringBuilder(arrowAllocator)
+		defer builder.Release()
+		builder.AppendValues(col.Strings(), valid)
+		return builder.NewArray(), nil
+	}
+
+	return nil, fmt.Errorf("%s: unsupported type for arrow - %s", col.Name(), pb.DType(col.DType()))
+}
+
+// ArrowToFrame converts an Arrow record to a frame. Fields with "frames.index"
+// metadata are index columns
+func ArrowToFrame(record array.Record) (Frame, error) {
+	var (
+		columns     []Column
+		indices     []Column
+		nullValues  []*pb.NullValuesMap
+		numRows     = int(record.NumRows())
+		schema      = record.Schema()
+		hasAnyNulls bool
+	)
+
+	for i, field := range schema.Fields() {
+		arr := record.Column(i)
+		col, err := arrowColumn(field, arr)
+		if err != nil {
+			return nil, err
+		}
+
+		if field.Metadata.FindKey(arrowIndexKey) != -1 {
+			indices = append(indices, col)
+			continue
+		}
+
+		columns = append(columns, col)
+		if arr.NullN() == 0 {
+			continue
+		}
+
+		if !hasAnyNulls {
+			nullValues = make([]*pb.NullValuesMap, numRows)
+			for r := range nullValues {
+				nullValues[r] = &pb.NullValuesMap{NullColumns: make(map[string]bool)}
+			}
+			hasAnyNulls = true
+		}
+
+		for r := 0; r < numRows; r++ {
+			if arr.IsNull(r) {
+				nullValues[r].NullColumns[field.Name] = true
+			}
+		}
+	}
+
+	var labels map[string]interface{}
+	metadata := schema.Metadata()
+	if i := metadata.FindKey(arrowLabelsKey); i != -1 {
+		var values map[string]*pb.Value
+		if err := json.Unmarshal([]byte(metadata.Values()[i]), &values); err != nil {
+			return nil, errors.Wrap(err, "bad labels")
+		}
+		labels = pb.AsGoMap(values)
+	}
+
+	return NewFrameWithNullValues(columns, indices, labels, nullValues)
+}
+
+// arrowColumn converts an Arrow array to a column, values are copied
+func arrowColumn(field arrow.Field, arr array.Interface) (Column, error) {
+	name := field.Name
+	if field.Metadata.FindKey(arrowListKey) != -1 {
+		return arrowListColumn(name, arr)
+	}
+
+	var data interface{}
+	switch arr := arr.(type) {
+	case *array.Int64:
+		values := make([]int64, arr.Len())
+		copy(values, arr.Int64Values())
+		data = values
+	case *array.Int32:
+		values := make([]int32, arr.Len())
+		copy(values, arr.Int32Values())
+		data = values
+	case *array.Float64:
+		values := make([]float64, arr.Len())
+		copy(values, arr.Float64Values())
+		data = values
+	case *array.Float32:
+		values := make([]float32, arr.Len())
+		copy(values, arr.Float32Values())
+		data = values
+	case *array.Binary:
+		values := make([][]byte, arr.Len())
+		for i := range values {
+			values[i] = append([]byte{}, arr.Value(i)...)
+		}
+		data = values
+	case *array.Decimal128:
+		scale := arr.DataType().(*arrow.Decimal128Type).Scale
+		if scale < 0 || scale > MaxDecimalScale {
+			return nil, fmt.Errorf("%s: decimal scale %d out of range [0:%d]", name, scale, MaxDecimalScale)
+		}
+
+		values := make([]Decimal, arr.Len())
+		for i, v := range arr.Values() {
+			if arr.IsNull(i) {
+				continue
+			}
+
+			// Values must fit in int64
+			low := int64(v.LowBits())
+			if v.HighBits() != low>>63 {
+				return nil, fmt.Errorf("%s: decimal at %d doesn't fit in 64 bits", name, i)
+			}
+			values[i] = Decimal{Value: low, Scale: scale}
+		}
+
+		if len(values) == 0 {
+			// Keep the scale in empty columns
+			col, err := NewSliceColumn(name, values)
+			if err != nil {
+				return nil, err
+			}
+			col.(*colImpl).msg.Scale = scale
+			return col, nil
+		}
+		data = values
+	case *array.String:
+		values := make([]string, arr.Len())
+		for i := range values {
+			values[i] = arr.Value(i)
+		}
+		data = values
+	case *array.Boolean:
+		values := make([]bool, arr.Len())
+		for i := range values {
+			values[i] = arr.Value(i)
+		}
+		data = values
+	case *array.Timestamp:
+		unit := arr.DataType().(*arrow.TimestampType).Unit
+		values := make([]time.Time, arr.Len())
+		for i, v := range arr.TimestampValues() {
+			if arr.IsNull(i) {
+				values[i] = time.Unix(0, 0)
+				continue
+			}
+			values[i] = time.Unix(0, int64(v)*int64(arrowUnitDuration(unit)))
+		}
+		data = values
+	default:
+		return nil, fmt.Errorf("%s: unsupported arrow type - %s", name, arr.DataType())
+	}
+
+	return NewSliceColumn(name, data)
+}
+
+// arrowListColumn converts an Arrow array of JSON strings to a list column
+func arrowListColumn(name string, arr array.Interface) (Column, error) {
+	values, ok := arr.(*array.String)
+	if !ok {
+		return nil, fmt.Errorf("%s: list column is %s, not a string", name, arr.DataType())
+	}
+
+	col := &colImpl{msg: &pb.Column{Kind: pb.Column_SLICE, Name: name, Dtype: pb.DType_LIST}}
+	for i := 0; i < values.Len(); i++ {
+		var list interface{} // nil for nulls
+		if values.IsValid(i) {
+			var err error
+			if list, err = DecodeJSONList(values.Value(i)); err != nil {
+				return nil, errors.Wrapf(err, "%s: bad list at %d", name, i)
+			}
+		}
+
+		if err := col.appendSlice(list); err != nil {
+			return nil, errors.Wrapf(err, "%s: bad list at %d", name, i)
+		}
+	}
+	return col, nil
+}
+
+func arrowUnitDuration(unit arrow.TimeUnit) time.Duration {
+	switch unit {
+	case arrow.Second:
+		return time.Second
+	case arrow.Millisecond:
+		return time.Millisecond
+	case arrow.Microsecond:
+		return time.Microsecond
+	}
+	return time.Nanosecond
+}
+
+// ArrowEncoder writes frames as Arrow IPC streams. Frames with the same schema
+// are record batches in one stream, a new stream starts when the schema changes
+type ArrowEncoder struct {
+	w      io.Writer
+	writer *ipc.Writer
+	schema *arrow.Schema
+}
+
+// NewArrowEncoder returns a new ArrowEncoder
+func NewArrowEncoder(w io.Writer) *ArrowEncoder {
+	return &ArrowEncoder{w: w}
+}
+
+// Encode writes a frame as a record batch
+func (e *ArrowEncoder) Encode(frame Frame) error {
+	record, err := FrameToArrow(frame)
+	if err != nil {
+		return err
+	}
+	defer record.Release()
+
+	if e.writer != nil && !sameArrowSchema(e.schema, record.Schema()) {
+		if err := e.Close(); err != nil {
+			return err
+		}
+	}
+
+	if e.writer == nil {
+		e.schema = record.Schema()
+		e.writer = ipc.NewWriter(e.w, ipc.WithSchema(e.schema), ipc.WithAllocator(arrowAllocator))
+	}
+
+	if err := e.writer.Write(record); err != nil {
+		return errors.Wrap(err, "can't write arrow record")
+	}
+
+	return nil
+}
+
+// EncodeError writes an error as a stream with no records, the decoder
+// returns it as an error
+func (e *ArrowEncoder) EncodeError(err error) error {
+	if err := e.Close(); err != nil {
+		return err
+	}
+
+	metadata := arrow.NewMetadata([]string{arrowErrorKey}, []string{err.Error()})
+	writer := ipc.NewWriter(e.w, ipc.WithSchema(arrow.NewSchema(nil, &metadata)))
+	return writer.Close()
+}
+
+// Close ends the current stream, it doesn't close the underlying writer
+func (e *ArrowEncoder) Close() error {
+	if e.writer == nil {
+		return nil
+	}
+
+	err := e.writer.Close()
+	e.writer, e.schema = nil, nil
+	if err != nil {
+		return errors.Wrap(err, "can't close arrow stream")
+	}
+
+	return nil
+}
+
+// sameArrowSchema compares schemas including metadata (where labels are kept)
+func sameArrowSchema(s1, s2 *arrow.Schema) bool {
+	if !s1.Equal(s2) {
+		return false
+	}
+
+	m1, m2 := s1.Metadata(), s2.Metadata()
+	return reflect.DeepEqual(m1.Keys(), m2.Keys()) && reflect.DeepEqual(m1.Values(), m2.Values())
+}
+
+// ArrowDecoder reads frames from Arrow IPC streams written by ArrowEncoder
+type ArrowDecoder struct {
+	r      *bufio.Reader
+	reader *ipc.Reader
+}
+
+// NewArrowDecoder returns a new ArrowDecoder
+func NewArrowDecoder(r io.Reader) *ArrowDecoder {
+	return &ArrowDecoder{r: bufio.NewReader(r)}
+}
+
+// Decode returns the next frame, or io.EOF at the end of the input
+func (d *ArrowDecoder) Decode() (Frame, error) {
+	for {
+		if d.reader == nil {
+			if _, err := d.r.Peek(1); err != nil {
+				return nil, err
+			}
+
+			reader, err := ipc.NewReader(d.r, ipc.WithAllocator(arrowAllocator))
+			if err != nil {
+				return nil, errors.Wrap(err, "can't read arrow stream")
+			}
+
+			metadata := reader.Schema().Metadata()
+			if i := metadata.FindKey(arrowErrorKey); i != -1 {
+				for reader.Next() { // Consume the end of stream marker
+				}
+				reader.Release()
+				return nil, errors.New(metadata.Values()[i])
+			}
+			d.reader = reader
+		}
+
+		if d.reader.Next() {
+			return ArrowToFrame(d.reader.Record())
+		}
+
+		err := d.reader.Err()
+		d.reader.Release()
+		d.reader = nil
+		if err != nil {
+			return nil, errors.Wrap(err, "can't read arrow record")
+		}
+	}
+}
+
+// MarshalArrow serializes a frame to an Arrow IPC stream with one record batch
+func MarshalArrow(frame Frame) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := NewArrowEncoder(&buf)
+	if err := enc.Encode(frame); err != nil {
+		return nil, err
+	}
+
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UnmarshalArrow de-serializes a frame from an Arrow IPC stream
+func UnmarshalArrow(data []byte) (Frame, error) {
+	frame, err := NewArrowDecoder(bytes.NewReader(data)).Decode()
+	if err == io.EOF {
+		return nil, fmt.Errorf("no record in arrow stream")
+	}
+
+	return frame, err
+}
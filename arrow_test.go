@@ -0,0 +1,298 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package frames
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/v3io/frames/pb"
+)
+
+func newArrowTestFrame(t *testing.T, size int) Frame {
+	var (
+		ints   []int64
+		floats []float64
+		strs   []string
+		times  []time.Time
+		bools  []bool
+		nulls  []*pb.NullValuesMap
+		now    = time.Unix(1577836800, 17)
+	)
+
+	for i := 0; i < size; i++ {
+		ints = append(ints, int64(i))
+		floats = append(floats, float64(i)/3)
+		strs = append(strs, fmt.Sprintf("val%d", i))
+		times = append(times, now.Add(time.Duration(i)*time.Minute))
+		bools = append(bools, i%2 == 0)
+
+		nullColumns := map[string]bool{}
+		if i%3 == 0 {
+			nullColumns["floats"] = true
+		}
+		nulls = append(nulls, &pb.NullValuesMap{NullColumns: nullColumns})
+	}
+
+	data := []struct {
+		name   string
+		values interface{}
+	}{
+		{"ints", ints},
+		{"floats", floats},
+		{"strings", strs},
+		{"times", times},
+		{"bools", bools},
+	}
+
+	var cols []Column
+	for _, d := range data {
+		col, err := NewSliceColumn(d.name, d.values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cols = append(cols, col)
+	}
+
+	index, err := NewSliceColumn("idx", strs)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	labels := map[string]interface{}{"host": "a", "shard": int64(7)}
+	frame, err := NewFrameWithNullValues(cols, []Column{index}, labels, nulls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return frame
+}
+
+func checkArrowFrame(t *testing.T, expected, frame Frame) {
+	if !reflect.DeepEqual(frame.Names(), expected.Names()) {
+		t.Fatalf("columns mismatch: %v != %v", frame.Names(), expected.Names())
+	}
+
+	if frame.Len() != expected.Len() {
+		t.Fatalf("length mismatch: %d != %d", frame.Len(), expected.Len())
+	}
+
+	if !reflect.DeepEqual(frame.Labels(), expected.Labels()) {
+		t.Fatalf("labels mismatch: %v != %v", frame.Labels(), expected.Labels())
+	}
+
+	if len(frame.Indices()) != 1 || frame.Indices()[0].Name() != "idx" {
+		t.Fatalf("bad indices: %v", frame.Indices())
+	}
+
+	for _, name := range expected.Names() {
+		col, err := frame.Column(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedCol, err := expected.Column(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if col.DType() != expectedCol.DType() {
+			t.Fatalf("%s: dtype mismatch: %v != %v", name, col.DType(), expectedCol.DType())
+		}
+
+		for i := 0; i < frame.Len(); i++ {
+			if frame.IsNull(i, name) != expected.IsNull(i, name) {
+				t.Fatalf("%s[%d]: null mismatch", name, i)
+			}
+		}
+
+		if col.DType() == TimeType {
+			times, _ := col.Times()
+			expectedTimes, _ := expectedCol.Times()
+			for i := range times {
+				if !times[i].Equal(expectedTimes[i]) {
+					t.Fatalf("%s[%d]: %v != %v", name, i, times[i], expectedTimes[i])
+				}
+			}
+			continue
+		}
+
+		for i := 0; i < frame.Len(); i++ {
+			if frame.IsNull(i, name) {
+				continue
+			}
+
+			val, expectedVal := columnValue(t, col, i), columnValue(t, expectedCol, i)
+			if val != expectedVal {
+				t.Fatalf("%s[%d]: %v != %v", name, i, val, expectedVal)
+			}
+		}
+	}
+}
+
+func columnValue(t *testing.T, col Column, i int) interface{} {
+	var (
+		val interface{}
+		err error
+	)
+
+	switch col.DType() {
+	case IntType:
+		val, err = col.IntAt(i)
+	case FloatType:
+		val, err = col.FloatAt(i)
+	case StringType:
+		val, err = col.StringAt(i)
+	case BoolType:
+		val, err = col.BoolAt(i)
+	default:
+		val, err = col.StringAt(i)
+	}
+
+	if err != nil {
+		t.Fatal(err)
+	}
+	return val
+}
+
+func TestArrowRoundTrip(t *testing.T) {
+	frame := newArrowTestFrame(t, 17)
+
+	data, err := MarshalArrow(frame)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := UnmarshalArrow(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkArrowFrame(t, frame, out)
+}
+
+func TestArrowExtendedDtypes(t *testing.T) {
+	data := []struct {
+		name   string
+		values interface{}
+	}{
+		{"int32", []int32{1, -2, 3}},
+		{"float32", []float32{0.5, 1, 2}},
+		{"bytes", [][]byte{[]byte("a"), {}, {0, 1}}},
+		{"decimal", []Decimal{{125, 2}, {-1, 0}, {3, 1}}},
+		{"list", [][]float64{{1.5}, {}, {2, 3}}},
+	}
+
+	var cols []Column
+	for _, d := range data {
+		col, err := NewSliceColumn(d.name, d.values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cols = append(cols, col)
+	}
+
+	index, err := NewSliceColumn("idx", []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nulls := make([]*pb.NullValuesMap, 3)
+	for i := range nulls {
+		nulls[i] = &pb.NullValuesMap{NullColumns: map[string]bool{}}
+	}
+	nulls[1].NullColumns["decimal"] = true
+	nulls[2].NullColumns["list"] = true
+
+	frame, err := NewFrameWithNullValues(cols, []Column{index}, nil, nulls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := MarshalArrow(frame)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := UnmarshalArrow(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkArrowFrame(t, frame, out)
+}
+
+func TestArrowEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := NewArrowEncoder(&buf)
+
+	frame1, frame2 := newArrowTestFrame(t, 10), newArrowTestFrame(t, 3)
+	col, err := NewSliceColumn("x", []int64{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Different schema, starts a new stream
+	frame3, err := NewFrame([]Column{col}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, frame := range []Frame{frame1, frame2, frame3} {
+		if err := enc.Encode(frame); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := enc.EncodeError(fmt.Errorf("oops")); err != nil {
+		t.Fatal(err)
+	}
+
+	dec := NewArrowDecoder(&buf)
+	for _, expected := range []Frame{frame1, frame2} {
+		frame, err := dec.Decode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkArrowFrame(t, expected, frame)
+	}
+
+	frame, err := dec.Decode()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(frame.Names(), []string{"x"}) || frame.Len() != 2 {
+		t.Fatalf("bad frame after schema change: %v (%d rows)", frame.Names(), frame.Len())
+	}
+
+	if _, err := dec.Decode(); err == nil || err.Error() != "oops" {
+		t.Fatalf("bad error: %v", err)
+	}
+
+	if _, err := dec.Decode(); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+}
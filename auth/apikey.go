@@ -0,0 +1,116 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+)
+
+// APIKeyProvider is the provider of API key identities
+const APIKeyProvider = "apikey"
+
+// apiKeysFile is the API keys file, e.g.
+//
+//	keys:
+//	  - user: daffy
+//	    groups: [ducks]
+//	    sha256: 2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae
+type apiKeysFile struct {
+	Keys []struct {
+		User   string   `json:"user"`
+		Groups []string `json:"groups,omitempty"`
+		Key    string   `json:"key,omitempty"`    // Key in plain text
+		SHA256 string   `json:"sha256,omitempty"` // Hex SHA256 digest of the key
+	} `json:"keys"`
+}
+
+// APIKeys authenticates static API keys
+type APIKeys struct {
+	identities map[string]*Identity // key digest -> identity
+}
+
+// NewAPIKeys returns API keys authenticator with the keys in path
+func NewAPIKeys(path string) (*APIKeys, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read API keys file")
+	}
+
+	var file apiKeysFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, errors.Wrapf(err, "bad API keys file %q", path)
+	}
+
+	keys := &APIKeys{identities: make(map[string]*Identity)}
+	for i, entry := range file.Keys {
+		if entry.User == "" {
+			return nil, errors.Errorf("API key %d has no user", i)
+		}
+
+		digest := strings.ToLower(entry.SHA256)
+		switch {
+		case entry.Key != "" && digest != "":
+			return nil, errors.Errorf("API key of %q has both key and sha256", entry.User)
+		case entry.Key != "":
+			digest = keyDigest(entry.Key)
+		case len(digest) != sha256.Size*2:
+			return nil, errors.Errorf("API key of %q has no key or a bad sha256", entry.User)
+		}
+
+		if _, ok := keys.identities[digest]; ok {
+			return nil, errors.Errorf("API key of %q is used more than once", entry.User)
+		}
+
+		keys.identities[digest] = &Identity{
+			User:     entry.User,
+			Groups:   entry.Groups,
+			Provider: APIKeyProvider,
+		}
+	}
+
+	return keys, nil
+}
+
+// Authenticate returns the identity of the creds API key
+func (k *APIKeys) Authenticate(creds Credentials) (*Identity, error) {
+	if creds.APIKey == "" {
+		return nil, ErrNoCredentials
+	}
+
+	identity, ok := k.identities[keyDigest(creds.APIKey)]
+	if !ok {
+		return nil, errors.New("unknown API key")
+	}
+
+	return identity, nil
+}
+
+// Keys are looked up by digest so lookup time doesn't depend on the key
+func keyDigest(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
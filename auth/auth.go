@@ -0,0 +1,142 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+// Package auth authenticates framesd requests.
+//
+// Credentials are sent in an API key header (or gRPC metadata) and in a
+// bearer "Authorization" header. These are separate from the v3io credentials
+// in the request session, which are passed to the backends.
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/v3io/frames"
+)
+
+const (
+	// APIKeyHeader is the HTTP header (and gRPC metadata) of API keys
+	APIKeyHeader = "X-Api-Key"
+	// AuthorizationHeader is the HTTP header (and gRPC metadata) of bearer tokens
+	AuthorizationHeader = "Authorization"
+
+	bearerPrefix = "Bearer "
+)
+
+// Identity is a verified identity
+type Identity = frames.Identity
+
+// Credentials are the authentication credentials of a request
+type Credentials struct {
+	APIKey      string
+	BearerToken string
+	// Client certificate verified by the TLS handshake
+	Certificate *x509.Certificate
+}
+
+// ErrNoCredentials is returned when there are no credentials the
+// authenticator handles
+var ErrNoCredentials = errors.New("no credentials")
+
+// Authenticator verifies request credentials
+type Authenticator interface {
+	// Authenticate returns the identity of creds, or ErrNoCredentials if
+	// creds don't have credentials the authenticator handles
+	Authenticate(creds Credentials) (*Identity, error)
+}
+
+// New returns an authenticator of the providers in config.Auth, and of client
+// certificates if config.TLS has a client CA. It returns nil if there are
+// neither (no authentication)
+func New(config *frames.Config) (Authenticator, error) {
+	var providers chain
+	if config.Auth != nil {
+		if config.Auth.APIKeysFile != "" {
+			provider, err := NewAPIKeys(config.Auth.APIKeysFile)
+			if err != nil {
+				return nil, err
+			}
+			providers = append(providers, provider)
+		}
+
+		if config.Auth.JWT != nil {
+			provider, err := NewJWT(config.Auth.JWT)
+			if err != nil {
+				return nil, err
+			}
+			providers = append(providers, provider)
+		}
+
+		if len(providers) == 0 {
+			return nil, errors.New("no authentication providers configured")
+		}
+	}
+
+	if config.TLS != nil && config.TLS.ClientCAFile != "" {
+		providers = append(providers, ClientCerts{})
+	}
+
+	if len(providers) == 0 {
+		return nil, nil
+	}
+
+	return providers, nil
+}
+
+// ParseBearer returns the token of a bearer authorization header value, ""
+// for other schemes
+func ParseBearer(value string) string {
+	if !strings.HasPrefix(value, bearerPrefix) {
+		return ""
+	}
+
+	return strings.TrimSpace(value[len(bearerPrefix):])
+}
+
+// chain authenticates with the first provider that handles the credentials
+type chain []Authenticator
+
+func (c chain) Authenticate(creds Credentials) (*Identity, error) {
+	for _, provider := range c {
+		identity, err := provider.Authenticate(creds)
+		if err == ErrNoCredentials {
+			continue
+		}
+		return identity, err
+	}
+
+	return nil, ErrNoCredentials
+}
+
+type identityKey struct{}
+
+// NewContext returns a copy of ctx with identity
+func NewContext(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+// FromContext returns the identity in ctx, nil if there's none
+func FromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityKey{}).(*Identity)
+	return identity
+}
@@ -0,0 +1,343 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/v3io/frames"
+)
+
+var (
+	hmacSecret = []byte("the quick brown fox")
+	rsaKey     *rsa.PrivateKey
+)
+
+func writeFile(t *testing.T, dir, name string, data []byte) string {
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "frames-auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestAPIKeys(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	digest := sha256.Sum256([]byte("bugs-key"))
+	keysFile := []byte(`
+keys:
+  - user: daffy
+    groups: [ducks]
+    key: daffy-key
+  - user: bugs
+    sha256: ` + hex.EncodeToString(digest[:]) + `
+`)
+	keys, err := NewAPIKeys(writeFile(t, dir, "keys.yml", keysFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	identity, err := keys.Authenticate(Credentials{APIKey: "daffy-key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.User != "daffy" || identity.Provider != APIKeyProvider || len(identity.Groups) != 1 {
+		t.Fatalf("bad identity - %+v", identity)
+	}
+
+	identity, err = keys.Authenticate(Credentials{APIKey: "bugs-key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.User != "bugs" {
+		t.Fatalf("bad user - %q", identity.User)
+	}
+
+	if _, err := keys.Authenticate(Credentials{APIKey: "elmer-key"}); err == nil {
+		t.Fatal("authenticated unknown key")
+	}
+
+	if _, err := keys.Authenticate(Credentials{}); err != ErrNoCredentials {
+		t.Fatalf("bad error without key - %v", err)
+	}
+}
+
+func TestAPIKeysBadFile(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	keysFile := []byte("keys:\n  - user: daffy\n")
+	if _, err := NewAPIKeys(writeFile(t, dir, "keys.yml", keysFile)); err == nil {
+		t.Fatal("loaded key without key or sha256")
+	}
+}
+
+func newJWT(t *testing.T, config *frames.JWTConfig) *JWT {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	if rsaKey == nil {
+		var err error
+		if rsaKey, err = rsa.GenerateKey(rand.Reader, 2048); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	jwks := map[string]interface{}{
+		"keys": []map[string]string{
+			{
+				"kty": "oct",
+				"kid": "hmac",
+				"k":   base64.RawURLEncoding.EncodeToString(hmacSecret),
+			},
+			{
+				"kty": "RSA",
+				"kid": "rsa",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(rsaKey.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(rsaKey.E)).Bytes()),
+			},
+		},
+	}
+	data, err := json.Marshal(jwks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config.JWKSFile = writeFile(t, dir, "jwks.json", data)
+	authenticator, err := NewJWT(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return authenticator
+}
+
+func signToken(t *testing.T, alg, kid string, claims map[string]interface{}) string {
+	header, err := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	var signature []byte
+	switch alg {
+	case "HS256":
+		mac := hmac.New(sha256.New, hmacSecret)
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	case "RS256":
+		digest := sha256.Sum256([]byte(signed))
+		if signature, err = rsa.SignPKCS1v15(rand.Reader, rsaKey, crypto.SHA256, digest[:]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestJWT(t *testing.T) {
+	authenticator := newJWT(t, &frames.JWTConfig{Issuer: "acme", Audience: "frames"})
+	exp := time.Now().Add(time.Hour).Unix()
+
+	testCases := []struct {
+		name   string
+		alg    string
+		kid    string
+		claims map[string]interface{}
+		user   string // empty if authentication should fail
+	}{
+		{"hs256", "HS256", "hmac", map[string]interface{}{"sub": "daffy", "iss": "acme", "aud": "frames", "exp": exp, "groups": []string{"ducks"}}, "daffy"},
+		{"rs256", "RS256", "rsa", map[string]interface{}{"sub": "bugs", "iss": "acme", "aud": []string{"other", "frames"}, "exp": exp}, "bugs"},
+		{"expired", "HS256", "hmac", map[string]interface{}{"sub": "daffy", "iss": "acme", "aud": "frames", "exp": time.Now().Add(-time.Hour).Unix()}, ""},
+		{"not yet", "HS256", "hmac", map[string]interface{}{"sub": "daffy", "iss": "acme", "aud": "frames", "nbf": exp}, ""},
+		{"wrong audience", "HS256", "hmac", map[string]interface{}{"sub": "daffy", "iss": "acme", "aud": "other"}, ""},
+		{"wrong issuer", "HS256", "hmac", map[string]interface{}{"sub": "daffy", "iss": "evil", "aud": "frames"}, ""},
+		{"no user", "HS256", "hmac", map[string]interface{}{"iss": "acme", "aud": "frames"}, ""},
+		{"wrong algorithm", "HS256", "rsa", map[string]interface{}{"sub": "daffy", "iss": "acme", "aud": "frames"}, ""},
+		{"none algorithm", "none", "hmac", map[string]interface{}{"sub": "daffy", "iss": "acme", "aud": "frames"}, ""},
+		{"unknown key", "HS256", "nope", map[string]interface{}{"sub": "daffy", "iss": "acme", "aud": "frames"}, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token := signToken(t, tc.alg, tc.kid, tc.claims)
+			identity, err := authenticator.Authenticate(Credentials{BearerToken: token})
+			if tc.user == "" {
+				if err == nil {
+					t.Fatalf("authenticated bad token - %+v", identity)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			if identity.User != tc.user || identity.Provider != JWTProvider {
+				t.Fatalf("bad identity - %+v", identity)
+			}
+		})
+	}
+}
+
+func TestJWTBadSignature(t *testing.T) {
+	authenticator := newJWT(t, &frames.JWTConfig{})
+	token := signToken(t, "HS256", "hmac", map[string]interface{}{"sub": "daffy"})
+
+	// Replace the claims, keep the signature
+	forged := signToken(t, "HS256", "hmac", map[string]interface{}{"sub": "elmer"})
+	parts := strings.Split(token, ".")
+	forgedParts := strings.Split(forged, ".")
+	if _, err := authenticator.Authenticate(Credentials{BearerToken: parts[0] + "." + forgedParts[1] + "." + parts[2]}); err == nil {
+		t.Fatal("authenticated token with bad signature")
+	}
+
+	unsigned := parts[0] + "." + parts[1] + "."
+	if _, err := authenticator.Authenticate(Credentials{BearerToken: unsigned}); err == nil {
+		t.Fatal("authenticated unsigned token")
+	}
+
+	if _, err := authenticator.Authenticate(Credentials{BearerToken: "v3io-token"}); err != ErrNoCredentials {
+		t.Fatalf("bad error for non JWT token - %v", err)
+	}
+}
+
+func TestJWTDuplicateKid(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	for _, kid := range []string{"hmac", ""} {
+		key := map[string]string{
+			"kty": "oct",
+			"kid": kid,
+			"k":   base64.RawURLEncoding.EncodeToString(hmacSecret),
+		}
+		data, err := json.Marshal(map[string]interface{}{"keys": []map[string]string{key, key}})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		config := &frames.JWTConfig{JWKSFile: writeFile(t, dir, "jwks.json", data)}
+		if _, err := NewJWT(config); err == nil {
+			t.Fatalf("loaded keys with duplicate kid %q", kid)
+		}
+	}
+}
+
+func TestJWTGroups(t *testing.T) {
+	authenticator := newJWT(t, &frames.JWTConfig{UserClaim: "email", GroupsClaim: "roles"})
+	token := signToken(t, "RS256", "rsa", map[string]interface{}{"email": "daffy@acme.com", "roles": []string{"ducks", "looney"}})
+
+	identity, err := authenticator.Authenticate(Credentials{BearerToken: token})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if identity.User != "daffy@acme.com" || len(identity.Groups) != 2 {
+		t.Fatalf("bad identity - %+v", identity)
+	}
+}
+
+func TestChain(t *testing.T) {
+	if authenticator, err := New(&frames.Config{}); err != nil || authenticator != nil {
+		t.Fatalf("authenticator without configuration - %v, %v", authenticator, err)
+	}
+
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	keysFile := writeFile(t, dir, "keys.yml", []byte("keys:\n  - user: daffy\n    key: daffy-key\n"))
+	authenticator, err := New(&frames.Config{Auth: &frames.AuthConfig{APIKeysFile: keysFile}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := authenticator.Authenticate(Credentials{BearerToken: "a.b.c"}); err != ErrNoCredentials {
+		t.Fatalf("bad error for unhandled credentials - %v", err)
+	}
+
+	identity, err := authenticator.Authenticate(Credentials{APIKey: "daffy-key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.User != "daffy" {
+		t.Fatalf("bad user - %q", identity.User)
+	}
+}
+
+func TestClientCerts(t *testing.T) {
+	authenticator, err := New(&frames.Config{TLS: &frames.TLSConfig{ClientCAFile: "ca.pem"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := authenticator.Authenticate(Credentials{APIKey: "daffy-key"}); err != ErrNoCredentials {
+		t.Fatalf("bad error for unhandled credentials - %v", err)
+	}
+
+	cert := &x509.Certificate{
+		Subject: pkix.Name{CommonName: "daffy", OrganizationalUnit: []string{"analysts"}},
+	}
+	identity, err := authenticator.Authenticate(Credentials{Certificate: cert})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := &Identity{User: "daffy", Groups: []string{"analysts"}, Provider: ClientCertProvider}
+	if !reflect.DeepEqual(identity, expected) {
+		t.Fatalf("bad identity - %+v", identity)
+	}
+
+	noName := &x509.Certificate{Subject: pkix.Name{Organization: []string{"acme"}}}
+	if _, err := authenticator.Authenticate(Credentials{Certificate: noName}); err == nil {
+		t.Fatal("no error for certificate without common name")
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package auth
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ClientCertProvider is the provider of client certificate identities
+const ClientCertProvider = "tls"
+
+// ClientCerts authenticates client certificates verified by the TLS
+// handshake, the subject common name is the user and the organizational units
+// are the groups
+type ClientCerts struct{}
+
+// Authenticate returns the identity of the creds client certificate
+func (ClientCerts) Authenticate(creds Credentials) (*Identity, error) {
+	if creds.Certificate == nil {
+		return nil, ErrNoCredentials
+	}
+
+	subject := creds.Certificate.Subject
+	if subject.CommonName == "" {
+		return nil, errors.Errorf("client certificate %q has no common name", subject)
+	}
+
+	identity := &Identity{
+		User:     subject.CommonName,
+		Groups:   subject.OrganizationalUnit,
+		Provider: ClientCertProvider,
+	}
+
+	return identity, nil
+}
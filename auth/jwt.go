@@ -0,0 +1,313 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/v3io/frames"
+)
+
+const (
+	// JWTProvider is the provider of JWT identities
+	JWTProvider = "jwt"
+
+	defaultUserClaim   = "sub"
+	defaultGroupsClaim = "groups"
+
+	// Allowed clock skew when checking "exp" and "nbf"
+	clockLeeway = time.Minute
+)
+
+// jwk is a JSON web key, only the fields we use
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	K   string `json:"k"` // oct
+	N   string `json:"n"` // RSA
+	E   string `json:"e"` // RSA
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// verificationKey is a key from the JWKS file
+type verificationKey struct {
+	alg    string // HS256 or RS256
+	secret []byte
+	public *rsa.PublicKey
+}
+
+// JWT authenticates bearer JSON web tokens
+type JWT struct {
+	config *frames.JWTConfig
+	keys   map[string]*verificationKey // kid -> key
+	now    func() time.Time
+}
+
+// NewJWT returns a JWT authenticator with the keys in config.JWKSFile
+func NewJWT(config *frames.JWTConfig) (*JWT, error) {
+	if config.JWKSFile == "" {
+		return nil, errors.New("JWT authentication requires a JWKS file")
+	}
+
+	data, err := ioutil.ReadFile(config.JWKSFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read JWKS file")
+	}
+
+	var jwks struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &jwks); err != nil {
+		return nil, errors.Wrapf(err, "bad JWKS file %q", config.JWKSFile)
+	}
+
+	keys := make(map[string]*verificationKey)
+	for i, key := range jwks.Keys {
+		vkey, err := parseJWK(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "bad key %d in %q", i, config.JWKSFile)
+		}
+		// Tokens pick keys by kid, so keys without one must be alone
+		if _, ok := keys[key.Kid]; ok {
+			return nil, errors.Errorf("duplicate key id %q in %q", key.Kid, config.JWKSFile)
+		}
+		keys[key.Kid] = vkey
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.Errorf("no keys in %q", config.JWKSFile)
+	}
+
+	return &JWT{config: config, keys: keys, now: time.Now}, nil
+}
+
+// Authenticate verifies the creds bearer token and returns its identity
+func (j *JWT) Authenticate(creds Credentials) (*Identity, error) {
+	parts := strings.Split(creds.BearerToken, ".")
+	if len(parts) != 3 {
+		return nil, ErrNoCredentials
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, errors.Wrap(err, "bad token header")
+	}
+
+	key, err := j.key(header)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.Wrap(err, "bad token signature")
+	}
+
+	if err := key.verify(parts[0]+"."+parts[1], signature); err != nil {
+		return nil, err
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, errors.Wrap(err, "bad token claims")
+	}
+
+	if err := j.checkClaims(claims); err != nil {
+		return nil, err
+	}
+
+	return j.identity(claims)
+}
+
+func (j *JWT) key(header jwtHeader) (*verificationKey, error) {
+	key, ok := j.keys[header.Kid]
+	if !ok {
+		return nil, errors.Errorf("unknown token key ID %q", header.Kid)
+	}
+
+	// Never let the token pick the algorithm, this is also where "none" is
+	// rejected
+	if header.Alg != key.alg {
+		return nil, errors.Errorf("token algorithm %q doesn't match key algorithm %q", header.Alg, key.alg)
+	}
+
+	return key, nil
+}
+
+func (j *JWT) checkClaims(claims map[string]interface{}) error {
+	now := j.now()
+	if exp, ok := claims["exp"].(float64); ok {
+		if now.After(time.Unix(int64(exp), 0).Add(clockLeeway)) {
+			return errors.New("token expired")
+		}
+	} else if _, found := claims["exp"]; found {
+		return errors.New("bad token exp claim")
+	}
+
+	if nbf, ok := claims["nbf"].(float64); ok {
+		if now.Before(time.Unix(int64(nbf), 0).Add(-clockLeeway)) {
+			return errors.New("token not valid yet")
+		}
+	} else if _, found := claims["nbf"]; found {
+		return errors.New("bad token nbf claim")
+	}
+
+	if j.config.Issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != j.config.Issuer {
+			return errors.Errorf("bad token issuer %q", iss)
+		}
+	}
+
+	if j.config.Audience != "" && !hasAudience(claims["aud"], j.config.Audience) {
+		return errors.New("token audience doesn't match")
+	}
+
+	return nil
+}
+
+func (j *JWT) identity(claims map[string]interface{}) (*Identity, error) {
+	userClaim := j.config.UserClaim
+	if userClaim == "" {
+		userClaim = defaultUserClaim
+	}
+
+	user, _ := claims[userClaim].(string)
+	if user == "" {
+		return nil, errors.Errorf("token has no %q claim", userClaim)
+	}
+
+	groupsClaim := j.config.GroupsClaim
+	if groupsClaim == "" {
+		groupsClaim = defaultGroupsClaim
+	}
+
+	identity := &Identity{User: user, Provider: JWTProvider}
+	switch groups := claims[groupsClaim].(type) {
+	case nil:
+	case string:
+		identity.Groups = []string{groups}
+	case []interface{}:
+		for _, group := range groups {
+			name, ok := group.(string)
+			if !ok {
+				return nil, errors.Errorf("bad group in %q claim - %v", groupsClaim, group)
+			}
+			identity.Groups = append(identity.Groups, name)
+		}
+	default:
+		return nil, errors.Errorf("bad %q claim - %v", groupsClaim, groups)
+	}
+
+	return identity, nil
+}
+
+func (k *verificationKey) verify(signed string, signature []byte) error {
+	switch k.alg {
+	case "HS256":
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(mac.Sum(nil), signature) {
+			return errors.New("bad token signature")
+		}
+	case "RS256":
+		digest := sha256.Sum256([]byte(signed))
+		if err := rsa.VerifyPKCS1v15(k.public, crypto.SHA256, digest[:], signature); err != nil {
+			return errors.New("bad token signature")
+		}
+	default:
+		return errors.Errorf("unsupported token algorithm %q", k.alg)
+	}
+
+	return nil
+}
+
+func parseJWK(key jwk) (*verificationKey, error) {
+	switch key.Kty {
+	case "oct":
+		if key.Alg != "" && key.Alg != "HS256" {
+			return nil, errors.Errorf("unsupported oct key algorithm %q", key.Alg)
+		}
+		secret, err := base64.RawURLEncoding.DecodeString(key.K)
+		if err != nil || len(secret) == 0 {
+			return nil, errors.New("bad oct key")
+		}
+		return &verificationKey{alg: "HS256", secret: secret}, nil
+	case "RSA":
+		if key.Alg != "" && key.Alg != "RS256" {
+			return nil, errors.Errorf("unsupported RSA key algorithm %q", key.Alg)
+		}
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil || len(n) == 0 {
+			return nil, errors.New("bad RSA key modulus")
+		}
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil || len(e) == 0 {
+			return nil, errors.New("bad RSA key exponent")
+		}
+		public := &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+		return &verificationKey{alg: "RS256", public: public}, nil
+	}
+
+	return nil, errors.Errorf("unsupported key type %q", key.Kty)
+}
+
+func decodeSegment(segment string, out interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, out)
+}
+
+// "aud" is either a string or an array of strings
+func hasAudience(aud interface{}, audience string) bool {
+	switch aud := aud.(type) {
+	case string:
+		return aud == audience
+	case []interface{}:
+		for _, value := range aud {
+			if value == audience {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,284 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+// Package authz authorizes requests with a policy of allow and deny rules.
+//
+// Rules match the verified request identity (see the auth package). Requests
+// without identity can't be told apart from the listed users and groups, so
+// they match deny rules that list them but not allow rules.
+package authz
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/v3io/frames"
+)
+
+// Request actions
+const (
+	ReadAction   = "read"
+	WriteAction  = "write"
+	CreateAction = "create"
+	DeleteAction = "delete"
+	ExecAction   = "exec"
+)
+
+const (
+	allowEffect = "allow"
+	denyEffect  = "deny"
+)
+
+var actions = map[string]bool{
+	ReadAction:   true,
+	WriteAction:  true,
+	CreateAction: true,
+	DeleteAction: true,
+	ExecAction:   true,
+}
+
+// Request is a request to authorize
+type Request struct {
+	Identity  *frames.Identity
+	Action    string
+	Backend   string
+	Container string
+	Table     string
+	Command   string // Exec command
+}
+
+// DeniedError is returned for requests the policy denies
+type DeniedError struct {
+	Request Request
+	Rule    int // Index of the deny rule, -1 if no rule allowed the request
+}
+
+func (e *DeniedError) Error() string {
+	user := "anonymous user"
+	if e.Request.Identity != nil {
+		user = fmt.Sprintf("user %q", e.Request.Identity.User)
+	}
+
+	action := e.Request.Action
+	if e.Request.Command != "" {
+		action = fmt.Sprintf("%s %q on", action, e.Request.Command)
+	}
+
+	return fmt.Sprintf("permission denied - %s can't %s table %q of %s backend", user, action, e.Request.Table, e.Request.Backend)
+}
+
+// IsDenied returns true if err (or its cause) is a DeniedError
+func IsDenied(err error) bool {
+	_, ok := errors.Cause(err).(*DeniedError)
+	return ok
+}
+
+// Policy authorizes requests
+type Policy struct {
+	defaultAllow bool
+	rules        []*rule
+}
+
+// New returns the policy of config, nil if config is nil (everything is
+// allowed)
+func New(config *frames.AuthorizationConfig) (*Policy, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	policy := &Policy{}
+	switch config.DefaultEffect {
+	case "", denyEffect:
+	case allowEffect:
+		policy.defaultAllow = true
+	default:
+		return nil, errors.Errorf("bad default effect %q", config.DefaultEffect)
+	}
+
+	for i, ruleConfig := range config.Rules {
+		r, err := newRule(ruleConfig)
+		if err != nil {
+			return nil, errors.Wrapf(err, "bad authorization rule %d", i)
+		}
+		policy.rules = append(policy.rules, r)
+	}
+
+	return policy, nil
+}
+
+// Authorize returns a *DeniedError if the request is denied
+func (p *Policy) Authorize(request Request) error {
+	allowed := p.defaultAllow
+	for i, r := range p.rules {
+		if !r.match(request) {
+			continue
+		}
+
+		if !r.allow {
+			return &DeniedError{Request: request, Rule: i}
+		}
+		allowed = true
+	}
+
+	if !allowed {
+		return &DeniedError{Request: request, Rule: -1}
+	}
+
+	return nil
+}
+
+type rule struct {
+	allow      bool
+	users      map[string]bool
+	groups     map[string]bool
+	backends   map[string]bool
+	containers map[string]bool
+	tables     []*regexp.Regexp
+	actions    map[string]bool
+	commands   map[string]bool
+}
+
+func newRule(config *frames.AuthorizationRule) (*rule, error) {
+	r := &rule{
+		users:      stringSet(config.Users),
+		groups:     stringSet(config.Groups),
+		backends:   stringSet(config.Backends),
+		containers: stringSet(config.Containers),
+		actions:    stringSet(config.Actions),
+		commands:   stringSet(config.Commands),
+	}
+
+	switch config.Effect {
+	case allowEffect:
+		r.allow = true
+	case denyEffect:
+	default:
+		return nil, errors.Errorf("bad effect %q", config.Effect)
+	}
+
+	for action := range r.actions {
+		if !actions[action] {
+			return nil, errors.Errorf("unknown action %q", action)
+		}
+	}
+
+	for _, glob := range config.Tables {
+		re, err := globRegexp(glob)
+		if err != nil {
+			return nil, errors.Wrapf(err, "bad table glob %q", glob)
+		}
+		r.tables = append(r.tables, re)
+	}
+
+	return r, nil
+}
+
+func (r *rule) match(request Request) bool {
+	if len(r.users) > 0 || len(r.groups) > 0 {
+		if request.Identity == nil {
+			if r.allow {
+				return false
+			}
+		} else if !r.matchIdentity(request.Identity) {
+			return false
+		}
+	}
+
+	if !matchSet(r.backends, request.Backend) ||
+		!matchSet(r.containers, request.Container) ||
+		!matchSet(r.actions, request.Action) {
+		return false
+	}
+
+	if len(r.commands) > 0 && (request.Action != ExecAction || !r.commands[request.Command]) {
+		return false
+	}
+
+	if len(r.tables) == 0 {
+		return true
+	}
+
+	table := normalizeTable(request.Table)
+	for _, re := range r.tables {
+		if re.MatchString(table) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *rule) matchIdentity(identity *frames.Identity) bool {
+	if r.users[identity.User] {
+		return true
+	}
+
+	for _, group := range identity.Groups {
+		if r.groups[group] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// globRegexp returns a regular expression matching normalized tables
+func globRegexp(glob string) (*regexp.Regexp, error) {
+	glob = normalizeTable(glob)
+	var buf strings.Builder
+	buf.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				buf.WriteString(".*")
+				i++
+			} else {
+				buf.WriteString("[^/]*")
+			}
+		case '?':
+			buf.WriteString("[^/]")
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	buf.WriteString("$")
+
+	return regexp.Compile(buf.String())
+}
+
+// Tables are used both with and without leading and trailing slashes
+func normalizeTable(table string) string {
+	return strings.Trim(table, "/")
+}
+
+func stringSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
+
+func matchSet(set map[string]bool, value string) bool {
+	return len(set) == 0 || set[value]
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package authz
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/v3io/frames"
+)
+
+var policyConfig = `
+rules:
+  - effect: allow
+    groups: [analysts]
+    actions: [read]
+  - effect: deny
+    groups: [analysts]
+    backends: [tsdb]
+    tables: ["/prod/**"]
+  - effect: allow
+    users: [admin]
+  - effect: allow
+    groups: [ops]
+    actions: [exec]
+    commands: [ping]
+  - effect: allow
+    containers: [scratch]
+    tables: ["tmp_*"]
+`
+
+func newPolicy(t *testing.T) *Policy {
+	config := &frames.AuthorizationConfig{}
+	if err := yaml.Unmarshal([]byte(policyConfig), config); err != nil {
+		t.Fatal(err)
+	}
+
+	policy, err := New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return policy
+}
+
+func TestAuthorize(t *testing.T) {
+	policy := newPolicy(t)
+
+	analyst := &frames.Identity{User: "daffy", Groups: []string{"analysts"}}
+	admin := &frames.Identity{User: "admin"}
+	ops := &frames.Identity{User: "bugs", Groups: []string{"ops"}}
+
+	testCases := []struct {
+		name    string
+		request Request
+		allowed bool
+	}{
+		{"analyst read", Request{Identity: analyst, Action: ReadAction, Backend: "kv", Table: "prod/users"}, true},
+		{"analyst read tsdb prod", Request{Identity: analyst, Action: ReadAction, Backend: "tsdb", Table: "/prod/metrics/cpu"}, false},
+		{"analyst read tsdb dev", Request{Identity: analyst, Action: ReadAction, Backend: "tsdb", Table: "dev/metrics"}, true},
+		{"analyst delete", Request{Identity: analyst, Action: DeleteAction, Backend: "kv", Table: "dev/users"}, false},
+		{"admin delete", Request{Identity: admin, Action: DeleteAction, Backend: "tsdb", Table: "prod/metrics"}, true},
+		{"ops ping", Request{Identity: ops, Action: ExecAction, Backend: "kv", Table: "t", Command: "ping"}, true},
+		{"ops infer", Request{Identity: ops, Action: ExecAction, Backend: "kv", Table: "t", Command: "infer"}, false},
+		{"ops read", Request{Identity: ops, Action: ReadAction, Backend: "kv", Table: "t"}, false},
+		{"anonymous scratch", Request{Action: WriteAction, Backend: "kv", Container: "scratch", Table: "tmp_1"}, true},
+		{"anonymous scratch subdir", Request{Action: WriteAction, Backend: "kv", Container: "scratch", Table: "tmp_1/x"}, false},
+		{"anonymous", Request{Action: ReadAction, Backend: "kv", Container: "bigdata", Table: "t"}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := policy.Authorize(tc.request)
+			if tc.allowed {
+				if err != nil {
+					t.Fatalf("denied - %s", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("allowed")
+			}
+			if !IsDenied(errors.Wrap(err, "wrapped")) {
+				t.Fatalf("bad error type - %T", err)
+			}
+		})
+	}
+}
+
+func TestDefaultAllow(t *testing.T) {
+	config := &frames.AuthorizationConfig{
+		DefaultEffect: "allow",
+		Rules: []*frames.AuthorizationRule{
+			{Effect: "deny", Actions: []string{DeleteAction}},
+		},
+	}
+
+	policy, err := New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := policy.Authorize(Request{Action: ReadAction, Table: "t"}); err != nil {
+		t.Fatalf("read denied - %s", err)
+	}
+
+	err = policy.Authorize(Request{Action: DeleteAction, Table: "t"})
+	denied, ok := err.(*DeniedError)
+	if !ok || denied.Rule != 0 {
+		t.Fatalf("bad delete error - %v", err)
+	}
+}
+
+func TestNoIdentity(t *testing.T) {
+	config := &frames.AuthorizationConfig{
+		DefaultEffect: "allow",
+		Rules: []*frames.AuthorizationRule{
+			{Effect: "deny", Users: []string{"bugs"}, Tables: []string{"secret"}},
+			{Effect: "deny", Groups: []string{"ops"}, Actions: []string{DeleteAction}},
+			{Effect: "allow", Users: []string{"daffy"}, Tables: []string{"private"}},
+			{Effect: "deny", Tables: []string{"private"}},
+		},
+	}
+
+	policy, err := New(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name    string
+		request Request
+		rule    int // -1 if allowed
+	}{
+		{"user deny", Request{Action: ReadAction, Table: "secret"}, 0},
+		{"group deny", Request{Action: DeleteAction, Table: "t"}, 1},
+		{"user allow", Request{Action: ReadAction, Table: "private"}, 3},
+		{"default", Request{Action: ReadAction, Table: "t"}, -1},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := policy.Authorize(tc.request)
+			if tc.rule == -1 {
+				if err != nil {
+					t.Fatalf("denied - %s", err)
+				}
+				return
+			}
+
+			denied, ok := err.(*DeniedError)
+			if !ok || denied.Rule != tc.rule {
+				t.Fatalf("bad error - %v", err)
+			}
+		})
+	}
+}
+
+func TestBadConfig(t *testing.T) {
+	configs := []*frames.AuthorizationConfig{
+		{DefaultEffect: "maybe"},
+		{Rules: []*frames.AuthorizationRule{{Effect: "permit"}}},
+		{Rules: []*frames.AuthorizationRule{{Effect: "allow", Actions: []string{"drop"}}}},
+	}
+
+	for _, config := range configs {
+		if _, err := New(config); err == nil {
+			t.Fatalf("no error for %+v", config)
+		}
+	}
+
+	if policy, err := New(nil); err != nil || policy != nil {
+		t.Fatalf("policy without configuration - %v, %v", policy, err)
+	}
+}
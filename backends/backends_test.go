@@ -59,32 +59,99 @@ func (suite *BackendsTestSuite) TestBackends() {
 	suite.Require().Equal(errorBackendsTest, err)
 }
 
+var testCapabilities = &frames.Capabilities{
+	Type:         "test",
+	ReadFields:   ReadFields("Segments"),
+	WriteFields:  WriteFields("PartitionKeys", "SaveMode"),
+	CreateFields: CreateFields("Rate"),
+	DeleteFields: DeleteFields(),
+	Commands: []*frames.ExecCommand{
+		{
+			Name: "update",
+			Args: []*frames.ExecArgument{
+				{Name: "key", Type: "string", Required: true},
+				{Name: "count", Type: "long"},
+			},
+		},
+	},
+	SaveModes: SaveModes(frames.ErrorIfTableExists, frames.OverwriteTable),
+}
+
 func (suite *BackendsTestSuite) TestValidateEmptyReadRequest() {
-	err := ValidateRequest("mybackend", &pb.ReadRequest{}, nil)
+	err := ValidateRequest(&frames.Capabilities{Type: "mybackend"}, &pb.ReadRequest{})
 	suite.NoError(err)
 }
 
 func (suite *BackendsTestSuite) TestValidateSimpleReadRequest() {
-	err := ValidateRequest("kv", &pb.ReadRequest{Segments: []int64{5}}, map[string]bool{"Segments": true})
+	err := ValidateRequest(testCapabilities, &pb.ReadRequest{Table: "t", Segments: []int64{5}})
 	suite.Require().NoError(err)
 }
 
 func (suite *BackendsTestSuite) TestValidateBadReadRequest() {
-	err := ValidateRequest("tsdb", &pb.ReadRequest{Segments: []int64{5}}, nil)
+	caps := &frames.Capabilities{Type: "tsdb", ReadFields: ReadFields("Start")}
+	err := ValidateRequest(caps, &pb.ReadRequest{Segments: []int64{5}})
 	suite.Require().Error(err)
 	expected := "Segments cannot be used as an argument to a ReadRequest to tsdb backend"
 	suite.Require().Equal(expected, err.Error())
 }
 
 func (suite *BackendsTestSuite) TestValidateWriteRequest() {
-	request := &frames.WriteRequest{Session: &frames.Session{}, PartitionKeys: []string{"mykey"}}
-	err := ValidateRequest("kv", request, map[string]bool{"PartitionKeys": true})
+	request := &frames.WriteRequest{
+		Session:       &frames.Session{},
+		Password:      frames.InitSecretString("secret"),
+		PartitionKeys: []string{"mykey"},
+		SaveMode:      frames.OverwriteTable,
+	}
+	err := ValidateRequest(testCapabilities, request)
 	suite.Require().NoError(err)
 }
 
 func (suite *BackendsTestSuite) TestValidateBadWriteRequest() {
-	request := &frames.WriteRequest{Session: &frames.Session{}, PartitionKeys: []string{"mykey"}}
-	err := ValidateRequest("tsdb", request, nil)
+	request := &frames.WriteRequest{Session: &frames.Session{}, Expression: "a=1"}
+	err := ValidateRequest(testCapabilities, request)
+	suite.Require().Error(err)
+
+	request = &frames.WriteRequest{Session: &frames.Session{}, SaveMode: frames.UpdateItem}
+	err = ValidateRequest(testCapabilities, request)
+	suite.Require().Error(err)
+
+	caps := &frames.Capabilities{Type: "rows", WriteFields: WriteFields("SaveMode"), SaveModes: SaveModes(frames.AppendRows)}
+	err = ValidateRequest(caps, request)
+	suite.Require().Error(err)
+	suite.Require().Equal("rows backend doesn't support save mode updateItem, its rows have no keys (use append to add rows)", err.Error())
+}
+
+func (suite *BackendsTestSuite) TestValidateCreateDeleteRequest() {
+	err := ValidateRequest(testCapabilities, &pb.CreateRequest{Table: "t", Rate: "1/m"})
+	suite.Require().NoError(err)
+	err = ValidateRequest(testCapabilities, &pb.CreateRequest{Table: "t", Shards: 3})
+	suite.Require().Error(err)
+
+	err = ValidateRequest(testCapabilities, &pb.DeleteRequest{Table: "t", Filter: "x > 1"})
+	suite.Require().NoError(err)
+	err = ValidateRequest(testCapabilities, &pb.DeleteRequest{Table: "t", Metrics: []string{"cpu"}})
+	suite.Require().Error(err)
+}
+
+func (suite *BackendsTestSuite) TestValidateExecRequest() {
+	args, err := pb.FromGoMap(map[string]interface{}{"key": "k1", "count": 3})
+	suite.Require().NoError(err)
+	err = ValidateRequest(testCapabilities, &pb.ExecRequest{Command: " Update", Args: args})
+	suite.Require().NoError(err)
+
+	badRequests := map[string]map[string]interface{}{
+		"missing argument": {"count": 3},
+		"unknown argument": {"key": "k1", "size": 3},
+		"bad type":         {"key": 1},
+	}
+	for name, goArgs := range badRequests {
+		args, err := pb.FromGoMap(goArgs)
+		suite.Require().NoError(err, name)
+		err = ValidateRequest(testCapabilities, &pb.ExecRequest{Command: "update", Args: args})
+		suite.Require().Error(err, name)
+	}
+
+	err = ValidateRequest(testCapabilities, &pb.ExecRequest{Command: "ping"})
 	suite.Require().Error(err)
 }
 
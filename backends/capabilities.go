@@ -0,0 +1,233 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package backends
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/pb"
+	"github.com/v3io/frames/v3ioutils"
+)
+
+// Request fields every backend supports
+var (
+	commonReadFields = []string{
+		"Session", "Backend", "Schema", "DataFormat", "RowLayout", "Table",
+		"Columns", "Filter", "Join", "Limit", "MessageLimit", "ResetIndex",
+		"ExtendedDtypes",
+	}
+	commonWriteFields  = []string{"Session", "Backend", "Table", "ImmidiateData"}
+	commonCreateFields = []string{"Session", "Backend", "Table", "IfExists"}
+	commonDeleteFields = []string{"Session", "Backend", "Table", "Filter", "IfMissing"}
+)
+
+// Fields set by the server are allowed in every request and are not listed in
+// capabilities
+var serverFields = map[string]bool{
+	"Password": true,
+	"Token":    true,
+	"Identity": true,
+}
+
+// ReadFields returns the common read request fields followed by fields
+func ReadFields(fields ...string) []string {
+	return withFields(commonReadFields, fields)
+}
+
+// WriteFields returns the common write request fields followed by fields
+func WriteFields(fields ...string) []string {
+	return withFields(commonWriteFields, fields)
+}
+
+// CreateFields returns the common create request fields followed by fields
+func CreateFields(fields ...string) []string {
+	return withFields(commonCreateFields, fields)
+}
+
+// DeleteFields returns the common delete request fields followed by fields
+func DeleteFields(fields ...string) []string {
+	return withFields(commonDeleteFields, fields)
+}
+
+func withFields(common []string, fields []string) []string {
+	out := make([]string, 0, len(common)+len(fields))
+	out = append(out, common...)
+	return append(out, fields...)
+}
+
+// SaveModes returns the names of modes
+func SaveModes(modes ...frames.SaveMode) []string {
+	names := make([]string, len(modes))
+	for i, mode := range modes {
+		names[i] = mode.String()
+	}
+	return names
+}
+
+// ValidateRequest checks that request uses only what caps declare: request
+// fields, write save modes and exec commands with their arguments
+func ValidateRequest(caps *frames.Capabilities, request interface{}) error {
+	switch request := request.(type) {
+	case *pb.ReadRequest:
+		return validateFields(caps, request, caps.ReadFields)
+	case *frames.WriteRequest:
+		if err := validateFields(caps, request, caps.WriteFields); err != nil {
+			return err
+		}
+		return validateSaveMode(caps, request.SaveMode)
+	case *pb.CreateRequest:
+		return validateFields(caps, request, caps.CreateFields)
+	case *pb.DeleteRequest:
+		return validateFields(caps, request, caps.DeleteFields)
+	case *pb.ExecRequest:
+		return validateExec(caps, request)
+	}
+
+	return errors.Errorf("can't validate %T", request)
+}
+
+func validateFields(caps *frames.Capabilities, request interface{}, allowed []string) error {
+	allowedFields := make(map[string]bool, len(allowed))
+	for _, name := range allowed {
+		allowedFields[name] = true
+	}
+
+	value := reflect.ValueOf(request).Elem()
+	reftype := value.Type()
+	for i := 0; i < reftype.NumField(); i++ {
+		field := reftype.Field(i)
+		if allowedFields[field.Name] || serverFields[field.Name] {
+			continue
+		}
+
+		fieldValue := value.Field(i).Interface()
+		zeroValue := reflect.Zero(field.Type).Interface()
+		if !reflect.DeepEqual(fieldValue, zeroValue) {
+			return errors.Errorf("%s cannot be used as an argument to a %s to %s backend", field.Name, reftype.Name(), caps.Type)
+		}
+	}
+	return nil
+}
+
+func validateSaveMode(caps *frames.Capabilities, mode frames.SaveMode) error {
+	if mode == frames.ErrorIfTableExists {
+		return nil
+	}
+
+	name := mode.String()
+	for _, supported := range caps.SaveModes {
+		if supported == name {
+			return nil
+		}
+	}
+
+	// Backends that append rows have no item keys
+	switch mode {
+	case frames.UpdateItem, frames.OverwriteItem, frames.CreateNewItemsOnly:
+		appendMode := frames.AppendRows.String()
+		for _, supported := range caps.SaveModes {
+			if supported == appendMode {
+				return errors.Errorf("%s backend doesn't support save mode %s, its rows have no keys (use %s to add rows)", caps.Type, name, appendMode)
+			}
+		}
+	}
+
+	return errors.Errorf("%s backend doesn't support save mode %s", caps.Type, name)
+}
+
+func validateExec(caps *frames.Capabilities, request *pb.ExecRequest) error {
+	name := strings.ToLower(strings.TrimSpace(request.Command))
+	var command *frames.ExecCommand
+	for _, cmd := range caps.Commands {
+		if cmd.Name == name {
+			command = cmd
+			break
+		}
+	}
+
+	if command == nil {
+		return errors.Errorf("%s backend doesn't support execute command '%s'", caps.Type, name)
+	}
+
+	known := make(map[string]bool, len(command.Args))
+	for _, arg := range command.Args {
+		known[arg.Name] = true
+		value, ok := request.Args[arg.Name]
+		if !ok {
+			if arg.Required {
+				return errors.Errorf("%s command requires a %q argument", name, arg.Name)
+			}
+			continue
+		}
+
+		if !argTypeMatches(arg.Type, value) {
+			return errors.Errorf("%q argument of %s command must be a %s", arg.Name, name, arg.Type)
+		}
+	}
+
+	for argName := range request.Args {
+		if !known[argName] {
+			return errors.Errorf("%q is not an argument of %s command to %s backend", argName, name, caps.Type)
+		}
+	}
+
+	return nil
+}
+
+func argTypeMatches(typ string, value *pb.Value) bool {
+	if value == nil {
+		return false
+	}
+
+	var ok bool
+	switch typ {
+	case "":
+		ok = true
+	case v3ioutils.StringType:
+		_, ok = value.Value.(*pb.Value_Sval)
+	case v3ioutils.LongType, v3ioutils.IntType:
+		_, ok = value.Value.(*pb.Value_Ival)
+	case v3ioutils.DoubleType, v3ioutils.FloatType:
+		switch value.Value.(type) {
+		case *pb.Value_Fval, *pb.Value_Ival:
+			ok = true
+		}
+	case v3ioutils.BoolType:
+		_, ok = value.Value.(*pb.Value_Bval)
+	case v3ioutils.TimeType:
+		_, ok = value.Value.(*pb.Value_Tval)
+	}
+	return ok
+}
+
+// SupportsReadField returns true if a backend with caps supports the read
+// request field
+func SupportsReadField(caps *frames.Capabilities, field string) bool {
+	for _, name := range caps.ReadFields {
+		if name == field {
+			return true
+		}
+	}
+	return false
+}
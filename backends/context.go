@@ -0,0 +1,189 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package backends
+
+import (
+	"context"
+	"time"
+
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/tracing"
+)
+
+// ReadContext reads from backend, backends that are not
+// frames.ContextDataBackend are checked for cancellation between frames. The
+// read is traced until the iteration ends
+func ReadContext(ctx context.Context, backend frames.DataBackend, request *frames.ReadRequest) (frames.FrameIterator, error) {
+	ctx, span := tracing.Start(ctx, "backend.Read", requestAttributes(request.Proto.GetBackend(), request.Proto.GetTable())...)
+	iter, err := readContext(ctx, backend, request)
+	if err != nil {
+		tracing.End(span, err)
+		return nil, err
+	}
+
+	return &tracedIterator{FrameIterator: iter, span: span}, nil
+}
+
+func readContext(ctx context.Context, backend frames.DataBackend, request *frames.ReadRequest) (frames.FrameIterator, error) {
+	if cb, ok := backend.(frames.ContextDataBackend); ok {
+		return cb.ReadContext(ctx, request)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	iter, err := backend.Read(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewContextIterator(ctx, iter), nil
+}
+
+// WriteContext writes to backend, backends that are not
+// frames.ContextDataBackend are checked for cancellation between frames. The
+// write is traced until the appender completes or is closed
+func WriteContext(ctx context.Context, backend frames.DataBackend, request *frames.WriteRequest) (frames.FrameAppender, error) {
+	ctx, span := tracing.Start(ctx, "backend.Write", requestAttributes(request.Backend, request.Table)...)
+	appender, err := writeContext(ctx, backend, request)
+	if err != nil {
+		tracing.End(span, err)
+		return nil, err
+	}
+
+	traced := &tracedAppender{FrameAppender: appender, span: span}
+	if request.ImmidiateData != nil {
+		traced.count(request.ImmidiateData)
+	}
+	return traced, nil
+}
+
+func writeContext(ctx context.Context, backend frames.DataBackend, request *frames.WriteRequest) (frames.FrameAppender, error) {
+	if cb, ok := backend.(frames.ContextDataBackend); ok {
+		return cb.WriteContext(ctx, request)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	appender, err := backend.Write(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewContextAppender(ctx, appender), nil
+}
+
+// ExecContext executes a command on backend, backends that are not
+// frames.ContextDataBackend are only checked before the command starts
+func ExecContext(ctx context.Context, backend frames.DataBackend, request *frames.ExecRequest) (frame frames.Frame, err error) {
+	attrs := append(requestAttributes(request.Proto.GetBackend(), request.Proto.GetTable()), tracing.CommandKey.String(request.Proto.GetCommand()))
+	ctx, span := tracing.Start(ctx, "backend.Exec", attrs...)
+	defer func() { tracing.End(span, err) }()
+
+	return execContext(ctx, backend, request)
+}
+
+func execContext(ctx context.Context, backend frames.DataBackend, request *frames.ExecRequest) (frames.Frame, error) {
+	if cb, ok := backend.(frames.ContextDataBackend); ok {
+		return cb.ExecContext(ctx, request)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return backend.Exec(request)
+}
+
+type contextIterator struct {
+	frames.FrameIterator
+	ctx context.Context
+	err error
+}
+
+// NewContextIterator returns an iterator that stops once ctx is done
+func NewContextIterator(ctx context.Context, iter frames.FrameIterator) frames.FrameIterator {
+	return &contextIterator{FrameIterator: iter, ctx: ctx}
+}
+
+func (it *contextIterator) Next() bool {
+	if it.err = it.ctx.Err(); it.err != nil {
+		return false
+	}
+
+	return it.FrameIterator.Next()
+}
+
+func (it *contextIterator) Err() error {
+	if it.err != nil {
+		return it.err
+	}
+
+	return it.FrameIterator.Err()
+}
+
+type contextAppender struct {
+	frames.FrameAppender
+	ctx context.Context
+}
+
+// NewContextAppender returns an appender that fails once ctx is done, the
+// WaitForComplete timeout is capped by the ctx deadline
+func NewContextAppender(ctx context.Context, appender frames.FrameAppender) frames.FrameAppender {
+	return &contextAppender{FrameAppender: appender, ctx: ctx}
+}
+
+func (a *contextAppender) Add(frame frames.Frame) error {
+	if err := a.ctx.Err(); err != nil {
+		return err
+	}
+
+	return a.FrameAppender.Add(frame)
+}
+
+func (a *contextAppender) WaitForComplete(timeout time.Duration) error {
+	if err := a.ctx.Err(); err != nil {
+		return err
+	}
+
+	return a.FrameAppender.WaitForComplete(ContextTimeout(a.ctx, timeout))
+}
+
+// ContextTimeout returns timeout capped by the ctx deadline
+func ContextTimeout(ctx context.Context, timeout time.Duration) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return timeout
+	}
+
+	if left := time.Until(deadline); timeout <= 0 || left < timeout {
+		// Zero means no timeout in some appenders
+		if left <= 0 {
+			left = time.Nanosecond
+		}
+		return left
+	}
+
+	return timeout
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package backends
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/v3io/frames"
+)
+
+// endlessIterator returns the same frame forever
+type endlessIterator struct {
+	frame frames.Frame
+}
+
+func (it *endlessIterator) Next() bool       { return true }
+func (it *endlessIterator) Err() error       { return nil }
+func (it *endlessIterator) At() frames.Frame { return it.frame }
+
+type endlessBackend struct {
+	frames.DataBackend
+}
+
+func (b *endlessBackend) Read(request *frames.ReadRequest) (frames.FrameIterator, error) {
+	return &endlessIterator{}, nil
+}
+
+func TestReadContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	iter, err := ReadContext(ctx, &endlessBackend{}, &frames.ReadRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		if !iter.Next() {
+			t.Fatalf("%d: iteration stopped - %v", i, iter.Err())
+		}
+	}
+
+	cancel()
+	if iter.Next() {
+		t.Fatal("iteration didn't stop after cancel")
+	}
+
+	if iter.Err() != context.Canceled {
+		t.Fatalf("bad error - %v", iter.Err())
+	}
+
+	if _, err := ReadContext(ctx, &endlessBackend{}, &frames.ReadRequest{}); err == nil {
+		t.Fatal("no error on read with cancelled context")
+	}
+}
+
+func TestContextTimeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	testCases := []struct {
+		ctx     context.Context
+		timeout time.Duration
+		max     time.Duration
+	}{
+		{context.Background(), time.Hour, time.Hour},
+		{context.Background(), 0, 0},
+		{ctx, time.Hour, time.Minute},
+		{ctx, 0, time.Minute},
+		{ctx, time.Second, time.Second},
+	}
+
+	for _, tc := range testCases {
+		timeout := ContextTimeout(tc.ctx, tc.timeout)
+		if timeout > tc.max || (tc.max > 0 && timeout < tc.max-time.Second) {
+			t.Fatalf("%v: bad timeout %v (max %v)", tc.timeout, timeout, tc.max)
+		}
+	}
+}
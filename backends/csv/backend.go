@@ -21,19 +21,26 @@ such restriction.
 package csv
 
 import (
-	"encoding/csv"
+	"bufio"
+	"context"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
-	"strconv"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/nuclio/logger"
 	"github.com/pkg/errors"
 	"github.com/v3io/frames"
 	"github.com/v3io/frames/backends"
+	"github.com/v3io/frames/backends/filter"
 	"github.com/v3io/frames/backends/utils"
+	"github.com/v3io/frames/ops"
+	"github.com/v3io/frames/pb"
+	"github.com/v3io/frames/v3ioutils"
 	v3io "github.com/v3io/v3io-go/pkg/dataplane"
 )
 
@@ -41,63 +48,103 @@ import (
 type Backend struct {
 	rootDir string
 	logger  logger.Logger
+	dialect *dialect
+}
+
+var capabilities = &frames.Capabilities{
+	Type:         "csv",
+	ReadFields:   backends.ReadFields("Marker", "GroupBy", "Aggregators"),
+	WriteFields:  backends.WriteFields("HaveMore", "SaveMode"),
+	CreateFields: backends.CreateFields("Schema"),
+	DeleteFields: backends.DeleteFields(),
+	Commands: []*frames.ExecCommand{
+		{
+			Name: "ping",
+			Doc:  "Return a frame of integers",
+			Args: []*frames.ExecArgument{
+				{Name: "rows", Type: v3ioutils.LongType, Doc: "Number of rows (default 37)"},
+				{Name: "cols", Type: v3ioutils.LongType, Doc: "Number of columns (default 4)"},
+			},
+		},
+	},
+	SaveModes: backends.SaveModes(frames.ErrorIfTableExists, frames.OverwriteTable, frames.AppendRows),
 }
 
 // NewBackend returns a new CSV backend
 func NewBackend(logger logger.Logger, v3ioContext v3io.Context, config *frames.BackendConfig, framesConfig *frames.Config) (frames.DataBackend, error) {
+	dialect, err := newDialect(config.Options)
+	if err != nil {
+		return nil, errors.Wrap(err, "bad CSV options")
+	}
+
 	backend := &Backend{
 		rootDir: config.RootDir,
 		logger:  logger.GetChild("csv"),
+		dialect: dialect,
 	}
 
 	return backend, nil
 }
 
-// Create creates a CSV file
+// Capabilities returns the requests the backend supports
+func (b *Backend) Capabilities() *frames.Capabilities {
+	return capabilities
+}
+
+// Create creates a CSV file. The schema is saved with the file and used to
+// parse columns on read
 func (b *Backend) Create(request *frames.CreateRequest) error {
+	if err := backends.ValidateRequest(capabilities, request.Proto); err != nil {
+		return err
+	}
+
 	csvPath := b.csvPath(request.Proto.Table)
 	// TODO: Overwrite?
 	if fileExists(csvPath) {
 		return fmt.Errorf("file '%q' already exists", request.Proto.Table)
 	}
 
+	schema := request.Proto.Schema
+	hasSchema := schema != nil && len(schema.Fields) > 0
+	if hasSchema {
+		if err := validateSchema(schema); err != nil {
+			return errors.Wrap(err, "bad schema")
+		}
+	}
+
 	file, err := os.Create(csvPath)
 	if err != nil {
 		return errors.Wrapf(err, "cannot create file")
 	}
 
 	defer file.Close()
-	if request.Proto.Schema == nil || len(request.Proto.Schema.Fields) == 0 {
+	if !hasSchema {
 		return nil
 	}
 
-	numFields := len(request.Proto.Schema.Fields)
-	names := make([]string, numFields)
-	for i, field := range request.Proto.Schema.Fields {
-		if field.Name == "" {
-			return fmt.Errorf("field %d with no name", i)
+	if b.dialect.header {
+		csvWriter := b.dialect.newWriter(file)
+		if err := csvWriter.Write(schemaNames(schema, 0)); err != nil {
+			return errors.Wrapf(err, "cannot create header")
 		}
 
-		names[i] = field.Name
-	}
-
-	csvWriter := csv.NewWriter(file)
-	if err := csvWriter.Write(names); err != nil {
-		return errors.Wrapf(err, "cannot create header")
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return errors.Wrapf(err, "cannot create header")
+		}
 	}
 
-	csvWriter.Flush()
 	if err := file.Sync(); err != nil {
 		return errors.Wrap(err, "cannot flush CSV file")
 	}
 
-	return nil
+	return writeSchema(csvPath, schema)
 }
 
 // Delete will delete a table
 func (b *Backend) Delete(request *frames.DeleteRequest) error {
 
-	err := backends.ValidateRequest("csv", request.Proto, nil)
+	err := backends.ValidateRequest(capabilities, request.Proto)
 	if err != nil {
 		return err
 	}
@@ -107,60 +154,441 @@ func (b *Backend) Delete(request *frames.DeleteRequest) error {
 		return fmt.Errorf("path to file '%q' doesn't exist", request.Proto.Table)
 	}
 
+	if request.Proto.Filter != "" {
+		return b.deleteRows(csvPath, request.Proto.Filter)
+	}
+
 	if err := os.Remove(csvPath); err != nil {
 		return errors.Wrapf(err, "cannot delete file '%q'", request.Proto.Table)
 	}
 
-	return nil
+	return removeSchema(csvPath)
+}
+
+// deleteRows deletes the rows matching the filter, the file is rewritten to
+// a temporary file which then replaces the original
+func (b *Backend) deleteRows(csvPath string, filterExpr string) error {
+	expr, err := filter.Parse(filterExpr)
+	if err != nil {
+		return errors.Wrap(err, "bad filter")
+	}
+
+	schema, err := readSchema(csvPath)
+	if err != nil {
+		return err
+	}
+
+	in, err := b.openTable(csvPath, schema)
+	if err != nil {
+		if os.IsNotExist(errors.Cause(err)) { // IfMissing is IgnoreError
+			return nil
+		}
+		return err
+	}
+	defer in.file.Close()
+
+	parsers, err := newFieldParsers(in.columns, schema, b.dialect)
+	if err != nil {
+		return err
+	}
+
+	out, err := ioutil.TempFile(filepath.Dir(csvPath), ".csv-delete")
+	if err != nil {
+		return errors.Wrap(err, "cannot create temporary file")
+	}
+	defer os.Remove(out.Name()) // Fails after the rename, which is fine
+
+	writer := b.dialect.newWriter(out)
+	if b.dialect.header {
+		if err := writer.Write(in.columns); err != nil {
+			out.Close()
+			return errors.Wrap(err, "cannot write header")
+		}
+	}
+
+	row := newCSVRow(in.columns, parsers)
+	numDeleted := 0
+	for {
+		record, err := in.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			out.Close()
+			return err
+		}
+
+		row.values = record
+		match, err := filter.Match(expr, row)
+		if err != nil {
+			out.Close()
+			return errors.Wrap(err, "cannot evaluate filter")
+		}
+
+		if match {
+			numDeleted++
+			continue
+		}
+
+		if err := writer.Write(record); err != nil {
+			out.Close()
+			return errors.Wrap(err, "cannot write record")
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		out.Close()
+		return err
+	}
+
+	if err := out.Close(); err != nil {
+		return err
+	}
+
+	b.logger.DebugWith("deleted rows", "path", csvPath, "filter", filterExpr, "numDeleted", numDeleted)
+	return os.Rename(out.Name(), csvPath)
 }
 
 // Read handles reading
 func (b *Backend) Read(request *frames.ReadRequest) (frames.FrameIterator, error) {
+	return b.ReadContext(context.Background(), request)
+}
 
-	err := backends.ValidateRequest("csv", request.Proto, nil)
+// ReadContext reads a CSV file, the file is closed once the rows are read, on
+// error or once ctx is done
+func (b *Backend) ReadContext(ctx context.Context, request *frames.ReadRequest) (frames.FrameIterator, error) {
+	err := backends.ValidateRequest(capabilities, request.Proto)
 	if err != nil {
 		return nil, err
 	}
 
-	file, err := os.Open(b.csvPath(request.Proto.Table))
+	var expr filter.Expr
+	if request.Proto.Filter != "" {
+		expr, err = filter.Parse(request.Proto.Filter)
+		if err != nil {
+			return nil, errors.Wrap(err, "bad filter")
+		}
+	}
+
+	keys, aggregations, err := backends.ParseGroupBy(request.Proto)
 	if err != nil {
 		return nil, err
 	}
 
-	reader := csv.NewReader(file)
-	columns, err := reader.Read()
+	// An explicit schema in the request takes precedence over the table schema
+	csvPath := b.csvPath(request.Proto.Table)
+	schema := request.Proto.Schema
+	if schema == nil || len(schema.Fields) == 0 {
+		schema, err = readSchema(csvPath)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	table, err := b.openTable(csvPath, schema)
 	if err != nil {
-		return nil, errors.Wrap(err, "cannot read header (columns)")
+		return nil, err
+	}
+
+	columns := request.Proto.Columns
+	if aggregations != nil {
+		columns = backends.GroupByColumns(columns, keys, aggregations)
+	}
+
+	names, indices, err := projectColumns(table.columns, columns)
+	if err != nil {
+		table.file.Close()
+		return nil, err
+	}
+
+	parsers, err := newFieldParsers(table.columns, schema, b.dialect)
+	if err != nil {
+		table.file.Close()
+		return nil, err
 	}
 
 	it := &FrameIterator{
 		logger:      b.logger,
 		path:        request.Proto.Table,
-		reader:      reader,
-		columnNames: columns,
+		reader:      table,
+		numFields:   len(table.columns),
+		columnNames: names,
+		indices:     indices,
+		parsers:     parsers,
 		limit:       int(request.Proto.Limit),
 		frameLimit:  int(request.Proto.MessageLimit),
+		filter:      expr,
+		row:         newCSVRow(table.columns, parsers),
+		ctx:         ctx,
+		done:        make(chan struct{}),
+	}
+
+	if request.Proto.Marker != "" {
+		if err := it.resume(request.Proto.Marker); err != nil {
+			table.file.Close()
+			return nil, err
+		}
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			it.close()
+		case <-it.done:
+		}
+	}()
+
+	if aggregations != nil {
+		collect := func() (*ops.GroupBy, error) {
+			return aggregate(it, keys, aggregations)
+		}
+		return backends.NewGroupByIterator(collect, int(request.Proto.MessageLimit)), nil
 	}
 
 	return it, nil
 }
 
-// Write handles writing
+// aggregate returns the group by of the rows of it
+func aggregate(it *FrameIterator, keys []string, aggregations []ops.Aggregation) (*ops.GroupBy, error) {
+	groupBy, err := ops.NewGroupBy(keys, aggregations)
+	if err != nil {
+		return nil, err
+	}
+
+	for it.Next() {
+		if err := groupBy.Add(it.At()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return groupBy, nil
+}
+
+// resume moves the iterator to the position of a marker
+func (it *FrameIterator) resume(marker string) error {
+	m, err := decodeMarker(marker)
+	if err != nil {
+		return err
+	}
+
+	// Markers can't point into the header
+	if m.Offset < it.reader.offset() {
+		return fmt.Errorf("bad marker - offset %d before first record", m.Offset)
+	}
+
+	if err := it.reader.seek(m.Offset); err != nil {
+		return errors.Wrap(err, "can't seek to marker")
+	}
+
+	it.nRows, it.nRead = m.NRows, m.NRead
+	return nil
+}
+
+// tableReader reads records of a table
+type tableReader struct {
+	file    *os.File
+	counter *countingReader
+	buffer  *bufio.Reader // Shared with reader, so we can tell the record offsets
+	dialect *dialect
+	reader  recordReader
+	columns []string
+	pending []string // First record, read to count columns of files without header
+}
+
+// openTable opens a table for reading. Column names are read from the header,
+// or taken from the schema for files without one
+func (b *Backend) openTable(csvPath string, schema *frames.TableSchema) (*tableReader, error) {
+	file, err := os.Open(csvPath)
+	if err != nil {
+		return nil, err
+	}
+
+	counter := &countingReader{r: file}
+	buffer := bufio.NewReader(counter)
+	table := &tableReader{
+		file:    file,
+		counter: counter,
+		buffer:  buffer,
+		dialect: b.dialect,
+		reader:  b.dialect.newReader(buffer),
+	}
+	switch {
+	case b.dialect.header:
+		table.columns, err = readHeader(table.reader)
+		if err != nil && err != io.EOF { // Empty file has no columns
+			file.Close()
+			return nil, errors.Wrap(err, "cannot read header (columns)")
+		}
+	case schema != nil && len(schema.Fields) > 0:
+		table.columns = schemaNames(schema, 0)
+	default:
+		table.pending, err = readHeader(table.reader)
+		if err != nil && err != io.EOF {
+			file.Close()
+			return nil, errors.Wrap(err, "cannot read first row")
+		}
+		table.columns = schemaNames(nil, len(table.pending))
+	}
+
+	return table, nil
+}
+
+// Read returns the next record
+func (t *tableReader) Read() ([]string, error) {
+	if t.pending != nil {
+		record := t.pending
+		t.pending = nil
+		return record, nil
+	}
+
+	return t.reader.Read()
+}
+
+// offset returns the file offset of the next record
+func (t *tableReader) offset() int64 {
+	if t.pending != nil { // The first record
+		return 0
+	}
+
+	return t.counter.n - int64(t.buffer.Buffered())
+}
+
+// seek moves to a record at offset
+func (t *tableReader) seek(offset int64) error {
+	if _, err := t.file.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	t.counter.n = offset
+	t.buffer.Reset(t.counter)
+	t.reader = t.dialect.newReader(t.buffer)
+	t.pending = nil
+	return nil
+}
+
+// projectColumns returns the names and header indices of the requested
+// columns (all columns if none were requested)
+func projectColumns(header []string, requested []string) ([]string, []int, error) {
+	if len(requested) == 0 {
+		indices := make([]int, len(header))
+		for i := range header {
+			indices[i] = i
+		}
+		return header, indices, nil
+	}
+
+	byName := make(map[string]int, len(header))
+	for i, name := range header {
+		byName[name] = i
+	}
+
+	indices := make([]int, len(requested))
+	for i, name := range requested {
+		index, ok := byName[name]
+		if !ok {
+			return nil, nil, fmt.Errorf("column '%v' doesn't exist", name)
+		}
+		indices[i] = index
+	}
+
+	return requested, indices, nil
+}
+
+// WriteContext writes to a CSV file, the appender fails once ctx is done
+func (b *Backend) WriteContext(ctx context.Context, request *frames.WriteRequest) (frames.FrameAppender, error) {
+	appender, err := b.Write(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return backends.NewContextAppender(ctx, appender), nil
+}
+
+// Write handles writing. AppendRows appends rows to an existing file, item
+// save modes are not supported since CSV rows have no keys
 func (b *Backend) Write(request *frames.WriteRequest) (frames.FrameAppender, error) {
-	// TODO: Append?
-	file, err := os.Create(b.csvPath(request.Table))
+	err := backends.ValidateRequest(capabilities, request)
+	if err != nil {
+		return nil, err
+	}
+
+	csvPath := b.csvPath(request.Table)
+
+	var (
+		header []string
+		schema *frames.TableSchema
+	)
+	newTable := true
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	switch request.SaveMode {
+	case frames.OverwriteTable:
+	case frames.ErrorIfTableExists, frames.AppendRows:
+		if !fileExists(csvPath) {
+			break
+		}
+
+		schema, err = readSchema(csvPath)
+		if err != nil {
+			return nil, err
+		}
+
+		var hasRows bool
+		header, hasRows, err = b.tableHeader(csvPath, schema)
+		if err != nil {
+			return nil, err
+		}
+
+		// A file with only a header (e.g. from Create) is not considered as existing
+		if hasRows && request.SaveMode == frames.ErrorIfTableExists {
+			return nil, fmt.Errorf("table '%v' already exists; either use a different save mode or save to a different table", request.Table)
+		}
+
+		if err := ensureNewline(csvPath); err != nil {
+			return nil, err
+		}
+		flags = os.O_WRONLY | os.O_APPEND
+		newTable = false
+	default:
+		return nil, fmt.Errorf("unknown save mode - %d", request.SaveMode)
+	}
+
+	// The schema of new tables is written with the first frame
+	if newTable {
+		if err := removeSchema(csvPath); err != nil {
+			return nil, err
+		}
+	}
+
+	file, err := os.OpenFile(csvPath, flags, 0666)
 	if err != nil {
 		return nil, err
 	}
 
 	ca := &csvAppender{
-		writer:    file,
-		csvWriter: csv.NewWriter(file),
-		logger:    b.logger,
+		writer:      file,
+		csvWriter:   b.dialect.newWriter(file),
+		logger:      b.logger,
+		path:        csvPath,
+		dialect:     b.dialect,
+		header:      header,
+		writeHeader: newTable && b.dialect.header,
+		newTable:    newTable,
+	}
+
+	if header != nil {
+		if ca.parsers, err = newFieldParsers(header, schema, b.dialect); err != nil {
+			file.Close()
+			return nil, err
+		}
 	}
 
 	if request.ImmidiateData != nil {
 		if err := ca.Add(request.ImmidiateData); err != nil {
+			ca.Close()
 			return nil, errors.Wrap(err, "cannot add immediate data")
 		}
 	}
@@ -169,6 +597,62 @@ func (b *Backend) Write(request *frames.WriteRequest) (frames.FrameAppender, err
 
 }
 
+// tableHeader returns the columns of a table and if it has rows. Columns are
+// nil for files without header and schema
+func (b *Backend) tableHeader(csvPath string, schema *frames.TableSchema) ([]string, bool, error) {
+	table, err := b.openTable(csvPath, schema)
+	if err != nil {
+		return nil, false, err
+	}
+	defer table.file.Close()
+
+	header := table.columns
+	if len(header) == 0 || table.pending != nil {
+		return nil, table.pending != nil, nil
+	}
+
+	_, err = table.Read()
+	switch err {
+	case nil:
+		return header, true, nil
+	case io.EOF:
+		return header, false, nil
+	}
+
+	return nil, false, errors.Wrap(err, "cannot read first row")
+}
+
+// ensureNewline adds a missing newline at the end of a non empty file so
+// appended rows start in a new line
+func ensureNewline(csvPath string) error {
+	file, err := os.OpenFile(csvPath, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	if info.Size() == 0 {
+		return nil
+	}
+
+	last := make([]byte, 1)
+	if _, err := file.ReadAt(last, info.Size()-1); err != nil {
+		return err
+	}
+
+	if last[0] == '\n' {
+		return nil
+	}
+
+	_, err = file.WriteAt([]byte("\n"), info.Size())
+	return err
+}
+
 func getInt(r *frames.ExecRequest, name string, defval int) int {
 	ival, err := r.Proto.Arg(name)
 	if err != nil {
@@ -183,8 +667,21 @@ func getInt(r *frames.ExecRequest, name string, defval int) int {
 	return int(val)
 }
 
+// ExecContext executes a command if ctx is not done
+func (b *Backend) ExecContext(ctx context.Context, request *frames.ExecRequest) (frames.Frame, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return b.Exec(request)
+}
+
 // Exec executes a command
 func (b *Backend) Exec(request *frames.ExecRequest) (frames.Frame, error) {
+	if err := backends.ValidateRequest(capabilities, request.Proto); err != nil {
+		return nil, err
+	}
+
 	if strings.ToLower(request.Proto.Command) == "ping" {
 		b.logger.Info("PONG")
 		nRows, nCols := getInt(request, "rows", 37), getInt(request, "cols", 4)
@@ -213,19 +710,44 @@ func (b *Backend) csvPath(table string) string {
 type FrameIterator struct {
 	logger      logger.Logger
 	path        string
-	reader      *csv.Reader
+	reader      *tableReader
 	frame       frames.Frame
 	err         error
-	columnNames []string
+	numFields   int
+	columnNames []string       // Requested columns
+	indices     []int          // Requested column indices in records
+	parsers     []*fieldParser // Per record field
 	nRows       int
+	nRead       int
 	limit       int
 	frameLimit  int
+	filter      filter.Expr
+	row         *csvRow
+	ctx         context.Context
+	done        chan struct{} // Closed with the file
+	closeOnce   sync.Once
 }
 
 // Next reads the next frame, return true of succeeded
 func (it *FrameIterator) Next() bool {
+	if it.next() {
+		return true
+	}
+
+	it.close()
+	return false
+}
+
+func (it *FrameIterator) next() bool {
+	if it.err = it.ctx.Err(); it.err != nil {
+		return false
+	}
+
 	rows, err := it.readNextRows()
 	if err != nil {
+		if ctxErr := it.ctx.Err(); ctxErr != nil {
+			err = ctxErr // The file was closed since ctx is done
+		}
 		it.logger.ErrorWith("cannot read rows", "error", err)
 		it.err = err
 		return false
@@ -235,16 +757,39 @@ func (it *FrameIterator) Next() bool {
 		return false
 	}
 
-	it.frame, err = it.buildFrame(rows)
+	frame, err := it.buildFrame(rows)
 	if err != nil {
 		it.logger.ErrorWith("cannot build a DataFrames iterator", "error", err)
 		it.err = err
 		return false
 	}
 
+	marker := &readMarker{Offset: it.reader.offset(), NRows: it.nRows, NRead: it.nRead}
+	encoded, err := marker.encode()
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	it.frame, err = frames.WithMarker(frame, encoded)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
 	return true
 }
 
+// close closes the file, it's safe to call more than once
+func (it *FrameIterator) close() {
+	it.closeOnce.Do(func() {
+		if err := it.reader.file.Close(); err != nil {
+			it.logger.WarnWith("cannot close file", "path", it.path, "error", err)
+		}
+		close(it.done)
+	})
+}
+
 // At return the current Frame
 func (it *FrameIterator) At() frames.Frame {
 	return it.frame
@@ -257,7 +802,7 @@ func (it *FrameIterator) Err() error {
 
 func (it *FrameIterator) readNextRows() ([][]string, error) {
 	var rows [][]string
-	for r := 0; it.inLimits(r); r, it.nRows = r+1, it.nRows+1 {
+	for r := 0; it.inLimits(r); {
 		row, err := it.reader.Read()
 		if err != nil {
 			if err == io.EOF {
@@ -268,13 +813,35 @@ func (it *FrameIterator) readNextRows() ([][]string, error) {
 			return nil, err
 		}
 
-		if len(row) != len(it.columnNames) {
-			err := fmt.Errorf("%s (row %d) number of columns doesn't match headers (%d != %d)", it.path, it.nRows, len(row), len(it.columnNames))
-			it.logger.ErrorWith("row size mismatch", "error", err, "row", it.nRows)
+		if len(row) != it.numFields {
+			err := fmt.Errorf("%s (row %d) number of columns doesn't match headers (%d != %d)", it.path, it.nRead, len(row), it.numFields)
+			it.logger.ErrorWith("row size mismatch", "error", err, "row", it.nRead)
 			return nil, err
 		}
+		it.nRead++
+
+		if it.filter != nil {
+			it.row.values = row
+			match, err := filter.Match(it.filter, it.row)
+			if err != nil {
+				return nil, errors.Wrapf(err, "%s (row %d) cannot evaluate filter", it.path, it.nRead-1)
+			}
+
+			if !match {
+				continue
+			}
+		}
+
+		// Only requested fields are kept (and later parsed). Values are copied
+		// since the record is reused and so they don't pin the whole line
+		projected := make([]string, len(it.indices))
+		for i, index := range it.indices {
+			projected[i] = string([]byte(row[index]))
+		}
 
-		rows = append(rows, row)
+		rows = append(rows, projected)
+		r++
+		it.nRows++
 	}
 
 	return rows, nil
@@ -293,84 +860,37 @@ func (it *FrameIterator) inLimits(frameRow int) bool {
 }
 
 func (it *FrameIterator) buildFrame(rows [][]string) (frames.Frame, error) {
-	columns := make([]frames.Column, len(it.columnNames))
-	for c, colName := range it.columnNames {
-		var (
-			val0 = it.parseValue(rows[0][c])
-			col  frames.Column
-			data interface{}
-			err  error
-		)
-
-		switch val0 := val0.(type) {
-		case int64:
-			typedData := make([]int64, len(rows))
-			typedData[0] = val0
-			for r, row := range rows[1:] {
-				val, ok := it.parseValue(row[c]).(int64)
-				if !ok {
-					err := fmt.Errorf("type mismatch in row %d, col %d", it.nRows, c)
-					it.logger.ErrorWith("type mismatch", "error", err)
-					return nil, err
-				}
-
-				typedData[r+1] = val // +1 since we start in first row
-			}
-			data = typedData
-		case float64:
-			typedData := make([]float64, len(rows))
-			typedData[0] = val0
-			for r, row := range rows[1:] {
-				val, ok := it.parseValue(row[c]).(float64)
-				if !ok {
-					err := fmt.Errorf("type mismatch in row %d, col %d", it.nRows, c)
-					it.logger.ErrorWith("type mismatch", "error", err)
-					return nil, err
-				}
-
-				typedData[r+1] = val // +1 since we start in first row
-			}
-			data = typedData
-		case string:
-			typedData := make([]string, len(rows))
-			typedData[0] = val0
-			for r, row := range rows[1:] {
-				typedData[r+1] = row[c] // +1 since we start in first row
-			}
-			data = typedData
-		case time.Time:
-			typedData := make([]time.Time, len(rows))
-			typedData[0] = val0
-			for r, row := range rows[1:] {
-				val, ok := it.parseValue(row[c]).(time.Time)
-				if !ok {
-					err := fmt.Errorf("type mismatch in row %d, col %d", it.nRows, c)
-					it.logger.ErrorWith("type mismatch", "error", err)
-					return nil, err
-				}
+	var (
+		columns     = make([]frames.Column, len(it.columnNames))
+		nullValues  = make([]*pb.NullValuesMap, len(rows))
+		hasAnyNulls bool
+		firstRow    = it.nRows - len(rows)
+	)
+
+	for i := range nullValues {
+		nullValues[i] = &pb.NullValuesMap{NullColumns: make(map[string]bool)}
+	}
 
-				typedData[r+1] = val // +1 since we start in first row
+	values := make([]interface{}, len(rows))
+	for c, colName := range it.columnNames {
+		parser := it.parsers[it.indices[c]]
+		for r, row := range rows {
+			val, err := parser.parse(row[c])
+			if err != nil {
+				err = fmt.Errorf("%s (row %d) column '%s': %s", it.path, firstRow+r, colName, err)
+				it.logger.ErrorWith("cannot parse value", "error", err)
+				return nil, err
 			}
-			data = typedData
-		case bool:
-			typedData := make([]bool, len(rows))
-			typedData[0] = val0
-			for r, row := range rows[1:] {
-				val, ok := it.parseValue(row[c]).(bool)
-				if !ok {
-					err := fmt.Errorf("type mismatch in row %d, col %d", it.nRows, c)
-					it.logger.ErrorWith("type mismatch", "error", err)
-					return nil, err
-				}
+			values[r] = val
+		}
 
-				typedData[r+1] = val // +1 since we start in first row
-			}
-			data = typedData
-		default:
-			return nil, fmt.Errorf("%s - unknown type '%T'", colName, val0)
+		// Types which are not in the schema are inferred per frame
+		dtype := parser.dtype
+		if dtype == 0 {
+			dtype = inferType(values)
 		}
 
-		col, err = frames.NewSliceColumn(colName, data)
+		col, err := it.buildColumn(colName, dtype, rows, c, values, nullValues, &hasAnyNulls)
 		if err != nil {
 			it.logger.ErrorWith("cannot build column", "error", err, "column", colName)
 			return nil, errors.Wrapf(err, "cannot build column '%s'", colName)
@@ -379,49 +899,94 @@ func (it *FrameIterator) buildFrame(rows [][]string) (frames.Frame, error) {
 		columns[c] = col
 	}
 
-	return frames.NewFrame(columns, nil, nil)
+	if !hasAnyNulls {
+		nullValues = nil
+	}
+
+	return frames.NewFrameWithNullValues(columns, nil, nil, nullValues)
 }
 
-func (it *FrameIterator) parseValue(value string) interface{} {
-	// time/date formats
-	timeFormats := []string{time.RFC3339, time.RFC3339Nano, "2006-01-02"}
-	for _, format := range timeFormats {
-		t, err := time.Parse(format, value)
-		if err == nil {
-			return t
+func (it *FrameIterator) buildColumn(name string, dtype frames.DType, rows [][]string, c int, values []interface{}, nullValues []*pb.NullValuesMap, hasAnyNulls *bool) (frames.Column, error) {
+	data, err := utils.NewColumnFromType(v3ioutils.ConvertDTypeToString(dtype), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	col, err := frames.NewSliceColumn(name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	for r, val := range values {
+		if val == nil {
+			if err := utils.AppendNil(col); err != nil {
+				return nil, err
+			}
+			nullValues[r].NullColumns[name] = true
+			*hasAnyNulls = true
+			continue
+		}
+
+		switch dtype {
+		case frames.FloatType:
+			if ival, ok := val.(int64); ok {
+				val = float64(ival)
+			}
+		case frames.StringType:
+			val = rows[r][c] // Mixed types are kept as they are in the file
+		}
+
+		if err := utils.AppendColumn(col, val); err != nil {
+			return nil, err
 		}
 	}
 
-	// bool
-	switch strings.ToLower(value) {
-	case "true":
-		return true
-	case "false":
-		return false
+	return col, nil
+}
+
+// csvRow is a CSV record, used for evaluating filters
+type csvRow struct {
+	columns map[string]int
+	parsers []*fieldParser
+	values  []string
+}
+
+func newCSVRow(columnNames []string, parsers []*fieldParser) *csvRow {
+	columns := make(map[string]int, len(columnNames))
+	for i, name := range columnNames {
+		columns[name] = i
 	}
 
-	// int
-	i, err := strconv.Atoi(value)
-	if err == nil {
-		return int64(i)
+	return &csvRow{columns: columns, parsers: parsers}
+}
+
+// Value returns the parsed value of a column, values which can't be parsed
+// are treated as missing
+func (r *csvRow) Value(name string) (interface{}, bool) {
+	i, ok := r.columns[name]
+	if !ok || i >= len(r.values) {
+		return nil, false
 	}
 
-	// float
-	f, err := strconv.ParseFloat(value, 64)
-	if err == nil {
-		return f
+	value, err := r.parsers[i].parse(r.values[i])
+	if err != nil || value == nil {
+		return nil, false
 	}
 
-	// Leave as string
-	return value
+	return value, true
 }
 
 type csvAppender struct {
-	logger        logger.Logger
-	writer        io.Writer
-	csvWriter     *csv.Writer
-	headerWritten bool
-	closed        bool
+	logger      logger.Logger
+	writer      io.Writer
+	csvWriter   recordWriter
+	path        string
+	dialect     *dialect
+	header      []string // nil until the first frame for new tables
+	parsers     []*fieldParser
+	writeHeader bool
+	newTable    bool // Schema is taken from the first frame
+	closed      bool
 }
 
 func (ca *csvAppender) Add(frame frames.Frame) error {
@@ -431,18 +996,26 @@ func (ca *csvAppender) Add(frame frames.Frame) error {
 		return err
 	}
 	ca.logger.InfoWith("adding frame", "size", frame.Len())
-	names := frame.Names()
-	if !ca.headerWritten {
-		if err := ca.csvWriter.Write(names); err != nil {
-			ca.logger.ErrorWith("cannot write header", "error", err)
-			return errors.Wrap(err, "cannot write header")
+	if ca.header == nil {
+		if err := ca.initHeader(frame); err != nil {
+			return err
 		}
-		ca.headerWritten = true
+	}
+
+	// Columns are written in header order
+	names := ca.header
+	if err := validateColumns(frame.Names(), names); err != nil {
+		ca.logger.ErrorWith("columns mismatch", "error", err)
+		return err
 	}
 
 	for r := 0; r < frame.Len(); r++ {
 		record := make([]string, len(names))
 		for c, name := range names {
+			if frame.IsNull(r, name) {
+				continue // Nulls are empty
+			}
+
 			col, err := frame.Column(name)
 			if err != nil {
 				ca.logger.ErrorWith("cannot get column", "error", err)
@@ -455,7 +1028,10 @@ func (ca *csvAppender) Add(frame frames.Frame) error {
 				return errors.Wrapf(err, "%s:%d cannot get value", name, r)
 			}
 
-			record[c] = fmt.Sprintf("%v", val)
+			if record[c], err = ca.parsers[c].format(val); err != nil {
+				ca.logger.ErrorWith("cannot format value", "error", err, "name", name, "row", r)
+				return errors.Wrapf(err, "%s:%d cannot format value", name, r)
+			}
 		}
 
 		if err := ca.csvWriter.Write(record); err != nil {
@@ -467,6 +1043,58 @@ func (ca *csvAppender) Add(frame frames.Frame) error {
 	return nil
 }
 
+// initHeader sets the columns from the first frame, writing the header and
+// the schema of new tables
+func (ca *csvAppender) initHeader(frame frames.Frame) error {
+	header := frame.Names()
+	if ca.writeHeader {
+		if err := ca.csvWriter.Write(header); err != nil {
+			ca.logger.ErrorWith("cannot write header", "error", err)
+			return errors.Wrap(err, "cannot write header")
+		}
+	}
+
+	if ca.newTable {
+		schema, err := schemaFromFrame(frame)
+		if err != nil {
+			return err
+		}
+
+		if err := writeSchema(ca.path, schema); err != nil {
+			return err
+		}
+	}
+
+	parsers, err := newFieldParsers(header, nil, ca.dialect)
+	if err != nil {
+		return err
+	}
+
+	ca.header, ca.parsers = header, parsers
+	return nil
+}
+
+// validateColumns checks that the frame has the same columns as the header
+func validateColumns(names []string, header []string) error {
+	inHeader := make(map[string]bool, len(header))
+	for _, name := range header {
+		inHeader[name] = true
+	}
+
+	mismatch := len(names) != len(header)
+	for _, name := range names {
+		if !inHeader[name] {
+			mismatch = true
+		}
+	}
+
+	if mismatch {
+		return fmt.Errorf("frame columns %v don't match table columns %v", names, header)
+	}
+
+	return nil
+}
+
 // File Sync
 type syncer interface {
 	Sync() error
@@ -494,6 +1122,11 @@ func (ca *csvAppender) WaitForComplete(timeout time.Duration) error {
 
 func (ca *csvAppender) Close() {
 	ca.closed = true
+	if closer, ok := ca.writer.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			ca.logger.WarnWith("cannot close file", "error", err)
+		}
+	}
 }
 
 func fileExists(path string) bool {
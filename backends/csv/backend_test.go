@@ -21,9 +21,14 @@ such restriction.
 package csv
 
 import (
+	"context"
 	"io/ioutil"
+	"os"
 	"path"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/v3io/frames"
 	"github.com/v3io/frames/pb"
@@ -113,16 +118,129 @@ func TestMaxInMessage(t *testing.T) {
 	}
 }
 
-func totalRows(result []frames.Frame) int {
-	total := 0
-	for _, frame := range result {
-		total += frame.Len()
+func TestResume(t *testing.T) {
+	frameLimit := numCSVRows / 3
+
+	req := &frames.ReadRequest{Proto: &pb.ReadRequest{}}
+	req.Proto.MessageLimit = int64(frameLimit)
+	result := loadTempCSV(t, req)
+
+	marker := result[0].Marker()
+	if marker == "" {
+		t.Fatal("no marker in frame")
 	}
 
-	return total
+	req = &frames.ReadRequest{Proto: &pb.ReadRequest{}}
+	req.Proto.MessageLimit = int64(frameLimit)
+	req.Proto.Marker = marker
+	resumed := loadTempCSV(t, req)
+
+	if nRows, expected := totalRows(resumed), numCSVRows-frameLimit; nRows != expected {
+		t.Fatalf("got %d rows, expected %d", nRows, expected)
+	}
+
+	for i := range resumed {
+		if !reflect.DeepEqual(resumed[i].Names(), result[i+1].Names()) {
+			t.Fatalf("%d: names mismatch", i)
+		}
+		for _, name := range resumed[i].Names() {
+			col, err := resumed[i].Column(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected, err := result[i+1].Column(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(col, expected) {
+				t.Fatalf("%d: column %q mismatch", i, name)
+			}
+		}
+	}
+
+	req = &frames.ReadRequest{Proto: &pb.ReadRequest{}}
+	req.Proto.Marker = "not a marker"
+	if _, err := readTempCSV(t, req); err == nil {
+		t.Fatal("no error on bad marker")
+	}
 }
 
-func loadTempCSV(t *testing.T, req *frames.ReadRequest) []frames.Frame {
+func TestReadClosesFile(t *testing.T) {
+	req := &frames.ReadRequest{Proto: &pb.ReadRequest{}}
+	it, err := readTempCSV(t, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for it.Next() {
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := it.(*FrameIterator).reader.file.Close(); err == nil {
+		t.Fatal("file not closed at end of read")
+	}
+}
+
+func TestReadCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	req := &frames.ReadRequest{Proto: &pb.ReadRequest{}}
+	req.Proto.MessageLimit = 2
+	it, err := readTempCSVContext(t, ctx, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !it.Next() {
+		t.Fatalf("no first frame - %v", it.Err())
+	}
+
+	cancel()
+	select {
+	case <-it.(*FrameIterator).done:
+	case <-time.After(time.Second):
+		t.Fatal("file not closed after cancel")
+	}
+
+	if it.Next() {
+		t.Fatal("got frame after cancel")
+	}
+	if err := it.Err(); err != context.Canceled {
+		t.Fatalf("bad error after cancel - %v", err)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	req := &frames.ReadRequest{Proto: &pb.ReadRequest{}}
+	req.Proto.Filter = "PRCP > 0 and DATE < '2000-01-13'"
+	req.Proto.Limit = 2
+
+	result := loadTempCSV(t, req)
+	if nRows := totalRows(result); nRows != 2 {
+		t.Fatalf("got %d rows, expected %d", nRows, 2)
+	}
+
+	col, err := result[0].Column("PRCP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	prcp, err := col.Ints()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prcp[0] != 178 || prcp[1] != 5 {
+		t.Fatalf("bad PRCP values - %v", prcp)
+	}
+
+	req = &frames.ReadRequest{Proto: &pb.ReadRequest{}}
+	req.Proto.Filter = "starts(STATION, 'XYZ')"
+	if nRows := totalRows(loadTempCSV(t, req)); nRows != 0 {
+		t.Fatalf("got %d rows, expected none", nRows)
+	}
+}
+
+func TestDeleteFilter(t *testing.T) {
 	logger, err := frames.NewLogger("debug")
 	if err != nil {
 		t.Fatalf("can't create logger - %s", err)
@@ -144,8 +262,440 @@ func loadTempCSV(t *testing.T, req *frames.ReadRequest) []frames.Frame {
 		t.Fatal(err)
 	}
 
-	req.Proto.Table = path.Base(csvPath)
-	it, err := backend.Read(req)
+	req := &frames.DeleteRequest{Proto: &pb.DeleteRequest{
+		Table:  path.Base(csvPath),
+		Filter: "PRCP == 0 or TMIN < 0",
+	}}
+	if err := backend.Delete(req); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(csvPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Header and the 3 rows with PRCP > 0 and TMIN >= 0
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 4 {
+		t.Fatalf("got %d lines, expected %d:\n%s", len(lines), 4, data)
+	}
+}
+
+func TestColumns(t *testing.T) {
+	req := &frames.ReadRequest{Proto: &pb.ReadRequest{}}
+	req.Proto.Columns = []string{"TMAX", "STATION"}
+	req.Proto.Filter = "TMIN < 0" // Filter on a column that is not returned
+
+	result := loadTempCSV(t, req)
+	if nRows := totalRows(result); nRows != 3 {
+		t.Fatalf("got %d rows, expected %d", nRows, 3)
+	}
+
+	for _, frame := range result {
+		if names := frame.Names(); !reflect.DeepEqual(names, req.Proto.Columns) {
+			t.Fatalf("bad columns - %v", names)
+		}
+	}
+
+	req = &frames.ReadRequest{Proto: &pb.ReadRequest{}}
+	req.Proto.Columns = []string{"TMAX", "NOPE"}
+	_, err := readTempCSV(t, req)
+	if err == nil {
+		t.Fatal("no error on unknown column")
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	req := &frames.ReadRequest{Proto: &pb.ReadRequest{}}
+	req.Proto.Columns = []string{"TMAX"} // Key and aggregated columns are added
+	req.Proto.GroupBy = "STATION"
+	req.Proto.Aggregators = "sum(PRCP),max,count"
+	req.Proto.MessageLimit = 5
+
+	result := loadTempCSV(t, req)
+	if len(result) != 1 || result[0].Len() != 1 {
+		t.Fatalf("bad result - %v", result)
+	}
+
+	frame := result[0]
+	expected := map[string]int64{
+		"sum(PRCP)":   409,
+		"max(TMAX)":   178,
+		"max(PRCP)":   213,
+		"count(TMAX)": int64(numCSVRows),
+		"count(PRCP)": int64(numCSVRows),
+	}
+	if len(frame.Names()) != len(expected) {
+		t.Fatalf("bad columns - %v", frame.Names())
+	}
+
+	for name, value := range expected {
+		col, err := frame.Column(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if out, err := col.IntAt(0); err != nil || out != value {
+			t.Fatalf("bad %s - %v != %v (%v)", name, out, value, err)
+		}
+	}
+
+	if station, _ := frame.Indices()[0].StringAt(0); station != "GHCND:USW00094728" {
+		t.Fatalf("bad station - %q", station)
+	}
+
+	req = &frames.ReadRequest{Proto: &pb.ReadRequest{}}
+	req.Proto.GroupBy = "STATION"
+	if _, err := readTempCSV(t, req); err == nil {
+		t.Fatal("no error on group by without aggregators")
+	}
+}
+
+func TestSaveModes(t *testing.T) {
+	logger, err := frames.NewLogger("debug")
+	if err != nil {
+		t.Fatalf("can't create logger - %s", err)
+	}
+
+	rootDir, err := ioutil.TempDir("", "csv-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	cfg := &frames.BackendConfig{Name: "testCsv", Type: "csv", RootDir: rootDir}
+	backend, err := NewBackend(logger, nil, cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	write := func(table string, mode frames.SaveMode, names ...string) error {
+		var cols []frames.Column
+		for i, name := range names {
+			col, err := frames.NewSliceColumn(name, []int64{int64(i), int64(i * 10)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			cols = append(cols, col)
+		}
+
+		frame, err := frames.NewFrame(cols, nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := &frames.WriteRequest{Table: table, SaveMode: mode, ImmidiateData: frame}
+		appender, err := backend.Write(req)
+		if err != nil {
+			return err
+		}
+		defer appender.Close()
+		return appender.WaitForComplete(0)
+	}
+
+	content := func(table string) string {
+		data, err := ioutil.ReadFile(path.Join(rootDir, table))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	if err := write("t1", frames.ErrorIfTableExists, "a", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := write("t1", frames.ErrorIfTableExists, "a", "b"); err == nil {
+		t.Fatal("no error writing to an existing table")
+	}
+
+	// Appended columns are written in header order
+	if err := write("t1", frames.AppendRows, "b", "a"); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "a,b\n0,1\n0,10\n1,0\n10,0\n"; content("t1") != expected {
+		t.Fatalf("bad content after append:\n%s", content("t1"))
+	}
+
+	if err := write("t1", frames.AppendRows, "a", "c"); err == nil {
+		t.Fatal("no error appending mismatched columns")
+	}
+
+	// Item modes need keys, they don't append duplicate rows
+	for _, mode := range []frames.SaveMode{frames.UpdateItem, frames.OverwriteItem, frames.CreateNewItemsOnly} {
+		if err := write("t1", mode, "a", "b"); err == nil {
+			t.Fatalf("no error writing with %s", mode)
+		}
+	}
+	if expected := "a,b\n0,1\n0,10\n1,0\n10,0\n"; content("t1") != expected {
+		t.Fatalf("rows written with item mode:\n%s", content("t1"))
+	}
+
+	if err := write("t1", frames.OverwriteTable, "c"); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "c\n0\n0\n"; content("t1") != expected {
+		t.Fatalf("bad content after overwrite:\n%s", content("t1"))
+	}
+
+	// Tables created with a schema are validated against it
+	createReq := &frames.CreateRequest{Proto: &pb.CreateRequest{
+		Table: "t2",
+		Schema: &frames.TableSchema{Fields: []*frames.SchemaField{
+			{Name: "x", Type: "long"},
+			{Name: "y", Type: "long"},
+		}},
+	}}
+	if err := backend.Create(createReq); err != nil {
+		t.Fatal(err)
+	}
+	if err := write("t2", frames.ErrorIfTableExists, "x"); err == nil {
+		t.Fatal("no error writing columns that don't match the schema")
+	}
+	if err := write("t2", frames.ErrorIfTableExists, "y", "x"); err != nil {
+		t.Fatal(err)
+	}
+	if expected := "x,y\n1,0\n10,0\n"; content("t2") != expected {
+		t.Fatalf("bad content after write:\n%s", content("t2"))
+	}
+}
+
+func TestSchema(t *testing.T) {
+	logger, err := frames.NewLogger("debug")
+	if err != nil {
+		t.Fatalf("can't create logger - %s", err)
+	}
+
+	rootDir, err := ioutil.TempDir("", "csv-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	cfg := &frames.BackendConfig{Name: "testCsv", Type: "csv", RootDir: rootDir}
+	backend, err := NewBackend(logger, nil, cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	props, err := pb.FromGoMap(map[string]interface{}{"format": "02/01/2006"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createReq := &frames.CreateRequest{Proto: &pb.CreateRequest{
+		Table: "typed",
+		Schema: &frames.TableSchema{Fields: []*frames.SchemaField{
+			{Name: "x", Type: "double"},
+			{Name: "day", Type: "timestamp", Properties: props},
+			{Name: "n", Type: "long"},
+			{Name: "s"},
+		}},
+	}}
+	if err := backend.Create(createReq); err != nil {
+		t.Fatal(err)
+	}
+
+	// Values of "x" look like ints in the first frame
+	rows := "1,02/01/2020,7,a\n2,03/01/2020,,b\n1.5,,9,3\n4,04/01/2020,1,x\n"
+	file, err := os.OpenFile(path.Join(rootDir, "typed"), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.WriteString(rows); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	readReq := &frames.ReadRequest{Proto: &pb.ReadRequest{Table: "typed", MessageLimit: 2}}
+	it, err := backend.Read(readReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedTypes := map[string]frames.DType{
+		"x":   frames.FloatType,
+		"day": frames.TimeType,
+		"n":   frames.IntType,
+		"s":   frames.StringType, // Inferred, mixed in the second frame
+	}
+
+	var result []frames.Frame
+	for it.Next() {
+		frame := it.At()
+		for name, dtype := range expectedTypes {
+			col, err := frame.Column(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if col.DType() != dtype {
+				t.Fatalf("%s: dtype mismatch %d != %d", name, col.DType(), dtype)
+			}
+		}
+		result = append(result, frame)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result) != 2 {
+		t.Fatalf("got %d frames, expected 2", len(result))
+	}
+
+	if !result[0].IsNull(1, "n") || result[0].IsNull(0, "n") || !result[1].IsNull(0, "day") {
+		t.Fatal("bad null values")
+	}
+
+	col, err := result[0].Column("day")
+	if err != nil {
+		t.Fatal(err)
+	}
+	day, err := col.TimeAt(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !day.Equal(time.Date(2020, 1, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("bad time - %s", day)
+	}
+
+	// An explicit schema overrides the table one
+	readReq.Proto.Schema = &frames.TableSchema{Fields: []*frames.SchemaField{{Name: "s", Type: "long"}}}
+	readReq.Proto.Columns = []string{"s"}
+	it, err = backend.Read(readReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for it.Next() {
+	}
+	if it.Err() == nil {
+		t.Fatal("no error parsing strings as long")
+	}
+
+	// Delete removes the schema as well
+	if err := backend.Delete(&frames.DeleteRequest{Proto: &pb.DeleteRequest{Table: "typed"}}); err != nil {
+		t.Fatal(err)
+	}
+	if fileExists(path.Join(rootDir, "typed"+schemaSuffix)) {
+		t.Fatal("schema not deleted")
+	}
+}
+
+func TestDialect(t *testing.T) {
+	logger, err := frames.NewLogger("debug")
+	if err != nil {
+		t.Fatalf("can't create logger - %s", err)
+	}
+
+	rootDir, err := ioutil.TempDir("", "csv-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	cfg := &frames.BackendConfig{
+		Name:    "testCsv",
+		Type:    "csv",
+		RootDir: rootDir,
+		Options: map[string]interface{}{
+			"delimiter": ";",
+			"quote":     "'",
+			"header":    false,
+		},
+	}
+	backend, err := NewBackend(logger, nil, cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	scol, err := frames.NewSliceColumn("s", []string{"a;b", "it's", "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tcol, err := frames.NewSliceColumn("t", []time.Time{time.Unix(1, 0).UTC(), time.Unix(2, 0).UTC(), time.Unix(3, 0).UTC()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nulls := []*pb.NullValuesMap{
+		{NullColumns: map[string]bool{}},
+		{NullColumns: map[string]bool{"t": true}},
+		{NullColumns: map[string]bool{}},
+	}
+	frame, err := frames.NewFrameWithNullValues([]frames.Column{scol, tcol}, nil, nil, nulls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appender, err := backend.Write(&frames.WriteRequest{Table: "t1", ImmidiateData: frame})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := appender.WaitForComplete(0); err != nil {
+		t.Fatal(err)
+	}
+	appender.Close()
+
+	data, err := ioutil.ReadFile(path.Join(rootDir, "t1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "'a;b';1970-01-01T00:00:01Z\n'it''s';\nc;1970-01-01T00:00:03Z\n"
+	if string(data) != expected {
+		t.Fatalf("bad content:\n%s", data)
+	}
+
+	// Column names and types come from the schema written with the table
+	it, err := backend.Read(&frames.ReadRequest{Proto: &pb.ReadRequest{Table: "t1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !it.Next() {
+		t.Fatalf("no frame - %v", it.Err())
+	}
+
+	out := it.At()
+	if names := out.Names(); !reflect.DeepEqual(names, []string{"s", "t"}) {
+		t.Fatalf("bad columns - %v", names)
+	}
+	col, err := out.Column("s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(col.Strings(), []string{"a;b", "it's", "c"}) {
+		t.Fatalf("bad values - %v", col.Strings())
+	}
+	col, err = out.Column("t")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col.DType() != frames.TimeType || !out.IsNull(1, "t") {
+		t.Fatal("bad time column")
+	}
+
+	badOptions := []map[string]interface{}{
+		{"delimiter": ""},
+		{"delimiter": "ab"},
+		{"quote": ","},
+		{"header": "yes"},
+		{"nope": 1},
+	}
+	for _, options := range badOptions {
+		cfg.Options = options
+		if _, err := NewBackend(logger, nil, cfg, nil); err == nil {
+			t.Fatalf("no error for %v", options)
+		}
+	}
+}
+
+func totalRows(result []frames.Frame) int {
+	total := 0
+	for _, frame := range result {
+		total += frame.Len()
+	}
+
+	return total
+}
+
+func loadTempCSV(t *testing.T, req *frames.ReadRequest) []frames.Frame {
+	it, err := readTempCSV(t, req)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -162,6 +712,36 @@ func loadTempCSV(t *testing.T, req *frames.ReadRequest) []frames.Frame {
 	return result
 }
 
+func readTempCSV(t *testing.T, req *frames.ReadRequest) (frames.FrameIterator, error) {
+	return readTempCSVContext(t, context.Background(), req)
+}
+
+func readTempCSVContext(t *testing.T, ctx context.Context, req *frames.ReadRequest) (frames.FrameIterator, error) {
+	logger, err := frames.NewLogger("debug")
+	if err != nil {
+		t.Fatalf("can't create logger - %s", err)
+	}
+
+	csvPath, err := tmpCSV()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &frames.BackendConfig{
+		Name:    "testCsv",
+		Type:    "csv",
+		RootDir: path.Dir(csvPath),
+	}
+
+	backend, err := NewBackend(logger, nil, cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.Proto.Table = path.Base(csvPath)
+	return backend.(*Backend).ReadContext(ctx, req)
+}
+
 func tmpCSV() (string, error) {
 	tmp, err := ioutil.TempFile("", "csv-test")
 	if err != nil {
@@ -179,3 +759,87 @@ func tmpCSV() (string, error) {
 
 	return tmp.Name(), nil
 }
+
+func TestExtendedTypes(t *testing.T) {
+	logger, err := frames.NewLogger("debug")
+	if err != nil {
+		t.Fatalf("can't create logger - %s", err)
+	}
+
+	rootDir, err := ioutil.TempDir("", "csv-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(rootDir)
+
+	cfg := &frames.BackendConfig{Name: "testCsv", Type: "csv", RootDir: rootDir}
+	backend, err := NewBackend(logger, nil, cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createReq := &frames.CreateRequest{Proto: &pb.CreateRequest{
+		Table: "typed",
+		Schema: &frames.TableSchema{Fields: []*frames.SchemaField{
+			{Name: "i", Type: "int"},
+			{Name: "f", Type: "float"},
+			{Name: "d", Type: "decimal"},
+			{Name: "b", Type: "blob"},
+			{Name: "l", Type: "list"},
+		}},
+	}}
+	if err := backend.Create(createReq); err != nil {
+		t.Fatal(err)
+	}
+
+	rows := "1,0.5,12.30,aGk=,\"[1,2]\"\n2,,-1,,\n"
+	file, err := os.OpenFile(path.Join(rootDir, "typed"), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := file.WriteString(rows); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	it, err := backend.Read(&frames.ReadRequest{Proto: &pb.ReadRequest{Table: "typed"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !it.Next() {
+		t.Fatalf("no frame - %v", it.Err())
+	}
+	frame := it.At()
+
+	expected := map[string]string{"i": "1", "f": "0.5", "d": "12.30", "b": "aGk=", "l": "[1,2]"}
+	expectedTypes := map[string]frames.DType{
+		"i": frames.Int32Type,
+		"f": frames.Float32Type,
+		"d": frames.DecimalType,
+		"b": frames.BytesType,
+		"l": frames.ListType,
+	}
+	for name, value := range expected {
+		col, err := frame.Column(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if col.DType() != expectedTypes[name] {
+			t.Fatalf("%s: dtype mismatch %d != %d", name, col.DType(), expectedTypes[name])
+		}
+
+		s, err := col.StringAt(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s != value {
+			t.Fatalf("%s: bad value %q != %q", name, s, value)
+		}
+	}
+
+	if !frame.IsNull(1, "f") || !frame.IsNull(1, "l") || frame.IsNull(1, "d") {
+		t.Fatal("bad null values")
+	}
+}
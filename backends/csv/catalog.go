@@ -0,0 +1,123 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package csv
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/pb"
+	"github.com/v3io/frames/v3ioutils"
+)
+
+// Make sure we're implementing frames.CatalogBackend
+var _ frames.CatalogBackend = &Backend{}
+
+// describeRows is the number of rows read to infer the types of fields
+// missing from the table schema
+const describeRows = 1000
+
+// ListTables lists the CSV files in a directory
+func (b *Backend) ListTables(request *frames.ListTablesRequest) ([]string, error) {
+	entries, err := ioutil.ReadDir(b.csvPath(request.Proto.Path))
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []string
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasSuffix(entry.Name(), schemaSuffix) {
+			continue
+		}
+		tables = append(tables, path.Join(request.Proto.Path, entry.Name()))
+	}
+
+	return tables, nil
+}
+
+// DescribeTable returns the table columns, types not in the table schema are
+// inferred from the first rows
+func (b *Backend) DescribeTable(request *frames.DescribeTableRequest) (*pb.DescribeTableResponse, error) {
+	csvPath := b.csvPath(request.Proto.Table)
+	info, err := os.Stat(csvPath)
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := readSchema(csvPath)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]*frames.SchemaField)
+	schema := &frames.TableSchema{}
+	if stored != nil {
+		for _, field := range stored.Fields {
+			fields[field.Name] = field
+		}
+		schema.Key = stored.Key
+		schema.Doc = stored.Doc
+	}
+
+	iter, err := b.Read(&frames.ReadRequest{Proto: &pb.ReadRequest{Table: request.Proto.Table, MessageLimit: describeRows}})
+	if err != nil {
+		return nil, err
+	}
+	it := iter.(*FrameIterator)
+	defer it.close()
+
+	var sample frames.Frame
+	if it.Next() {
+		sample = it.At()
+	} else if err := it.Err(); err != nil {
+		return nil, errors.Wrap(err, "can't read rows")
+	}
+
+	for _, name := range it.columnNames {
+		field, ok := fields[name]
+		if !ok {
+			field = &frames.SchemaField{Name: name}
+		}
+
+		if field.Type == "" && sample != nil {
+			col, err := sample.Column(name)
+			if err != nil {
+				return nil, err
+			}
+			field.Type = v3ioutils.ConvertDTypeToString(col.DType())
+		}
+		schema.Fields = append(schema.Fields, field)
+	}
+
+	attributes, err := pb.FromGoMap(map[string]interface{}{
+		"header": b.dialect.header,
+		"size":   info.Size(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.DescribeTableResponse{Schema: schema, Attributes: attributes}, nil
+}
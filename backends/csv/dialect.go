@@ -0,0 +1,306 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package csv
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// dialect is the CSV file format, configured from the backend options:
+//
+//	delimiter: field delimiter (default ",")
+//	quote: quote character, "" to disable quoting (default `"`)
+//	header: files start with a header line (default true)
+//	timeFormats: extra time layouts used when parsing (e.g. "02/01/2006")
+type dialect struct {
+	delimiter   rune
+	quote       rune // 0 is no quoting
+	header      bool
+	timeFormats []string
+}
+
+var defaultTimeFormats = []string{time.RFC3339, time.RFC3339Nano, "2006-01-02"}
+
+func newDialect(options map[string]interface{}) (*dialect, error) {
+	d := &dialect{
+		delimiter:   ',',
+		quote:       '"',
+		header:      true,
+		timeFormats: defaultTimeFormats,
+	}
+
+	for key, value := range options {
+		var err error
+		switch key {
+		case "delimiter":
+			d.delimiter, err = runeOption(key, value)
+			if err == nil && d.delimiter == 0 {
+				err = fmt.Errorf("empty delimiter")
+			}
+		case "quote":
+			d.quote, err = runeOption(key, value)
+		case "header":
+			var ok bool
+			if d.header, ok = value.(bool); !ok {
+				err = fmt.Errorf("%s: bad value %v (%T), expected bool", key, value, value)
+			}
+		case "timeFormats":
+			var formats []string
+			formats, err = stringsOption(key, value)
+			// Custom formats are tried first
+			d.timeFormats = append(formats, defaultTimeFormats...)
+		default:
+			err = fmt.Errorf("unknown option - %q", key)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if d.delimiter == d.quote || d.delimiter == '\n' || d.delimiter == '\r' {
+		return nil, fmt.Errorf("bad delimiter - %q", d.delimiter)
+	}
+
+	return d, nil
+}
+
+func runeOption(key string, value interface{}) (rune, error) {
+	str, ok := value.(string)
+	if !ok {
+		return 0, fmt.Errorf("%s: bad value %v (%T), expected string", key, value, value)
+	}
+
+	if str == "" {
+		return 0, nil
+	}
+
+	r, size := utf8.DecodeRuneInString(str)
+	if r == utf8.RuneError || size != len(str) {
+		return 0, fmt.Errorf("%s: %q is not a single character", key, str)
+	}
+
+	return r, nil
+}
+
+func stringsOption(key string, value interface{}) ([]string, error) {
+	switch value := value.(type) {
+	case string:
+		return []string{value}, nil
+	case []string:
+		return value, nil
+	case []interface{}: // From JSON/YAML
+		out := make([]string, len(value))
+		for i, v := range value {
+			str, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s: bad value %v (%T), expected string", key, v, v)
+			}
+			out[i] = str
+		}
+		return out, nil
+	}
+
+	return nil, fmt.Errorf("%s: bad value %v (%T), expected list of strings", key, value, value)
+}
+
+// recordReader reads CSV records, returned records are valid until the next
+// call to Read
+type recordReader interface {
+	Read() ([]string, error)
+}
+
+// recordWriter writes CSV records
+type recordWriter interface {
+	Write(record []string) error
+	Flush()
+	Error() error
+}
+
+func (d *dialect) newReader(r io.Reader) recordReader {
+	if d.quote != '"' {
+		return &quoteReader{r: bufio.NewReader(r), delimiter: d.delimiter, quote: d.quote}
+	}
+
+	reader := csv.NewReader(r)
+	reader.Comma = d.delimiter
+	reader.ReuseRecord = true
+	return reader
+}
+
+func (d *dialect) newWriter(w io.Writer) recordWriter {
+	if d.quote != '"' {
+		return &quoteWriter{w: bufio.NewWriter(w), delimiter: d.delimiter, quote: d.quote}
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = d.delimiter
+	return writer
+}
+
+// readHeader reads the header line, the returned names are not reused by the
+// reader
+func readHeader(reader recordReader) ([]string, error) {
+	header, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]string(nil), header...), nil
+}
+
+// quoteReader reads CSV with a quote character other than '"' (which
+// encoding/csv doesn't support), or with no quoting at all
+type quoteReader struct {
+	r         *bufio.Reader
+	delimiter rune
+	quote     rune
+	line      int
+}
+
+func (qr *quoteReader) Read() ([]string, error) {
+	var (
+		record   []string
+		field    strings.Builder
+		inQuotes bool
+		quoted   bool // Current field started with a quote
+		started  bool // Read something in the current record
+	)
+
+	for {
+		c, _, err := qr.r.ReadRune()
+		if err == io.EOF {
+			if inQuotes {
+				return nil, fmt.Errorf("line %d: unterminated quoted field", qr.line+1)
+			}
+			if !started {
+				return nil, io.EOF
+			}
+			return append(record, field.String()), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if c == '\r' && !inQuotes {
+			if next, _, err := qr.r.ReadRune(); err == nil && next != '\n' {
+				qr.r.UnreadRune()
+			}
+			c = '\n'
+		}
+
+		switch {
+		case inQuotes:
+			if c == '\n' {
+				qr.line++
+			}
+			if c != qr.quote {
+				field.WriteRune(c)
+				break
+			}
+			// Two quotes are a literal quote
+			next, _, err := qr.r.ReadRune()
+			if err == nil && next == qr.quote {
+				field.WriteRune(c)
+				break
+			}
+			if err == nil {
+				qr.r.UnreadRune()
+			}
+			inQuotes = false
+		case c == '\n':
+			qr.line++
+			if !started { // Skip empty lines
+				continue
+			}
+			return append(record, field.String()), nil
+		case c == qr.delimiter:
+			record = append(record, field.String())
+			field.Reset()
+			quoted = false
+		case c == qr.quote && field.Len() == 0 && !quoted:
+			inQuotes, quoted = true, true
+		default:
+			field.WriteRune(c)
+		}
+
+		started = true
+	}
+}
+
+// quoteWriter is the writer counterpart of quoteReader
+type quoteWriter struct {
+	w         *bufio.Writer
+	delimiter rune
+	quote     rune
+	err       error
+}
+
+func (qw *quoteWriter) Write(record []string) error {
+	if qw.err != nil {
+		return qw.err
+	}
+
+	special := string([]rune{qw.delimiter, '\r', '\n'})
+	if qw.quote != 0 {
+		special += string(qw.quote)
+	}
+
+	for i, field := range record {
+		if i > 0 {
+			qw.w.WriteRune(qw.delimiter)
+		}
+
+		if !strings.ContainsAny(field, special) {
+			qw.w.WriteString(field)
+			continue
+		}
+
+		if qw.quote == 0 {
+			qw.err = fmt.Errorf("%q needs quoting, but quoting is disabled", field)
+			return qw.err
+		}
+
+		quote := string(qw.quote)
+		qw.w.WriteString(quote)
+		qw.w.WriteString(strings.Replace(field, quote, quote+quote, -1))
+		qw.w.WriteString(quote)
+	}
+
+	_, qw.err = qw.w.WriteRune('\n')
+	return qw.err
+}
+
+func (qw *quoteWriter) Flush() {
+	if err := qw.w.Flush(); err != nil && qw.err == nil {
+		qw.err = err
+	}
+}
+
+func (qw *quoteWriter) Error() error {
+	return qw.err
+}
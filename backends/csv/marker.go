@@ -0,0 +1,73 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package csv
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// readMarker is the read position after a frame. Markers are opaque to
+// clients, they're base64 encoded JSON
+type readMarker struct {
+	Offset int64 `json:"o"` // File offset of the next record
+	NRows  int   `json:"r"` // Rows returned so far (for limit)
+	NRead  int   `json:"n"` // Records read so far (for row numbers in errors)
+}
+
+func (m *readMarker) encode() (string, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeMarker(marker string) (*readMarker, error) {
+	data, err := base64.RawURLEncoding.DecodeString(marker)
+	if err != nil {
+		return nil, errors.Wrap(err, "bad marker")
+	}
+
+	m := &readMarker{}
+	if err := json.Unmarshal(data, m); err != nil {
+		return nil, errors.Wrap(err, "bad marker")
+	}
+
+	return m, nil
+}
+
+// countingReader counts the bytes read, the offset of a record is the count
+// minus what's still buffered
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
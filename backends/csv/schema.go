@@ -0,0 +1,332 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package csv
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/pkg/errors"
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/v3ioutils"
+)
+
+// The table schema is kept next to the CSV file (same as the KV .#schema)
+const schemaSuffix = ".#schema"
+
+// formatProperty is the schema field property with the time layout of the field
+const formatProperty = "format"
+
+func schemaPath(csvPath string) string {
+	return csvPath + schemaSuffix
+}
+
+// readSchema reads the table schema, it returns nil if the table has none
+func readSchema(csvPath string) (*frames.TableSchema, error) {
+	data, err := ioutil.ReadFile(schemaPath(csvPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "cannot read schema")
+	}
+
+	schema := &frames.TableSchema{}
+	if err := jsonpb.UnmarshalString(string(data), schema); err != nil {
+		return nil, errors.Wrapf(err, "bad schema in %q", schemaPath(csvPath))
+	}
+
+	return schema, nil
+}
+
+func writeSchema(csvPath string, schema *frames.TableSchema) error {
+	marshaler := &jsonpb.Marshaler{Indent: "  "}
+	data, err := marshaler.MarshalToString(schema)
+	if err != nil {
+		return errors.Wrap(err, "cannot encode schema")
+	}
+
+	if err := ioutil.WriteFile(schemaPath(csvPath), []byte(data), 0666); err != nil {
+		return errors.Wrap(err, "cannot write schema")
+	}
+
+	return nil
+}
+
+func removeSchema(csvPath string) error {
+	err := os.Remove(schemaPath(csvPath))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "cannot delete schema")
+	}
+
+	return nil
+}
+
+// validateSchema checks field names, types and time formats
+func validateSchema(schema *frames.TableSchema) error {
+	for i, field := range schema.Fields {
+		if field.Name == "" {
+			return fmt.Errorf("field %d with no name", i)
+		}
+
+		if field.Type != "" {
+			if _, err := v3ioutils.ConvertStringToDType(field.Type); err != nil {
+				return errors.Wrapf(err, "field %q", field.Name)
+			}
+		}
+
+		if format, ok := field.Property(formatProperty); ok {
+			if _, isString := format.(string); !isString {
+				return fmt.Errorf("field %q: %s property must be a string", field.Name, formatProperty)
+			}
+		}
+	}
+
+	return nil
+}
+
+// schemaFromFrame returns a schema with the frame columns and their types
+func schemaFromFrame(frame frames.Frame) (*frames.TableSchema, error) {
+	schema := &frames.TableSchema{}
+	for _, name := range frame.Names() {
+		col, err := frame.Column(name)
+		if err != nil {
+			return nil, err
+		}
+
+		field := &frames.SchemaField{Name: name, Type: v3ioutils.ConvertDTypeToString(col.DType())}
+		schema.Fields = append(schema.Fields, field)
+	}
+
+	return schema, nil
+}
+
+// schemaNames returns the schema field names, or col-0, col-1 ... for tables
+// without header and schema
+func schemaNames(schema *frames.TableSchema, numFields int) []string {
+	if schema != nil && len(schema.Fields) > 0 {
+		names := make([]string, len(schema.Fields))
+		for i, field := range schema.Fields {
+			names[i] = field.Name
+		}
+		return names
+	}
+
+	names := make([]string, numFields)
+	for i := range names {
+		names[i] = fmt.Sprintf("col-%d", i)
+	}
+	return names
+}
+
+// fieldParser parses CSV values of a column. Empty values are nulls
+type fieldParser struct {
+	dtype       frames.DType // 0 if the type is not known and should be inferred
+	layout      string       // Time layout from the schema
+	timeFormats []string
+}
+
+// newFieldParsers returns parsers for columns, types and time formats are
+// taken from schema fields with the same name
+func newFieldParsers(columns []string, schema *frames.TableSchema, d *dialect) ([]*fieldParser, error) {
+	fields := make(map[string]*frames.SchemaField)
+	if schema != nil {
+		if err := validateSchema(schema); err != nil {
+			return nil, errors.Wrap(err, "bad schema")
+		}
+
+		for _, field := range schema.Fields {
+			fields[field.Name] = field
+		}
+	}
+
+	parsers := make([]*fieldParser, len(columns))
+	for i, name := range columns {
+		parser := &fieldParser{timeFormats: d.timeFormats}
+		if field, ok := fields[name]; ok {
+			if field.Type != "" {
+				parser.dtype, _ = v3ioutils.ConvertStringToDType(field.Type) // validated above
+			}
+
+			if format, ok := field.Property(formatProperty); ok {
+				parser.layout = format.(string)
+				parser.timeFormats = append([]string{parser.layout}, d.timeFormats...)
+			}
+		}
+		parsers[i] = parser
+	}
+
+	return parsers, nil
+}
+
+// parse returns the parsed value, nil for nulls
+func (p *fieldParser) parse(value string) (interface{}, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	switch p.dtype {
+	case frames.IntType:
+		return strconv.ParseInt(value, 10, 64)
+	case frames.FloatType:
+		return strconv.ParseFloat(value, 64)
+	case frames.BoolType:
+		return strconv.ParseBool(value)
+	case frames.StringType:
+		return value, nil
+	case frames.TimeType:
+		if t, ok := parseTime(value, p.timeFormats); ok {
+			return t, nil
+		}
+		return nil, fmt.Errorf("%q doesn't match time formats %v", value, p.timeFormats)
+	case frames.Int32Type:
+		i, err := strconv.ParseInt(value, 10, 32)
+		return int32(i), err
+	case frames.Float32Type:
+		f, err := strconv.ParseFloat(value, 32)
+		return float32(f), err
+	case frames.BytesType:
+		return base64.StdEncoding.DecodeString(value)
+	case frames.DecimalType:
+		return frames.ParseDecimal(value)
+	case frames.ListType:
+		return frames.DecodeJSONList(value)
+	}
+
+	return parseValue(value, p.timeFormats), nil
+}
+
+// format formats a value for writing, times are written in the schema layout
+// (or RFC3339Nano), bytes in base64 and lists in JSON
+func (p *fieldParser) format(value interface{}) (string, error) {
+	switch value := value.(type) {
+	case time.Time:
+		if p.layout != "" {
+			return value.Format(p.layout), nil
+		}
+		return value.Format(time.RFC3339Nano), nil
+	case []byte:
+		return base64.StdEncoding.EncodeToString(value), nil
+	case frames.Column:
+		values, err := frames.ListValues(value)
+		if err != nil {
+			return "", err
+		}
+
+		data, err := json.Marshal(values)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+
+	return fmt.Sprintf("%v", value), nil
+}
+
+func parseTime(value string, timeFormats []string) (time.Time, bool) {
+	for _, format := range timeFormats {
+		t, err := time.Parse(format, value)
+		if err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// parseValue infers the type of a value
+func parseValue(value string, timeFormats []string) interface{} {
+	if t, ok := parseTime(value, timeFormats); ok {
+		return t
+	}
+
+	// bool
+	switch strings.ToLower(value) {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+
+	// int
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err == nil {
+		return i
+	}
+
+	// float
+	f, err := strconv.ParseFloat(value, 64)
+	if err == nil {
+		return f
+	}
+
+	// Leave as string
+	return value
+}
+
+// inferType returns the type of a column from its parsed values. Ints are
+// promoted to floats if mixed with them, other mixed types are strings
+func inferType(values []interface{}) frames.DType {
+	var dtype frames.DType
+	for _, value := range values {
+		var valueType frames.DType
+		switch value.(type) {
+		case nil:
+			continue
+		case int64:
+			valueType = frames.IntType
+		case float64:
+			valueType = frames.FloatType
+		case bool:
+			valueType = frames.BoolType
+		case time.Time:
+			valueType = frames.TimeType
+		default:
+			return frames.StringType
+		}
+
+		switch {
+		case dtype == 0 || dtype == valueType:
+			dtype = valueType
+		case isNumeric(dtype) && isNumeric(valueType):
+			dtype = frames.FloatType
+		default:
+			return frames.StringType
+		}
+	}
+
+	if dtype == 0 { // All nulls
+		return frames.StringType
+	}
+
+	return dtype
+}
+
+func isNumeric(dtype frames.DType) bool {
+	return dtype == frames.IntType || dtype == frames.FloatType
+}
@@ -0,0 +1,631 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+// Package filter parses and evaluates v3io filter and update expressions, for
+// backends that don't pass filters to v3io and for the fake v3io container
+package filter
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/v3io/frames"
+)
+
+// Operators
+const (
+	OpAnd = "and"
+	OpOr  = "or"
+	OpNot = "not"
+	OpNeg = "-"
+	OpEq  = "=="
+	OpNe  = "!="
+	OpLt  = "<"
+	OpLe  = "<="
+	OpGt  = ">"
+	OpGe  = ">="
+	OpAdd = "+"
+	OpSub = "-"
+	OpMul = "*"
+	OpDiv = "/"
+)
+
+// Expr is a filter expression AST node. Eval returns nil for missing values
+type Expr interface {
+	Eval(row Row) (interface{}, error)
+	String() string
+}
+
+// Literal is a number (int64 or float64), string, bool or time
+type Literal struct {
+	Value interface{}
+}
+
+// Eval returns the literal value
+func (e *Literal) Eval(row Row) (interface{}, error) {
+	return e.Value, nil
+}
+
+func (e *Literal) String() string {
+	switch value := e.Value.(type) {
+	case string:
+		if strings.Contains(value, "'") {
+			return `"` + value + `"`
+		}
+		return "'" + value + "'"
+	case float64:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	case time.Time:
+		return fmt.Sprintf("%d:%d", value.Unix(), value.Nanosecond())
+	}
+	return fmt.Sprintf("%v", e.Value)
+}
+
+// Attribute is a reference to an attribute (column)
+type Attribute struct {
+	Name string
+}
+
+// Eval returns the attribute value in the row
+func (e *Attribute) Eval(row Row) (interface{}, error) {
+	value, ok := row.Value(e.Name)
+	if !ok {
+		return nil, nil
+	}
+	return normalize(value), nil
+}
+
+func (e *Attribute) String() string {
+	for i := 0; i < len(e.Name); i++ {
+		if !isIdentChar(e.Name[i]) || (i == 0 && !isIdentStart(e.Name[i])) {
+			return "`" + e.Name + "`"
+		}
+	}
+	return e.Name
+}
+
+// Index is an element of an array attribute (e.g. "a[3]")
+type Index struct {
+	Name  string
+	Index Expr
+}
+
+// Eval returns the array element, nil if the attribute is missing
+func (e *Index) Eval(row Row) (interface{}, error) {
+	value, ok := row.Value(e.Name)
+	if !ok {
+		return nil, nil
+	}
+
+	blob, ok := value.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("%s is not an array", e.Name)
+	}
+
+	index, err := evalIndex(e.Index, row)
+	if err != nil {
+		return nil, err
+	}
+
+	return arrayAt(blob, index)
+}
+
+func (e *Index) String() string {
+	return fmt.Sprintf("%s[%s]", &Attribute{Name: e.Name}, e.Index)
+}
+
+// Unary is "not" or negation
+type Unary struct {
+	Op   string
+	Expr Expr
+}
+
+// Eval evaluates the expression
+func (e *Unary) Eval(row Row) (interface{}, error) {
+	value, err := e.Expr.Eval(row)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.Op == OpNot {
+		return !isTrue(value), nil
+	}
+
+	switch value := value.(type) {
+	case int64:
+		return -value, nil
+	case float64:
+		return -value, nil
+	case nil:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("can't negate %T", value)
+}
+
+func (e *Unary) String() string {
+	if e.Op == OpNot {
+		return fmt.Sprintf("not (%s)", e.Expr)
+	}
+	return fmt.Sprintf("-(%s)", e.Expr)
+}
+
+// Binary is a logical, comparison or arithmetic expression
+type Binary struct {
+	Op          string
+	Left, Right Expr
+}
+
+// Eval evaluates the expression
+func (e *Binary) Eval(row Row) (interface{}, error) {
+	left, err := e.Left.Eval(row)
+	if err != nil {
+		return nil, err
+	}
+
+	// Short circuit
+	switch e.Op {
+	case OpAnd:
+		if !isTrue(left) {
+			return false, nil
+		}
+	case OpOr:
+		if isTrue(left) {
+			return true, nil
+		}
+	}
+
+	right, err := e.Right.Eval(row)
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.Op {
+	case OpAnd, OpOr:
+		return isTrue(right), nil
+	case OpEq, OpNe, OpLt, OpLe, OpGt, OpGe:
+		return compareOp(e.Op, left, right), nil
+	case OpAdd, OpSub, OpMul, OpDiv:
+		return arithmetic(e.Op, left, right)
+	}
+
+	return nil, fmt.Errorf("unknown operator - %q", e.Op)
+}
+
+func (e *Binary) String() string {
+	return fmt.Sprintf("(%s %s %s)", e.Left, e.Op, e.Right)
+}
+
+// In checks if a value is in a list of values
+type In struct {
+	Expr   Expr
+	Values []Expr
+}
+
+// Eval evaluates the expression
+func (e *In) Eval(row Row) (interface{}, error) {
+	value, err := e.Expr.Eval(row)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, valueExpr := range e.Values {
+		other, err := valueExpr.Eval(row)
+		if err != nil {
+			return nil, err
+		}
+		if compareOp(OpEq, value, other) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (e *In) String() string {
+	values := make([]string, len(e.Values))
+	for i, value := range e.Values {
+		values[i] = value.String()
+	}
+	return fmt.Sprintf("%s in (%s)", e.Expr, strings.Join(values, ", "))
+}
+
+// Call is a function call (e.g. "exists(x)")
+type Call struct {
+	Name string
+	Args []Expr
+}
+
+type function struct {
+	numArgs int
+	optArgs int  // Optional arguments after numArgs
+	attrArg bool // First argument must be an attribute
+	eval    func(args []interface{}) (interface{}, error)
+}
+
+var functions = map[string]function{
+	"exists": {numArgs: 1, attrArg: true, eval: func(args []interface{}) (interface{}, error) {
+		return args[0] != nil, nil
+	}},
+	"starts":   {numArgs: 2, attrArg: true, eval: stringFunc(strings.HasPrefix)},
+	"ends":     {numArgs: 2, attrArg: true, eval: stringFunc(strings.HasSuffix)},
+	"contains": {numArgs: 2, attrArg: true, eval: stringFunc(strings.Contains)},
+	"length": {numArgs: 1, eval: func(args []interface{}) (interface{}, error) {
+		switch value := args[0].(type) {
+		case string:
+			return int64(len(value)), nil
+		case []byte:
+			return int64(len(value)), nil
+		case nil:
+			return nil, nil
+		}
+		return nil, fmt.Errorf("length of %T", args[0])
+	}},
+	"if_not_exists": {numArgs: 2, eval: func(args []interface{}) (interface{}, error) {
+		if args[0] != nil {
+			return args[0], nil
+		}
+		return args[1], nil
+	}},
+	"min": {numArgs: 2, eval: minMax(-1)},
+	"max": {numArgs: 2, eval: minMax(1)},
+	"blob": {numArgs: 1, eval: func(args []interface{}) (interface{}, error) {
+		str, ok := args[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("blob of %T", args[0])
+		}
+		return base64.StdEncoding.DecodeString(str)
+	}},
+	"init_array": {numArgs: 2, optArgs: 1, eval: initArray},
+}
+
+func stringFunc(fn func(string, string) bool) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		str, ok := args[0].(string)
+		if !ok {
+			return false, nil
+		}
+		sub, ok := args[1].(string)
+		if !ok {
+			return false, nil
+		}
+		return fn(str, sub), nil
+	}
+}
+
+// minMax returns a function returning the minimum (sign -1) or the maximum
+// (sign 1) of its two arguments, a missing argument is ignored
+func minMax(sign int) func([]interface{}) (interface{}, error) {
+	return func(args []interface{}) (interface{}, error) {
+		if args[0] == nil {
+			return args[1], nil
+		}
+		if args[1] == nil {
+			return args[0], nil
+		}
+
+		cmp, ok := Compare(args[0], args[1])
+		if !ok {
+			return nil, fmt.Errorf("can't compare %T to %T", args[0], args[1])
+		}
+
+		if cmp*sign >= 0 {
+			return args[0], nil
+		}
+		return args[1], nil
+	}
+}
+
+// NewCall returns a call of a filter function, it checks the function exists
+// and its arguments
+func NewCall(name string, args []Expr) (*Call, error) {
+	name = strings.ToLower(name)
+	fn, ok := functions[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function - %q", name)
+	}
+
+	if len(args) < fn.numArgs || len(args) > fn.numArgs+fn.optArgs {
+		if fn.optArgs > 0 {
+			return nil, fmt.Errorf("%s expects %d to %d arguments, got %d", name, fn.numArgs, fn.numArgs+fn.optArgs, len(args))
+		}
+		return nil, fmt.Errorf("%s expects %d arguments, got %d", name, fn.numArgs, len(args))
+	}
+
+	if fn.attrArg {
+		if _, ok := args[0].(*Attribute); !ok {
+			return nil, fmt.Errorf("first argument of %s must be an attribute", name)
+		}
+	}
+
+	return &Call{Name: name, Args: args}, nil
+}
+
+// Eval evaluates the function
+func (e *Call) Eval(row Row) (interface{}, error) {
+	fn, ok := functions[e.Name]
+	if !ok {
+		return nil, fmt.Errorf("unknown function - %q", e.Name)
+	}
+
+	args := make([]interface{}, len(e.Args))
+	for i, arg := range e.Args {
+		value, err := arg.Eval(row)
+		if err != nil {
+			return nil, err
+		}
+		args[i] = value
+	}
+
+	value, err := fn.eval(args)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", e.Name, err)
+	}
+	return value, nil
+}
+
+func (e *Call) String() string {
+	args := make([]string, len(e.Args))
+	for i, arg := range e.Args {
+		args[i] = arg.String()
+	}
+	return fmt.Sprintf("%s(%s)", e.Name, strings.Join(args, ", "))
+}
+
+// Match returns true if the expression evaluates to true for the row
+func Match(expr Expr, row Row) (bool, error) {
+	value, err := expr.Eval(row)
+	if err != nil {
+		return false, err
+	}
+	return isTrue(value), nil
+}
+
+// Attributes returns the (sorted) names of attributes used in the expression
+func Attributes(expr Expr) []string {
+	names := make(map[string]bool)
+	collectAttributes(expr, names)
+
+	out := make([]string, 0, len(names))
+	for name := range names {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func collectAttributes(expr Expr, names map[string]bool) {
+	switch expr := expr.(type) {
+	case *Attribute:
+		names[expr.Name] = true
+	case *Index:
+		names[expr.Name] = true
+		collectAttributes(expr.Index, names)
+	case *Unary:
+		collectAttributes(expr.Expr, names)
+	case *Binary:
+		collectAttributes(expr.Left, names)
+		collectAttributes(expr.Right, names)
+	case *In:
+		collectAttributes(expr.Expr, names)
+		for _, value := range expr.Values {
+			collectAttributes(value, names)
+		}
+	case *Call:
+		for _, arg := range expr.Args {
+			collectAttributes(arg, names)
+		}
+	}
+}
+
+func isTrue(value interface{}) bool {
+	b, ok := value.(bool)
+	return ok && b
+}
+
+// normalize converts row values to the types used in evaluation
+func normalize(value interface{}) interface{} {
+	switch value := value.(type) {
+	case int:
+		return int64(value)
+	case int32:
+		return int64(value)
+	case float32:
+		return float64(value)
+	case frames.Decimal:
+		return value.Float64()
+	}
+	return value
+}
+
+// compareOp compares two values, mismatched types or missing values are
+// never equal (same as in v3io)
+func compareOp(op string, left, right interface{}) bool {
+	// Only == and != make sense for bools
+	if _, isBool := left.(bool); isBool && op != OpEq && op != OpNe {
+		return false
+	}
+
+	cmp, ok := Compare(left, right)
+	if !ok {
+		return false
+	}
+
+	switch op {
+	case OpEq:
+		return cmp == 0
+	case OpNe:
+		return cmp != 0
+	case OpLt:
+		return cmp < 0
+	case OpLe:
+		return cmp <= 0
+	case OpGt:
+		return cmp > 0
+	case OpGe:
+		return cmp >= 0
+	}
+	return false
+}
+
+var timeFormats = []string{time.RFC3339Nano, "2006-01-02 15:04:05", "2006-01-02"}
+
+// Compare returns -1, 0 or 1 if left is less than, equal to or greater than
+// right. ok is false if the values can't be compared (e.g. mismatched types)
+func Compare(left, right interface{}) (cmp int, ok bool) {
+	switch left := left.(type) {
+	case int64:
+		switch right := right.(type) {
+		case int64:
+			return compareInts(left, right), true
+		case float64:
+			return compareFloats(float64(left), right), true
+		}
+	case float64:
+		switch right := right.(type) {
+		case int64:
+			return compareFloats(left, float64(right)), true
+		case float64:
+			return compareFloats(left, right), true
+		}
+	case string:
+		switch right := right.(type) {
+		case string:
+			return strings.Compare(left, right), true
+		case time.Time:
+			cmp, ok := Compare(right, left)
+			return -cmp, ok
+		}
+	case []byte:
+		if right, ok := right.([]byte); ok {
+			return bytes.Compare(left, right), true
+		}
+	case bool:
+		if right, ok := right.(bool); ok {
+			if left == right {
+				return 0, true
+			}
+			return 1, true
+		}
+	case time.Time:
+		switch right := right.(type) {
+		case time.Time:
+			return compareInts(left.UnixNano(), right.UnixNano()), true
+		case string:
+			for _, format := range timeFormats {
+				if t, err := time.Parse(format, right); err == nil {
+					return compareInts(left.UnixNano(), t.UnixNano()), true
+				}
+			}
+		}
+	}
+
+	return 0, false
+}
+
+func compareInts(left, right int64) int {
+	switch {
+	case left < right:
+		return -1
+	case left > right:
+		return 1
+	}
+	return 0
+}
+
+func compareFloats(left, right float64) int {
+	switch {
+	case left < right:
+		return -1
+	case left > right:
+		return 1
+	}
+	return 0
+}
+
+func arithmetic(op string, left, right interface{}) (interface{}, error) {
+	if left == nil || right == nil {
+		return nil, nil
+	}
+
+	if l, ok := left.(string); ok && op == OpAdd {
+		if r, ok := right.(string); ok {
+			return l + r, nil
+		}
+	}
+
+	if l, ok := left.([]byte); ok && op == OpAdd {
+		if r, ok := right.([]byte); ok {
+			return append(append([]byte(nil), l...), r...), nil
+		}
+	}
+
+	li, lInt := left.(int64)
+	ri, rInt := right.(int64)
+	if lInt && rInt && op != OpDiv {
+		switch op {
+		case OpAdd:
+			return li + ri, nil
+		case OpSub:
+			return li - ri, nil
+		case OpMul:
+			return li * ri, nil
+		}
+	}
+
+	lf, ok := toFloat(left)
+	if !ok {
+		return nil, fmt.Errorf("bad operand for %q - %T", op, left)
+	}
+	rf, ok := toFloat(right)
+	if !ok {
+		return nil, fmt.Errorf("bad operand for %q - %T", op, right)
+	}
+
+	switch op {
+	case OpAdd:
+		return lf + rf, nil
+	case OpSub:
+		return lf - rf, nil
+	case OpMul:
+		return lf * rf, nil
+	case OpDiv:
+		if rf == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return lf / rf, nil
+	}
+
+	return nil, fmt.Errorf("unknown operator - %q", op)
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch value := value.(type) {
+	case int64:
+		return float64(value), true
+	case float64:
+		return value, true
+	}
+	return 0, false
+}
@@ -0,0 +1,255 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package filter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/pb"
+)
+
+var testRow = MapRow{
+	"i":      int64(7),
+	"f":      2.5,
+	"s":      "hello",
+	"b":      true,
+	"t":      time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+	"col-1":  int64(1),
+	"n":      nil,
+	"__name": "key1",
+}
+
+func TestMatch(t *testing.T) {
+	testCases := []struct {
+		filter string
+		match  bool
+	}{
+		{"i == 7", true},
+		{"i = 7", true},
+		{"i != 7", false},
+		{"i <> 8", true},
+		{"i > 6.5", true},
+		{"f >= 2.5 AND f < 3", true},
+		{"i < 7 or f > 2", true},
+		{"i < 7 || f > 3", false},
+		{"not i == 7", false},
+		{"!(i == 7) && true", false},
+		{"s == 'hello'", true},
+		{`s == "hello"`, true},
+		{"s > 'a'", true},
+		{"b == true", true},
+		{"b", true},
+		{"b > false", false},
+		{"i + 1 == 8", true},
+		{"i * 2 - 1 == 13", true},
+		{"i / 2 == 3.5", true},
+		{"-i < 0", true},
+		{"s + ' world' == 'hello world'", true},
+		{"exists(i)", true},
+		{"exists(nope)", false},
+		{"exists(n)", false},
+		{"not exists(nope)", true},
+		{"starts(s, 'he')", true},
+		{"ends(s, 'lo')", true},
+		{"contains(s, 'ell')", true},
+		{"contains(s, 'x')", false},
+		{"starts(i, '7')", false},
+		{"length(s) == 5", true},
+		{"i in (1, 7, 9)", true},
+		{"s IN ('a', 'b')", false},
+		{"t > '2020-01-01'", true},
+		{"t == '2020-01-02T03:04:05Z'", true},
+		{"`col-1` == 1", true},
+		{"__name == 'key1'", true},
+		// Missing values and type mismatches are false
+		{"nope == 1", false},
+		{"nope != 1", false},
+		{"not nope == 1", true},
+		{"s == 1", false},
+		{"i == 'seven'", false},
+		{"1.5e1 > i", true},
+		{"t == 1577934245:0", true},
+		{"min(i, f) == 2.5", true},
+		{"max(i, nope) == 7", true},
+		{"if_not_exists(nope, 3) == 3", true},
+		{"blob('aGk=') == blob('aGk=')", true},
+	}
+
+	for _, tc := range testCases {
+		expr, err := Parse(tc.filter)
+		if err != nil {
+			t.Fatalf("%q: can't parse - %s", tc.filter, err)
+		}
+
+		match, err := Match(expr, testRow)
+		if err != nil {
+			t.Fatalf("%q: can't evaluate - %s", tc.filter, err)
+		}
+
+		if match != tc.match {
+			t.Fatalf("%q: got %v, expected %v", tc.filter, match, tc.match)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	filters := []string{
+		"",
+		"i ==",
+		"(i == 1",
+		"i == 'a",
+		"i == 1)",
+		"no_such_func(i)",
+		"exists(1)",
+		"starts(s)",
+		"i in 1",
+		"i # 2",
+		"i[1",
+		"init_array(1)",
+		"a; b",
+	}
+
+	for _, filter := range filters {
+		if _, err := Parse(filter); err == nil {
+			t.Fatalf("%q: no error", filter)
+		}
+	}
+}
+
+func TestString(t *testing.T) {
+	filter := "a > 1 and not (starts(b, 'x') or `c d` in (1, 'y'))"
+	expr, err := Parse(filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Printed expressions parse to the same AST
+	expr2, err := Parse(expr.String())
+	if err != nil {
+		t.Fatalf("%q: can't parse - %s", expr.String(), err)
+	}
+
+	if !reflect.DeepEqual(expr, expr2) {
+		t.Fatalf("AST mismatch: %s != %s", expr, expr2)
+	}
+
+	attrs := Attributes(expr)
+	if !reflect.DeepEqual(attrs, []string{"a", "b", "c d"}) {
+		t.Fatalf("bad attributes - %v", attrs)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	expression := "SET x = x + 2; s = if_not_exists(s, 'a') + 'b'; " +
+		"arr = init_array(3, 'int'); arr[1] = 7; arr[2] = max(arr[1], 3) * 2; " +
+		"d = init_array(2, 'double', 0.5); delete(f)"
+	assignments, err := ParseUpdate(expression)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	attrs := map[string]interface{}{"x": 1, "f": 1.5}
+	if err := Update(assignments, attrs); err != nil {
+		t.Fatal(err)
+	}
+
+	if attrs["x"] != int64(3) || attrs["s"] != "ab" {
+		t.Fatalf("bad attributes - %v", attrs)
+	}
+	if _, ok := attrs["f"]; ok {
+		t.Fatalf("deleted attribute in %v", attrs)
+	}
+
+	row := MapRow(attrs)
+	for _, filter := range []string{"arr[0] == 0", "arr[1] == 7", "arr[2] == 14", "d[1] == 0.5"} {
+		expr, err := Parse(filter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if match, err := Match(expr, row); err != nil || !match {
+			t.Fatalf("%q: no match (%v)", filter, err)
+		}
+	}
+
+	for _, expression := range []string{"x", "x = ", "arr[1 = 2", "delete(1)", "x = 1 y = 2"} {
+		if _, err := ParseUpdate(expression); err == nil {
+			t.Fatalf("%q: no error", expression)
+		}
+	}
+
+	assignments, err = ParseUpdate("arr[5] = 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Update(assignments, attrs); err == nil {
+		t.Fatal("no error for index out of range")
+	}
+}
+
+func TestFrameRow(t *testing.T) {
+	icol, err := frames.NewSliceColumn("key", []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	xcol, err := frames.NewSliceColumn("x", []int64{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nulls := []*pb.NullValuesMap{
+		{NullColumns: map[string]bool{}},
+		{NullColumns: map[string]bool{"x": true}},
+		{NullColumns: map[string]bool{}},
+	}
+	frame, err := frames.NewFrameWithNullValues([]frames.Column{xcol}, []frames.Column{icol}, nil, nulls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expr, err := Parse("key != 'c' and not exists(x)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	row, err := NewFrameRow(frame)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var matches []int
+	for i := 0; i < frame.Len(); i++ {
+		row.SetIndex(i)
+		match, err := Match(expr, row)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if match {
+			matches = append(matches, i)
+		}
+	}
+
+	if !reflect.DeepEqual(matches, []int{1}) {
+		t.Fatalf("bad matches - %v", matches)
+	}
+}
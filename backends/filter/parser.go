@@ -0,0 +1,555 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package filter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIdent
+	tokNumber
+	tokString
+	tokTime
+	tokOp
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of filter"
+	}
+	return fmt.Sprintf("%q (at %d)", t.value, t.pos)
+}
+
+// Operators, longest first
+var operators = []string{
+	"==", "!=", "<>", "<=", ">=", "&&", "||",
+	"=", "<", ">", "!", "+", "-", "*", "/", "(", ")", "[", "]", ",", ";",
+}
+
+func tokenize(filter string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(filter); {
+		c := filter[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(filter[i+1:], c)
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated string at %d", i)
+			}
+			tokens = append(tokens, token{tokString, filter[i+1 : i+1+end], i})
+			i += end + 2
+		case c == '`': // Quoted attribute name (e.g. `col-1`)
+			end := strings.IndexByte(filter[i+1:], c)
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated attribute name at %d", i)
+			}
+			tokens = append(tokens, token{tokIdent, filter[i+1 : i+1+end], i})
+			i += end + 2
+		case isDigit(c) || (c == '.' && i+1 < len(filter) && isDigit(filter[i+1])):
+			j := i
+			for j < len(filter) && (isDigit(filter[j]) || filter[j] == '.') {
+				j++
+			}
+			// Exponent
+			if j < len(filter) && (filter[j] == 'e' || filter[j] == 'E') {
+				k := j + 1
+				if k < len(filter) && (filter[k] == '+' || filter[k] == '-') {
+					k++
+				}
+				if k < len(filter) && isDigit(filter[k]) {
+					for j = k; j < len(filter) && isDigit(filter[j]); j++ {
+					}
+				}
+			}
+			kind := tokNumber
+			// Timestamp (seconds:nanoseconds)
+			if j+1 < len(filter) && filter[j] == ':' && isDigit(filter[j+1]) {
+				for j++; j < len(filter) && isDigit(filter[j]); j++ {
+				}
+				kind = tokTime
+			}
+			tokens = append(tokens, token{kind, filter[i:j], i})
+			i = j
+		case isIdentStart(c):
+			j := i + 1
+			for j < len(filter) && isIdentChar(filter[j]) {
+				j++
+			}
+			tokens = append(tokens, token{tokIdent, filter[i:j], i})
+			i = j
+		default:
+			op := ""
+			for _, candidate := range operators {
+				if strings.HasPrefix(filter[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected %q at %d", c, i)
+			}
+			tokens = append(tokens, token{tokOp, op, i})
+			i += len(op)
+		}
+	}
+
+	return append(tokens, token{kind: tokEOF, pos: len(filter)}), nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
+
+// Parse parses a v3io filter expression (e.g. "x > 3 and starts(name, 'a')")
+func Parse(filter string) (Expr, error) {
+	tokens, err := tokenize(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %s", tok)
+	}
+
+	return expr, nil
+}
+
+// ParseUpdate parses a v3io update expression (e.g. "a = a + 1; delete(b)")
+func ParseUpdate(expression string) ([]*Assignment, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	var assignments []*Assignment
+	for p.peek().kind != tokEOF {
+		if _, ok := p.isOp(";"); ok {
+			p.next()
+			continue
+		}
+
+		assignment, err := p.parseAssignment()
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, assignment)
+
+		if _, ok := p.isOp(";"); !ok && p.peek().kind != tokEOF {
+			return nil, fmt.Errorf("unexpected %s", p.peek())
+		}
+	}
+
+	return assignments, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// isOp returns the current operator if it's one of ops
+func (p *parser) isOp(ops ...string) (string, bool) {
+	tok := p.peek()
+	if tok.kind != tokOp {
+		return "", false
+	}
+
+	for _, op := range ops {
+		if tok.value == op {
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) isKeyword(keyword string) bool {
+	tok := p.peek()
+	return tok.kind == tokIdent && strings.EqualFold(tok.value, keyword)
+}
+
+func (p *parser) expect(op string) error {
+	if _, ok := p.isOp(op); !ok {
+		return fmt.Errorf("expected %q, got %s", op, p.peek())
+	}
+	p.next()
+	return nil
+}
+
+// parseAssignment parses "[SET] name = expr", "name[index] = expr" or
+// "delete(name)"
+func (p *parser) parseAssignment() (*Assignment, error) {
+	if p.isKeyword("set") && p.tokens[p.pos+1].kind == tokIdent {
+		p.next()
+	}
+
+	name := p.next()
+	if name.kind != tokIdent {
+		return nil, fmt.Errorf("expected attribute name, got %s", name)
+	}
+
+	if _, ok := p.isOp("("); ok && strings.EqualFold(name.value, "delete") {
+		p.next()
+		attr := p.next()
+		if attr.kind != tokIdent {
+			return nil, fmt.Errorf("expected attribute name, got %s", attr)
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return &Assignment{Name: attr.value}, nil
+	}
+
+	assignment := &Assignment{Name: name.value}
+	if _, ok := p.isOp("["); ok {
+		p.next()
+		index, err := p.parseAdd()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+		assignment.Index = index
+	}
+
+	if err := p.expect("="); err != nil {
+		return nil, err
+	}
+
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	assignment.Expr = expr
+
+	return assignment, nil
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, ok := p.isOp("||"); !ok && !p.isKeyword("or") {
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: OpOr, Left: left, Right: right}
+	}
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, ok := p.isOp("&&"); !ok && !p.isKeyword("and") {
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: OpAnd, Left: left, Right: right}
+	}
+}
+
+func (p *parser) parseNot() (Expr, error) {
+	if _, ok := p.isOp("!"); ok || p.isKeyword("not") {
+		p.next()
+		expr, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{Op: OpNot, Expr: expr}, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Expr, error) {
+	left, err := p.parseAdd()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.isKeyword("in") {
+		p.next()
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		return &In{Expr: left, Values: values}, nil
+	}
+
+	op, ok := p.isOp("==", "=", "!=", "<>", "<", "<=", ">", ">=")
+	if !ok {
+		return left, nil
+	}
+	p.next()
+
+	right, err := p.parseAdd()
+	if err != nil {
+		return nil, err
+	}
+
+	switch op {
+	case "=":
+		op = OpEq
+	case "<>":
+		op = OpNe
+	}
+
+	return &Binary{Op: op, Left: left, Right: right}, nil
+}
+
+func (p *parser) parseList() ([]Expr, error) {
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	var values []Expr
+	for {
+		value, err := p.parseAdd()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+
+		if _, ok := p.isOp(","); !ok {
+			break
+		}
+		p.next()
+	}
+
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+func (p *parser) parseAdd() (Expr, error) {
+	left, err := p.parseMul()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op, ok := p.isOp("+", "-")
+		if !ok {
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.parseMul()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: op, Left: left, Right: right}
+	}
+}
+
+func (p *parser) parseMul() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		op, ok := p.isOp("*", "/")
+		if !ok {
+			return left, nil
+		}
+		p.next()
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &Binary{Op: op, Left: left, Right: right}
+	}
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if _, ok := p.isOp("-"); ok {
+		p.next()
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Unary{Op: OpNeg, Expr: expr}, nil
+	}
+
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokNumber:
+		if i, err := strconv.ParseInt(tok.value, 10, 64); err == nil {
+			return &Literal{Value: i}, nil
+		}
+		f, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad number %s", tok)
+		}
+		return &Literal{Value: f}, nil
+	case tokTime:
+		parts := strings.SplitN(tok.value, ":", 2)
+		secs, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad timestamp %s", tok)
+		}
+		nsecs, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad timestamp %s", tok)
+		}
+		return &Literal{Value: time.Unix(secs, nsecs)}, nil
+	case tokString:
+		return &Literal{Value: tok.value}, nil
+	case tokIdent:
+		switch strings.ToLower(tok.value) {
+		case "true":
+			return &Literal{Value: true}, nil
+		case "false":
+			return &Literal{Value: false}, nil
+		}
+
+		if _, ok := p.isOp("["); ok {
+			p.next()
+			index, err := p.parseAdd()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			return &Index{Name: tok.value, Index: index}, nil
+		}
+
+		if _, ok := p.isOp("("); !ok {
+			return &Attribute{Name: tok.value}, nil
+		}
+		return p.parseCall(tok)
+	case tokOp:
+		if tok.value == "(" {
+			expr, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return expr, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unexpected %s", tok)
+}
+
+func (p *parser) parseCall(name token) (Expr, error) {
+	if _, ok := functions[strings.ToLower(name.value)]; !ok {
+		return nil, fmt.Errorf("unknown function %s", name)
+	}
+
+	p.next() // (
+	var args []Expr
+	if _, ok := p.isOp(")"); !ok {
+		for {
+			arg, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+
+			if _, ok := p.isOp(","); !ok {
+				break
+			}
+			p.next()
+		}
+	}
+
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	call, err := NewCall(name.value, args)
+	if err != nil {
+		return nil, err
+	}
+	return call, nil
+}
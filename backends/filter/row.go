@@ -0,0 +1,87 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package filter
+
+import (
+	"github.com/v3io/frames"
+)
+
+// Row is a row filters are evaluated against
+type Row interface {
+	// Value returns the value of an attribute, ok is false if it's missing (or null)
+	Value(name string) (value interface{}, ok bool)
+}
+
+// MapRow is a row of attribute values
+type MapRow map[string]interface{}
+
+// Value returns the value of an attribute
+func (r MapRow) Value(name string) (interface{}, bool) {
+	value, ok := r[name]
+	return value, ok && value != nil
+}
+
+// FrameRow is a row in a frame, indices can be referenced by name as well
+type FrameRow struct {
+	frame   frames.Frame
+	columns map[string]frames.Column
+	index   int
+}
+
+// NewFrameRow returns a row pointing to the first row of frame
+func NewFrameRow(frame frames.Frame) (*FrameRow, error) {
+	columns := make(map[string]frames.Column)
+	for _, col := range frame.Indices() {
+		if col.Name() != "" {
+			columns[col.Name()] = col
+		}
+	}
+
+	// Columns take precedence over indices with the same name
+	for _, name := range frame.Names() {
+		col, err := frame.Column(name)
+		if err != nil {
+			return nil, err
+		}
+		columns[name] = col
+	}
+
+	return &FrameRow{frame: frame, columns: columns}, nil
+}
+
+// SetIndex sets the row index in the frame
+func (r *FrameRow) SetIndex(index int) {
+	r.index = index
+}
+
+// Value returns the value of a column in the current row
+func (r *FrameRow) Value(name string) (interface{}, bool) {
+	col, ok := r.columns[name]
+	if !ok || r.frame.IsNull(r.index, name) {
+		return nil, false
+	}
+
+	value, err := frames.ValueAt(col, r.index)
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package filter
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Arrays are blobs with a 16 byte header (type and size) followed by 8 byte
+// little endian values, this is the layout v3ioutils.AsInt64Array expects
+const (
+	arrayHeaderSize = 16
+	intArray        = 1
+	doubleArray     = 2
+)
+
+// Assignment is an update expression statement, it sets an attribute or an
+// element of an array attribute (if Index isn't nil). Expr is nil for delete
+type Assignment struct {
+	Name  string
+	Index Expr
+	Expr  Expr
+}
+
+func (a *Assignment) String() string {
+	name := (&Attribute{Name: a.Name}).String()
+	switch {
+	case a.Expr == nil:
+		return fmt.Sprintf("delete(%s)", name)
+	case a.Index != nil:
+		return fmt.Sprintf("%s[%s] = %s", name, a.Index, a.Expr)
+	}
+	return fmt.Sprintf("%s = %s", name, a.Expr)
+}
+
+// Update applies assignments to attrs (in place), every assignment sees the
+// attributes set by the ones before it
+func Update(assignments []*Assignment, attrs map[string]interface{}) error {
+	row := MapRow(attrs)
+	for _, assignment := range assignments {
+		name := assignment.Name
+		if assignment.Expr == nil {
+			delete(attrs, name)
+			continue
+		}
+
+		value, err := assignment.Expr.Eval(row)
+		if err != nil {
+			return fmt.Errorf("%s: %s", name, err)
+		}
+
+		if assignment.Index == nil {
+			if value == nil {
+				return fmt.Errorf("%s: can't set to a missing value", name)
+			}
+			attrs[name] = value
+			continue
+		}
+
+		index, err := evalIndex(assignment.Index, row)
+		if err != nil {
+			return fmt.Errorf("%s: %s", name, err)
+		}
+
+		blob, ok := attrs[name].([]byte)
+		if !ok {
+			return fmt.Errorf("%s: attribute is not an array", name)
+		}
+
+		blob = append([]byte(nil), blob...)
+		if err := setArrayAt(blob, index, value); err != nil {
+			return fmt.Errorf("%s: %s", name, err)
+		}
+		attrs[name] = blob
+	}
+
+	return nil
+}
+
+func evalIndex(expr Expr, row Row) (int, error) {
+	value, err := expr.Eval(row)
+	if err != nil {
+		return 0, err
+	}
+
+	index, ok := value.(int64)
+	if !ok {
+		return 0, fmt.Errorf("array index must be an integer, got %v", value)
+	}
+	return int(index), nil
+}
+
+// initArray is init_array(size, type[, value])
+func initArray(args []interface{}) (interface{}, error) {
+	size, ok := args[0].(int64)
+	if !ok || size < 0 {
+		return nil, fmt.Errorf("bad array size - %v", args[0])
+	}
+
+	tag := uint64(intArray)
+	typ, _ := args[1].(string)
+	switch strings.ToLower(typ) {
+	case "int":
+	case "double":
+		tag = doubleArray
+	default:
+		return nil, fmt.Errorf("unknown array type - %v", args[1])
+	}
+
+	var value interface{} = int64(0)
+	if len(args) > 2 {
+		value = args[2]
+	}
+
+	blob := make([]byte, arrayHeaderSize+8*int(size))
+	binary.LittleEndian.PutUint64(blob[0:8], tag)
+	binary.LittleEndian.PutUint64(blob[8:16], uint64(size))
+	for i := 0; i < int(size); i++ {
+		if err := setArrayAt(blob, i, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return blob, nil
+}
+
+func checkArrayIndex(blob []byte, index int) error {
+	if len(blob) < arrayHeaderSize {
+		return fmt.Errorf("attribute is not an array")
+	}
+
+	size := (len(blob) - arrayHeaderSize) / 8
+	if index < 0 || index >= size {
+		return fmt.Errorf("array index %d out of range [0:%d]", index, size)
+	}
+
+	return nil
+}
+
+// arrayAt returns an array element, int64 or float64 by the array type
+func arrayAt(blob []byte, index int) (interface{}, error) {
+	if err := checkArrayIndex(blob, index); err != nil {
+		return nil, err
+	}
+
+	offset := arrayHeaderSize + 8*index
+	bits := binary.LittleEndian.Uint64(blob[offset : offset+8])
+	if binary.LittleEndian.Uint64(blob[0:8]) == doubleArray {
+		return math.Float64frombits(bits), nil
+	}
+	return int64(bits), nil
+}
+
+func setArrayAt(blob []byte, index int, value interface{}) error {
+	if err := checkArrayIndex(blob, index); err != nil {
+		return err
+	}
+
+	f, ok := toFloat(value)
+	if !ok {
+		return fmt.Errorf("can't store %T in an array", value)
+	}
+
+	var bits uint64
+	switch i, isInt := value.(int64); {
+	case binary.LittleEndian.Uint64(blob[0:8]) == doubleArray:
+		bits = math.Float64bits(f)
+	case isInt:
+		bits = uint64(i)
+	default:
+		bits = uint64(int64(f))
+	}
+
+	offset := arrayHeaderSize + 8*index
+	binary.LittleEndian.PutUint64(blob[offset:offset+8], bits)
+	return nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package backends
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/ops"
+	"github.com/v3io/frames/pb"
+)
+
+const defaultGroupByFrameSize = 1024
+
+// ParseGroupBy returns the group by keys and the aggregations of a read
+// request for backends that aggregate in process. aggregations is nil if the
+// request isn't aggregated
+func ParseGroupBy(msg *pb.ReadRequest) (keys []string, aggregations []ops.Aggregation, err error) {
+	if msg.GroupBy == "" && msg.Aggregators == "" {
+		return nil, nil, nil
+	}
+
+	if msg.Aggregators == "" {
+		return nil, nil, fmt.Errorf("group by without aggregators")
+	}
+
+	if msg.Marker != "" {
+		return nil, nil, fmt.Errorf("aggregated reads can't be resumed from a marker")
+	}
+
+	for _, key := range strings.Split(msg.GroupBy, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	aggregations, err = ops.ParseAggregations(msg.Aggregators)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "bad aggregators")
+	}
+
+	return keys, aggregations, nil
+}
+
+// GroupByColumns returns the columns to read for a group by, which are columns
+// with the keys and aggregated columns. All the columns are read if columns
+// is empty
+func GroupByColumns(columns []string, keys []string, aggregations []ops.Aggregation) []string {
+	if len(columns) == 0 {
+		return columns
+	}
+
+	has := make(map[string]bool)
+	for _, name := range columns {
+		has[name] = true
+	}
+
+	add := func(name string) {
+		if name != "" && !has[name] {
+			has[name] = true
+			columns = append(columns, name)
+		}
+	}
+
+	for _, key := range keys {
+		add(key)
+	}
+
+	for _, aggregation := range aggregations {
+		add(aggregation.Column)
+	}
+
+	return columns
+}
+
+// NewGroupByIterator returns an iterator over the groups of a group by, which
+// is collected on the first call to Next. Groups are returned in frames of
+// frameSize rows
+func NewGroupByIterator(collect func() (*ops.GroupBy, error), frameSize int) frames.FrameIterator {
+	if frameSize <= 0 {
+		frameSize = defaultGroupByFrameSize
+	}
+
+	return &groupByIterator{
+		collect:   collect,
+		frameSize: frameSize,
+	}
+}
+
+type groupByIterator struct {
+	collect   func() (*ops.GroupBy, error)
+	frameSize int
+
+	groups  frames.Frame
+	start   int
+	current frames.Frame
+	err     error
+}
+
+func (it *groupByIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	if it.groups == nil {
+		groupBy, err := it.collect()
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		if it.groups, err = groupBy.Frame(); err != nil {
+			it.err = err
+			return false
+		}
+	}
+
+	if it.start >= it.groups.Len() {
+		return false
+	}
+
+	end := it.start + it.frameSize
+	if end > it.groups.Len() {
+		end = it.groups.Len()
+	}
+
+	it.current, it.err = it.groups.Slice(it.start, end)
+	it.start = end
+	return it.err == nil
+}
+
+func (it *groupByIterator) Err() error {
+	return it.err
+}
+
+func (it *groupByIterator) At() frames.Frame {
+	return it.current
+}
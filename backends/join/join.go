@@ -0,0 +1,483 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+// Package join implements hash joins of read results. The right side of a
+// join is loaded to memory once and the left side is joined with it frame by
+// frame.
+package join
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/backends/filter"
+	"github.com/v3io/frames/backends/utils"
+	"github.com/v3io/frames/pb"
+)
+
+// Join types
+const (
+	InnerJoin = "inner"
+	LeftJoin  = "left"
+)
+
+const defaultSuffix = "_right"
+
+// Validate checks that join is a valid join
+func Validate(join *frames.JoinStruct) error {
+	if join.Table == "" {
+		return fmt.Errorf("join without table")
+	}
+
+	if len(join.LeftOn) == 0 {
+		return fmt.Errorf("join with %q without keys", join.Table)
+	}
+
+	if len(join.RightOn) > 0 && len(join.RightOn) != len(join.LeftOn) {
+		return fmt.Errorf("join with %q has %d left keys and %d right keys", join.Table, len(join.LeftOn), len(join.RightOn))
+	}
+
+	switch join.How {
+	case "", InnerJoin, LeftJoin:
+	default:
+		return fmt.Errorf("unknown join type - %q", join.How)
+	}
+
+	return nil
+}
+
+// RightKeys returns the key columns of the right side of join
+func RightKeys(join *frames.JoinStruct) []string {
+	if len(join.RightOn) > 0 {
+		return join.RightOn
+	}
+	return join.LeftOn
+}
+
+// Request returns the read request of the right side of join in request, with
+// the session and credentials of request
+func Request(request *frames.ReadRequest, join *frames.JoinStruct) *frames.ReadRequest {
+	backend := join.Backend
+	if backend == "" {
+		backend = request.Proto.Backend
+	}
+
+	var columns []string
+	if len(join.Columns) > 0 {
+		columns = append(columns, join.Columns...)
+		for _, key := range RightKeys(join) {
+			if !contains(columns, key) {
+				columns = append(columns, key)
+			}
+		}
+	}
+
+	return &frames.ReadRequest{
+		Proto: &pb.ReadRequest{
+			Session:      request.Proto.Session,
+			Backend:      backend,
+			Table:        join.Table,
+			Columns:      columns,
+			Filter:       join.Filter,
+			MessageLimit: request.Proto.MessageLimit,
+		},
+		Password: request.Password,
+		Token:    request.Token,
+		Identity: request.Identity,
+	}
+}
+
+// Table is the right side of a join
+type Table struct {
+	name     string
+	keys     []string
+	names    []string // Non key columns
+	dtypes   []frames.DType
+	columns  map[string]int
+	rows     [][]interface{} // Row values by column, shorter if columns were added later
+	index    map[string][]int
+	size     int64
+	maxBytes int64
+}
+
+// Load reads the frames of it to a table with the key columns keys, it fails
+// if the table takes more than about maxBytes (no limit if it's not positive)
+func Load(name string, it frames.FrameIterator, keys []string, maxBytes int64) (*Table, error) {
+	table := &Table{
+		name:     name,
+		keys:     keys,
+		columns:  make(map[string]int),
+		index:    make(map[string][]int),
+		maxBytes: maxBytes,
+	}
+
+	for it.Next() {
+		if err := table.add(it.At()); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+
+	return table, nil
+}
+
+// Len returns the number of rows in the table
+func (t *Table) Len() int {
+	return len(t.rows)
+}
+
+// add adds the rows of frame, rows with null keys are skipped since they
+// don't match any row
+func (t *Table) add(frame frames.Frame) error {
+	row, err := filter.NewFrameRow(frame)
+	if err != nil {
+		return err
+	}
+
+	var sources []frames.Column // Source column of every table column
+	for _, col := range frame.Indices() {
+		if name := col.Name(); name != "" && !contains(t.keys, name) && !contains(frame.Names(), name) {
+			sources = t.source(sources, col)
+		}
+	}
+
+	for _, name := range frame.Names() {
+		if contains(t.keys, name) {
+			continue
+		}
+
+		col, err := frame.Column(name)
+		if err != nil {
+			return err
+		}
+		sources = t.source(sources, col)
+	}
+
+	for i := 0; i < frame.Len(); i++ {
+		row.SetIndex(i)
+		key, ok := rowKey(row, t.keys)
+		if !ok {
+			continue
+		}
+
+		values := make([]interface{}, len(sources))
+		size := int64(len(key)) + 48
+		for c, col := range sources {
+			if col == nil {
+				continue
+			}
+
+			value, ok := row.Value(col.Name())
+			if !ok {
+				continue
+			}
+
+			if n, ok := value.(int64); ok && t.dtypes[c] == frames.FloatType {
+				value = float64(n)
+			}
+			values[c] = value
+			size += valueSize(value)
+		}
+
+		t.size += size
+		if t.maxBytes > 0 && t.size > t.maxBytes {
+			return fmt.Errorf("right side of join with %q is more than %d bytes", t.name, t.maxBytes)
+		}
+
+		t.index[key] = append(t.index[key], len(t.rows))
+		t.rows = append(t.rows, values)
+	}
+
+	return nil
+}
+
+// source sets the frame column of a table column in sources, adding the
+// table column if it's new
+func (t *Table) source(sources []frames.Column, col frames.Column) []frames.Column {
+	pos, ok := t.columns[col.Name()]
+	if !ok {
+		pos = len(t.names)
+		t.columns[col.Name()] = pos
+		t.names = append(t.names, col.Name())
+		t.dtypes = append(t.dtypes, col.DType())
+	}
+
+	for len(sources) <= pos {
+		sources = append(sources, nil)
+	}
+	sources[pos] = col
+	return sources
+}
+
+func (t *Table) value(row, column int) interface{} {
+	values := t.rows[row]
+	if column >= len(values) {
+		return nil
+	}
+	return values[column]
+}
+
+// joinIterator joins the frames of the left side of a join with a table
+type joinIterator struct {
+	left   frames.FrameIterator
+	table  *Table
+	keys   []string
+	isLeft bool
+	suffix string
+
+	frame frames.Frame
+	err   error
+}
+
+// NewIterator returns an iterator over the frames of left joined with table by
+// join
+func NewIterator(left frames.FrameIterator, table *Table, join *frames.JoinStruct) frames.FrameIterator {
+	suffix := join.Suffix
+	if suffix == "" {
+		suffix = defaultSuffix
+	}
+
+	return &joinIterator{
+		left:   left,
+		table:  table,
+		keys:   join.LeftOn,
+		isLeft: join.How == LeftJoin,
+		suffix: suffix,
+	}
+}
+
+func (it *joinIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for it.left.Next() {
+		frame, err := it.join(it.left.At())
+		if err != nil {
+			it.err = err
+			return false
+		}
+
+		// Inner joins skip frames without matching rows
+		if frame != nil {
+			it.frame = frame
+			return true
+		}
+	}
+
+	it.err = it.left.Err()
+	return false
+}
+
+func (it *joinIterator) Err() error {
+	return it.err
+}
+
+func (it *joinIterator) At() frames.Frame {
+	return it.frame
+}
+
+// join returns the rows of frame joined with the table, nil if there are none
+func (it *joinIterator) join(frame frames.Frame) (frames.Frame, error) {
+	row, err := filter.NewFrameRow(frame)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rows of the result in frame and in the table (-1 for none)
+	var leftRows, rightRows []int
+	for i := 0; i < frame.Len(); i++ {
+		row.SetIndex(i)
+		var matches []int
+		if key, ok := rowKey(row, it.keys); ok {
+			matches = it.table.index[key]
+		}
+
+		if len(matches) == 0 && it.isLeft {
+			matches = []int{-1}
+		}
+
+		for _, match := range matches {
+			leftRows = append(leftRows, i)
+			rightRows = append(rightRows, match)
+		}
+	}
+
+	if len(leftRows) == 0 {
+		return nil, nil
+	}
+
+	// Unchanged left rows keep their columns
+	same := len(leftRows) == frame.Len() && len(frame.NullValuesMap()) == 0
+	for i, r := range leftRows {
+		if r != i {
+			same = false
+			break
+		}
+	}
+
+	names := make(map[string]bool)
+	var columns, indices []frames.Column
+	for _, col := range frame.Indices() {
+		if !same {
+			if col, err = takeRows(col, leftRows, col.IsNull); err != nil {
+				return nil, err
+			}
+		}
+		indices = append(indices, col)
+		names[col.Name()] = true
+	}
+
+	for _, name := range frame.Names() {
+		col, err := frame.Column(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if !same {
+			isNull := func(i int) bool { return frame.IsNull(i, name) }
+			if col, err = takeRows(col, leftRows, isNull); err != nil {
+				return nil, err
+			}
+		}
+		columns = append(columns, col)
+		names[name] = true
+	}
+
+	for c, name := range it.table.names {
+		if names[name] {
+			name += it.suffix
+		}
+
+		builder := frames.NewSliceColumnBuilder(name, it.table.dtypes[c], len(rightRows))
+		for i, r := range rightRows {
+			var value interface{}
+			if r >= 0 {
+				value = it.table.value(r, c)
+			}
+
+			if value == nil {
+				err = builder.SetNull(i)
+			} else {
+				err = builder.Set(i, value)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("column %q of %q - %s", name, it.table.name, err)
+			}
+		}
+		columns = append(columns, builder.Finish())
+	}
+
+	out, err := frames.NewFrame(columns, indices, frame.Labels())
+	if err != nil {
+		return nil, err
+	}
+
+	// Joined frames have the same left rows, reads can resume after them
+	if marker := frame.Marker(); marker != "" {
+		return frames.WithMarker(out, marker)
+	}
+	return out, nil
+}
+
+// takeRows returns a column with the values of col in rows
+func takeRows(col frames.Column, rows []int, isNull func(int) bool) (frames.Column, error) {
+	builder := frames.NewSliceColumnBuilder(col.Name(), col.DType(), len(rows))
+	for i, r := range rows {
+		if isNull(r) {
+			if err := builder.SetNull(i); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		value, err := utils.ColAt(col, r)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := builder.Set(i, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return builder.Finish(), nil
+}
+
+// rowKey returns the hash key of the key columns in row, ok is false if one
+// of them is null. Integral floats match integers
+func rowKey(row filter.Row, keys []string) (string, bool) {
+	var buf strings.Builder
+	for _, name := range keys {
+		value, ok := row.Value(name)
+		if !ok {
+			return "", false
+		}
+
+		switch v := value.(type) {
+		case int32:
+			value = int64(v)
+		case float32:
+			if f := float64(v); f == math.Trunc(f) && math.Abs(f) < 1<<63 {
+				value = int64(f)
+			} else {
+				value = f
+			}
+		case float64:
+			if v == math.Trunc(v) && math.Abs(v) < 1<<63 {
+				value = int64(v)
+			}
+		case frames.Decimal:
+			value = v.Normalize()
+		case time.Time:
+			fmt.Fprintf(&buf, "time:%d\x00", v.UnixNano())
+			continue
+		}
+		fmt.Fprintf(&buf, "%T:%v\x00", value, value)
+	}
+	return buf.String(), true
+}
+
+// valueSize is the approximate size of a value in memory
+func valueSize(value interface{}) int64 {
+	size := int64(16)
+	switch v := value.(type) {
+	case string:
+		size += int64(len(v))
+	case []byte:
+		size += int64(len(v))
+	}
+	return size
+}
+
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
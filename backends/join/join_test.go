@@ -0,0 +1,204 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package join
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/backends/utils"
+	"github.com/v3io/frames/pb"
+)
+
+type sliceIterator struct {
+	frames []frames.Frame
+	frame  frames.Frame
+}
+
+func (it *sliceIterator) Next() bool {
+	if len(it.frames) == 0 {
+		return false
+	}
+	it.frame, it.frames = it.frames[0], it.frames[1:]
+	return true
+}
+
+func (it *sliceIterator) Err() error       { return nil }
+func (it *sliceIterator) At() frames.Frame { return it.frame }
+
+// Metrics of devices, the device is a label in the first frame (like TSDB
+// results) and a column in the second
+func leftFrames(t *testing.T) []frames.Frame {
+	device, err := frames.NewLabelColumn("device", "d1", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := frames.NewSliceColumn("value", []float64{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := frames.NewFrame([]frames.Column{value, device}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := frames.NewFrameFromMap(map[string]interface{}{
+		"device": []string{"d2", "d3"},
+		"value":  []float64{3, 4},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return []frames.Frame{first, second}
+}
+
+// Sites of devices, d2 is in two sites and the last row has no device
+func rightFrames(t *testing.T) []frames.Frame {
+	rows := []map[string]interface{}{
+		{"device": "d1", "site": "a", "value": int64(10)},
+		{"device": "d2", "site": "b", "value": int64(20)},
+		{"device": "d2", "site": "c"},
+		{"site": "d", "value": int64(30)},
+	}
+	frame, err := frames.NewFrameFromRows(rows, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return []frames.Frame{frame}
+}
+
+// run returns the rows of join by column name, null values are nil
+func run(t *testing.T, join *frames.JoinStruct) []map[string]interface{} {
+	if err := Validate(join); err != nil {
+		t.Fatal(err)
+	}
+
+	table, err := Load(join.Table, &sliceIterator{frames: rightFrames(t)}, RightKeys(join), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if table.Len() != 3 {
+		t.Fatalf("bad table size - %d", table.Len())
+	}
+
+	it := NewIterator(&sliceIterator{frames: leftFrames(t)}, table, join)
+	var rows []map[string]interface{}
+	for it.Next() {
+		frame := it.At()
+		for i := 0; i < frame.Len(); i++ {
+			row := make(map[string]interface{})
+			for _, name := range frame.Names() {
+				if frame.IsNull(i, name) {
+					row[name] = nil
+					continue
+				}
+
+				col, err := frame.Column(name)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if row[name], err = utils.ColAt(col, i); err != nil {
+					t.Fatal(err)
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	return rows
+}
+
+func TestInnerJoin(t *testing.T) {
+	rows := run(t, &frames.JoinStruct{Table: "sites", LeftOn: []string{"device"}})
+	expected := []map[string]interface{}{
+		{"device": "d1", "value": 1.0, "site": "a", "value_right": int64(10)},
+		{"device": "d1", "value": 2.0, "site": "a", "value_right": int64(10)},
+		{"device": "d2", "value": 3.0, "site": "b", "value_right": int64(20)},
+		{"device": "d2", "value": 3.0, "site": "c", "value_right": nil},
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("bad rows - %v", rows)
+	}
+}
+
+func TestLeftJoin(t *testing.T) {
+	join := &frames.JoinStruct{
+		Table:   "sites",
+		LeftOn:  []string{"device"},
+		RightOn: []string{"device"},
+		How:     LeftJoin,
+		Suffix:  "_site",
+	}
+
+	rows := run(t, join)
+	unmatched := map[string]interface{}{"device": "d3", "value": 4.0, "site": nil, "value_site": nil}
+	if len(rows) != 5 || !reflect.DeepEqual(rows[4], unmatched) {
+		t.Fatalf("bad rows - %v", rows)
+	}
+}
+
+func TestMaxBytes(t *testing.T) {
+	_, err := Load("sites", &sliceIterator{frames: rightFrames(t)}, []string{"device"}, 100)
+	if err == nil {
+		t.Fatal("no error for table over limit")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	joins := []*frames.JoinStruct{
+		{LeftOn: []string{"a"}},
+		{Table: "t"},
+		{Table: "t", LeftOn: []string{"a"}, RightOn: []string{"a", "b"}},
+		{Table: "t", LeftOn: []string{"a"}, How: "outer"},
+	}
+
+	for _, join := range joins {
+		if err := Validate(join); err == nil {
+			t.Fatalf("no error for %+v", join)
+		}
+	}
+}
+
+func TestRequest(t *testing.T) {
+	request := &frames.ReadRequest{
+		Proto:    &pb.ReadRequest{Backend: "tsdb", Table: "metrics", Session: &frames.Session{Container: "c"}},
+		Identity: &frames.Identity{User: "u"},
+	}
+	join := &frames.JoinStruct{Backend: "kv", Table: "sites", Columns: []string{"site"}, LeftOn: []string{"device"}, Filter: "site != 'x'"}
+
+	right := Request(request, join)
+	msg := right.Proto
+	ok := msg.Backend == "kv" && msg.Table == "sites" && msg.Filter == join.Filter &&
+		reflect.DeepEqual(msg.Columns, []string{"site", "device"}) && msg.Session.Container == "c" && right.Identity == request.Identity
+	if !ok {
+		t.Fatalf("bad request - %+v", msg)
+	}
+}
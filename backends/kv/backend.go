@@ -21,6 +21,7 @@ such restriction.
 package kv
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -44,6 +45,50 @@ type Backend struct {
 	maxRecordsInfer    int
 }
 
+var (
+	// Make sure we're implementing frames.ContextDataBackend and frames.CatalogBackend
+	_ frames.ContextDataBackend = &Backend{}
+	_ frames.CatalogBackend     = &Backend{}
+)
+
+var capabilities = &frames.Capabilities{
+	Type: "kv",
+	ReadFields: backends.ReadFields(
+		"Segments", "TotalSegments", "ShardingKeys", "SortKeyRangeStart", "SortKeyRangeEnd", "Marker",
+		"GroupBy", "Aggregators",
+	),
+	WriteFields:  backends.WriteFields("Expression", "Condition", "PartitionKeys", "SaveMode"),
+	DeleteFields: backends.DeleteFields(),
+	Commands: []*frames.ExecCommand{
+		{
+			Name: "infer",
+			Doc:  "Infer the table schema from its items",
+			Args: []*frames.ExecArgument{
+				{Name: "key", Type: v3ioutils.StringType, Doc: "Name of the key column"},
+			},
+		},
+		{
+			Name: "infer_schema",
+			Doc:  "Same as infer",
+			Args: []*frames.ExecArgument{
+				{Name: "key", Type: v3ioutils.StringType, Doc: "Name of the key column"},
+			},
+		},
+		{
+			Name: "update",
+			Doc:  "Update an item with an update expression",
+			Args: []*frames.ExecArgument{
+				{Name: "key", Type: v3ioutils.StringType, Required: true, Doc: "Item key"},
+				{Name: "expression", Type: v3ioutils.StringType, Required: true, Doc: "Update expression"},
+				{Name: "condition", Type: v3ioutils.StringType, Doc: "Update condition"},
+			},
+		},
+	},
+	SaveModes: backends.SaveModes(
+		frames.ErrorIfTableExists, frames.OverwriteTable, frames.UpdateItem, frames.OverwriteItem, frames.CreateNewItemsOnly,
+	),
+}
+
 // NewBackend returns a new NoSQL (key/value) backend
 func NewBackend(logger logger.Logger, v3ioContext v3io.Context, config *frames.BackendConfig, framesConfig *frames.Config) (frames.DataBackend, error) {
 	newBackend := Backend{
@@ -58,6 +103,11 @@ func NewBackend(logger logger.Logger, v3ioContext v3io.Context, config *frames.B
 	return &newBackend, nil
 }
 
+// Capabilities returns the requests the backend supports, there's no create
+func (b *Backend) Capabilities() *frames.Capabilities {
+	return capabilities
+}
+
 // Create creates a table - not required for the NoSQL backend
 func (b *Backend) Create(request *frames.CreateRequest) error {
 	return fmt.Errorf("'create' isn't required for the NoSQL backend; the table is created on first write")
@@ -66,7 +116,7 @@ func (b *Backend) Create(request *frames.CreateRequest) error {
 // Delete deletes a table (or part of it)
 func (b *Backend) Delete(request *frames.DeleteRequest) error {
 
-	err := backends.ValidateRequest("kv", request.Proto, nil)
+	err := backends.ValidateRequest(capabilities, request.Proto)
 	if err != nil {
 		return err
 	}
@@ -82,10 +132,23 @@ func (b *Backend) Delete(request *frames.DeleteRequest) error {
 
 // Exec executes a command
 func (b *Backend) Exec(request *frames.ExecRequest) (frames.Frame, error) {
+	return b.ExecContext(context.Background(), request)
+}
+
+// ExecContext executes a command, infer stops reading items once ctx is done
+func (b *Backend) ExecContext(ctx context.Context, request *frames.ExecRequest) (frames.Frame, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := backends.ValidateRequest(capabilities, request.Proto); err != nil {
+		return nil, err
+	}
+
 	cmd := strings.TrimSpace(strings.ToLower(request.Proto.Command))
 	switch cmd {
 	case "infer", "infer_schema":
-		return nil, b.inferSchema(request)
+		return nil, b.inferSchema(ctx, request)
 	case "update":
 		return nil, b.updateItem(request)
 	}
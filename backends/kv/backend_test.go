@@ -0,0 +1,266 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/suite"
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/pb"
+	"github.com/v3io/frames/v3ioutils"
+)
+
+// Runs the backend against a fake v3io container
+type BackendTestSuite struct {
+	suite.Suite
+	backend frames.DataBackend
+	session *frames.Session
+}
+
+func (suite *BackendTestSuite) SetupTest() {
+	logger, err := frames.NewLogger("info")
+	suite.Require().NoError(err)
+
+	v3ioContext, err := v3ioutils.NewFakeContext(v3ioutils.FakeMemoryRoot)
+	suite.Require().NoError(err)
+
+	cfg := &frames.BackendConfig{Name: "kv", Type: "kv", UpdateWorkersPerVN: 2}
+	suite.backend, err = NewBackend(logger, v3ioContext, cfg, &frames.Config{})
+	suite.Require().NoError(err)
+
+	suite.session = &frames.Session{Container: "bigdata"}
+}
+
+func (suite *BackendTestSuite) write(table string, frame frames.Frame, saveMode frames.SaveMode) error {
+	appender, err := suite.backend.Write(&frames.WriteRequest{
+		Session:       suite.session,
+		Table:         table,
+		ImmidiateData: frame,
+		SaveMode:      saveMode,
+		Password:      frames.InitSecretString(""),
+		Token:         frames.InitSecretString(""),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := appender.WaitForComplete(0); err != nil {
+		return err
+	}
+	appender.Close()
+	return nil
+}
+
+func (suite *BackendTestSuite) read(proto *pb.ReadRequest) int {
+	proto.Session = suite.session
+	it, err := suite.backend.Read(&frames.ReadRequest{
+		Proto:    proto,
+		Password: frames.InitSecretString(""),
+		Token:    frames.InitSecretString(""),
+	})
+	suite.Require().NoError(err)
+
+	rows := 0
+	for it.Next() {
+		rows += it.At().Len()
+	}
+	suite.Require().NoError(it.Err())
+	return rows
+}
+
+func (suite *BackendTestSuite) TestWriteReadDelete() {
+	names := map[string]string{"f": "float", "s": "string", "b": "bool", "t": "time"}
+	frame := generateSequentialSampleFrameWithTypes(suite.T(), 10, "idx", names)
+	suite.Require().NoError(suite.write("t1", frame, frames.ErrorIfTableExists))
+	suite.Require().Error(suite.write("t1", frame, frames.ErrorIfTableExists))
+
+	suite.Require().Equal(10, suite.read(&pb.ReadRequest{Table: "t1"}))
+	suite.Require().Equal(3, suite.read(&pb.ReadRequest{Table: "t1", Filter: "idx < 3"}))
+
+	// Only new items are written
+	frame = generateSequentialSampleFrameWithTypes(suite.T(), 12, "idx", names)
+	suite.Require().NoError(suite.write("t1", frame, frames.CreateNewItemsOnly))
+	suite.Require().Equal(12, suite.read(&pb.ReadRequest{Table: "t1"}))
+
+	err := suite.backend.Delete(&frames.DeleteRequest{Proto: &pb.DeleteRequest{
+		Session: suite.session,
+		Table:   "t1",
+		Filter:  "idx >= 5",
+	},
+		Password: frames.InitSecretString(""),
+		Token:    frames.InitSecretString(""),
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(5, suite.read(&pb.ReadRequest{Table: "t1"}))
+}
+
+func (suite *BackendTestSuite) TestReadCancel() {
+	frame := generateSequentialSampleFrameWithTypes(suite.T(), 10, "idx", map[string]string{"f": "float"})
+	suite.Require().NoError(suite.write("t1", frame, frames.ErrorIfTableExists))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	it, err := suite.backend.(frames.ContextDataBackend).ReadContext(ctx, &frames.ReadRequest{
+		Proto:    &pb.ReadRequest{Session: suite.session, Table: "t1"},
+		Password: frames.InitSecretString(""),
+		Token:    frames.InitSecretString(""),
+	})
+	suite.Require().NoError(err)
+	suite.Require().False(it.Next())
+	suite.Require().Equal(context.Canceled, errors.Cause(it.Err()))
+}
+
+func (suite *BackendTestSuite) TestReadResume() {
+	frame := generateSequentialSampleFrameWithTypes(suite.T(), 10, "idx", map[string]string{"f": "float"})
+	suite.Require().NoError(suite.write("t1", frame, frames.ErrorIfTableExists))
+
+	it, err := suite.backend.Read(&frames.ReadRequest{
+		Proto:    &pb.ReadRequest{Session: suite.session, Table: "t1", MessageLimit: 3},
+		Password: frames.InitSecretString(""),
+		Token:    frames.InitSecretString(""),
+	})
+	suite.Require().NoError(err)
+	suite.Require().True(it.Next())
+	first := it.At()
+	suite.Require().NotEqual("", first.Marker())
+
+	suite.Require().Equal(10-first.Len(), suite.read(&pb.ReadRequest{Table: "t1", Marker: first.Marker()}))
+
+	_, err = suite.backend.Read(&frames.ReadRequest{
+		Proto:    &pb.ReadRequest{Session: suite.session, Table: "t1", Marker: "not a marker"},
+		Password: frames.InitSecretString(""),
+		Token:    frames.InitSecretString(""),
+	})
+	suite.Require().Error(err)
+}
+
+func (suite *BackendTestSuite) TestReadGroupBy() {
+	size := 10
+	idx, cat, v := make([]int64, size), make([]string, size), make([]float64, size)
+	for i := range idx {
+		idx[i], cat[i], v[i] = int64(i), string(rune('a'+i%3)), float64(i)
+	}
+
+	idxCol, err := frames.NewSliceColumn("idx", idx)
+	suite.Require().NoError(err)
+	catCol, err := frames.NewSliceColumn("cat", cat)
+	suite.Require().NoError(err)
+	vCol, err := frames.NewSliceColumn("v", v)
+	suite.Require().NoError(err)
+	frame, err := frames.NewFrame([]frames.Column{catCol, vCol}, []frames.Column{idxCol}, nil)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.write("t1", frame, frames.ErrorIfTableExists))
+
+	it, err := suite.backend.Read(&frames.ReadRequest{
+		Proto: &pb.ReadRequest{
+			Session:      suite.session,
+			Table:        "t1",
+			Columns:      []string{"v"},
+			GroupBy:      "cat",
+			Aggregators:  "count(v),sum(v),max(idx)",
+			MessageLimit: 2,
+		},
+		Password: frames.InitSecretString(""),
+		Token:    frames.InitSecretString(""),
+	})
+	suite.Require().NoError(err)
+
+	type group struct {
+		count int64
+		sum   float64
+		max   int64
+	}
+	groups := make(map[string]group)
+	for it.Next() {
+		frame := it.At()
+		suite.Require().True(frame.Len() <= 2)
+		for i := 0; i < frame.Len(); i++ {
+			key, err := frame.Indices()[0].StringAt(i)
+			suite.Require().NoError(err)
+			count, err := frame.Column("count(v)")
+			suite.Require().NoError(err)
+			sum, err := frame.Column("sum(v)")
+			suite.Require().NoError(err)
+			max, err := frame.Column("max(idx)")
+			suite.Require().NoError(err)
+
+			var g group
+			g.count, _ = count.IntAt(i)
+			g.sum, _ = sum.FloatAt(i)
+			g.max, _ = max.IntAt(i)
+			groups[key] = g
+		}
+	}
+	suite.Require().NoError(it.Err())
+
+	expected := map[string]group{"a": {4, 18, 9}, "b": {3, 12, 7}, "c": {3, 15, 8}}
+	suite.Require().Equal(expected, groups)
+
+	_, err = suite.backend.Read(&frames.ReadRequest{
+		Proto:    &pb.ReadRequest{Session: suite.session, Table: "t1", Aggregators: "median"},
+		Password: frames.InitSecretString(""),
+		Token:    frames.InitSecretString(""),
+	})
+	suite.Require().Error(err)
+}
+
+func (suite *BackendTestSuite) TestNarrowTypes() {
+	idxCol, err := frames.NewSliceColumn("idx", []int64{1, 2})
+	suite.Require().NoError(err)
+	i32Col, err := frames.NewSliceColumn("i32", []int32{-1, 7})
+	suite.Require().NoError(err)
+	f32Col, err := frames.NewSliceColumn("f32", []float32{0.5, 1.25})
+	suite.Require().NoError(err)
+	decCol, err := frames.NewSliceColumn("d", []frames.Decimal{{Value: 1050, Scale: 2}, {Value: -3, Scale: 2}})
+	suite.Require().NoError(err)
+	frame, err := frames.NewFrame([]frames.Column{i32Col, f32Col, decCol}, []frames.Column{idxCol}, nil)
+	suite.Require().NoError(err)
+	suite.Require().NoError(suite.write("t1", frame, frames.ErrorIfTableExists))
+
+	it, err := suite.backend.Read(&frames.ReadRequest{
+		Proto:    &pb.ReadRequest{Session: suite.session, Table: "t1", Columns: []string{"i32", "f32", "d"}},
+		Password: frames.InitSecretString(""),
+		Token:    frames.InitSecretString(""),
+	})
+	suite.Require().NoError(err)
+
+	rows := 0
+	for it.Next() {
+		for _, name := range []string{"i32", "f32", "d"} {
+			col, err := it.At().Column(name)
+			suite.Require().NoError(err)
+			expected, err := frame.Column(name)
+			suite.Require().NoError(err)
+			suite.Require().Equal(expected.DType(), col.DType(), name)
+		}
+		rows += it.At().Len()
+	}
+	suite.Require().NoError(it.Err())
+	suite.Require().Equal(2, rows)
+}
+
+func TestBackendTestSuite(t *testing.T) {
+	suite.Run(t, new(BackendTestSuite))
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package kv
+
+import (
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/pb"
+	"github.com/v3io/frames/v3ioutils"
+)
+
+// ListTables lists the tables (directories with a schema) in a directory
+func (b *Backend) ListTables(request *frames.ListTablesRequest) ([]string, error) {
+	container, dirPath, err := b.newConnection(request.Proto.Session, request.Password.Get(), request.Token.Get(), request.Proto.Path, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return v3ioutils.ListTables(container, dirPath, request.Proto.Path, func(dir string) (bool, error) {
+		return v3ioutils.PathExists(container, path.Join(dir, ".#schema"))
+	})
+}
+
+// DescribeTable returns the table schema
+func (b *Backend) DescribeTable(request *frames.DescribeTableRequest) (*pb.DescribeTableResponse, error) {
+	container, tablePath, err := b.newConnection(request.Proto.Session, request.Password.Get(), request.Token.Get(), request.Proto.Table, true)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaObj, err := v3ioutils.GetSchema(tablePath, container)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't read schema of '%s'", request.Proto.Table)
+	}
+
+	schema := schemaObj.(*v3ioutils.OldV3ioSchema)
+	description := &pb.DescribeTableResponse{Schema: schema.TableSchema()}
+	if schema.HashingBucketNum > 0 {
+		description.Attributes = map[string]*pb.Value{
+			"hashing_bucket_num": {Value: &pb.Value_Ival{Ival: int64(schema.HashingBucketNum)}},
+		}
+	}
+
+	return description, nil
+}
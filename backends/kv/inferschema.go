@@ -21,6 +21,7 @@ such restriction.
 package kv
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -39,7 +40,7 @@ var (
 	hashedBucketFormat = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*_[0-9]+$")
 )
 
-func (b *Backend) inferSchema(request *frames.ExecRequest) error {
+func (b *Backend) inferSchema(ctx context.Context, request *frames.ExecRequest) error {
 
 	container, table, err := b.newConnection(request.Proto.Session, request.Password.Get(), request.Token.Get(), request.Proto.Table, true)
 	if err != nil {
@@ -53,7 +54,7 @@ func (b *Backend) inferSchema(request *frames.ExecRequest) error {
 
 	input := v3io.GetItemsInput{Path: table, Filter: "", AttributeNames: []string{"*"}}
 	b.logger.DebugWith("GetItems for schema", "input", input)
-	iter, err := v3ioutils.NewAsyncItemsCursor(container, &input, b.numWorkers, []string{}, b.logger, b.maxRecordsInfer, []string{table}, "", "")
+	iter, err := v3ioutils.NewAsyncItemsCursorWithContext(ctx, container, &input, b.numWorkers, []string{}, b.logger, b.maxRecordsInfer, []string{table}, "", "", "")
 	if err != nil {
 		return err
 	}
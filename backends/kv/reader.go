@@ -21,14 +21,17 @@ such restriction.
 package kv
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/v3io/frames"
 	"github.com/v3io/frames/backends"
 	"github.com/v3io/frames/backends/utils"
+	"github.com/v3io/frames/ops"
 	"github.com/v3io/frames/pb"
 	"github.com/v3io/frames/v3ioutils"
 	"github.com/v3io/v3io-go/pkg/dataplane"
@@ -41,22 +44,28 @@ const (
 
 var systemAttrs = []string{"__gid", "__mode", "__mtime_nsecs", "__mtime_secs", "__size", "__uid", "__ctime_nsecs", "__ctime_secs", "__atime_secs", "__atime_nsecs", "__obj_type", "__collection_id"}
 
-var allowedReadRequestFields = map[string]bool{
-	"Segments":          true,
-	"TotalSegments":     true,
-	"ShardingKeys":      true,
-	"SortKeyRangeStart": true,
-	"SortKeyRangeEnd":   true,
-}
-
 // Read sends a read request
 func (kv *Backend) Read(request *frames.ReadRequest) (frames.FrameIterator, error) {
+	return kv.ReadContext(context.Background(), request)
+}
+
+// ReadContext sends a read request, the iterator stops once ctx is done
+func (kv *Backend) ReadContext(ctx context.Context, request *frames.ReadRequest) (frames.FrameIterator, error) {
+
+	err := backends.ValidateRequest(capabilities, request.Proto)
+	if err != nil {
+		return nil, err
+	}
 
-	err := backends.ValidateRequest("kv", request.Proto, allowedReadRequestFields)
+	keys, aggregations, err := backends.ParseGroupBy(request.Proto)
 	if err != nil {
 		return nil, err
 	}
 
+	if aggregations != nil {
+		request.Proto.Columns = backends.GroupByColumns(request.Proto.Columns, keys, aggregations)
+	}
+
 	if request.Proto.MessageLimit == 0 {
 		request.Proto.MessageLimit = 256 // TODO: More?
 	}
@@ -90,9 +99,9 @@ func (kv *Backend) Read(request *frames.ReadRequest) (frames.FrameIterator, erro
 	input := v3io.GetItemsInput{Filter: request.Proto.Filter, AttributeNames: columns, SortKeyRangeStart: request.Proto.SortKeyRangeStart, SortKeyRangeEnd: request.Proto.SortKeyRangeEnd}
 	kv.logger.DebugWith("read input", "input", input, "request", request)
 
-	iter, err := v3ioutils.NewAsyncItemsCursor(
-		container, &input, kv.numWorkers, request.Proto.ShardingKeys, kv.logger, 0, partitions,
-		request.Proto.SortKeyRangeStart, request.Proto.SortKeyRangeEnd)
+	iter, err := v3ioutils.NewAsyncItemsCursorWithContext(
+		ctx, container, &input, kv.numWorkers, request.Proto.ShardingKeys, kv.logger, 0, partitions,
+		request.Proto.SortKeyRangeStart, request.Proto.SortKeyRangeEnd, request.Proto.Marker)
 	if err != nil {
 		return nil, err
 	}
@@ -114,9 +123,75 @@ func (kv *Backend) Read(request *frames.ReadRequest) (frames.FrameIterator, erro
 
 	shouldDuplicateSorting := schemaObj.SortingKey != "" && containsString(columns, schemaObj.SortingKey)
 	newKVIter := Iterator{request: request, iter: iter, schema: schemaObj, shouldDuplicateIndex: containsString(columns, schemaObj.Key), shouldDuplicateSorting: shouldDuplicateSorting}
+	if aggregations != nil {
+		newKVIter.trackStreams = true
+		collect := func() (*ops.GroupBy, error) {
+			return aggregate(&newKVIter, keys, aggregations)
+		}
+		return backends.NewGroupByIterator(collect, int(request.Proto.MessageLimit)), nil
+	}
 	return &newKVIter, nil
 }
 
+// aggregate returns the group by of the items of ki. The items of every
+// cursor stream are aggregated on their own, and the partial aggregates are
+// merged in stream order
+func aggregate(ki *Iterator, keys []string, aggregations []ops.Aggregation) (*ops.GroupBy, error) {
+	partials := make(map[int]*ops.GroupBy)
+	for ki.Next() {
+		frame := ki.At()
+		rows := make(map[int][]int)
+		for i, stream := range ki.streams {
+			rows[stream] = append(rows[stream], i)
+		}
+
+		for stream, streamRows := range rows {
+			part := frame
+			if len(streamRows) != frame.Len() {
+				var err error
+				if part, err = ops.Take(frame, streamRows); err != nil {
+					return nil, err
+				}
+			}
+
+			partial, ok := partials[stream]
+			if !ok {
+				var err error
+				if partial, err = ops.NewGroupBy(keys, aggregations); err != nil {
+					return nil, err
+				}
+				partials[stream] = partial
+			}
+
+			if err := partial.Add(part); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := ki.Err(); err != nil {
+		return nil, err
+	}
+
+	streams := make([]int, 0, len(partials))
+	for stream := range partials {
+		streams = append(streams, stream)
+	}
+	sort.Ints(streams)
+
+	groupBy, err := ops.NewGroupBy(keys, aggregations)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, stream := range streams {
+		if err := groupBy.Merge(partials[stream]); err != nil {
+			return nil, err
+		}
+	}
+	return groupBy, nil
+}
+
 // Iterator is key/value iterator
 type Iterator struct {
 	request                *frames.ReadRequest
@@ -126,6 +201,8 @@ type Iterator struct {
 	shouldDuplicateIndex   bool
 	schema                 *v3ioutils.OldV3ioSchema
 	shouldDuplicateSorting bool
+	trackStreams           bool  // Set streams when aggregating
+	streams                []int // Cursor stream of every row of the current frame
 }
 
 // Next advances the iterator to next frame
@@ -139,6 +216,7 @@ func (ki *Iterator) Next() bool {
 	hasAnyNulls := false
 
 	columnNamesToReturn := ki.request.Proto.Columns
+	ki.streams = ki.streams[:0]
 	specificColumnsRequested := len(columnNamesToReturn) != 0
 
 	// Create columns
@@ -154,7 +232,7 @@ func (ki *Iterator) Next() bool {
 			ki.err = err
 			return false
 		}
-		data, err := utils.NewColumnFromType(f.Type, 0)
+		data, err := utils.NewColumnFromType(f.FramesType(), 0)
 		if err != nil {
 			ki.err = err
 			return false
@@ -244,6 +322,13 @@ func (ki *Iterator) Next() bool {
 				return false
 			}
 
+			// Decimals are kept as strings
+			if s, ok := field.(string); ok && col.DType() == frames.DecimalType {
+				if field, ki.err = frames.ParseDecimal(s); ki.err != nil {
+					return false
+				}
+			}
+
 			if err := utils.AppendColumn(col, field); err != nil {
 				ki.err = err
 				return false
@@ -270,6 +355,9 @@ func (ki *Iterator) Next() bool {
 			hasAnyNulls = true
 		}
 		nullColumns = append(nullColumns, &currentNullMask)
+		if ki.trackStreams {
+			ki.streams = append(ki.streams, ki.iter.Stream())
+		}
 	}
 
 	if ki.iter.Err() != nil {
@@ -299,8 +387,19 @@ func (ki *Iterator) Next() bool {
 	if !hasAnyNulls {
 		nullColumns = nil
 	}
-	var err error
-	ki.currFrame, err = frames.NewFrameWithNullValues(columns, indices, nil, nullColumns)
+	frame, err := frames.NewFrameWithNullValues(columns, indices, nil, nullColumns)
+	if err != nil {
+		ki.err = err
+		return false
+	}
+
+	marker, err := ki.iter.Marker()
+	if err != nil {
+		ki.err = err
+		return false
+	}
+
+	ki.currFrame, err = frames.WithMarker(frame, marker)
 	if err != nil {
 		ki.err = err
 		return false
@@ -21,6 +21,7 @@ such restriction.
 package kv
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"regexp"
@@ -39,6 +40,7 @@ import (
 
 // Appender is key/value appender
 type Appender struct {
+	ctx           context.Context
 	request       *frames.WriteRequest
 	container     v3io.Container
 	tablePath     string
@@ -62,17 +64,16 @@ var (
 	validColumnNamePattern = regexp.MustCompile("^[a-zA-Z_][a-zA-Z0-9_]*$")
 )
 
-var allowedWriteRequestFields = map[string]bool{
-	"Expression":    true,
-	"Condition":     true,
-	"PartitionKeys": true,
-	"SaveMode":      true,
-}
-
 // Write supports writing to the backend
 func (kv *Backend) Write(request *frames.WriteRequest) (frames.FrameAppender, error) {
+	return kv.WriteContext(context.Background(), request)
+}
 
-	err := backends.ValidateRequest("kv", request, allowedWriteRequestFields)
+// WriteContext supports writing to the backend, pending updates are dropped
+// once ctx is done
+func (kv *Backend) WriteContext(ctx context.Context, request *frames.WriteRequest) (frames.FrameAppender, error) {
+
+	err := backends.ValidateRequest(capabilities, request)
 	if err != nil {
 		return nil, err
 	}
@@ -123,6 +124,7 @@ func (kv *Backend) Write(request *frames.WriteRequest) (frames.FrameAppender, er
 	numUpdateWorkers := kv.numWorkers * kv.updateWorkersPerVN
 
 	appender := Appender{
+		ctx:         ctx,
 		request:     request,
 		container:   container,
 		tablePath:   tablePath,
@@ -215,6 +217,10 @@ func validateFrameInput(frame frames.Frame, request *frames.WriteRequest) error
 
 // Add adds a frame
 func (a *Appender) Add(frame frames.Frame) error {
+	if err := a.ctx.Err(); err != nil {
+		return err
+	}
+
 	err := validateFrameInput(frame, a.request)
 	if err != nil {
 		return err
@@ -268,6 +274,10 @@ func (a *Appender) Add(frame frames.Frame) error {
 		if err != nil {
 			return err
 		}
+		if col.DType() == frames.ListType {
+			return fmt.Errorf("%q - list columns are not supported in NoSQL tables", name)
+		}
+
 		name = validColName(name)
 		err = newSchema.AddColumn(name, col, true)
 		if err != nil {
@@ -485,6 +495,8 @@ func (a *Appender) WaitForComplete(timeout time.Duration) error {
 	select {
 	case <-a.doneChan:
 		return a.asyncErr
+	case <-a.ctx.Done():
+		return a.ctx.Err()
 	case <-time.After(maxWaitTime):
 		return errors.Errorf("The operation timed out after %.2f seconds.", maxWaitTime.Seconds())
 	}
@@ -545,6 +557,10 @@ func (a *Appender) funcFromCol(indexCol frames.Column) (func(int) interface{}, e
 
 func (a *Appender) updateItemWorker(doneChan chan<- struct{}) {
 	for req := range a.requestChan {
+		if a.ctx.Err() != nil {
+			continue // Drain the channel
+		}
+
 		a.logger.DebugWith("write request", "request", req)
 
 		resp, err := a.container.UpdateItemSync(req)
@@ -607,11 +623,7 @@ func getMapFromRow(columns map[string]frames.Column,
 			return nil, nil, nil, err
 		}
 
-		if val64, ok := val.(int64); ok {
-			val = int(val64)
-		}
-
-		row[name] = val
+		row[name] = itemValue(val)
 	}
 
 	key := indexValFunc(index)
@@ -648,6 +660,10 @@ func getUpdateExpressionFromRow(columns map[string]frames.Column,
 			return "", nil, nil, err
 		}
 
+		if _, ok := val.([]byte); ok {
+			return "", nil, nil, fmt.Errorf("%q - bytes can't be written in update mode", name)
+		}
+
 		expression.WriteString(name)
 		expression.WriteString("=")
 		expression.WriteString(valueToTypedExpressionString(val))
@@ -673,8 +689,24 @@ func getUpdateExpressionFromRow(columns map[string]frames.Column,
 	return expression.String(), key, sortingVal, nil
 }
 
-func valueToTypedExpressionString(value interface{}) string {
+// itemValue converts a column value to a type v3io items support, decimals are
+// kept as strings
+func itemValue(value interface{}) interface{} {
 	switch typedVal := value.(type) {
+	case int64:
+		return int(typedVal)
+	case int32:
+		return int(typedVal)
+	case float32:
+		return float64(typedVal)
+	case frames.Decimal:
+		return typedVal.String()
+	}
+	return value
+}
+
+func valueToTypedExpressionString(value interface{}) string {
+	switch typedVal := itemValue(value).(type) {
 	case string:
 		return fmt.Sprintf("'%v'", typedVal)
 	case time.Time:
@@ -0,0 +1,290 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/nuclio/logger"
+	"github.com/pkg/errors"
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/backends"
+	"github.com/v3io/frames/backends/filter"
+	"github.com/v3io/frames/v3ioutils"
+	v3io "github.com/v3io/v3io-go/pkg/dataplane"
+)
+
+const (
+	defaultKeyColumn = v3ioutils.DefaultKeyColumn
+)
+
+// Backend is an in-memory backend, tables are kept per session container in
+// process memory and are lost when the process exits
+type Backend struct {
+	logger       logger.Logger
+	framesConfig *frames.Config
+
+	lock   sync.RWMutex
+	tables map[string]*table // container/table -> table
+}
+
+var capabilities = &frames.Capabilities{
+	Type:         "memory",
+	ReadFields:   backends.ReadFields(),
+	WriteFields:  backends.WriteFields("HaveMore", "SaveMode"),
+	CreateFields: backends.CreateFields("Schema"),
+	DeleteFields: backends.DeleteFields(),
+	Commands: []*frames.ExecCommand{
+		{
+			Name: "ping",
+			Doc:  "Return a frame of integers",
+			Args: []*frames.ExecArgument{
+				{Name: "rows", Type: v3ioutils.LongType, Doc: "Number of rows (default 37)"},
+				{Name: "cols", Type: v3ioutils.LongType, Doc: "Number of columns (default 4)"},
+			},
+		},
+		{
+			Name: "tables",
+			Doc:  "List the tables in the session container with their sizes",
+		},
+	},
+	SaveModes: backends.SaveModes(
+		frames.ErrorIfTableExists, frames.OverwriteTable, frames.UpdateItem, frames.OverwriteItem, frames.CreateNewItemsOnly,
+	),
+}
+
+// NewBackend returns a new in-memory backend
+func NewBackend(logger logger.Logger, v3ioContext v3io.Context, config *frames.BackendConfig, framesConfig *frames.Config) (frames.DataBackend, error) {
+	backend := &Backend{
+		logger:       logger.GetChild("memory"),
+		framesConfig: framesConfig,
+		tables:       make(map[string]*table),
+	}
+
+	return backend, nil
+}
+
+// Capabilities returns the requests the backend supports
+func (b *Backend) Capabilities() *frames.Capabilities {
+	return capabilities
+}
+
+// Create creates a table
+func (b *Backend) Create(request *frames.CreateRequest) error {
+	if err := backends.ValidateRequest(capabilities, request.Proto); err != nil {
+		return err
+	}
+
+	if request.Proto.Table == "" {
+		return fmt.Errorf("missing table name")
+	}
+
+	tbl := newTable()
+	if schema := request.Proto.Schema; schema != nil {
+		for i, field := range schema.Fields {
+			if field.Name == "" {
+				return fmt.Errorf("field %d with no name", i)
+			}
+
+			dtype, err := v3ioutils.ConvertStringToDType(field.Type)
+			if err != nil {
+				return errors.Wrapf(err, "field %q", field.Name)
+			}
+
+			tbl.addColumn(field.Name, dtype)
+		}
+
+		if schema.Key != nil && len(schema.Key.ShardingKey) > 0 {
+			tbl.key = schema.Key.ShardingKey[0]
+		}
+		tbl.schema = schema
+	}
+
+	key := b.tableKey(request.Proto.Session, request.Proto.Table)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if _, ok := b.tables[key]; ok {
+		if request.Proto.IfExists == frames.IgnoreError {
+			return nil
+		}
+		return fmt.Errorf("table %q already exists", request.Proto.Table)
+	}
+
+	b.tables[key] = tbl
+	return nil
+}
+
+// Delete deletes a table, or the items matching the filter
+func (b *Backend) Delete(request *frames.DeleteRequest) error {
+	err := backends.ValidateRequest(capabilities, request.Proto)
+	if err != nil {
+		return err
+	}
+
+	key := b.tableKey(request.Proto.Session, request.Proto.Table)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	tbl, ok := b.tables[key]
+	if !ok {
+		if request.Proto.IfMissing == frames.IgnoreError {
+			return nil
+		}
+		return fmt.Errorf("table %q doesn't exist", request.Proto.Table)
+	}
+
+	if request.Proto.Filter == "" {
+		delete(b.tables, key)
+		return nil
+	}
+
+	expr, err := filter.Parse(request.Proto.Filter)
+	if err != nil {
+		return errors.Wrap(err, "bad filter")
+	}
+
+	var keep []string
+	for _, itemKey := range tbl.keys {
+		match, err := filter.Match(expr, filter.MapRow(tbl.items[itemKey]))
+		if err != nil {
+			return err
+		}
+
+		if match {
+			delete(tbl.items, itemKey)
+		} else {
+			keep = append(keep, itemKey)
+		}
+	}
+	tbl.keys = keep
+
+	return nil
+}
+
+func getInt(r *frames.ExecRequest, name string, defval int) int {
+	ival, err := r.Proto.Arg(name)
+	if err != nil {
+		return defval
+	}
+
+	val, ok := ival.(int64)
+	if !ok {
+		return defval
+	}
+
+	return int(val)
+}
+
+// Exec executes a command
+func (b *Backend) Exec(request *frames.ExecRequest) (frames.Frame, error) {
+	if err := backends.ValidateRequest(capabilities, request.Proto); err != nil {
+		return nil, err
+	}
+
+	cmd := strings.TrimSpace(strings.ToLower(request.Proto.Command))
+	switch cmd {
+	case "ping":
+		return b.ping(request)
+	case "tables":
+		return b.listTables(request)
+	}
+
+	return nil, fmt.Errorf("memory backend doesn't support execute command '%s'", cmd)
+}
+
+func (b *Backend) ping(request *frames.ExecRequest) (frames.Frame, error) {
+	b.logger.Info("PONG")
+	nRows, nCols := getInt(request, "rows", 37), getInt(request, "cols", 4)
+	cols := make([]frames.Column, nCols)
+	for c := 0; c < nCols; c++ {
+		name := fmt.Sprintf("col-%d", c)
+		bld := frames.NewSliceColumnBuilder(name, frames.IntType, nRows)
+		for r := 0; r < nRows; r++ {
+			if err := bld.Set(r, r*c); err != nil {
+				b.logger.WarnWith("cannot set column value", "name", name, "row", r)
+			}
+		}
+		cols[c] = bld.Finish()
+	}
+	return frames.NewFrame(cols, nil, nil)
+}
+
+// listTables returns the names and sizes of the tables in the session container
+func (b *Backend) listTables(request *frames.ExecRequest) (frames.Frame, error) {
+	prefix := b.tableKey(request.Proto.Session, "")
+
+	b.lock.RLock()
+	var names []string
+	sizes := make(map[string]int64)
+	for key, tbl := range b.tables {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		name := key[len(prefix):]
+		names = append(names, name)
+		sizes[name] = int64(len(tbl.keys))
+	}
+	b.lock.RUnlock()
+
+	sort.Strings(names)
+	rows := make([]int64, len(names))
+	for i, name := range names {
+		rows[i] = sizes[name]
+	}
+
+	nameCol, err := frames.NewSliceColumn("table", names)
+	if err != nil {
+		return nil, err
+	}
+
+	rowsCol, err := frames.NewSliceColumn("rows", rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return frames.NewFrame([]frames.Column{nameCol, rowsCol}, nil, nil)
+}
+
+// tableKey returns the key of a table in the tables map
+func (b *Backend) tableKey(session *frames.Session, table string) string {
+	container := ""
+	if session != nil {
+		container = session.Container
+	}
+
+	if container == "" && b.framesConfig != nil {
+		container = b.framesConfig.Container
+	}
+
+	return fmt.Sprintf("%s/%s", container, strings.Trim(table, "/"))
+}
+
+func init() {
+	if err := backends.Register("memory", NewBackend); err != nil {
+		panic(err)
+	}
+}
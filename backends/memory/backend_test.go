@@ -0,0 +1,281 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package memory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/pb"
+)
+
+type memoryTestSuite struct {
+	suite.Suite
+	backend frames.DataBackend
+	session *frames.Session
+}
+
+func (suite *memoryTestSuite) SetupTest() {
+	logger, err := frames.NewLogger("debug")
+	suite.Require().NoError(err)
+
+	cfg := &frames.BackendConfig{Name: "memory", Type: "memory"}
+	suite.backend, err = NewBackend(logger, nil, cfg, nil)
+	suite.Require().NoError(err)
+
+	suite.session = &frames.Session{Container: "bigdata"}
+}
+
+func (suite *memoryTestSuite) write(table string, saveMode frames.SaveMode, frame frames.Frame) error {
+	req := &frames.WriteRequest{
+		Session:       suite.session,
+		Table:         table,
+		ImmidiateData: frame,
+		SaveMode:      saveMode,
+	}
+
+	appender, err := suite.backend.Write(req)
+	if err != nil {
+		return err
+	}
+
+	if err := appender.WaitForComplete(0); err != nil {
+		return err
+	}
+	appender.Close()
+	return nil
+}
+
+func (suite *memoryTestSuite) read(proto *pb.ReadRequest) []frames.Frame {
+	proto.Session = suite.session
+	it, err := suite.backend.Read(&frames.ReadRequest{Proto: proto})
+	suite.Require().NoError(err)
+
+	var result []frames.Frame
+	for it.Next() {
+		result = append(result, it.At())
+	}
+	suite.Require().NoError(it.Err())
+	return result
+}
+
+func (suite *memoryTestSuite) readAll(proto *pb.ReadRequest) map[string]map[string]interface{} {
+	rows := make(map[string]map[string]interface{})
+	for _, frame := range suite.read(proto) {
+		iter := frame.IterRows(true)
+		for iter.Next() {
+			row := iter.Row()
+			rows[row["key"].(string)] = row
+		}
+		suite.Require().NoError(iter.Err())
+	}
+	return rows
+}
+
+func (suite *memoryTestSuite) frame(keys []string, values []int64, names []string) frames.Frame {
+	keyCol, err := frames.NewSliceColumn("key", keys)
+	suite.Require().NoError(err)
+
+	var cols []frames.Column
+	for _, name := range names {
+		col, err := frames.NewSliceColumn(name, values)
+		suite.Require().NoError(err)
+		cols = append(cols, col)
+	}
+
+	frame, err := frames.NewFrame(cols, []frames.Column{keyCol}, nil)
+	suite.Require().NoError(err)
+	return frame
+}
+
+func (suite *memoryTestSuite) TestWriteRead() {
+	frame := suite.frame([]string{"a", "b", "c"}, []int64{1, 2, 3}, []string{"x", "y"})
+	suite.Require().NoError(suite.write("t1", frames.ErrorIfTableExists, frame))
+
+	result := suite.read(&pb.ReadRequest{Table: "t1"})
+	suite.Require().Len(result, 1)
+	suite.Require().Equal(3, result[0].Len())
+	suite.Require().Equal([]string{"x", "y"}, result[0].Names())
+	suite.Require().Len(result[0].Indices(), 1)
+	suite.Require().Equal("key", result[0].Indices()[0].Name())
+
+	// Other containers don't see the table
+	_, err := suite.backend.Read(&frames.ReadRequest{Proto: &pb.ReadRequest{
+		Session: &frames.Session{Container: "users"},
+		Table:   "t1",
+	}})
+	suite.Require().Error(err)
+}
+
+func (suite *memoryTestSuite) TestColumnsFilterLimit() {
+	frame := suite.frame([]string{"a", "b", "c", "d"}, []int64{1, 2, 3, 4}, []string{"x", "y"})
+	suite.Require().NoError(suite.write("t1", frames.ErrorIfTableExists, frame))
+
+	result := suite.read(&pb.ReadRequest{Table: "t1", Columns: []string{"y"}})
+	suite.Require().Equal([]string{"y"}, result[0].Names())
+
+	rows := suite.readAll(&pb.ReadRequest{Table: "t1", Filter: "x > 1 and key != 'e'"})
+	suite.Require().Len(rows, 3)
+	suite.Require().NotContains(rows, "a")
+
+	result = suite.read(&pb.ReadRequest{Table: "t1", Limit: 2})
+	suite.Require().Equal(2, result[0].Len())
+
+	result = suite.read(&pb.ReadRequest{Table: "t1", MessageLimit: 3})
+	suite.Require().Len(result, 2)
+	suite.Require().Equal(3, result[0].Len())
+	suite.Require().Equal(1, result[1].Len())
+
+	_, err := suite.backend.Read(&frames.ReadRequest{Proto: &pb.ReadRequest{
+		Session: suite.session,
+		Table:   "t1",
+		Columns: []string{"z"},
+	}})
+	suite.Require().Error(err)
+}
+
+func (suite *memoryTestSuite) TestSaveModes() {
+	frame := suite.frame([]string{"a", "b"}, []int64{1, 2}, []string{"x"})
+	suite.Require().NoError(suite.write("t1", frames.ErrorIfTableExists, frame))
+	suite.Require().Error(suite.write("t1", frames.ErrorIfTableExists, frame))
+
+	// CreateNewItemsOnly doesn't touch existing items
+	frame = suite.frame([]string{"b", "c"}, []int64{20, 30}, []string{"x"})
+	suite.Require().NoError(suite.write("t1", frames.CreateNewItemsOnly, frame))
+	rows := suite.readAll(&pb.ReadRequest{Table: "t1"})
+	suite.Require().Len(rows, 3)
+	suite.Require().Equal(int64(2), rows["b"]["x"])
+	suite.Require().Equal(int64(30), rows["c"]["x"])
+
+	// UpdateItem keeps attributes that are not written
+	frame = suite.frame([]string{"a"}, []int64{100}, []string{"y"})
+	suite.Require().NoError(suite.write("t1", frames.UpdateItem, frame))
+	rows = suite.readAll(&pb.ReadRequest{Table: "t1"})
+	suite.Require().Equal(int64(1), rows["a"]["x"])
+	suite.Require().Equal(int64(100), rows["a"]["y"])
+
+	// OverwriteItem replaces the whole item
+	frame = suite.frame([]string{"a"}, []int64{7}, []string{"x"})
+	suite.Require().NoError(suite.write("t1", frames.OverwriteItem, frame))
+	rows = suite.readAll(&pb.ReadRequest{Table: "t1"})
+	suite.Require().Equal(int64(7), rows["a"]["x"])
+	result := suite.read(&pb.ReadRequest{Table: "t1", Filter: "key == 'a'"})
+	suite.Require().True(result[0].IsNull(0, "y"))
+
+	// OverwriteTable drops all existing items
+	frame = suite.frame([]string{"z"}, []int64{0}, []string{"x"})
+	suite.Require().NoError(suite.write("t1", frames.OverwriteTable, frame))
+	rows = suite.readAll(&pb.ReadRequest{Table: "t1"})
+	suite.Require().Len(rows, 1)
+	suite.Require().Contains(rows, "z")
+}
+
+func (suite *memoryTestSuite) TestCreateDelete() {
+	schema := &frames.TableSchema{
+		Fields: []*frames.SchemaField{
+			{Name: "key", Type: "string"},
+			{Name: "x", Type: "double"},
+		},
+		Key: &frames.SchemaKey{ShardingKey: []string{"key"}},
+	}
+	req := &frames.CreateRequest{Proto: &pb.CreateRequest{
+		Session: suite.session,
+		Table:   "t1",
+		Schema:  schema,
+	}}
+	suite.Require().NoError(suite.backend.Create(req))
+	suite.Require().Error(suite.backend.Create(req))
+	req.Proto.IfExists = frames.IgnoreError
+	suite.Require().NoError(suite.backend.Create(req))
+
+	// Ints are stored in the float column
+	frame := suite.frame([]string{"a", "b", "c"}, []int64{1, 2, 3}, []string{"x"})
+	suite.Require().NoError(suite.write("t1", frames.ErrorIfTableExists, frame))
+	result := suite.read(&pb.ReadRequest{Table: "t1"})
+	col, err := result[0].Column("x")
+	suite.Require().NoError(err)
+	suite.Require().Equal(frames.FloatType, col.DType())
+
+	delReq := &frames.DeleteRequest{Proto: &pb.DeleteRequest{
+		Session: suite.session,
+		Table:   "t1",
+		Filter:  "x >= 2",
+	}}
+	suite.Require().NoError(suite.backend.Delete(delReq))
+	rows := suite.readAll(&pb.ReadRequest{Table: "t1"})
+	suite.Require().Len(rows, 1)
+	suite.Require().Contains(rows, "a")
+
+	delReq.Proto.Filter = ""
+	suite.Require().NoError(suite.backend.Delete(delReq))
+	suite.Require().Error(suite.backend.Delete(delReq))
+	delReq.Proto.IfMissing = frames.IgnoreError
+	suite.Require().NoError(suite.backend.Delete(delReq))
+}
+
+func (suite *memoryTestSuite) TestDeleteDuringWrite() {
+	frame := suite.frame([]string{"a"}, []int64{1}, []string{"x"})
+	appender, err := suite.backend.Write(&frames.WriteRequest{
+		Session:       suite.session,
+		Table:         "t1",
+		ImmidiateData: frame,
+	})
+	suite.Require().NoError(err)
+
+	delReq := &frames.DeleteRequest{Proto: &pb.DeleteRequest{Session: suite.session, Table: "t1"}}
+	suite.Require().NoError(suite.backend.Delete(delReq))
+
+	frame = suite.frame([]string{"b"}, []int64{2}, []string{"x"})
+	suite.Require().Error(appender.Add(frame))
+
+	// A table created after the delete doesn't get the rows either
+	suite.Require().NoError(suite.write("t1", frames.ErrorIfTableExists, frame))
+	suite.Require().Error(appender.Add(frame))
+	rows := suite.readAll(&pb.ReadRequest{Table: "t1"})
+	suite.Require().Len(rows, 1)
+	suite.Require().Contains(rows, "b")
+}
+
+func (suite *memoryTestSuite) TestExec() {
+	frame := suite.frame([]string{"a"}, []int64{1}, []string{"x"})
+	suite.Require().NoError(suite.write("t2", frames.ErrorIfTableExists, frame))
+	suite.Require().NoError(suite.write("t1", frames.ErrorIfTableExists, frame))
+
+	req := &frames.ExecRequest{Proto: &pb.ExecRequest{Session: suite.session, Command: "tables"}}
+	out, err := suite.backend.Exec(req)
+	suite.Require().NoError(err)
+	col, err := out.Column("table")
+	suite.Require().NoError(err)
+	suite.Require().Equal([]string{"t1", "t2"}, col.Strings())
+
+	req.Proto.Command = "ping"
+	_, err = suite.backend.Exec(req)
+	suite.Require().NoError(err)
+
+	req.Proto.Command = "no-such-command"
+	_, err = suite.backend.Exec(req)
+	suite.Require().Error(err)
+}
+
+func TestMemorySuite(t *testing.T) {
+	suite.Run(t, new(memoryTestSuite))
+}
@@ -0,0 +1,247 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package memory
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/backends"
+	"github.com/v3io/frames/backends/filter"
+	"github.com/v3io/frames/backends/utils"
+	"github.com/v3io/frames/pb"
+	"github.com/v3io/frames/v3ioutils"
+)
+
+const (
+	defaultMessageLimit = 256
+)
+
+// Read reads a table, the items are read from a snapshot taken when the
+// read starts so concurrent writes don't affect it
+func (b *Backend) Read(request *frames.ReadRequest) (frames.FrameIterator, error) {
+	err := backends.ValidateRequest(capabilities, request.Proto)
+	if err != nil {
+		return nil, err
+	}
+
+	var expr filter.Expr
+	if request.Proto.Filter != "" {
+		expr, err = filter.Parse(request.Proto.Filter)
+		if err != nil {
+			return nil, errors.Wrap(err, "bad filter")
+		}
+	}
+
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	tbl, ok := b.tables[b.tableKey(request.Proto.Session, request.Proto.Table)]
+	if !ok {
+		return nil, fmt.Errorf("table %q doesn't exist", request.Proto.Table)
+	}
+
+	columns := request.Proto.Columns
+	if len(columns) == 0 {
+		// The key is returned as the index
+		for _, name := range tbl.names {
+			if name != tbl.key {
+				columns = append(columns, name)
+			}
+		}
+	}
+
+	for _, name := range columns {
+		if _, ok := tbl.dtypes[name]; !ok {
+			return nil, fmt.Errorf("column '%v' doesn't exist", name)
+		}
+	}
+
+	limit := int(request.Proto.Limit)
+	var items []item
+	for _, key := range tbl.keys {
+		if limit > 0 && len(items) >= limit {
+			break
+		}
+
+		it := tbl.items[key]
+		if expr != nil {
+			match, err := filter.Match(expr, filter.MapRow(it))
+			if err != nil {
+				return nil, err
+			}
+			if !match {
+				continue
+			}
+		}
+		items = append(items, it)
+	}
+
+	dtypes := make(map[string]frames.DType, len(tbl.dtypes))
+	for name, dtype := range tbl.dtypes {
+		dtypes[name] = dtype
+	}
+
+	frameLimit := int(request.Proto.MessageLimit)
+	if frameLimit == 0 {
+		frameLimit = defaultMessageLimit
+	}
+
+	iter := &frameIterator{
+		items:      items,
+		columns:    columns,
+		dtypes:     dtypes,
+		key:        tbl.key,
+		resetIndex: request.Proto.ResetIndex,
+		frameLimit: frameLimit,
+	}
+
+	return iter, nil
+}
+
+type frameIterator struct {
+	items      []item
+	columns    []string
+	dtypes     map[string]frames.DType
+	key        string
+	resetIndex bool
+	frameLimit int
+
+	pos   int
+	frame frames.Frame
+	err   error
+}
+
+// Next advances the iterator to the next frame
+func (it *frameIterator) Next() bool {
+	if it.err != nil || it.pos >= len(it.items) {
+		return false
+	}
+
+	end := it.pos + it.frameLimit
+	if end > len(it.items) {
+		end = len(it.items)
+	}
+
+	it.frame, it.err = it.buildFrame(it.items[it.pos:end])
+	if it.err != nil {
+		return false
+	}
+
+	it.pos = end
+	return true
+}
+
+// Err returns the last error
+func (it *frameIterator) Err() error {
+	return it.err
+}
+
+// At returns the current frame
+func (it *frameIterator) At() frames.Frame {
+	return it.frame
+}
+
+func (it *frameIterator) buildFrame(items []item) (frames.Frame, error) {
+	var (
+		columns     []frames.Column
+		indices     []frames.Column
+		nullValues  = make([]*pb.NullValuesMap, len(items))
+		hasAnyNulls bool
+	)
+
+	for i := range nullValues {
+		nullValues[i] = &pb.NullValuesMap{NullColumns: make(map[string]bool)}
+	}
+
+	// The key column is the index, unless it was asked for explicitly
+	names := it.columns
+	keyIsIndex := !it.resetIndex && !containsString(names, it.key)
+	if keyIsIndex {
+		names = append([]string{it.key}, names...)
+	}
+
+	for _, name := range names {
+		col, err := it.buildColumn(name, items, nullValues, &hasAnyNulls)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot build column %q", name)
+		}
+
+		if keyIsIndex && name == it.key {
+			indices = append(indices, col)
+			continue
+		}
+
+		columns = append(columns, col)
+	}
+
+	if !hasAnyNulls {
+		nullValues = nil
+	}
+
+	return frames.NewFrameWithNullValues(columns, indices, nil, nullValues)
+}
+
+func (it *frameIterator) buildColumn(name string, items []item, nullValues []*pb.NullValuesMap, hasAnyNulls *bool) (frames.Column, error) {
+	dtype := it.dtypes[name]
+	data, err := utils.NewColumnFromType(v3ioutils.ConvertDTypeToString(dtype), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	col, err := frames.NewSliceColumn(name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	for r, row := range items {
+		val, ok := row[name]
+		if !ok {
+			if err := utils.AppendNil(col); err != nil {
+				return nil, err
+			}
+			nullValues[r].NullColumns[name] = true
+			*hasAnyNulls = true
+			continue
+		}
+
+		if ival, isInt := val.(int64); isInt && dtype == frames.FloatType {
+			val = float64(ival)
+		}
+
+		if err := utils.AppendColumn(col, val); err != nil {
+			return nil, err
+		}
+	}
+
+	return col, nil
+}
+
+func containsString(s []string, subString string) bool {
+	for _, str := range s {
+		if str == subString {
+			return true
+		}
+	}
+
+	return false
+}
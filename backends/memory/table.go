@@ -0,0 +1,95 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package memory
+
+import (
+	"fmt"
+
+	"github.com/v3io/frames"
+)
+
+// item is a single table row, a missing attribute is a null value
+type item map[string]interface{}
+
+// table is an in-memory table, items are kept by key in insertion order
+type table struct {
+	schema *frames.TableSchema
+	key    string                  // Name of the key (index) column
+	names  []string                // Column names, in order of appearance
+	dtypes map[string]frames.DType // Column name -> dtype
+	keys   []string                // Item keys, in insertion order
+	items  map[string]item         // Item key -> item
+}
+
+func newTable() *table {
+	return &table{
+		key:    defaultKeyColumn,
+		dtypes: make(map[string]frames.DType),
+		items:  make(map[string]item),
+	}
+}
+
+// addColumn adds a column to the table, it's a no-op if the column already exists
+func (t *table) addColumn(name string, dtype frames.DType) {
+	if _, ok := t.dtypes[name]; ok {
+		return
+	}
+
+	t.names = append(t.names, name)
+	t.dtypes[name] = dtype
+}
+
+// checkColumn verifies that column can be stored in the table
+func (t *table) checkColumn(col frames.Column) error {
+	dtype, ok := t.dtypes[col.Name()]
+	if !ok || dtype == col.DType() {
+		return nil
+	}
+
+	// Same as in the KV schema, ints can be stored in float columns
+	if dtype == frames.FloatType && col.DType() == frames.IntType {
+		return nil
+	}
+
+	return fmt.Errorf("column %q type mismatch (%d != %d)", col.Name(), col.DType(), dtype)
+}
+
+func (t *table) get(key string) (item, bool) {
+	it, ok := t.items[key]
+	return it, ok
+}
+
+func (t *table) put(key string, it item) {
+	if _, ok := t.items[key]; !ok {
+		t.keys = append(t.keys, key)
+	}
+	t.items[key] = it
+}
+
+func (t *table) reset() {
+	t.keys = nil
+	t.items = make(map[string]item)
+}
+
+// itemKey returns the map key for a key value
+func itemKey(value interface{}) string {
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package memory
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/nuclio/logger"
+	"github.com/pkg/errors"
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/backends"
+	"github.com/v3io/frames/backends/utils"
+)
+
+// Write writes frames to a table
+func (b *Backend) Write(request *frames.WriteRequest) (frames.FrameAppender, error) {
+	err := backends.ValidateRequest(capabilities, request)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Table == "" {
+		return nil, fmt.Errorf("missing table name")
+	}
+
+	key := b.tableKey(request.Session, request.Table)
+
+	b.lock.Lock()
+	tbl, ok := b.tables[key]
+	switch {
+	case !ok:
+		tbl = newTable()
+		b.tables[key] = tbl
+	case request.SaveMode == frames.OverwriteTable:
+		tbl.reset()
+	case request.SaveMode == frames.ErrorIfTableExists && len(tbl.keys) > 0:
+		b.lock.Unlock()
+		return nil, fmt.Errorf("table '%v' already exists; either use a different save mode or save to a different table", request.Table)
+	}
+	b.lock.Unlock()
+
+	appender := &appender{
+		backend:  b,
+		key:      key,
+		name:     request.Table,
+		table:    tbl,
+		saveMode: request.SaveMode,
+		logger:   b.logger,
+	}
+
+	if request.ImmidiateData != nil {
+		if err := appender.Add(request.ImmidiateData); err != nil {
+			return nil, errors.Wrap(err, "cannot add immediate data")
+		}
+	}
+
+	return appender, nil
+}
+
+type appender struct {
+	backend       *Backend
+	key           string // Table key in the backend
+	name          string
+	table         *table
+	saveMode      frames.SaveMode
+	logger        logger.Logger
+	rowsProcessed int
+	closed        bool
+}
+
+// Add adds a frame to the table, rows are applied according to the save mode
+func (a *appender) Add(frame frames.Frame) error {
+	if a.closed {
+		err := errors.New("Adding on a closed memory appender")
+		a.logger.Error(err)
+		return err
+	}
+
+	names := frame.Names()
+	columns := make([]frames.Column, len(names))
+	for i, name := range names {
+		col, err := frame.Column(name)
+		if err != nil {
+			return err
+		}
+		columns[i] = col
+	}
+
+	a.backend.lock.Lock()
+	defer a.backend.lock.Unlock()
+
+	// Rows written to a deleted table are lost, a new table with the same
+	// name is a different table
+	if a.backend.tables[a.key] != a.table {
+		return fmt.Errorf("table %q was deleted during the write", a.name)
+	}
+
+	keyCol, keyName := a.keyColumn(frame)
+
+	for _, col := range columns {
+		if err := a.table.checkColumn(col); err != nil {
+			return err
+		}
+	}
+	if keyCol != nil {
+		if err := a.table.checkColumn(keyCol); err != nil {
+			return err
+		}
+	}
+
+	if keyName != a.table.key {
+		if len(a.table.keys) > 0 {
+			return fmt.Errorf("changing primary key is not allowed, old: %v, new:%v", a.table.key, keyName)
+		}
+		a.table.key = keyName
+	}
+
+	if keyCol != nil {
+		a.table.addColumn(keyName, keyCol.DType())
+	} else {
+		a.table.addColumn(keyName, frames.IntType)
+	}
+	for _, col := range columns {
+		if col.DType() != frames.NullType {
+			a.table.addColumn(col.Name(), col.DType())
+		}
+	}
+
+	for r := 0; r < frame.Len(); r++ {
+		row := make(item)
+		for _, col := range columns {
+			if col.DType() == frames.NullType || frame.IsNull(r, col.Name()) {
+				continue
+			}
+
+			val, err := utils.ColAt(col, r)
+			if err != nil {
+				return errors.Wrapf(err, "%s:%d cannot get value", col.Name(), r)
+			}
+			row[col.Name()] = val
+		}
+
+		var keyVal interface{} = int64(a.rowsProcessed + r)
+		if keyCol != nil {
+			val, err := utils.ColAt(keyCol, r)
+			if err != nil {
+				return errors.Wrapf(err, "%s:%d cannot get key", keyName, r)
+			}
+			keyVal = val
+		}
+		row[keyName] = keyVal
+
+		a.apply(itemKey(keyVal), row, frame, r)
+	}
+
+	a.rowsProcessed += frame.Len()
+	return nil
+}
+
+// apply applies a row to the table according to the save mode
+func (a *appender) apply(key string, row item, frame frames.Frame, r int) {
+	current, exists := a.table.get(key)
+	switch a.saveMode {
+	case frames.CreateNewItemsOnly:
+		if exists {
+			a.logger.DebugWith("item exists, skipping", "key", key)
+			return
+		}
+	case frames.UpdateItem:
+		if exists {
+			merged := make(item, len(current)+len(row))
+			for name, val := range current {
+				merged[name] = val
+			}
+			// Explicit nulls delete the attribute (same as in the KV backend)
+			for _, name := range frame.Names() {
+				if frame.IsNull(r, name) {
+					delete(merged, name)
+				}
+			}
+			for name, val := range row {
+				merged[name] = val
+			}
+			row = merged
+		}
+	}
+
+	a.table.put(key, row)
+}
+
+// keyColumn returns the column (if any) that holds the item keys and its name
+func (a *appender) keyColumn(frame frames.Frame) (frames.Column, string) {
+	indices := frame.Indices()
+	if len(indices) == 0 {
+		return nil, a.table.key
+	}
+
+	name := indices[0].Name()
+	if name == "" {
+		name = a.table.key
+	}
+
+	if name != indices[0].Name() {
+		return indices[0].CopyWithName(name), name
+	}
+	return indices[0], name
+}
+
+// WaitForComplete waits for write to complete, writes to memory are synchronous
+func (a *appender) WaitForComplete(timeout time.Duration) error {
+	if a.closed {
+		err := errors.New("Adding on a closed memory appender")
+		a.logger.Error(err)
+		return err
+	}
+
+	return nil
+}
+
+func (a *appender) Close() {
+	a.closed = true
+}
@@ -0,0 +1,302 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+// Package parquet is a backend for Parquet files, rooted at a directory (same
+// as the csv backend)
+package parquet
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nuclio/logger"
+	"github.com/pkg/errors"
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/backends"
+	"github.com/v3io/frames/backends/filter"
+	"github.com/v3io/frames/v3ioutils"
+	v3io "github.com/v3io/v3io-go/pkg/dataplane"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+// Number of goroutines parquet-go uses to encode and decode pages
+const parallelism = 4
+
+// Backend is a Parquet backend
+type Backend struct {
+	rootDir string
+	logger  logger.Logger
+}
+
+var capabilities = &frames.Capabilities{
+	Type:         "parquet",
+	ReadFields:   backends.ReadFields(),
+	WriteFields:  backends.WriteFields("HaveMore", "SaveMode"),
+	CreateFields: backends.CreateFields("Schema"),
+	DeleteFields: backends.DeleteFields(),
+	SaveModes:    backends.SaveModes(frames.ErrorIfTableExists, frames.OverwriteTable),
+}
+
+// NewBackend returns a new Parquet backend
+func NewBackend(logger logger.Logger, v3ioContext v3io.Context, config *frames.BackendConfig, framesConfig *frames.Config) (frames.DataBackend, error) {
+	backend := &Backend{
+		rootDir: config.RootDir,
+		logger:  logger.GetChild("parquet"),
+	}
+
+	return backend, nil
+}
+
+// Capabilities returns the requests the backend supports, there are no exec commands
+func (b *Backend) Capabilities() *frames.Capabilities {
+	return capabilities
+}
+
+// Create creates an empty Parquet file with the schema columns
+func (b *Backend) Create(request *frames.CreateRequest) error {
+	if err := backends.ValidateRequest(capabilities, request.Proto); err != nil {
+		return err
+	}
+
+	path := b.tablePath(request.Proto.Table)
+	if fileExists(path) {
+		return fmt.Errorf("table '%v' already exists", request.Proto.Table)
+	}
+
+	schema := request.Proto.Schema
+	if schema == nil || len(schema.Fields) == 0 {
+		return fmt.Errorf("parquet backend requires a schema to create a table")
+	}
+
+	names := make([]string, len(schema.Fields))
+	dtypes := make([]frames.DType, len(schema.Fields))
+	for i, field := range schema.Fields {
+		dtype, err := v3ioutils.ConvertStringToDType(field.Type)
+		if err != nil {
+			return errors.Wrapf(err, "field %q", field.Name)
+		}
+		names[i], dtypes[i] = field.Name, dtype
+	}
+
+	appender, err := newAppender(b.logger, path)
+	if err != nil {
+		return err
+	}
+	defer appender.Close()
+
+	if err := appender.init(names, dtypes); err != nil {
+		return err
+	}
+
+	return appender.WaitForComplete(0)
+}
+
+// Delete deletes a table, or rows matching the filter
+func (b *Backend) Delete(request *frames.DeleteRequest) error {
+	err := backends.ValidateRequest(capabilities, request.Proto)
+	if err != nil {
+		return err
+	}
+
+	path := b.tablePath(request.Proto.Table)
+	if !fileExists(path) {
+		if request.Proto.IfMissing == frames.FailOnError {
+			return fmt.Errorf("table '%v' doesn't exist", request.Proto.Table)
+		}
+		return nil
+	}
+
+	if request.Proto.Filter != "" {
+		return b.deleteRows(path, request.Proto.Filter)
+	}
+
+	if err := os.Remove(path); err != nil {
+		return errors.Wrapf(err, "cannot delete table '%v'", request.Proto.Table)
+	}
+
+	return nil
+}
+
+// deleteRows rewrites the file without the rows matching the filter
+func (b *Backend) deleteRows(path string, filterExpr string) error {
+	expr, err := filter.Parse(filterExpr)
+	if err != nil {
+		return errors.Wrap(err, "bad filter")
+	}
+
+	it, err := newFrameIterator(b.logger, path, nil)
+	if err != nil {
+		return err
+	}
+	defer it.close()
+	it.filter = &filter.Unary{Op: filter.OpNot, Expr: expr}
+
+	appender, err := newAppender(b.logger, path)
+	if err != nil {
+		return err
+	}
+	defer appender.Close()
+
+	// Keep the schema even if all rows are deleted
+	if err := appender.init(it.columnNames(), it.columnTypes()); err != nil {
+		return err
+	}
+
+	for it.Next() {
+		if err := appender.Add(it.At()); err != nil {
+			return err
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return err
+	}
+
+	return appender.WaitForComplete(0)
+}
+
+// Read reads a table, one or more frames per row group
+func (b *Backend) Read(request *frames.ReadRequest) (frames.FrameIterator, error) {
+	err := backends.ValidateRequest(capabilities, request.Proto)
+	if err != nil {
+		return nil, err
+	}
+
+	var expr filter.Expr
+	if request.Proto.Filter != "" {
+		expr, err = filter.Parse(request.Proto.Filter)
+		if err != nil {
+			return nil, errors.Wrap(err, "bad filter")
+		}
+	}
+
+	it, err := newFrameIterator(b.logger, b.tablePath(request.Proto.Table), request.Proto.Columns)
+	if err != nil {
+		return nil, err
+	}
+
+	it.filter = expr
+	it.limit = int(request.Proto.Limit)
+	it.frameLimit = int(request.Proto.MessageLimit)
+
+	if expr != nil {
+		it.addColumns(filter.Attributes(expr))
+	}
+
+	return it, nil
+}
+
+// Write writes frames as row groups of a new file. Parquet files can't be
+// appended to, so only ErrorIfTableExists (for empty tables) and
+// OverwriteTable are supported
+func (b *Backend) Write(request *frames.WriteRequest) (frames.FrameAppender, error) {
+	err := backends.ValidateRequest(capabilities, request)
+	if err != nil {
+		return nil, err
+	}
+
+	path := b.tablePath(request.Table)
+
+	var (
+		names  []string
+		dtypes []frames.DType
+	)
+	switch request.SaveMode {
+	case frames.OverwriteTable:
+	case frames.ErrorIfTableExists:
+		if !fileExists(path) {
+			break
+		}
+
+		// An empty table (e.g. from Create) can be written, in its schema
+		it, err := newFrameIterator(b.logger, path, nil)
+		if err != nil {
+			return nil, err
+		}
+		names, dtypes = it.columnNames(), it.columnTypes()
+		numRows := it.reader.GetNumRows()
+		it.close()
+
+		if numRows > 0 {
+			return nil, fmt.Errorf("table '%v' already exists; either use a different save mode or save to a different table", request.Table)
+		}
+	default:
+		return nil, fmt.Errorf("parquet backend doesn't support save mode %s", request.SaveMode)
+	}
+
+	appender, err := newAppender(b.logger, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if names != nil {
+		if err := appender.init(names, dtypes); err != nil {
+			appender.Close()
+			return nil, err
+		}
+	}
+
+	if request.ImmidiateData != nil {
+		if err := appender.Add(request.ImmidiateData); err != nil {
+			appender.Close()
+			return nil, errors.Wrap(err, "cannot add immediate data")
+		}
+	}
+
+	return appender, nil
+}
+
+// Exec executes a command
+func (b *Backend) Exec(request *frames.ExecRequest) (frames.Frame, error) {
+	return nil, fmt.Errorf("parquet backend doesn't support execute command '%v'", request.Proto.Command)
+}
+
+func (b *Backend) tablePath(table string) string {
+	return filepath.Join(b.rootDir, table)
+}
+
+// newParquetReader opens a parquet file for reading columns
+func newParquetReader(path string) (*reader.ParquetReader, error) {
+	file, err := local.NewLocalFileReader(path)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, err := reader.NewParquetColumnReader(file, parallelism)
+	if err != nil {
+		file.Close()
+		return nil, errors.Wrapf(err, "cannot read parquet file '%v'", path)
+	}
+
+	return pr, nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func init() {
+	if err := backends.Register("parquet", NewBackend); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,415 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package parquet
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/backends/utils"
+	"github.com/v3io/frames/pb"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+const numRows = 10
+
+func newTestBackend(t *testing.T) (frames.DataBackend, func()) {
+	logger, err := frames.NewLogger("debug")
+	if err != nil {
+		t.Fatalf("can't create logger - %s", err)
+	}
+
+	rootDir, err := ioutil.TempDir("", "parquet-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &frames.BackendConfig{Name: "testParquet", Type: "parquet", RootDir: rootDir}
+	backend, err := NewBackend(logger, nil, cfg, nil)
+	if err != nil {
+		os.RemoveAll(rootDir)
+		t.Fatal(err)
+	}
+
+	return backend, func() { os.RemoveAll(rootDir) }
+}
+
+// testFrame returns a frame with all types, "s" is null in odd rows
+func testFrame(t *testing.T, start int) frames.Frame {
+	var (
+		ints     []int64
+		floats   []float64
+		strs     []string
+		bools    []bool
+		times    []time.Time
+		ints32   []int32
+		floats32 []float32
+		blobs    [][]byte
+		nulls    []*pb.NullValuesMap
+		baseTime = time.Date(2020, 1, 1, 0, 0, 0, 123, time.UTC)
+	)
+
+	for i := start; i < start+numRows; i++ {
+		ints = append(ints, int64(i))
+		floats = append(floats, float64(i)/2)
+		bools = append(bools, i%2 == 0)
+		times = append(times, baseTime.Add(time.Duration(i)*time.Hour))
+		ints32 = append(ints32, int32(-i))
+		floats32 = append(floats32, float32(i)/4)
+		blobs = append(blobs, []byte{byte(i), 0, 0xff})
+		if i%2 == 1 {
+			strs = append(strs, "")
+			nulls = append(nulls, &pb.NullValuesMap{NullColumns: map[string]bool{"s": true}})
+		} else {
+			strs = append(strs, "val")
+			nulls = append(nulls, &pb.NullValuesMap{NullColumns: map[string]bool{}})
+		}
+	}
+
+	var cols []frames.Column
+	columns := map[string]interface{}{
+		"i": ints, "f": floats, "s": strs, "b": bools, "t": times,
+		"i32": ints32, "f32": floats32, "blob": blobs,
+	}
+	for name, data := range columns {
+		col, err := frames.NewSliceColumn(name, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cols = append(cols, col)
+	}
+
+	frame, err := frames.NewFrameWithNullValues(cols, nil, nil, nulls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return frame
+}
+
+func writeFrames(backend frames.DataBackend, table string, mode frames.SaveMode, frs ...frames.Frame) error {
+	appender, err := backend.Write(&frames.WriteRequest{Table: table, SaveMode: mode})
+	if err != nil {
+		return err
+	}
+	defer appender.Close()
+
+	for _, frame := range frs {
+		if err := appender.Add(frame); err != nil {
+			return err
+		}
+	}
+
+	return appender.WaitForComplete(0)
+}
+
+func readFrames(t *testing.T, backend frames.DataBackend, req *pb.ReadRequest) []frames.Frame {
+	it, err := backend.Read(&frames.ReadRequest{Proto: req})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var result []frames.Frame
+	for it.Next() {
+		result = append(result, it.At())
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	return result
+}
+
+func totalRows(result []frames.Frame) int {
+	total := 0
+	for _, frame := range result {
+		total += frame.Len()
+	}
+	return total
+}
+
+func TestRoundTrip(t *testing.T) {
+	backend, cleanup := newTestBackend(t)
+	defer cleanup()
+
+	in := []frames.Frame{testFrame(t, 0), testFrame(t, numRows)}
+	if err := writeFrames(backend, "t1", frames.ErrorIfTableExists, in...); err != nil {
+		t.Fatal(err)
+	}
+
+	// One frame per row group
+	out := readFrames(t, backend, &pb.ReadRequest{Table: "t1"})
+	if len(out) != len(in) {
+		t.Fatalf("# frames mismatch %d != %d", len(out), len(in))
+	}
+
+	for i, frame := range out {
+		if frame.Len() != numRows {
+			t.Fatalf("frame %d: # rows mismatch %d != %d", i, frame.Len(), numRows)
+		}
+
+		for _, name := range in[i].Names() {
+			expected, err := in[i].Column(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			col, err := frame.Column(name)
+			if err != nil {
+				t.Fatalf("frame %d: can't find column %q", i, name)
+			}
+
+			if col.DType() != expected.DType() {
+				t.Fatalf("%s: type mismatch %v != %v", name, col.DType(), expected.DType())
+			}
+
+			for r := 0; r < numRows; r++ {
+				isNull := frame.IsNull(r, name)
+				if isNull != in[i].IsNull(r, name) {
+					t.Fatalf("%s[%d]: null mismatch", name, r)
+				}
+
+				if isNull {
+					continue
+				}
+
+				value, err := utils.ColAt(col, r)
+				if err != nil {
+					t.Fatal(err)
+				}
+				expectedValue, err := utils.ColAt(expected, r)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if tm, ok := value.(time.Time); ok {
+					if !tm.Equal(expectedValue.(time.Time)) {
+						t.Fatalf("%s[%d]: %v != %v", name, r, value, expectedValue)
+					}
+					continue
+				}
+
+				if !reflect.DeepEqual(value, expectedValue) {
+					t.Fatalf("%s[%d]: %v != %v", name, r, value, expectedValue)
+				}
+			}
+		}
+	}
+
+	if err := writeFrames(backend, "t1", frames.ErrorIfTableExists, in...); err == nil {
+		t.Fatal("no error writing to an existing table")
+	}
+
+	if err := writeFrames(backend, "t1", frames.UpdateItem, in...); err == nil {
+		t.Fatal("no error appending to a table")
+	}
+
+	if err := writeFrames(backend, "t1", frames.OverwriteTable, in[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	if nRows := totalRows(readFrames(t, backend, &pb.ReadRequest{Table: "t1"})); nRows != numRows {
+		t.Fatalf("# rows mismatch after overwrite %d != %d", nRows, numRows)
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	backend, cleanup := newTestBackend(t)
+	defer cleanup()
+
+	// Decimals are written by other tools, in all their physical types
+	tags := []string{
+		"name=d32, type=INT32, convertedtype=DECIMAL, scale=2, precision=9, repetitiontype=OPTIONAL",
+		"name=d64, type=INT64, convertedtype=DECIMAL, scale=4, precision=18, repetitiontype=OPTIONAL",
+		"name=dfix, type=FIXED_LEN_BYTE_ARRAY, length=4, convertedtype=DECIMAL, scale=3, precision=9, repetitiontype=OPTIONAL",
+	}
+
+	path := filepath.Join(backend.(*Backend).rootDir, "decimals")
+	file, err := local.NewLocalFileWriter(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := writer.NewCSVWriter(tags, file, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := [][]interface{}{
+		{int32(12345), int64(-98765432), string([]byte{0, 0, 0x30, 0x39})},
+		{int32(-1), nil, string([]byte{0xff, 0xff, 0xff, 0xfe})},
+	}
+	for _, record := range records {
+		if err := w.Write(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.WriteStop(); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	out := readFrames(t, backend, &pb.ReadRequest{Table: "decimals"})
+	if len(out) != 1 {
+		t.Fatalf("# frames mismatch %d != 1", len(out))
+	}
+
+	expected := map[string][]string{
+		"d32":  {"123.45", "-0.01"},
+		"d64":  {"-9876.5432", ""},
+		"dfix": {"12.345", "-0.002"},
+	}
+	for name, values := range expected {
+		col, err := out[0].Column(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if col.DType() != frames.DecimalType {
+			t.Fatalf("%s: bad type %v", name, col.DType())
+		}
+
+		for r, value := range values {
+			if value == "" {
+				if !out[0].IsNull(r, name) {
+					t.Fatalf("%s[%d]: not null", name, r)
+				}
+				continue
+			}
+
+			d, err := col.DecimalAt(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.String() != value {
+				t.Fatalf("%s[%d]: %s != %s", name, r, d, value)
+			}
+		}
+	}
+
+	col, err := frames.NewSliceColumn("d", []frames.Decimal{{Value: 1, Scale: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	frame, err := frames.NewFrame([]frames.Column{col}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = writeFrames(backend, "decimals", frames.OverwriteTable, frame)
+	if err == nil || err.Error() != fmt.Sprintf("%s: parquet backend can't write decimal columns", "d") {
+		t.Fatalf("bad error writing decimals - %v", err)
+	}
+}
+
+func TestRead(t *testing.T) {
+	backend, cleanup := newTestBackend(t)
+	defer cleanup()
+
+	if err := writeFrames(backend, "t1", frames.ErrorIfTableExists, testFrame(t, 0), testFrame(t, numRows)); err != nil {
+		t.Fatal(err)
+	}
+
+	out := readFrames(t, backend, &pb.ReadRequest{Table: "t1", MessageLimit: 3})
+	if len(out) != 8 { // 3+3+3+1 per row group
+		t.Fatalf("# frames mismatch %d != 8", len(out))
+	}
+	for _, frame := range out {
+		if frame.Len() > 3 {
+			t.Fatalf("frame with %d rows", frame.Len())
+		}
+	}
+
+	out = readFrames(t, backend, &pb.ReadRequest{Table: "t1", Limit: 12})
+	if nRows := totalRows(out); nRows != 12 {
+		t.Fatalf("# rows mismatch %d != 12", nRows)
+	}
+
+	out = readFrames(t, backend, &pb.ReadRequest{Table: "t1", Columns: []string{"t", "i"}, Filter: "b == true and f > 2.5"})
+	if nRows := totalRows(out); nRows != 7 { // 6, 8, ..., 18
+		t.Fatalf("# rows mismatch %d != 7", nRows)
+	}
+	for _, frame := range out {
+		if names := frame.Names(); !reflect.DeepEqual(names, []string{"t", "i"}) {
+			t.Fatalf("bad columns - %v", names)
+		}
+	}
+
+	_, err := backend.Read(&frames.ReadRequest{Proto: &pb.ReadRequest{Table: "t1", Columns: []string{"nope"}}})
+	if err == nil {
+		t.Fatal("no error reading unknown column")
+	}
+}
+
+func TestCreateDelete(t *testing.T) {
+	backend, cleanup := newTestBackend(t)
+	defer cleanup()
+
+	createReq := &frames.CreateRequest{Proto: &pb.CreateRequest{
+		Table: "t1",
+		Schema: &frames.TableSchema{Fields: []*frames.SchemaField{
+			{Name: "i", Type: "long"},
+			{Name: "f", Type: "double"},
+			{Name: "s", Type: "string"},
+			{Name: "b", Type: "boolean"},
+			{Name: "t", Type: "timestamp"},
+			{Name: "i32", Type: "int"},
+			{Name: "f32", Type: "float"},
+			{Name: "blob", Type: "blob"},
+		}},
+	}}
+	if err := backend.Create(createReq); err != nil {
+		t.Fatal(err)
+	}
+
+	if out := readFrames(t, backend, &pb.ReadRequest{Table: "t1"}); len(out) != 0 {
+		t.Fatalf("%d frames in empty table", len(out))
+	}
+
+	// Created tables can be written once
+	if err := writeFrames(backend, "t1", frames.ErrorIfTableExists, testFrame(t, 0)); err != nil {
+		t.Fatal(err)
+	}
+
+	deleteReq := &frames.DeleteRequest{Proto: &pb.DeleteRequest{Table: "t1", Filter: "i >= 5"}}
+	if err := backend.Delete(deleteReq); err != nil {
+		t.Fatal(err)
+	}
+
+	out := readFrames(t, backend, &pb.ReadRequest{Table: "t1"})
+	if nRows := totalRows(out); nRows != 5 {
+		t.Fatalf("# rows mismatch after delete %d != 5", nRows)
+	}
+
+	deleteReq = &frames.DeleteRequest{Proto: &pb.DeleteRequest{Table: "t1"}}
+	if err := backend.Delete(deleteReq); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := backend.Read(&frames.ReadRequest{Proto: &pb.ReadRequest{Table: "t1"}}); err == nil {
+		t.Fatal("no error reading deleted table")
+	}
+}
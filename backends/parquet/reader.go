@@ -0,0 +1,307 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package parquet
+
+import (
+	"fmt"
+
+	"github.com/nuclio/logger"
+	"github.com/pkg/errors"
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/backends/filter"
+	"github.com/v3io/frames/backends/utils"
+	"github.com/v3io/frames/pb"
+	"github.com/v3io/frames/v3ioutils"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+// FrameIterator iterates over row groups of a parquet file
+type FrameIterator struct {
+	logger       logger.Logger
+	path         string
+	reader       *reader.ParquetReader
+	columns      []*column // Returned columns
+	readColumns  []*column // Returned columns and columns used in the filter
+	rowGroups    []int64   // Number of rows in each row group
+	rowGroup     int       // Next row group
+	rowGroupLeft int64     // Rows left in the current row group
+	nRows        int
+	limit        int
+	frameLimit   int
+	filter       filter.Expr
+	frame        frames.Frame
+	err          error
+	closed       bool
+}
+
+func newFrameIterator(logger logger.Logger, path string, columnNames []string) (*FrameIterator, error) {
+	pr, err := newParquetReader(path)
+	if err != nil {
+		return nil, err
+	}
+
+	it := &FrameIterator{
+		logger: logger,
+		path:   path,
+		reader: pr,
+	}
+
+	for _, rowGroup := range pr.Footer.RowGroups {
+		it.rowGroups = append(it.rowGroups, rowGroup.NumRows)
+	}
+
+	all := fileColumns(pr.SchemaHandler)
+	if len(columnNames) == 0 {
+		it.columns = all
+	} else {
+		byName := make(map[string]*column, len(all))
+		for _, col := range all {
+			byName[col.name] = col
+		}
+
+		for _, name := range columnNames {
+			col, ok := byName[name]
+			if !ok {
+				it.close()
+				return nil, fmt.Errorf("column '%v' doesn't exist", name)
+			}
+			it.columns = append(it.columns, col)
+		}
+	}
+
+	it.readColumns = it.columns
+	return it, nil
+}
+
+// addColumns adds columns which are read but not returned (e.g. used in the
+// filter). Unknown columns are ignored since filters treat them as missing
+func (it *FrameIterator) addColumns(names []string) {
+	read := make(map[string]bool, len(it.readColumns))
+	for _, col := range it.readColumns {
+		read[col.name] = true
+	}
+
+	all := fileColumns(it.reader.SchemaHandler)
+	for _, name := range names {
+		if read[name] {
+			continue
+		}
+
+		for _, col := range all {
+			if col.name == name {
+				it.readColumns = append(it.readColumns, col)
+				read[name] = true
+			}
+		}
+	}
+}
+
+func (it *FrameIterator) columnNames() []string {
+	names := make([]string, len(it.columns))
+	for i, col := range it.columns {
+		names[i] = col.name
+	}
+	return names
+}
+
+func (it *FrameIterator) columnTypes() []frames.DType {
+	dtypes := make([]frames.DType, len(it.columns))
+	for i, col := range it.columns {
+		dtypes[i] = col.dtype
+	}
+	return dtypes
+}
+
+// Next reads the next frame, return true of succeeded
+func (it *FrameIterator) Next() bool {
+	for {
+		if it.limit > 0 && it.nRows >= it.limit {
+			it.close()
+			return false
+		}
+
+		for it.rowGroupLeft == 0 {
+			if it.rowGroup >= len(it.rowGroups) {
+				it.close()
+				return false
+			}
+			it.rowGroupLeft = it.rowGroups[it.rowGroup]
+			it.rowGroup++
+		}
+
+		// Frames don't cross row groups
+		num := it.rowGroupLeft
+		if it.frameLimit > 0 && num > int64(it.frameLimit) {
+			num = int64(it.frameLimit)
+		}
+
+		frame, err := it.readFrame(num)
+		if err != nil {
+			it.logger.ErrorWith("cannot read frame", "error", err, "path", it.path)
+			it.err = err
+			it.close()
+			return false
+		}
+		it.rowGroupLeft -= num
+
+		if frame == nil { // No rows matched the filter
+			continue
+		}
+
+		it.frame = frame
+		it.nRows += frame.Len()
+		return true
+	}
+}
+
+// At return the current Frame
+func (it *FrameIterator) At() frames.Frame {
+	return it.frame
+}
+
+// Err returns the last error
+func (it *FrameIterator) Err() error {
+	return it.err
+}
+
+func (it *FrameIterator) close() {
+	if it.closed {
+		return
+	}
+
+	it.closed = true
+	it.reader.ReadStop()
+	if err := it.reader.PFile.Close(); err != nil {
+		it.logger.WarnWith("cannot close file", "error", err, "path", it.path)
+	}
+}
+
+// readFrame reads the next num rows, it returns nil if no row matched the filter
+func (it *FrameIterator) readFrame(num int64) (frames.Frame, error) {
+	values := make(map[string][]interface{}, len(it.readColumns))
+	for _, col := range it.readColumns {
+		colValues, _, _, err := it.reader.ReadColumnByPath(col.path, num)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot read column '%s'", col.name)
+		}
+
+		if int64(len(colValues)) != num {
+			return nil, fmt.Errorf("column '%s': read %d values out of %d", col.name, len(colValues), num)
+		}
+
+		for i, value := range colValues {
+			if colValues[i], err = col.goValue(value); err != nil {
+				return nil, err
+			}
+		}
+		values[col.name] = colValues
+	}
+
+	var rows []int
+	row := &chunkRow{values: values}
+	for r := 0; r < int(num); r++ {
+		if it.limit > 0 && it.nRows+len(rows) >= it.limit {
+			break
+		}
+
+		if it.filter != nil {
+			row.index = r
+			match, err := filter.Match(it.filter, row)
+			if err != nil {
+				return nil, errors.Wrap(err, "cannot evaluate filter")
+			}
+			if !match {
+				continue
+			}
+		}
+
+		rows = append(rows, r)
+	}
+
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	return it.buildFrame(values, rows)
+}
+
+func (it *FrameIterator) buildFrame(values map[string][]interface{}, rows []int) (frames.Frame, error) {
+	var (
+		columns     = make([]frames.Column, len(it.columns))
+		nullValues  = make([]*pb.NullValuesMap, len(rows))
+		hasAnyNulls bool
+	)
+
+	for i := range nullValues {
+		nullValues[i] = &pb.NullValuesMap{NullColumns: make(map[string]bool)}
+	}
+
+	for c, column := range it.columns {
+		data, err := utils.NewColumnFromType(v3ioutils.ConvertDTypeToString(column.dtype), 0)
+		if err != nil {
+			return nil, err
+		}
+
+		col, err := frames.NewSliceColumn(column.name, data)
+		if err != nil {
+			return nil, err
+		}
+
+		colValues := values[column.name]
+		for i, r := range rows {
+			if colValues[r] == nil {
+				if err := utils.AppendNil(col); err != nil {
+					return nil, err
+				}
+				nullValues[i].NullColumns[column.name] = true
+				hasAnyNulls = true
+				continue
+			}
+
+			if err := utils.AppendColumn(col, colValues[r]); err != nil {
+				return nil, errors.Wrapf(err, "cannot build column '%s'", column.name)
+			}
+		}
+
+		columns[c] = col
+	}
+
+	if !hasAnyNulls {
+		nullValues = nil
+	}
+
+	return frames.NewFrameWithNullValues(columns, nil, nil, nullValues)
+}
+
+// chunkRow is a row in the values read from the file, used for filters
+type chunkRow struct {
+	values map[string][]interface{}
+	index  int
+}
+
+// Value returns the value of a column in the current row
+func (r *chunkRow) Value(name string) (interface{}, bool) {
+	values, ok := r.values[name]
+	if !ok || values[r.index] == nil {
+		return nil, false
+	}
+	return values[r.index], true
+}
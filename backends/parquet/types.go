@@ -0,0 +1,322 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package parquet
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/v3io/frames"
+	"github.com/xitongsys/parquet-go/common"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/schema"
+	"github.com/xitongsys/parquet-go/types"
+)
+
+// column is a top level (non nested) column in a parquet file
+type column struct {
+	name  string
+	path  string // Path in the parquet schema
+	elem  *parquet.SchemaElement
+	dtype frames.DType
+}
+
+// fileColumns returns the top level columns in a parquet schema. Nested
+// columns are not supported and skipped
+func fileColumns(handler *schema.SchemaHandler) []*column {
+	var (
+		columns []*column
+		elems   = handler.SchemaElements
+		root    = handler.GetRootExName()
+	)
+
+	for i := 1; i < len(elems); {
+		elem := elems[i]
+		if elem.GetNumChildren() > 0 {
+			i += subtreeSize(elems, i)
+			continue
+		}
+
+		i++
+		dtype, err := columnDType(elem)
+		if err != nil || elem.GetRepetitionType() == parquet.FieldRepetitionType_REPEATED {
+			continue
+		}
+
+		name := handler.Infos[i-1].ExName
+		columns = append(columns, &column{
+			name:  name,
+			path:  common.PathToStr([]string{root, name}),
+			elem:  elem,
+			dtype: dtype,
+		})
+	}
+
+	return columns
+}
+
+// subtreeSize returns the number of schema elements in a group, including it
+func subtreeSize(elems []*parquet.SchemaElement, i int) int {
+	size := 1
+	for c := int32(0); c < elems[i].GetNumChildren(); c++ {
+		size += subtreeSize(elems, i+size)
+	}
+	return size
+}
+
+// columnDType returns the frames type of a parquet column
+func columnDType(elem *parquet.SchemaElement) (frames.DType, error) {
+	if isDecimal(elem) {
+		scale, precision := decimalScale(elem)
+		if scale < 0 || scale > frames.MaxDecimalScale || precision > maxDecimalPrecision {
+			return 0, fmt.Errorf("unsupported decimal precision %d and scale %d", precision, scale)
+		}
+
+		switch elem.GetType() {
+		case parquet.Type_INT32, parquet.Type_INT64, parquet.Type_BYTE_ARRAY, parquet.Type_FIXED_LEN_BYTE_ARRAY:
+			return frames.DecimalType, nil
+		}
+		return 0, fmt.Errorf("unsupported decimal type - %s", elem.GetType())
+	}
+
+	switch elem.GetType() {
+	case parquet.Type_BOOLEAN:
+		return frames.BoolType, nil
+	case parquet.Type_INT32:
+		if isDate(elem) {
+			return frames.TimeType, nil
+		}
+		if isUint32(elem) {
+			return frames.IntType, nil
+		}
+		return frames.Int32Type, nil
+	case parquet.Type_INT64:
+		if _, ok := timestampUnit(elem); ok {
+			return frames.TimeType, nil
+		}
+		return frames.IntType, nil
+	case parquet.Type_INT96:
+		return frames.TimeType, nil
+	case parquet.Type_FLOAT:
+		return frames.Float32Type, nil
+	case parquet.Type_DOUBLE:
+		return frames.FloatType, nil
+	case parquet.Type_BYTE_ARRAY, parquet.Type_FIXED_LEN_BYTE_ARRAY:
+		if isString(elem) {
+			return frames.StringType, nil
+		}
+		return frames.BytesType, nil
+	}
+
+	return 0, fmt.Errorf("unsupported parquet type - %s", elem.GetType())
+}
+
+// maxDecimalPrecision is the number of digits that fit in a frames.Decimal
+const maxDecimalPrecision = 18
+
+func isDecimal(elem *parquet.SchemaElement) bool {
+	if elem.LogicalType != nil && elem.LogicalType.IsSetDECIMAL() {
+		return true
+	}
+	return elem.IsSetConvertedType() && elem.GetConvertedType() == parquet.ConvertedType_DECIMAL
+}
+
+// decimalScale returns the scale and precision of a decimal column
+func decimalScale(elem *parquet.SchemaElement) (int32, int32) {
+	if elem.IsSetScale() || elem.IsSetPrecision() {
+		return elem.GetScale(), elem.GetPrecision()
+	}
+	if elem.LogicalType != nil && elem.LogicalType.IsSetDECIMAL() {
+		return elem.LogicalType.DECIMAL.Scale, elem.LogicalType.DECIMAL.Precision
+	}
+	return 0, 0
+}
+
+// isString returns true if a byte array column holds text
+func isString(elem *parquet.SchemaElement) bool {
+	if lt := elem.LogicalType; lt != nil && (lt.IsSetSTRING() || lt.IsSetENUM() || lt.IsSetJSON()) {
+		return true
+	}
+
+	if elem.IsSetConvertedType() {
+		switch elem.GetConvertedType() {
+		case parquet.ConvertedType_UTF8, parquet.ConvertedType_ENUM, parquet.ConvertedType_JSON:
+			return true
+		}
+	}
+
+	return false
+}
+
+// isUint32 returns true if an INT32 column holds unsigned values, which don't
+// fit in int32
+func isUint32(elem *parquet.SchemaElement) bool {
+	if lt := elem.LogicalType; lt != nil && lt.IsSetINTEGER() {
+		return !lt.INTEGER.IsSigned && lt.INTEGER.BitWidth == 32
+	}
+	return elem.IsSetConvertedType() && elem.GetConvertedType() == parquet.ConvertedType_UINT_32
+}
+
+func isDate(elem *parquet.SchemaElement) bool {
+	if elem.LogicalType != nil && elem.LogicalType.IsSetDATE() {
+		return true
+	}
+	return elem.IsSetConvertedType() && elem.GetConvertedType() == parquet.ConvertedType_DATE
+}
+
+// timestampUnit returns the unit of an INT64 timestamp column
+func timestampUnit(elem *parquet.SchemaElement) (time.Duration, bool) {
+	if elem.LogicalType != nil && elem.LogicalType.IsSetTIMESTAMP() {
+		unit := elem.LogicalType.TIMESTAMP.Unit
+		switch {
+		case unit == nil:
+			return 0, false
+		case unit.IsSetMILLIS():
+			return time.Millisecond, true
+		case unit.IsSetMICROS():
+			return time.Microsecond, true
+		}
+		return time.Nanosecond, true
+	}
+
+	if elem.IsSetConvertedType() {
+		switch elem.GetConvertedType() {
+		case parquet.ConvertedType_TIMESTAMP_MILLIS:
+			return time.Millisecond, true
+		case parquet.ConvertedType_TIMESTAMP_MICROS:
+			return time.Microsecond, true
+		}
+	}
+
+	return 0, false
+}
+
+// goValue converts a parquet value to the Go type of the column dtype
+func (c *column) goValue(value interface{}) (interface{}, error) {
+	switch value := value.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		return value, nil
+	case int32:
+		switch {
+		case isDecimal(c.elem):
+			scale, _ := decimalScale(c.elem)
+			return frames.Decimal{Value: int64(value), Scale: scale}, nil
+		case isDate(c.elem):
+			return time.Unix(int64(value)*24*60*60, 0).UTC(), nil
+		case c.dtype == frames.IntType:
+			return int64(uint32(value)), nil
+		}
+		return value, nil
+	case int64:
+		if isDecimal(c.elem) {
+			scale, _ := decimalScale(c.elem)
+			return frames.Decimal{Value: value, Scale: scale}, nil
+		}
+		if unit, ok := timestampUnit(c.elem); ok {
+			return time.Unix(0, value*int64(unit)).UTC(), nil
+		}
+		return value, nil
+	case float32:
+		return value, nil
+	case float64:
+		return value, nil
+	case string:
+		switch {
+		case c.elem.GetType() == parquet.Type_INT96:
+			return types.INT96ToTime(value).UTC(), nil
+		case isDecimal(c.elem):
+			return c.byteArrayDecimal(value)
+		case c.dtype == frames.BytesType:
+			return []byte(value), nil
+		}
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("%s: unsupported value type %T", c.name, value)
+}
+
+// byteArrayDecimal decodes a decimal stored as a big-endian two's complement
+// byte array
+func (c *column) byteArrayDecimal(value string) (interface{}, error) {
+	if len(value) > 8 {
+		return nil, fmt.Errorf("%s: decimal of %d bytes overflows", c.name, len(value))
+	}
+
+	var n int64
+	for i := 0; i < len(value); i++ {
+		n = n<<8 | int64(value[i])
+	}
+	if bits := uint(len(value) * 8); bits > 0 && bits < 64 && value[0]&0x80 != 0 {
+		n -= 1 << bits // Sign extend
+	}
+
+	scale, _ := decimalScale(c.elem)
+	return frames.Decimal{Value: n, Scale: scale}, nil
+}
+
+// columnTag returns the parquet-go schema tag of a column
+func columnTag(name string, dtype frames.DType) (string, error) {
+	if name == "" || strings.ContainsAny(name, ",=") || strings.TrimSpace(name) != name {
+		return "", fmt.Errorf("unsupported column name for parquet - %q", name)
+	}
+
+	var typ string
+	switch dtype {
+	case frames.IntType:
+		typ = "type=INT64"
+	case frames.FloatType:
+		typ = "type=DOUBLE"
+	case frames.StringType:
+		typ = "type=BYTE_ARRAY, convertedtype=UTF8"
+	case frames.BoolType:
+		typ = "type=BOOLEAN"
+	case frames.TimeType:
+		typ = "type=INT64, logicaltype=TIMESTAMP, logicaltype.isadjustedtoutc=true, logicaltype.unit=NANOS"
+	case frames.Int32Type:
+		typ = "type=INT32"
+	case frames.Float32Type:
+		typ = "type=FLOAT"
+	case frames.BytesType:
+		typ = "type=BYTE_ARRAY"
+	case frames.DecimalType:
+		// The scale is per file, and frame decimals can have any scale
+		return "", fmt.Errorf("%s: parquet backend can't write decimal columns", name)
+	default:
+		return "", fmt.Errorf("%s: unsupported type - %d", name, dtype)
+	}
+
+	// All columns are optional (nullable)
+	return fmt.Sprintf("name=%s, %s, repetitiontype=OPTIONAL", name, typ), nil
+}
+
+// parquetValue converts a frame value to the parquet-go value of its dtype
+func parquetValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case time.Time:
+		return value.UnixNano()
+	case []byte:
+		return string(value) // parquet-go keeps byte arrays in strings
+	}
+	return value
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package parquet
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nuclio/logger"
+	"github.com/pkg/errors"
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/backends/utils"
+	"github.com/xitongsys/parquet-go-source/local"
+	"github.com/xitongsys/parquet-go/source"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// appender writes to a temporary file, which replaces the table file in
+// WaitForComplete
+type appender struct {
+	logger  logger.Logger
+	path    string
+	tmpPath string
+	file    source.ParquetFile
+	writer  *writer.CSVWriter
+	names   []string
+	dtypes  []frames.DType
+	done    bool
+	closed  bool
+}
+
+func newAppender(logger logger.Logger, path string) (*appender, error) {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".parquet-write")
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create temporary file")
+	}
+	tmp.Close()
+
+	file, err := local.NewLocalFileWriter(tmp.Name())
+	if err != nil {
+		os.Remove(tmp.Name())
+		return nil, errors.Wrap(err, "cannot open temporary file")
+	}
+
+	a := &appender{
+		logger:  logger,
+		path:    path,
+		tmpPath: tmp.Name(),
+		file:    file,
+	}
+
+	return a, nil
+}
+
+// init sets the file schema
+func (a *appender) init(names []string, dtypes []frames.DType) error {
+	tags := make([]string, len(names))
+	for i, name := range names {
+		tag, err := columnTag(name, dtypes[i])
+		if err != nil {
+			return err
+		}
+		tags[i] = tag
+	}
+
+	w, err := writer.NewCSVWriter(tags, a.file, parallelism)
+	if err != nil {
+		return errors.Wrap(err, "cannot create parquet writer")
+	}
+
+	a.writer, a.names, a.dtypes = w, names, dtypes
+	return nil
+}
+
+// Add writes the frame as a row group
+func (a *appender) Add(frame frames.Frame) error {
+	if a.closed || a.done {
+		err := errors.New("adding on a closed parquet appender")
+		a.logger.Error(err)
+		return err
+	}
+
+	a.logger.InfoWith("adding frame", "size", frame.Len())
+	columns, err := a.frameColumns(frame)
+	if err != nil {
+		return err
+	}
+
+	for r := 0; r < frame.Len(); r++ {
+		record := make([]interface{}, len(columns))
+		for c, col := range columns {
+			if frame.IsNull(r, a.names[c]) {
+				continue
+			}
+
+			val, err := utils.ColAt(col, r)
+			if err != nil {
+				return errors.Wrapf(err, "%s:%d cannot get value", a.names[c], r)
+			}
+			record[c] = parquetValue(val)
+		}
+
+		if err := a.writer.Write(record); err != nil {
+			return errors.Wrap(err, "cannot write record")
+		}
+	}
+
+	if err := a.writer.Flush(true); err != nil {
+		return errors.Wrap(err, "cannot write row group")
+	}
+
+	return nil
+}
+
+// frameColumns returns the frame columns in file order, the schema is set
+// from the first frame
+func (a *appender) frameColumns(frame frames.Frame) ([]frames.Column, error) {
+	if a.writer == nil {
+		names := frame.Names()
+		dtypes := make([]frames.DType, len(names))
+		for i, name := range names {
+			col, err := frame.Column(name)
+			if err != nil {
+				return nil, err
+			}
+			dtypes[i] = col.DType()
+		}
+
+		if err := a.init(names, dtypes); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(frame.Names()) != len(a.names) {
+		return nil, fmt.Errorf("frame columns %v don't match table columns %v", frame.Names(), a.names)
+	}
+
+	columns := make([]frames.Column, len(a.names))
+	for i, name := range a.names {
+		col, err := frame.Column(name)
+		if err != nil {
+			return nil, fmt.Errorf("frame columns %v don't match table columns %v", frame.Names(), a.names)
+		}
+
+		if col.DType() != a.dtypes[i] {
+			return nil, fmt.Errorf("column '%s' type mismatch (%d != %d)", name, col.DType(), a.dtypes[i])
+		}
+		columns[i] = col
+	}
+
+	return columns, nil
+}
+
+// WaitForComplete writes the file footer and replaces the table file
+func (a *appender) WaitForComplete(timeout time.Duration) error {
+	if a.closed {
+		return errors.New("parquet appender is closed")
+	}
+
+	if a.done {
+		return nil
+	}
+
+	// No frames and no schema, there's nothing to write
+	if a.writer == nil {
+		a.Close()
+		return nil
+	}
+
+	if err := a.writer.WriteStop(); err != nil {
+		return errors.Wrap(err, "cannot write parquet footer")
+	}
+
+	if err := a.file.Close(); err != nil {
+		return errors.Wrap(err, "cannot close file")
+	}
+
+	if err := os.Rename(a.tmpPath, a.path); err != nil {
+		return errors.Wrap(err, "cannot replace table file")
+	}
+
+	a.done = true
+	return nil
+}
+
+// Close closes the appender, frames are discarded unless WaitForComplete was
+// called
+func (a *appender) Close() {
+	if a.closed {
+		return
+	}
+
+	a.closed = true
+	if a.done {
+		return
+	}
+
+	a.file.Close()
+	if err := os.Remove(a.tmpPath); err != nil {
+		a.logger.WarnWith("cannot remove temporary file", "error", err, "path", a.tmpPath)
+	}
+}
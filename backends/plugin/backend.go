@@ -0,0 +1,328 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os/exec"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/nuclio/logger"
+	"github.com/pkg/errors"
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/backends"
+	"github.com/v3io/frames/pb"
+	"github.com/v3io/frames/pluginsdk"
+	v3io "github.com/v3io/v3io-go/pkg/dataplane"
+	"google.golang.org/grpc"
+)
+
+// How long to wait for a plugin to start and be configured
+const startTimeout = 30 * time.Second
+
+// Backend is a backend served by a plugin process
+type Backend struct {
+	logger       logger.Logger
+	framesConfig *frames.Config
+	client       pb.BackendPluginClient
+	capabilities *frames.Capabilities
+
+	// Launched plugin, it exits once its stdin is closed
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+}
+
+var (
+	// Make sure we're implementing frames.ContextDataBackend
+	_ frames.ContextDataBackend = &Backend{}
+)
+
+// NewBackend returns a new plugin backend, it dials config.PluginAddress or
+// starts config.PluginCommand
+func NewBackend(logger logger.Logger, v3ioContext v3io.Context, config *frames.BackendConfig, framesConfig *frames.Config) (frames.DataBackend, error) {
+	backend := &Backend{
+		logger:       logger.GetChild("plugin").GetChild(config.Name),
+		framesConfig: framesConfig,
+	}
+
+	address := config.PluginAddress
+	if len(config.PluginCommand) > 0 {
+		var err error
+		if address, err = backend.launch(config.PluginCommand); err != nil {
+			return nil, err
+		}
+	}
+
+	if address == "" {
+		return nil, errors.Errorf("plugin backend %q requires a plugin address or command", config.Name)
+	}
+
+	conn, err := grpc.Dial(
+		address,
+		grpc.WithInsecure(),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(pluginsdk.MessageSize),
+			grpc.MaxCallSendMsgSize(pluginsdk.MessageSize),
+		),
+	)
+	if err != nil {
+		backend.stop()
+		return nil, errors.Wrap(err, "can't create gRPC connection")
+	}
+	backend.client = pb.NewBackendPluginClient(conn)
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		backend.stop()
+		return nil, errors.Wrap(err, "can't encode configuration")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), startTimeout)
+	defer cancel()
+	resp, err := backend.client.Configure(ctx, &pb.PluginConfigureRequest{Config: data}, grpc.WaitForReady(true))
+	if err != nil {
+		backend.stop()
+		return nil, errors.Wrapf(err, "can't configure plugin at %s", address)
+	}
+
+	backend.capabilities = resp.Capabilities
+	if backend.capabilities == nil {
+		backend.capabilities = &frames.Capabilities{}
+	}
+
+	backend.logger.InfoWith("plugin configured", "address", address, "type", backend.capabilities.Type)
+	return backend, nil
+}
+
+// Capabilities returns the requests the plugin backend supports
+func (b *Backend) Capabilities() *frames.Capabilities {
+	return b.capabilities
+}
+
+// Read reads from the plugin
+func (b *Backend) Read(request *frames.ReadRequest) (frames.FrameIterator, error) {
+	return b.ReadContext(context.Background(), request)
+}
+
+// ReadContext reads from the plugin, the read is cancelled once ctx is done
+func (b *Backend) ReadContext(ctx context.Context, request *frames.ReadRequest) (frames.FrameIterator, error) {
+	req := proto.Clone(request.Proto).(*pb.ReadRequest)
+	req.Session = b.session(req.Session, request.Password, request.Token)
+
+	stream, err := b.client.Read(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &frameIterator{stream: stream}, nil
+}
+
+// Write writes to the plugin
+func (b *Backend) Write(request *frames.WriteRequest) (frames.FrameAppender, error) {
+	return b.WriteContext(context.Background(), request)
+}
+
+// WriteContext writes to the plugin, the write is cancelled once ctx is done
+func (b *Backend) WriteContext(ctx context.Context, request *frames.WriteRequest) (frames.FrameAppender, error) {
+	var initialData *pb.Frame
+	if request.ImmidiateData != nil {
+		var err error
+		if initialData, err = frameProto(request.ImmidiateData); err != nil {
+			return nil, err
+		}
+	}
+
+	stream, err := b.client.Write(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ireq := &pb.InitialWriteRequest{
+		Session:       b.session(request.Session, request.Password, request.Token),
+		Backend:       request.Backend,
+		Table:         request.Table,
+		InitialData:   initialData,
+		Expression:    request.Expression,
+		More:          request.HaveMore,
+		PartitionKeys: request.PartitionKeys,
+		Condition:     request.Condition,
+		SaveMode:      request.SaveMode.String(),
+	}
+
+	msg := &pb.WriteRequest{Type: &pb.WriteRequest_Request{Request: ireq}}
+	if err := stream.Send(msg); err != nil {
+		_, _ = stream.CloseAndRecv()
+		return nil, err
+	}
+
+	return &frameAppender{stream: stream}, nil
+}
+
+// Create creates a table
+func (b *Backend) Create(request *frames.CreateRequest) error {
+	req := proto.Clone(request.Proto).(*pb.CreateRequest)
+	req.Session = b.session(req.Session, request.Password, request.Token)
+
+	_, err := b.client.Create(context.Background(), req)
+	return err
+}
+
+// Delete deletes a table or part of it
+func (b *Backend) Delete(request *frames.DeleteRequest) error {
+	req := proto.Clone(request.Proto).(*pb.DeleteRequest)
+	req.Session = b.session(req.Session, request.Password, request.Token)
+
+	_, err := b.client.Delete(context.Background(), req)
+	return err
+}
+
+// Exec executes a command
+func (b *Backend) Exec(request *frames.ExecRequest) (frames.Frame, error) {
+	return b.ExecContext(context.Background(), request)
+}
+
+// ExecContext executes a command, the command is cancelled once ctx is done
+func (b *Backend) ExecContext(ctx context.Context, request *frames.ExecRequest) (frames.Frame, error) {
+	req := proto.Clone(request.Proto).(*pb.ExecRequest)
+	req.Session = b.session(req.Session, request.Password, request.Token)
+
+	resp, err := b.client.Exec(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Frame == nil {
+		return nil, nil
+	}
+
+	return frames.NewFrameFromProto(resp.Frame), nil
+}
+
+// session returns a copy of session with the credentials, missing values are
+// taken from the frames configuration
+func (b *Backend) session(session *frames.Session, password frames.SecretString, token frames.SecretString) *frames.Session {
+	if session == nil {
+		session = &frames.Session{}
+	} else {
+		session = proto.Clone(session).(*frames.Session)
+	}
+
+	session.Password = password.Get()
+	session.Token = token.Get()
+	return frames.InitSessionDefaults(session, b.framesConfig)
+}
+
+func frameProto(frame frames.Frame) (*pb.Frame, error) {
+	fpb, ok := frame.(pb.Framed)
+	if !ok {
+		return nil, errors.New("unknown frame type")
+	}
+
+	return fpb.Proto(), nil
+}
+
+type frameIterator struct {
+	stream pb.BackendPlugin_ReadClient
+	frame  frames.Frame
+	err    error
+}
+
+func (it *frameIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.frame = nil
+	msg, err := it.stream.Recv()
+	if err != nil {
+		if err != io.EOF {
+			it.err = err
+		}
+		return false
+	}
+
+	it.frame = frames.NewFrameFromProto(msg)
+	return true
+}
+
+func (it *frameIterator) Err() error {
+	return it.err
+}
+
+func (it *frameIterator) At() frames.Frame {
+	return it.frame
+}
+
+type frameAppender struct {
+	stream pb.BackendPlugin_WriteClient
+	closed bool
+}
+
+func (fa *frameAppender) Add(frame frames.Frame) error {
+	if fa.closed {
+		return errors.New("stream closed")
+	}
+
+	fMsg, err := frameProto(frame)
+	if err != nil {
+		return err
+	}
+
+	msg := &pb.WriteRequest{Type: &pb.WriteRequest_Frame{Frame: fMsg}}
+	if err := fa.stream.Send(msg); err != nil {
+		// The plugin error is returned by Recv
+		fa.closed = true
+		if _, recvErr := fa.stream.CloseAndRecv(); recvErr != nil {
+			return recvErr
+		}
+		return err
+	}
+
+	return nil
+}
+
+// WaitForComplete waits for the plugin to write the frames, the timeout is
+// taken from the write context
+func (fa *frameAppender) WaitForComplete(timeout time.Duration) error {
+	if fa.closed {
+		return errors.New("stream closed")
+	}
+
+	fa.closed = true
+	_, err := fa.stream.CloseAndRecv()
+	return err
+}
+
+func (fa *frameAppender) Close() {
+	if !fa.closed {
+		fa.closed = true
+		_, _ = fa.stream.CloseAndRecv()
+	}
+}
+
+func init() {
+	if err := backends.Register("plugin", NewBackend); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package plugin
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/backends/memory"
+	"github.com/v3io/frames/pb"
+	"github.com/v3io/frames/pluginsdk"
+	"google.golang.org/grpc"
+)
+
+type pluginTestSuite struct {
+	suite.Suite
+	backend frames.DataBackend
+	server  *grpc.Server
+	session *frames.Session
+}
+
+// SetupTest serves the memory backend in process
+func (suite *pluginTestSuite) SetupTest() {
+	logger, err := frames.NewLogger("debug")
+	suite.Require().NoError(err)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	suite.server = grpc.NewServer()
+	pb.RegisterBackendPluginServer(suite.server, pluginsdk.NewServer(logger, memory.NewBackend))
+	go func() { _ = suite.server.Serve(lis) }()
+
+	cfg := &frames.BackendConfig{Name: "mem", Type: "plugin", PluginAddress: lis.Addr().String()}
+	suite.backend, err = NewBackend(logger, nil, cfg, &frames.Config{Container: "bigdata"})
+	suite.Require().NoError(err)
+
+	suite.session = &frames.Session{}
+}
+
+func (suite *pluginTestSuite) TearDownTest() {
+	suite.server.Stop()
+}
+
+func (suite *pluginTestSuite) TestCapabilities() {
+	caps := suite.backend.Capabilities()
+	suite.Require().Equal("memory", caps.Type)
+	suite.Require().Contains(caps.SaveModes, frames.OverwriteTable.String())
+}
+
+func (suite *pluginTestSuite) TestWriteRead() {
+	keys, err := frames.NewSliceColumn("key", []string{"a", "b", "c"})
+	suite.Require().NoError(err)
+	values, err := frames.NewSliceColumn("n", []int64{1, 2, 3})
+	suite.Require().NoError(err)
+	frame, err := frames.NewFrame([]frames.Column{values}, []frames.Column{keys}, nil)
+	suite.Require().NoError(err)
+
+	appender, err := suite.backend.Write(&frames.WriteRequest{Session: suite.session, Table: "t1", ImmidiateData: frame})
+	suite.Require().NoError(err)
+	head, err := frame.Slice(0, 1)
+	suite.Require().NoError(err)
+	suite.Require().NoError(appender.Add(head))
+	suite.Require().NoError(appender.WaitForComplete(0))
+	appender.Close()
+
+	readReq := &frames.ReadRequest{Proto: &pb.ReadRequest{Session: suite.session, Table: "t1", Filter: "n > 1"}}
+	it, err := suite.backend.Read(readReq)
+	suite.Require().NoError(err)
+
+	nRows := 0
+	for it.Next() {
+		nRows += it.At().Len()
+	}
+	suite.Require().NoError(it.Err())
+	suite.Require().Equal(2, nRows)
+
+	// Backend errors are returned by the plugin
+	it, err = suite.backend.Read(&frames.ReadRequest{Proto: &pb.ReadRequest{Session: suite.session, Table: "no-such-table"}})
+	suite.Require().NoError(err)
+	suite.Require().False(it.Next())
+	suite.Require().Error(it.Err())
+
+	deleteReq := &frames.DeleteRequest{Proto: &pb.DeleteRequest{Session: suite.session, Table: "t1"}}
+	suite.Require().NoError(suite.backend.Delete(deleteReq))
+}
+
+func (suite *pluginTestSuite) TestCreateExec() {
+	createReq := &frames.CreateRequest{Proto: &pb.CreateRequest{Session: suite.session, Table: "t2"}}
+	suite.Require().NoError(suite.backend.Create(createReq))
+	suite.Require().Error(suite.backend.Create(createReq), "created twice")
+
+	args, err := pb.FromGoMap(map[string]interface{}{"rows": 5})
+	suite.Require().NoError(err)
+	execReq := &frames.ExecRequest{Proto: &pb.ExecRequest{Session: suite.session, Command: "ping", Args: args}}
+	frame, err := suite.backend.Exec(execReq)
+	suite.Require().NoError(err)
+	suite.Require().Equal(5, frame.Len())
+
+	execReq.Proto.Command = "no-such-command"
+	_, err = suite.backend.Exec(execReq)
+	suite.Require().Error(err)
+}
+
+func TestPluginTestSuite(t *testing.T) {
+	suite.Run(t, new(pluginTestSuite))
+}
+
+func TestLaunch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("building the example plugin")
+	}
+
+	tmpDir, err := ioutil.TempDir("", "frames-plugin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	pluginPath := filepath.Join(tmpDir, "exampleplugin")
+	build := exec.Command("go", "build", "-o", pluginPath, "github.com/v3io/frames/cmd/exampleplugin")
+	if out, err := build.CombinedOutput(); err != nil {
+		t.Fatalf("can't build example plugin - %s\n%s", err, out)
+	}
+
+	logger, err := frames.NewLogger("debug")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &frames.BackendConfig{Name: "example", Type: "plugin", PluginCommand: []string{pluginPath}}
+	backend, err := NewBackend(logger, nil, cfg, &frames.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer backend.(*Backend).stop()
+
+	frame, err := backend.Exec(&frames.ExecRequest{Proto: &pb.ExecRequest{Command: "ping"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if frame.Len() != 37 {
+		t.Fatalf("bad ping frame length - %d", frame.Len())
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package plugin
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/v3io/frames/pluginsdk"
+)
+
+// launch starts the plugin and returns the address from its handshake line.
+// The plugin stdin is kept open, so it exits with us
+func (b *Backend) launch(command []string) (string, error) {
+	cmd := exec.Command(command[0], command[1:]...)
+	cmd.Env = append(os.Environ(), pluginsdk.LaunchedEnvVar+"=1")
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return "", errors.Wrap(err, "can't create plugin stdin")
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return "", errors.Wrap(err, "can't create plugin stdout")
+	}
+
+	if err := cmd.Start(); err != nil {
+		return "", errors.Wrapf(err, "can't start plugin %q", command[0])
+	}
+	b.cmd, b.stdin = cmd, stdin
+
+	lines := make(chan string, 1)
+	go func() {
+		reader := bufio.NewReader(stdout)
+		line, _ := reader.ReadString('\n')
+		lines <- line
+		// Rest of the plugin output (e.g. logs)
+		_, _ = io.Copy(os.Stdout, reader)
+		err := cmd.Wait()
+		b.logger.WarnWith("plugin exited", "command", command, "error", err)
+	}()
+
+	var line string
+	select {
+	case line = <-lines:
+	case <-time.After(startTimeout):
+		b.stop()
+		return "", errors.Errorf("plugin %q didn't start after %s", command[0], startTimeout)
+	}
+
+	prefix := pluginsdk.Handshake + " "
+	if !strings.HasPrefix(line, prefix) {
+		b.stop()
+		return "", errors.Errorf("bad handshake from plugin %q - %q", command[0], line)
+	}
+
+	address := strings.TrimSpace(strings.TrimPrefix(line, prefix))
+	b.logger.InfoWith("plugin started", "command", command, "pid", cmd.Process.Pid, "address", address)
+	return address, nil
+}
+
+// stop stops a launched plugin
+func (b *Backend) stop() {
+	if b.cmd == nil {
+		return
+	}
+
+	_ = b.stdin.Close()
+	_ = b.cmd.Process.Kill()
+}
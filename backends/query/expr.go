@@ -0,0 +1,231 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/v3io/frames/backends/filter"
+)
+
+// Expressions that exist only in queries, they can't be passed to backends as
+// filters
+
+// ref is a reference to a group by value or an aggregate result of a group
+type ref struct {
+	group bool // Group by value if true, aggregate otherwise
+	index int
+	text  string
+}
+
+// Eval returns the referenced value of the group
+func (e *ref) Eval(row filter.Row) (interface{}, error) {
+	grp, ok := row.(*group)
+	if !ok {
+		return nil, fmt.Errorf("%s used outside of an aggregation", e.text)
+	}
+
+	if e.group {
+		return grp.keys[e.index], nil
+	}
+	return grp.results[e.index], nil
+}
+
+func (e *ref) String() string {
+	return e.text
+}
+
+// notNull is "IS NOT NULL" of an expression that isn't an attribute
+type notNull struct {
+	Expr filter.Expr
+}
+
+// Eval evaluates the expression
+func (e *notNull) Eval(row filter.Row) (interface{}, error) {
+	value, err := e.Expr.Eval(row)
+	if err != nil {
+		return nil, err
+	}
+	return value != nil, nil
+}
+
+func (e *notNull) String() string {
+	return fmt.Sprintf("(%s) is not null", e.Expr)
+}
+
+// like matches a LIKE pattern ("%" is any sequence and "_" is any character)
+type like struct {
+	Expr    filter.Expr
+	pattern string
+	re      *regexp.Regexp
+}
+
+// newLike returns a filter function for simple patterns (e.g. "abc%") and a
+// like expression for others
+func newLike(expr filter.Expr, pattern string) (filter.Expr, error) {
+	if _, isAttr := expr.(*filter.Attribute); isAttr && !strings.Contains(pattern, "_") {
+		inner := strings.Trim(pattern, "%")
+		if !strings.Contains(inner, "%") {
+			prefix, suffix := strings.HasPrefix(pattern, "%"), strings.HasSuffix(pattern, "%")
+			name := ""
+			switch {
+			case prefix && suffix:
+				name = "contains"
+			case suffix:
+				name = "starts"
+			case prefix:
+				name = "ends"
+			default:
+				return &filter.Binary{Op: filter.OpEq, Left: expr, Right: &filter.Literal{Value: pattern}}, nil
+			}
+			return filter.NewCall(name, []filter.Expr{expr, &filter.Literal{Value: inner}})
+		}
+	}
+
+	var buf strings.Builder
+	buf.WriteString("(?s)^")
+	for _, c := range pattern {
+		switch c {
+		case '%':
+			buf.WriteString(".*")
+		case '_':
+			buf.WriteString(".")
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	buf.WriteString("$")
+
+	re, err := regexp.Compile(buf.String())
+	if err != nil {
+		return nil, fmt.Errorf("bad LIKE pattern %q - %s", pattern, err)
+	}
+	return &like{Expr: expr, pattern: pattern, re: re}, nil
+}
+
+// Eval evaluates the expression
+func (e *like) Eval(row filter.Row) (interface{}, error) {
+	value, err := e.Expr.Eval(row)
+	if err != nil {
+		return nil, err
+	}
+
+	str, ok := value.(string)
+	return ok && e.re.MatchString(str), nil
+}
+
+func (e *like) String() string {
+	return fmt.Sprintf("%s like '%s'", e.Expr, e.pattern)
+}
+
+// rewrite returns a copy of expr where fn replaces nodes, a node is replaced
+// if fn returns true and then its children are not visited
+func rewrite(expr filter.Expr, fn func(filter.Expr) (filter.Expr, bool)) filter.Expr {
+	if out, ok := fn(expr); ok {
+		return out
+	}
+
+	switch expr := expr.(type) {
+	case *filter.Unary:
+		return &filter.Unary{Op: expr.Op, Expr: rewrite(expr.Expr, fn)}
+	case *filter.Binary:
+		return &filter.Binary{Op: expr.Op, Left: rewrite(expr.Left, fn), Right: rewrite(expr.Right, fn)}
+	case *filter.In:
+		in := &filter.In{Expr: rewrite(expr.Expr, fn)}
+		for _, value := range expr.Values {
+			in.Values = append(in.Values, rewrite(value, fn))
+		}
+		return in
+	case *filter.Call:
+		call := &filter.Call{Name: expr.Name}
+		for _, arg := range expr.Args {
+			call.Args = append(call.Args, rewrite(arg, fn))
+		}
+		return call
+	case *notNull:
+		return &notNull{Expr: rewrite(expr.Expr, fn)}
+	case *like:
+		return &like{Expr: rewrite(expr.Expr, fn), pattern: expr.pattern, re: expr.re}
+	}
+
+	return expr
+}
+
+// visit calls fn on every node of expr
+func visit(expr filter.Expr, fn func(filter.Expr)) {
+	rewrite(expr, func(expr filter.Expr) (filter.Expr, bool) {
+		fn(expr)
+		return nil, false
+	})
+}
+
+// attributes returns the names of attributes in expressions by order of
+// appearance
+func attributes(exprs ...filter.Expr) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, expr := range exprs {
+		visit(expr, func(expr filter.Expr) {
+			if attr, ok := expr.(*filter.Attribute); ok && !seen[attr.Name] {
+				seen[attr.Name] = true
+				names = append(names, attr.Name)
+			}
+		})
+	}
+	return names
+}
+
+func hasAggregate(expr filter.Expr) bool {
+	found := false
+	visit(expr, func(expr filter.Expr) {
+		if ref, ok := expr.(*ref); ok && !ref.group {
+			found = true
+		}
+	})
+	return found
+}
+
+// pushable returns true if expr is a filter expression backends understand
+func pushable(expr filter.Expr) bool {
+	ok := true
+	visit(expr, func(expr filter.Expr) {
+		switch expr := expr.(type) {
+		case *filter.Literal:
+			if expr.Value == nil {
+				ok = false
+			}
+		case *filter.Attribute, *filter.Unary, *filter.Binary, *filter.In, *filter.Call:
+		default:
+			ok = false
+		}
+	})
+	return ok
+}
+
+// conjuncts splits expr to the expressions joined with "and"
+func conjuncts(expr filter.Expr) []filter.Expr {
+	if binary, ok := expr.(*filter.Binary); ok && binary.Op == filter.OpAnd {
+		return append(conjuncts(binary.Left), conjuncts(binary.Right)...)
+	}
+	return []filter.Expr{expr}
+}
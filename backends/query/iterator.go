@@ -0,0 +1,680 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package query
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/backends/filter"
+	"github.com/v3io/frames/ops"
+)
+
+const defaultFrameSize = 1024
+
+// queryIterator evaluates a query over the frames of a backend. Queries
+// without aggregation and ORDER BY are evaluated frame by frame, others once
+// all the frames are read
+type queryIterator struct {
+	query     *Query
+	input     frames.FrameIterator
+	frameSize int
+
+	frame frames.Frame
+	err   error
+	done  bool
+
+	skipped  int64 // Rows skipped for OFFSET
+	returned int64
+
+	result    *output // Sorted or grouped rows, nil until evaluated
+	resultPos int
+}
+
+func (it *queryIterator) Next() bool {
+	if it.done {
+		return false
+	}
+
+	var err error
+	if it.query.Aggregated() || len(it.query.OrderBy) > 0 {
+		it.frame, err = it.nextResult()
+	} else {
+		it.frame, err = it.nextStreamed()
+	}
+
+	if err != nil {
+		it.err = err
+	}
+
+	if it.frame == nil {
+		it.done = true
+		return false
+	}
+	return true
+}
+
+func (it *queryIterator) Err() error {
+	return it.err
+}
+
+func (it *queryIterator) At() frames.Frame {
+	return it.frame
+}
+
+// nextStreamed returns the result of the next input frame with rows in the
+// result, nil when done
+func (it *queryIterator) nextStreamed() (frames.Frame, error) {
+	query := it.query
+	for {
+		if query.Limit >= 0 && it.returned >= query.Limit {
+			return nil, nil
+		}
+
+		if !it.input.Next() {
+			return nil, it.input.Err()
+		}
+
+		out := newOutput()
+		frame := it.input.At()
+		slots, err := out.slots(query.Items, frame)
+		if err != nil {
+			return nil, err
+		}
+
+		err = it.scan(frame, func(row filter.Row) (bool, error) {
+			if it.skipped < query.Offset {
+				it.skipped++
+				return true, nil
+			}
+
+			values, err := evalSlots(slots, row)
+			if err != nil {
+				return false, err
+			}
+
+			out.rows = append(out.rows, &resultRow{values: values})
+			it.returned++
+			return query.Limit < 0 || it.returned < query.Limit, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(out.rows) > 0 {
+			return out.frame(out.rows)
+		}
+	}
+}
+
+// nextResult returns the next frame of the sorted or grouped rows, nil when
+// done
+func (it *queryIterator) nextResult() (frames.Frame, error) {
+	if it.result == nil {
+		var err error
+		if it.query.Aggregated() {
+			it.result, err = it.aggregate()
+		} else {
+			it.result, err = it.collect()
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		it.result.rows = it.sortAndLimit(it.result.rows)
+	}
+
+	if it.resultPos >= len(it.result.rows) {
+		return nil, nil
+	}
+
+	end := it.resultPos + it.frameSize
+	if end > len(it.result.rows) {
+		end = len(it.result.rows)
+	}
+
+	rows := it.result.rows[it.resultPos:end]
+	it.resultPos = end
+	return it.result.frame(rows)
+}
+
+// collect reads the rows of a query without aggregation
+func (it *queryIterator) collect() (*output, error) {
+	out := newOutput()
+	for it.input.Next() {
+		frame := it.input.At()
+		slots, err := out.slots(it.query.Items, frame)
+		if err != nil {
+			return nil, err
+		}
+
+		err = it.scan(frame, func(row filter.Row) (bool, error) {
+			values, err := evalSlots(slots, row)
+			if err != nil {
+				return false, err
+			}
+
+			keys, err := it.orderKeys(row)
+			if err != nil {
+				return false, err
+			}
+
+			out.rows = append(out.rows, &resultRow{values: values, keys: keys})
+			return true, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, it.input.Err()
+}
+
+// group is the group by values and the aggregate results of a group, the
+// items, HAVING and ORDER BY of an aggregation are evaluated against it
+type group struct {
+	keys    []interface{}
+	results []interface{}
+}
+
+// Value implements filter.Row, columns are accessed only with references
+func (g *group) Value(name string) (interface{}, bool) {
+	return nil, false
+}
+
+// aggregate reads the rows of an aggregation, the rows are grouped by
+// ops.GroupBy over frames of their group by values and aggregate arguments
+func (it *queryIterator) aggregate() (*output, error) {
+	query := it.query
+	keys := make([]string, len(query.GroupBy))
+	for i := range query.GroupBy {
+		keys[i] = keyColumn(i)
+	}
+
+	aggregations := make([]ops.Aggregation, len(query.Aggregates))
+	for i, aggregate := range query.Aggregates {
+		aggregations[i] = ops.Aggregation{Func: aggregate.Func, Column: argColumn(i)}
+	}
+
+	groupBy, err := ops.NewGroupBy(keys, aggregations)
+	if err != nil {
+		return nil, err
+	}
+
+	read := false
+	for it.input.Next() {
+		frame, err := it.groupByFrame(it.input.At())
+		if err != nil {
+			return nil, err
+		}
+
+		if err := groupBy.Add(frame); err != nil {
+			return nil, err
+		}
+		read = true
+	}
+
+	if err := it.input.Err(); err != nil {
+		return nil, err
+	}
+
+	// The group by is resolved by its first frame, aggregates without GROUP
+	// BY have a row even if there are no rows
+	if !read {
+		frame, err := it.groupByFrame(nil)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := groupBy.Add(frame); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := groupBy.Frame()
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := filter.NewFrameRow(result)
+	if err != nil {
+		return nil, err
+	}
+
+	out := newOutput()
+	for _, item := range query.Items {
+		out.column(item.Name, false)
+	}
+
+	for i := 0; i < result.Len(); i++ {
+		row.SetIndex(i)
+		grp := &group{
+			keys:    make([]interface{}, len(keys)),
+			results: make([]interface{}, len(aggregations)),
+		}
+		for k, name := range keys {
+			grp.keys[k], _ = row.Value(name)
+		}
+		for a, aggregation := range aggregations {
+			grp.results[a], _ = row.Value(fmt.Sprintf("%s(%s)", aggregation.Func, aggregation.Column))
+		}
+
+		if query.Having != nil {
+			ok, err := filter.Match(query.Having, grp)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		values := make([]interface{}, len(query.Items))
+		for i, item := range query.Items {
+			value, err := item.Expr.Eval(grp)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+
+		keys, err := it.orderKeys(grp)
+		if err != nil {
+			return nil, err
+		}
+
+		out.rows = append(out.rows, &resultRow{values: values, keys: keys})
+	}
+
+	return out, nil
+}
+
+// groupByFrame returns a frame of the group by values and the aggregate
+// arguments of the rows of frame that match WHERE, frame is nil for no rows
+func (it *queryIterator) groupByFrame(frame frames.Frame) (frames.Frame, error) {
+	query := it.query
+	keys := make([][]interface{}, len(query.GroupBy))
+	args := make([][]interface{}, len(query.Aggregates))
+	if frame != nil {
+		err := it.scan(frame, func(row filter.Row) (bool, error) {
+			for i, expr := range query.GroupBy {
+				value, err := expr.Eval(row)
+				if err != nil {
+					return false, err
+				}
+				keys[i] = append(keys[i], value)
+			}
+
+			for i, aggregate := range query.Aggregates {
+				var value interface{} = true // count(*)
+				if aggregate.Arg != nil {
+					var err error
+					if value, err = aggregate.Arg.Eval(row); err != nil {
+						return false, err
+					}
+				}
+				args[i] = append(args[i], value)
+			}
+			return true, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var columns []frames.Column
+	for i, values := range keys {
+		col, err := newColumn(keyColumn(i), values)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", query.GroupBy[i], err)
+		}
+		columns = append(columns, col)
+	}
+
+	for i, values := range args {
+		var col frames.Column
+		var err error
+		if hasValues(values) {
+			col, err = newColumn(argColumn(i), values)
+		} else {
+			// Arguments of only nulls don't set the aggregate type
+			col, err = frames.NewLabelColumn(argColumn(i), nil, len(values))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", query.Aggregates[i].text, err)
+		}
+		columns = append(columns, col)
+	}
+
+	return frames.NewFrame(columns, nil, nil)
+}
+
+func keyColumn(i int) string {
+	return fmt.Sprintf("key%d", i)
+}
+
+func argColumn(i int) string {
+	return fmt.Sprintf("arg%d", i)
+}
+
+func hasValues(values []interface{}) bool {
+	for _, value := range values {
+		if value != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// scan calls fn with the rows of frame that match WHERE until fn returns false
+func (it *queryIterator) scan(frame frames.Frame, fn func(row filter.Row) (bool, error)) error {
+	row, err := filter.NewFrameRow(frame)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < frame.Len(); i++ {
+		row.SetIndex(i)
+		if it.query.Where != nil {
+			ok, err := filter.Match(it.query.Where, row)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+		}
+
+		more, err := fn(row)
+		if err != nil || !more {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (it *queryIterator) orderKeys(row filter.Row) ([]interface{}, error) {
+	if len(it.query.OrderBy) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]interface{}, len(it.query.OrderBy))
+	for i, order := range it.query.OrderBy {
+		value, err := order.Expr.Eval(row)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = value
+	}
+	return keys, nil
+}
+
+// sortAndLimit sorts rows by ORDER BY and applies OFFSET and LIMIT
+func (it *queryIterator) sortAndLimit(rows []*resultRow) []*resultRow {
+	query := it.query
+	if len(query.OrderBy) > 0 {
+		sort.SliceStable(rows, func(i, j int) bool {
+			for k, order := range query.OrderBy {
+				cmp := compareKeys(rows[i].keys[k], rows[j].keys[k])
+				if cmp == 0 {
+					continue
+				}
+
+				if order.Desc {
+					return cmp > 0
+				}
+				return cmp < 0
+			}
+			return false
+		})
+	}
+
+	if query.Offset >= int64(len(rows)) {
+		return nil
+	}
+	rows = rows[query.Offset:]
+
+	if query.Limit >= 0 && query.Limit < int64(len(rows)) {
+		rows = rows[:query.Limit]
+	}
+	return rows
+}
+
+// compareKeys compares ORDER BY values, nulls are greater than other values
+// and values that can't be compared are equal
+func compareKeys(left, right interface{}) int {
+	switch {
+	case left == nil && right == nil:
+		return 0
+	case left == nil:
+		return 1
+	case right == nil:
+		return -1
+	}
+
+	if l, ok := left.(bool); ok {
+		if r, ok := right.(bool); ok {
+			switch {
+			case l == r:
+				return 0
+			case r:
+				return -1
+			}
+			return 1
+		}
+	}
+
+	cmp, _ := filter.Compare(left, right)
+	return cmp
+}
+
+// output is the result columns and rows of a query. Columns are added as
+// frames with new columns (from "*") are read
+type output struct {
+	names   []string
+	isIndex []bool
+	columns map[string]int
+	rows    []*resultRow
+}
+
+// resultRow is a row in the result, values are by output column and keys by
+// ORDER BY expression
+type resultRow struct {
+	values []interface{}
+	keys   []interface{}
+}
+
+// slot is the output column an expression is evaluated to
+type slot struct {
+	expr filter.Expr
+	pos  int
+}
+
+func newOutput() *output {
+	return &output{columns: make(map[string]int)}
+}
+
+// column returns the position of a column, it's added if it's a new column
+func (o *output) column(name string, isIndex bool) int {
+	pos, ok := o.columns[name]
+	if !ok {
+		pos = len(o.names)
+		o.names = append(o.names, name)
+		o.isIndex = append(o.isIndex, isIndex)
+		o.columns[name] = pos
+	}
+	return pos
+}
+
+// slots returns the output columns of items in frame, "*" is the frame indices
+// and columns
+func (o *output) slots(items []*Item, frame frames.Frame) ([]slot, error) {
+	var slots []slot
+	seen := make(map[string]bool)
+	add := func(name string, expr filter.Expr, isIndex bool) error {
+		if seen[name] {
+			return fmt.Errorf("duplicate column %q in query result", name)
+		}
+		seen[name] = true
+		slots = append(slots, slot{expr: expr, pos: o.column(name, isIndex)})
+		return nil
+	}
+
+	for _, item := range items {
+		if item.Expr != nil {
+			if err := add(item.Name, item.Expr, false); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		for _, col := range frame.Indices() {
+			if col.Name() == "" {
+				continue
+			}
+			if err := add(col.Name(), &filter.Attribute{Name: col.Name()}, true); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, name := range frame.Names() {
+			if err := add(name, &filter.Attribute{Name: name}, false); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return slots, nil
+}
+
+func evalSlots(slots []slot, row filter.Row) ([]interface{}, error) {
+	var values []interface{}
+	for _, slot := range slots {
+		value, err := slot.expr.Eval(row)
+		if err != nil {
+			return nil, err
+		}
+
+		for len(values) <= slot.pos {
+			values = append(values, nil)
+		}
+		values[slot.pos] = value
+	}
+	return values, nil
+}
+
+// frame returns a frame of rows
+func (o *output) frame(rows []*resultRow) (frames.Frame, error) {
+	var columns, indices []frames.Column
+	for pos, name := range o.names {
+		values := make([]interface{}, len(rows))
+		for i, row := range rows {
+			if pos < len(row.values) {
+				values[i] = row.values[pos]
+			}
+		}
+
+		col, err := newColumn(name, values)
+		if err != nil {
+			return nil, err
+		}
+
+		if o.isIndex[pos] {
+			indices = append(indices, col)
+		} else {
+			columns = append(columns, col)
+		}
+	}
+
+	return frames.NewFrame(columns, indices, nil)
+}
+
+// newColumn returns a column of values, nil values are nulls
+func newColumn(name string, values []interface{}) (frames.Column, error) {
+	dtype, err := columnType(name, values)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := frames.NewSliceColumnBuilder(name, dtype, len(values))
+	for i, value := range values {
+		if value == nil {
+			err = builder.SetNull(i)
+		} else {
+			if n, ok := value.(int64); ok && dtype == frames.FloatType {
+				value = float64(n)
+			}
+			err = builder.Set(i, value)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("column %q - %s", name, err)
+		}
+	}
+
+	return builder.Finish(), nil
+}
+
+// columnType returns the type of values, integers and floats are floats and
+// only nulls are floats as well
+func columnType(name string, values []interface{}) (frames.DType, error) {
+	var dtype frames.DType
+	var first interface{}
+	for _, value := range values {
+		var valueType frames.DType
+		switch value.(type) {
+		case nil:
+			continue
+		case int64:
+			valueType = frames.IntType
+		case float64:
+			valueType = frames.FloatType
+		case string:
+			valueType = frames.StringType
+		case bool:
+			valueType = frames.BoolType
+		case time.Time:
+			valueType = frames.TimeType
+		default:
+			return 0, fmt.Errorf("column %q - unsupported type %T", name, value)
+		}
+
+		isNumber := (dtype == frames.IntType || dtype == frames.FloatType) &&
+			(valueType == frames.IntType || valueType == frames.FloatType)
+		switch {
+		case first == nil:
+			dtype, first = valueType, value
+		case dtype == valueType:
+		case isNumber:
+			dtype = frames.FloatType
+		default:
+			return 0, fmt.Errorf("column %q has %T and %T values", name, first, value)
+		}
+	}
+
+	if first == nil {
+		return frames.FloatType, nil
+	}
+	return dtype, nil
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/backends"
+	"github.com/v3io/frames/backends/filter"
+	"github.com/v3io/frames/pb"
+)
+
+// Plan is a query read from a backend: what the backend read request does and
+// what is evaluated over the frames it returns
+type Plan struct {
+	Query *Query
+	// Backend read request, the filter is evaluated again over the frames
+	// since backends may return more rows (e.g. stream ignores filters)
+	Columns []string // nil for all
+	Filter  string
+	Limit   int64 // 0 for none
+}
+
+// Read reads the query of request from backend
+func Read(ctx context.Context, backend frames.DataBackend, request *frames.ReadRequest) (frames.FrameIterator, error) {
+	query, err := Parse(request.Proto.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := NewPlan(query, backend.Capabilities())
+	backendRequest, err := plan.Request(request)
+	if err != nil {
+		return nil, err
+	}
+
+	it, err := backends.ReadContext(ctx, backend, backendRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan.Iterator(it, int(request.Proto.MessageLimit)), nil
+}
+
+// NewPlan returns the plan of query on a backend with caps
+func NewPlan(query *Query, caps *frames.Capabilities) *Plan {
+	plan := &Plan{Query: query}
+
+	if query.Where != nil && backends.SupportsReadField(caps, "Filter") {
+		var pushed filter.Expr
+		for _, expr := range conjuncts(query.Where) {
+			if !pushable(expr) {
+				continue
+			}
+
+			if pushed == nil {
+				pushed = expr
+			} else {
+				pushed = &filter.Binary{Op: filter.OpAnd, Left: pushed, Right: expr}
+			}
+		}
+
+		if pushed != nil {
+			plan.Filter = pushed.String()
+		}
+	}
+
+	if backends.SupportsReadField(caps, "Columns") && !query.hasStar() {
+		plan.Columns = query.columns()
+	}
+
+	// The backend limit is the number of rows the query needs only when all of
+	// them are in the result
+	if query.Limit > 0 && query.Where == nil && !query.Aggregated() && len(query.OrderBy) == 0 && backends.SupportsReadField(caps, "Limit") {
+		plan.Limit = query.Limit + query.Offset
+	}
+
+	return plan
+}
+
+// Request returns the backend read request of the plan for request
+func (p *Plan) Request(request *frames.ReadRequest) (*frames.ReadRequest, error) {
+	msg := request.Proto
+	if msg.Table != "" && msg.Table != p.Query.Table {
+		return nil, fmt.Errorf("query table %q doesn't match request table %q", p.Query.Table, msg.Table)
+	}
+
+	conflicts := []struct {
+		field string
+		isSet bool
+	}{
+		{"Columns", len(msg.Columns) > 0},
+		{"Filter", msg.Filter != ""},
+		{"GroupBy", msg.GroupBy != ""},
+		{"Limit", msg.Limit != 0},
+		{"Marker", msg.Marker != ""},
+	}
+	for _, conflict := range conflicts {
+		if conflict.isSet {
+			return nil, fmt.Errorf("%s can't be used with a query", conflict.field)
+		}
+	}
+
+	backendMsg := proto.Clone(msg).(*pb.ReadRequest)
+	backendMsg.Query = ""
+	backendMsg.Table = p.Query.Table
+	backendMsg.Columns = p.Columns
+	backendMsg.Filter = p.Filter
+	backendMsg.Limit = p.Limit
+
+	backendRequest := *request
+	backendRequest.Proto = backendMsg
+	return &backendRequest, nil
+}
+
+// Iterator returns an iterator over the query result of the frames in it,
+// with up to frameSize rows in a frame when rows are sorted or grouped
+func (p *Plan) Iterator(it frames.FrameIterator, frameSize int) frames.FrameIterator {
+	if frameSize <= 0 {
+		frameSize = defaultFrameSize
+	}
+
+	return &queryIterator{
+		query:     p.Query,
+		input:     it,
+		frameSize: frameSize,
+	}
+}
+
+func (q *Query) hasStar() bool {
+	for _, item := range q.Items {
+		if item.Expr == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// columns returns the names of the columns the query uses
+func (q *Query) columns() []string {
+	var exprs []filter.Expr
+	for _, item := range q.Items {
+		exprs = append(exprs, item.Expr)
+	}
+
+	if q.Where != nil {
+		exprs = append(exprs, q.Where)
+	}
+	exprs = append(exprs, q.GroupBy...)
+
+	for _, aggregate := range q.Aggregates {
+		if aggregate.Arg != nil {
+			exprs = append(exprs, aggregate.Arg)
+		}
+	}
+
+	if q.Having != nil {
+		exprs = append(exprs, q.Having)
+	}
+
+	for _, order := range q.OrderBy {
+		exprs = append(exprs, order.Expr)
+	}
+
+	return attributes(exprs...)
+}
@@ -0,0 +1,577 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+// Package query runs SQL SELECT queries on backends that don't run queries
+// themselves. Columns, filters and limits are passed to the backend read
+// request, the rest of the query is evaluated over the frames it returns
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/backends/filter"
+	"github.com/xwb1989/sqlparser"
+)
+
+// Query is a parsed SELECT statement. Expressions are filter expressions,
+// group by values and aggregates are references to their values in a group
+type Query struct {
+	Table      string
+	Items      []*Item
+	Where      filter.Expr // nil if there's no WHERE
+	GroupBy    []filter.Expr
+	Having     filter.Expr // nil if there's no HAVING
+	OrderBy    []*Order
+	Limit      int64 // -1 if there's no LIMIT
+	Offset     int64
+	Aggregates []*Aggregate
+}
+
+// Item is a column in the query result
+type Item struct {
+	Name string
+	Expr filter.Expr // nil for "*" (all columns)
+}
+
+// Order is an ORDER BY expression
+type Order struct {
+	Expr filter.Expr
+	Desc bool
+}
+
+// Aggregate is an aggregate function (count, sum, avg, min or max)
+type Aggregate struct {
+	Func string
+	Arg  filter.Expr // nil for count(*)
+	text string
+}
+
+// Aggregated returns true if the query groups rows
+func (q *Query) Aggregated() bool {
+	return len(q.GroupBy) > 0 || len(q.Aggregates) > 0
+}
+
+// Parse parses a SELECT statement
+func Parse(sql string) (*Query, error) {
+	slct, table, err := frames.ParseSelect(sql)
+	if err != nil {
+		return nil, err
+	}
+
+	if slct.Distinct != "" {
+		return nil, fmt.Errorf("SELECT DISTINCT is not supported")
+	}
+
+	query := &Query{Table: table, Limit: -1}
+	c := &converter{query: query}
+
+	names := make(map[string]bool)
+	for _, sexpr := range slct.SelectExprs {
+		var item *Item
+		switch col := sexpr.(type) {
+		case *sqlparser.StarExpr:
+			item = &Item{Name: "*"}
+		case *sqlparser.AliasedExpr:
+			expr, err := c.convert(col.Expr, true)
+			if err != nil {
+				return nil, err
+			}
+			item = &Item{Name: itemName(col), Expr: expr}
+		default:
+			return nil, fmt.Errorf("unknown SELECT column type - %T", sexpr)
+		}
+
+		if names[item.Name] {
+			return nil, fmt.Errorf("duplicate column %q (use AS to rename it)", item.Name)
+		}
+		names[item.Name] = true
+		query.Items = append(query.Items, item)
+	}
+
+	if slct.Where != nil {
+		if query.Where, err = c.convert(slct.Where.Expr, false); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, sexpr := range slct.GroupBy {
+		expr, err := c.convert(sexpr, false)
+		if err != nil {
+			return nil, err
+		}
+
+		if expr, err = query.resolve(expr); err != nil {
+			return nil, err
+		}
+
+		if hasAggregate(expr) {
+			return nil, fmt.Errorf("can't group by aggregate %s", expr)
+		}
+		query.GroupBy = append(query.GroupBy, expr)
+	}
+
+	if slct.Having != nil {
+		expr, err := c.convert(slct.Having.Expr, true)
+		if err != nil {
+			return nil, err
+		}
+		query.Having = query.resolveAliases(expr)
+	}
+
+	for _, order := range slct.OrderBy {
+		expr, err := c.convert(order.Expr, true)
+		if err != nil {
+			return nil, err
+		}
+
+		if expr, err = query.resolve(expr); err != nil {
+			return nil, err
+		}
+		query.OrderBy = append(query.OrderBy, &Order{Expr: expr, Desc: order.Direction == sqlparser.DescScr})
+	}
+
+	if slct.Limit != nil {
+		if query.Limit, err = intValue(slct.Limit.Rowcount, "LIMIT"); err != nil {
+			return nil, err
+		}
+
+		if slct.Limit.Offset != nil {
+			if query.Offset, err = intValue(slct.Limit.Offset, "OFFSET"); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if query.Aggregated() {
+		if err := query.groupReferences(); err != nil {
+			return nil, err
+		}
+	}
+
+	return query, nil
+}
+
+// resolve replaces a position or an alias (in GROUP BY or ORDER BY) with the
+// expression of the item it refers to
+func (q *Query) resolve(expr filter.Expr) (filter.Expr, error) {
+	if lit, ok := expr.(*filter.Literal); ok {
+		pos, ok := lit.Value.(int64)
+		if !ok {
+			return expr, nil
+		}
+
+		if pos < 1 || int(pos) > len(q.Items) {
+			return nil, fmt.Errorf("column position %d is out of range [1:%d]", pos, len(q.Items))
+		}
+
+		item := q.Items[pos-1]
+		if item.Expr == nil {
+			return nil, fmt.Errorf("column position %d refers to *", pos)
+		}
+		return item.Expr, nil
+	}
+
+	if attr, ok := expr.(*filter.Attribute); ok {
+		if item := q.item(attr.Name); item != nil {
+			return item.Expr, nil
+		}
+	}
+
+	return expr, nil
+}
+
+// resolveAliases replaces aliases in expr (in HAVING) with their expressions
+func (q *Query) resolveAliases(expr filter.Expr) filter.Expr {
+	return rewrite(expr, func(expr filter.Expr) (filter.Expr, bool) {
+		if attr, ok := expr.(*filter.Attribute); ok {
+			if item := q.item(attr.Name); item != nil {
+				return item.Expr, true
+			}
+		}
+		return nil, false
+	})
+}
+
+func (q *Query) item(name string) *Item {
+	for _, item := range q.Items {
+		if item.Expr != nil && item.Name == name {
+			return item
+		}
+	}
+	return nil
+}
+
+// groupReferences replaces group by expressions in the items, HAVING and ORDER
+// BY with references to their group value. It fails if columns are used
+// outside of group by expressions and aggregates
+func (q *Query) groupReferences() error {
+	groups := make(map[string]int)
+	for i, expr := range q.GroupBy {
+		groups[expr.String()] = i
+	}
+
+	replace := func(expr filter.Expr) (filter.Expr, error) {
+		expr = rewrite(expr, func(expr filter.Expr) (filter.Expr, bool) {
+			if i, ok := groups[expr.String()]; ok {
+				return &ref{group: true, index: i, text: expr.String()}, true
+			}
+			return nil, false
+		})
+
+		if names := attributes(expr); len(names) > 0 {
+			return nil, fmt.Errorf("column %q must be in GROUP BY or in an aggregate", names[0])
+		}
+		return expr, nil
+	}
+
+	var err error
+	for _, item := range q.Items {
+		if item.Expr == nil {
+			return fmt.Errorf("can't select * in an aggregation")
+		}
+
+		if item.Expr, err = replace(item.Expr); err != nil {
+			return err
+		}
+	}
+
+	if q.Having != nil {
+		if q.Having, err = replace(q.Having); err != nil {
+			return err
+		}
+	}
+
+	for _, order := range q.OrderBy {
+		if order.Expr, err = replace(order.Expr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func itemName(col *sqlparser.AliasedExpr) string {
+	if !col.As.IsEmpty() {
+		return col.As.String()
+	}
+
+	if name, ok := col.Expr.(*sqlparser.ColName); ok {
+		return name.Name.String()
+	}
+
+	return sqlparser.String(col.Expr)
+}
+
+func intValue(expr sqlparser.Expr, clause string) (int64, error) {
+	val, ok := expr.(*sqlparser.SQLVal)
+	if !ok || val.Type != sqlparser.IntVal {
+		return 0, fmt.Errorf("%s must be an integer", clause)
+	}
+
+	n, err := strconv.ParseInt(string(val.Val), 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("bad %s - %s", clause, val.Val)
+	}
+	return n, nil
+}
+
+// aggregateFuncs are the aggregate function names
+var aggregateFuncs = map[string]bool{
+	"count": true,
+	"sum":   true,
+	"avg":   true,
+	"min":   true,
+	"max":   true,
+}
+
+// converter converts SQL expressions to filter expressions
+type converter struct {
+	query *Query
+}
+
+// convert converts expr, aggregates are allowed if withAggregates is true
+func (c *converter) convert(expr sqlparser.Expr, withAggregates bool) (filter.Expr, error) {
+	switch expr := expr.(type) {
+	case *sqlparser.ParenExpr:
+		return c.convert(expr.Expr, withAggregates)
+	case *sqlparser.ColName:
+		return &filter.Attribute{Name: expr.Name.String()}, nil
+	case *sqlparser.SQLVal:
+		return literal(expr)
+	case sqlparser.BoolVal:
+		return &filter.Literal{Value: bool(expr)}, nil
+	case *sqlparser.NullVal:
+		return &filter.Literal{}, nil
+	case *sqlparser.AndExpr:
+		return c.binary(filter.OpAnd, expr.Left, expr.Right, withAggregates)
+	case *sqlparser.OrExpr:
+		return c.binary(filter.OpOr, expr.Left, expr.Right, withAggregates)
+	case *sqlparser.NotExpr:
+		inner, err := c.convert(expr.Expr, withAggregates)
+		if err != nil {
+			return nil, err
+		}
+		return &filter.Unary{Op: filter.OpNot, Expr: inner}, nil
+	case *sqlparser.UnaryExpr:
+		inner, err := c.convert(expr.Expr, withAggregates)
+		if err != nil {
+			return nil, err
+		}
+
+		switch expr.Operator {
+		case sqlparser.UMinusStr:
+			return &filter.Unary{Op: filter.OpNeg, Expr: inner}, nil
+		case sqlparser.UPlusStr:
+			return inner, nil
+		case sqlparser.BangStr:
+			return &filter.Unary{Op: filter.OpNot, Expr: inner}, nil
+		}
+		return nil, fmt.Errorf("unsupported operator - %q", expr.Operator)
+	case *sqlparser.BinaryExpr:
+		switch expr.Operator {
+		case sqlparser.PlusStr, sqlparser.MinusStr, sqlparser.MultStr, sqlparser.DivStr:
+			return c.binary(expr.Operator, expr.Left, expr.Right, withAggregates)
+		}
+		return nil, fmt.Errorf("unsupported operator - %q", expr.Operator)
+	case *sqlparser.ComparisonExpr:
+		return c.comparison(expr, withAggregates)
+	case *sqlparser.RangeCond:
+		return c.between(expr, withAggregates)
+	case *sqlparser.IsExpr:
+		return c.is(expr, withAggregates)
+	case *sqlparser.FuncExpr:
+		return c.call(expr, withAggregates)
+	}
+
+	return nil, fmt.Errorf("unsupported expression - %s", sqlparser.String(expr))
+}
+
+func (c *converter) binary(op string, left, right sqlparser.Expr, withAggregates bool) (filter.Expr, error) {
+	l, err := c.convert(left, withAggregates)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := c.convert(right, withAggregates)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filter.Binary{Op: op, Left: l, Right: r}, nil
+}
+
+var comparisonOps = map[string]string{
+	sqlparser.EqualStr:        filter.OpEq,
+	sqlparser.NotEqualStr:     filter.OpNe,
+	sqlparser.LessThanStr:     filter.OpLt,
+	sqlparser.LessEqualStr:    filter.OpLe,
+	sqlparser.GreaterThanStr:  filter.OpGt,
+	sqlparser.GreaterEqualStr: filter.OpGe,
+}
+
+func (c *converter) comparison(expr *sqlparser.ComparisonExpr, withAggregates bool) (filter.Expr, error) {
+	if op, ok := comparisonOps[expr.Operator]; ok {
+		return c.binary(op, expr.Left, expr.Right, withAggregates)
+	}
+
+	left, err := c.convert(expr.Left, withAggregates)
+	if err != nil {
+		return nil, err
+	}
+
+	var out filter.Expr
+	switch expr.Operator {
+	case sqlparser.InStr, sqlparser.NotInStr:
+		tuple, ok := expr.Right.(sqlparser.ValTuple)
+		if !ok {
+			return nil, fmt.Errorf("IN requires a list of values")
+		}
+
+		in := &filter.In{Expr: left}
+		for _, value := range tuple {
+			valueExpr, err := c.convert(value, withAggregates)
+			if err != nil {
+				return nil, err
+			}
+			in.Values = append(in.Values, valueExpr)
+		}
+		out = in
+	case sqlparser.LikeStr, sqlparser.NotLikeStr:
+		if expr.Escape != nil {
+			return nil, fmt.Errorf("LIKE ... ESCAPE is not supported")
+		}
+
+		val, ok := expr.Right.(*sqlparser.SQLVal)
+		if !ok || val.Type != sqlparser.StrVal {
+			return nil, fmt.Errorf("LIKE pattern must be a string")
+		}
+
+		if out, err = newLike(left, string(val.Val)); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported operator - %q", expr.Operator)
+	}
+
+	if strings.HasPrefix(expr.Operator, "not ") {
+		out = &filter.Unary{Op: filter.OpNot, Expr: out}
+	}
+	return out, nil
+}
+
+func (c *converter) between(expr *sqlparser.RangeCond, withAggregates bool) (filter.Expr, error) {
+	from, err := c.comparison(&sqlparser.ComparisonExpr{Operator: sqlparser.GreaterEqualStr, Left: expr.Left, Right: expr.From}, withAggregates)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := c.comparison(&sqlparser.ComparisonExpr{Operator: sqlparser.LessEqualStr, Left: expr.Left, Right: expr.To}, withAggregates)
+	if err != nil {
+		return nil, err
+	}
+
+	var out filter.Expr = &filter.Binary{Op: filter.OpAnd, Left: from, Right: to}
+	if expr.Operator == sqlparser.NotBetweenStr {
+		out = &filter.Unary{Op: filter.OpNot, Expr: out}
+	}
+	return out, nil
+}
+
+func (c *converter) is(expr *sqlparser.IsExpr, withAggregates bool) (filter.Expr, error) {
+	inner, err := c.convert(expr.Expr, withAggregates)
+	if err != nil {
+		return nil, err
+	}
+
+	var out filter.Expr
+	switch expr.Operator {
+	case sqlparser.IsNullStr, sqlparser.IsNotNullStr:
+		// exists is a filter function, so it can be passed to the backend
+		if _, ok := inner.(*filter.Attribute); ok {
+			out = &filter.Call{Name: "exists", Args: []filter.Expr{inner}}
+		} else {
+			out = &notNull{Expr: inner}
+		}
+
+		if expr.Operator == sqlparser.IsNullStr {
+			out = &filter.Unary{Op: filter.OpNot, Expr: out}
+		}
+	case sqlparser.IsTrueStr, sqlparser.IsNotFalseStr:
+		out = &filter.Binary{Op: filter.OpEq, Left: inner, Right: &filter.Literal{Value: true}}
+	case sqlparser.IsFalseStr, sqlparser.IsNotTrueStr:
+		out = &filter.Binary{Op: filter.OpNe, Left: inner, Right: &filter.Literal{Value: true}}
+	default:
+		return nil, fmt.Errorf("unsupported operator - %q", expr.Operator)
+	}
+
+	return out, nil
+}
+
+func (c *converter) call(expr *sqlparser.FuncExpr, withAggregates bool) (filter.Expr, error) {
+	name := expr.Name.Lowered()
+	if !aggregateFuncs[name] {
+		if expr.Distinct {
+			return nil, fmt.Errorf("DISTINCT in %s is not supported", name)
+		}
+
+		args := make([]filter.Expr, len(expr.Exprs))
+		for i, sexpr := range expr.Exprs {
+			aliased, ok := sexpr.(*sqlparser.AliasedExpr)
+			if !ok {
+				return nil, fmt.Errorf("bad argument to %s - %s", name, sqlparser.String(sexpr))
+			}
+
+			arg, err := c.convert(aliased.Expr, withAggregates)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = arg
+		}
+
+		call, err := filter.NewCall(name, args)
+		if err != nil {
+			return nil, err
+		}
+		return call, nil
+	}
+
+	text := sqlparser.String(expr)
+	if !withAggregates {
+		return nil, fmt.Errorf("aggregate %s is not allowed here", text)
+	}
+
+	if expr.Distinct {
+		return nil, fmt.Errorf("DISTINCT in %s is not supported", name)
+	}
+
+	if len(expr.Exprs) != 1 {
+		return nil, fmt.Errorf("%s expects one argument", name)
+	}
+
+	aggregate := &Aggregate{Func: name, text: text}
+	switch arg := expr.Exprs[0].(type) {
+	case *sqlparser.StarExpr:
+		if name != "count" {
+			return nil, fmt.Errorf("%s(*) is not supported", name)
+		}
+	case *sqlparser.AliasedExpr:
+		argExpr, err := c.convert(arg.Expr, false)
+		if err != nil {
+			return nil, err
+		}
+		aggregate.Arg = argExpr
+	default:
+		return nil, fmt.Errorf("bad argument to %s - %s", name, sqlparser.String(arg))
+	}
+
+	// The same aggregate is computed once (e.g. in SELECT and ORDER BY)
+	for i, other := range c.query.Aggregates {
+		if other.text == text {
+			return &ref{index: i, text: text}, nil
+		}
+	}
+
+	c.query.Aggregates = append(c.query.Aggregates, aggregate)
+	return &ref{index: len(c.query.Aggregates) - 1, text: text}, nil
+}
+
+func literal(val *sqlparser.SQLVal) (filter.Expr, error) {
+	switch val.Type {
+	case sqlparser.StrVal:
+		return &filter.Literal{Value: string(val.Val)}, nil
+	case sqlparser.IntVal:
+		n, err := strconv.ParseInt(string(val.Val), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad integer - %s", val.Val)
+		}
+		return &filter.Literal{Value: n}, nil
+	case sqlparser.FloatVal:
+		f, err := strconv.ParseFloat(string(val.Val), 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad number - %s", val.Val)
+		}
+		return &filter.Literal{Value: f}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported value - %s", sqlparser.String(val))
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package query
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/backends"
+	"github.com/v3io/frames/backends/utils"
+	"github.com/v3io/frames/pb"
+)
+
+type sliceIterator struct {
+	frames []frames.Frame
+	frame  frames.Frame
+}
+
+func (it *sliceIterator) Next() bool {
+	if len(it.frames) == 0 {
+		return false
+	}
+	it.frame, it.frames = it.frames[0], it.frames[1:]
+	return true
+}
+
+func (it *sliceIterator) Err() error       { return nil }
+func (it *sliceIterator) At() frames.Frame { return it.frame }
+
+// Two frames of a table, "x" is missing (null) in the last row
+func testFrames(t *testing.T) []frames.Frame {
+	first, err := frames.NewFrameFromMap(map[string]interface{}{
+		"name":  []string{"a", "b", "c"},
+		"dept":  []string{"eng", "ops", "eng"},
+		"x":     []int64{1, 2, 3},
+		"score": []float64{1.5, 2.5, 3.5},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := []map[string]interface{}{
+		{"name": "d", "dept": "ops", "x": int64(4), "score": 4.5},
+		{"name": "e", "dept": "eng", "score": 5.5},
+	}
+	second, err := frames.NewFrameFromRows(rows, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return []frames.Frame{first, second}
+}
+
+// run returns the column names and rows of a query over testFrames
+func run(t *testing.T, sql string, frameSize int) ([]string, [][]interface{}) {
+	query, err := Parse(sql)
+	if err != nil {
+		t.Fatalf("%s: can't parse - %s", sql, err)
+	}
+
+	it := NewPlan(query, &frames.Capabilities{}).Iterator(&sliceIterator{frames: testFrames(t)}, frameSize)
+	var names []string
+	var rows [][]interface{}
+	for it.Next() {
+		frame := it.At()
+		if names == nil {
+			names = frame.Names()
+		}
+
+		for i := 0; i < frame.Len(); i++ {
+			row := make([]interface{}, len(names))
+			for j, name := range names {
+				if frame.IsNull(i, name) {
+					continue
+				}
+
+				col, err := frame.Column(name)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if row[j], err = utils.ColAt(col, i); err != nil {
+					t.Fatal(err)
+				}
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatalf("%s: %s", sql, err)
+	}
+
+	return names, rows
+}
+
+func TestQuery(t *testing.T) {
+	testCases := []struct {
+		sql   string
+		names []string
+		rows  [][]interface{}
+	}{
+		{
+			"SELECT name, x * 2 AS double FROM t WHERE x > 1 AND dept = 'eng'",
+			[]string{"name", "double"},
+			[][]interface{}{{"c", int64(6)}},
+		},
+		{
+			"SELECT name FROM t WHERE x IS NULL OR name LIKE '_' AND score BETWEEN 2 AND 3",
+			[]string{"name"},
+			[][]interface{}{{"b"}, {"e"}},
+		},
+		{
+			"SELECT name, score FROM t ORDER BY score DESC LIMIT 2 OFFSET 1",
+			[]string{"name", "score"},
+			[][]interface{}{{"d", 4.5}, {"c", 3.5}},
+		},
+		{
+			"SELECT name FROM t ORDER BY x DESC, 1",
+			[]string{"name"},
+			[][]interface{}{{"e"}, {"d"}, {"c"}, {"b"}, {"a"}},
+		},
+		{
+			"SELECT name FROM t LIMIT 4 OFFSET 2",
+			[]string{"name"},
+			[][]interface{}{{"c"}, {"d"}, {"e"}},
+		},
+		{
+			"SELECT dept, count(*) AS n, count(x), sum(x), avg(score), min(name), max(score) FROM t GROUP BY dept ORDER BY dept",
+			[]string{"dept", "n", "count(x)", "sum(x)", "avg(score)", "min(name)", "max(score)"},
+			[][]interface{}{
+				{"eng", int64(3), int64(2), int64(4), 3.5, "a", 5.5},
+				{"ops", int64(2), int64(2), int64(6), 3.5, "b", 4.5},
+			},
+		},
+		{
+			"SELECT dept, sum(score) / count(*) AS mean FROM t GROUP BY 1 HAVING mean > 3.4 AND count(*) > 2",
+			[]string{"dept", "mean"},
+			[][]interface{}{{"eng", 3.5}},
+		},
+		{
+			"SELECT x > 2 AS big, count(*) FROM t WHERE x IS NOT NULL GROUP BY x > 2 ORDER BY count(*) DESC",
+			[]string{"big", "count(*)"},
+			[][]interface{}{{false, int64(2)}, {true, int64(2)}},
+		},
+		{
+			"SELECT count(*), sum(x) FROM t WHERE x > 10",
+			[]string{"count(*)", "sum(x)"},
+			[][]interface{}{{int64(0), nil}},
+		},
+		{
+			// No rows in the first frame, integers stay integers
+			"SELECT min(x), sum(x) FROM t WHERE name > 'c'",
+			[]string{"min(x)", "sum(x)"},
+			[][]interface{}{{int64(4), int64(4)}},
+		},
+		{
+			"SELECT dept, max(x) FROM t WHERE x > 10 GROUP BY dept",
+			nil,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		names, rows := run(t, tc.sql, 1)
+		if !reflect.DeepEqual(names, tc.names) {
+			t.Fatalf("%s: bad names - %v", tc.sql, names)
+		}
+
+		if !reflect.DeepEqual(rows, tc.rows) {
+			t.Fatalf("%s: bad rows - %v", tc.sql, rows)
+		}
+	}
+}
+
+func TestStar(t *testing.T) {
+	names, rows := run(t, "SELECT * FROM t WHERE name IN ('a', 'e') ORDER BY name", 0)
+	if len(names) != 4 || len(rows) != 2 {
+		t.Fatalf("bad result - %v %v", names, rows)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	queries := []string{
+		"DELETE FROM t",
+		"SELECT DISTINCT x FROM t",
+		"SELECT x, y AS x FROM t",
+		"SELECT x FROM t WHERE count(*) > 1",
+		"SELECT x, count(*) FROM t",
+		"SELECT * FROM t GROUP BY x",
+		"SELECT sum(*) FROM t",
+		"SELECT max(count(x)) FROM t",
+		"SELECT x FROM t ORDER BY 2",
+		"SELECT nope(x) FROM t",
+		"SELECT x FROM t LIMIT 'a'",
+	}
+
+	for _, sql := range queries {
+		if _, err := Parse(sql); err == nil {
+			t.Fatalf("%s: no error", sql)
+		}
+	}
+}
+
+func TestPlan(t *testing.T) {
+	caps := &frames.Capabilities{ReadFields: backends.ReadFields()}
+	query, err := Parse("SELECT name, y FROM t WHERE x > 1 AND name LIKE 'a_%' AND starts(dept, 'e') LIMIT 10")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan := NewPlan(query, caps)
+	if plan.Filter != "((x > 1) and starts(dept, 'e'))" {
+		t.Fatalf("bad filter - %q", plan.Filter)
+	}
+
+	if !reflect.DeepEqual(plan.Columns, []string{"name", "y", "x", "dept"}) {
+		t.Fatalf("bad columns - %v", plan.Columns)
+	}
+
+	if plan.Limit != 0 {
+		t.Fatalf("limit with filter - %d", plan.Limit)
+	}
+
+	query, err = Parse("SELECT * FROM t LIMIT 10 OFFSET 5")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan = NewPlan(query, caps)
+	if plan.Limit != 15 || plan.Columns != nil {
+		t.Fatalf("bad plan - %+v", plan)
+	}
+
+	request := &frames.ReadRequest{Proto: &pb.ReadRequest{Backend: "b", Query: "SELECT * FROM t", MessageLimit: 7}}
+	backendRequest, err := plan.Request(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := backendRequest.Proto
+	if msg.Query != "" || msg.Table != "t" || msg.Limit != 15 || msg.MessageLimit != 7 || request.Proto.Table != "" {
+		t.Fatalf("bad backend request - %+v", msg)
+	}
+
+	request.Proto.Filter = "x > 1"
+	if _, err := plan.Request(request); err == nil {
+		t.Fatal("no error for query with filter")
+	}
+
+	// No pushdown to backends that don't declare filters and columns
+	plan = NewPlan(query, &frames.Capabilities{})
+	if plan.Filter != "" || plan.Columns != nil || plan.Limit != 0 {
+		t.Fatalf("pushdown without capabilities - %+v", plan)
+	}
+}
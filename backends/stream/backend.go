@@ -21,6 +21,7 @@ such restriction.
 package stream
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -40,6 +41,31 @@ type Backend struct {
 	v3ioContext   v3io.Context
 }
 
+var (
+	// Make sure we're implementing frames.ContextDataBackend and frames.CatalogBackend
+	_ frames.ContextDataBackend = &Backend{}
+	_ frames.CatalogBackend     = &Backend{}
+)
+
+var capabilities = &frames.Capabilities{
+	Type:         "stream",
+	ReadFields:   backends.ReadFields("Seek", "ShardId", "Sequence", "Start", "Marker"),
+	WriteFields:  backends.WriteFields("HaveMore"),
+	CreateFields: backends.CreateFields("Shards", "RetentionHours"),
+	DeleteFields: backends.DeleteFields(),
+	Commands: []*frames.ExecCommand{
+		{
+			Name: "put",
+			Doc:  "Put a record in the stream",
+			Args: []*frames.ExecArgument{
+				{Name: "data", Type: v3ioutils.StringType, Required: true, Doc: "Record data"},
+				{Name: "client_info", Type: v3ioutils.StringType, Doc: "Record client information"},
+				{Name: "partition_key", Type: v3ioutils.StringType, Doc: "Key used to select the record shard"},
+			},
+		},
+	},
+}
+
 // NewBackend returns a new platform ("v3io") streaming backend
 func NewBackend(logger logger.Logger, v3ioContext v3io.Context, cfg *frames.BackendConfig, framesConfig *frames.Config) (frames.DataBackend, error) {
 
@@ -53,8 +79,16 @@ func NewBackend(logger logger.Logger, v3ioContext v3io.Context, cfg *frames.Back
 	return &newBackend, nil
 }
 
+// Capabilities returns the requests the backend supports
+func (b *Backend) Capabilities() *frames.Capabilities {
+	return capabilities
+}
+
 // Create creates a stream
 func (b *Backend) Create(request *frames.CreateRequest) error {
+	if err := backends.ValidateRequest(capabilities, request.Proto); err != nil {
+		return err
+	}
 
 	// TODO: Check whether Stream exists; if it already has the desired params can silently ignore, may need a -silent flag
 
@@ -92,7 +126,7 @@ func (b *Backend) Create(request *frames.CreateRequest) error {
 // Delete deletes a table or part of it
 func (b *Backend) Delete(request *frames.DeleteRequest) error {
 
-	err := backends.ValidateRequest("stream", request.Proto, nil)
+	err := backends.ValidateRequest(capabilities, request.Proto)
 	if err != nil {
 		return err
 	}
@@ -112,6 +146,10 @@ func (b *Backend) Delete(request *frames.DeleteRequest) error {
 
 // Exec executes a command
 func (b *Backend) Exec(request *frames.ExecRequest) (frames.Frame, error) {
+	if err := backends.ValidateRequest(capabilities, request.Proto); err != nil {
+		return nil, err
+	}
+
 	cmd := strings.TrimSpace(strings.ToLower(request.Proto.Command))
 	switch cmd {
 	case "put":
@@ -120,6 +158,15 @@ func (b *Backend) Exec(request *frames.ExecRequest) (frames.Frame, error) {
 	return nil, fmt.Errorf("streaming backend doesn't support execute command '%s'", cmd)
 }
 
+// ExecContext executes a command
+func (b *Backend) ExecContext(ctx context.Context, request *frames.ExecRequest) (frames.Frame, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return b.Exec(request)
+}
+
 func (b *Backend) put(request *frames.ExecRequest) error {
 
 	varData, hasData := request.Proto.Args["data"]
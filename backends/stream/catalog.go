@@ -0,0 +1,78 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package stream
+
+import (
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/pb"
+	"github.com/v3io/frames/v3ioutils"
+	v3io "github.com/v3io/v3io-go/pkg/dataplane"
+)
+
+// ListTables lists the streams in a directory
+func (b *Backend) ListTables(request *frames.ListTablesRequest) ([]string, error) {
+	container, dirPath, err := b.newConnection(request.Proto.Session, request.Password.Get(), request.Token.Get(), request.Proto.Path, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// DescribeStream fails on directories that aren't streams
+	return v3ioutils.ListTables(container, dirPath, request.Proto.Path, func(dir string) (bool, error) {
+		resp, err := container.DescribeStreamSync(&v3io.DescribeStreamInput{Path: dir})
+		if err != nil {
+			return false, nil
+		}
+		resp.Release()
+		return true, nil
+	})
+}
+
+// DescribeTable returns the stream shard count and retention. The schema has
+// the fields added to every record, record fields depend on the data
+func (b *Backend) DescribeTable(request *frames.DescribeTableRequest) (*pb.DescribeTableResponse, error) {
+	container, streamPath, err := b.newConnection(request.Proto.Session, request.Password.Get(), request.Token.Get(), request.Proto.Table, true)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := container.DescribeStreamSync(&v3io.DescribeStreamInput{Path: streamPath})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Release()
+
+	output := resp.Output.(*v3io.DescribeStreamOutput)
+	schema := &frames.TableSchema{
+		Fields: []*frames.SchemaField{
+			{Name: "seq_number", Type: v3ioutils.LongType},
+			{Name: "stream_time", Type: v3ioutils.TimeType},
+		},
+	}
+	attributes, err := pb.FromGoMap(map[string]interface{}{
+		"shards":          output.ShardCount,
+		"retention_hours": output.RetentionPeriodHours,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.DescribeTableResponse{Schema: schema, Attributes: attributes}, nil
+}
@@ -21,6 +21,7 @@ such restriction.
 package stream
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -33,6 +34,7 @@ import (
 )
 
 type streamIterator struct {
+	ctx          context.Context
 	request      *frames.ReadRequest
 	container    v3io.Container
 	err          error
@@ -41,23 +43,24 @@ type streamIterator struct {
 	b            *Backend
 	endTime      int
 	isLast       bool
+	responseChan chan *v3io.Response
 }
 
-var allowedReadRequestFields = map[string]bool{
-	"Seek":     true,
-	"ShardId":  true,
-	"Sequence": true,
-	"Start":    true,
+func (b *Backend) Read(request *frames.ReadRequest) (frames.FrameIterator, error) {
+	return b.ReadContext(context.Background(), request)
 }
 
-func (b *Backend) Read(request *frames.ReadRequest) (frames.FrameIterator, error) {
+// ReadContext reads from a stream shard, the iterator stops waiting for
+// records once ctx is done
+func (b *Backend) ReadContext(ctx context.Context, request *frames.ReadRequest) (frames.FrameIterator, error) {
 
-	err := backends.ValidateRequest("stream", request.Proto, allowedReadRequestFields)
+	err := backends.ValidateRequest(capabilities, request.Proto)
 	if err != nil {
 		return nil, err
 	}
 
-	if request.Proto.Table == "" || request.Proto.Seek == "" || request.Proto.ShardId == "" {
+	// A marker (shard location) replaces the seek
+	if request.Proto.Table == "" || (request.Proto.Seek == "" && request.Proto.Marker == "") || request.Proto.ShardId == "" {
 		return nil, fmt.Errorf("missing essential parameters, need: table, seek, shard parameters")
 	}
 
@@ -67,7 +70,13 @@ func (b *Backend) Read(request *frames.ReadRequest) (frames.FrameIterator, error
 	}
 	request.Proto.Table = path
 
-	iter := streamIterator{request: request, b: b, container: container}
+	iter := streamIterator{
+		ctx:          ctx,
+		request:      request,
+		b:            b,
+		container:    container,
+		responseChan: make(chan *v3io.Response, 1),
+	}
 
 	input := v3io.SeekShardInput{Path: request.Proto.Table + request.Proto.ShardId}
 
@@ -83,6 +92,11 @@ func (b *Backend) Read(request *frames.ReadRequest) (frames.FrameIterator, error
 		iter.endTime = int(endTime)
 	}
 
+	if request.Proto.Marker != "" {
+		iter.nextLocation = request.Proto.Marker
+		return &iter, nil
+	}
+
 	switch strings.ToLower(request.Proto.Seek) {
 	case "time":
 		input.Type = v3io.SeekShardInputTypeTime
@@ -119,17 +133,36 @@ func (i *streamIterator) Next() bool {
 		return false
 	}
 
-	resp, err := i.container.GetRecordsSync(&v3io.GetRecordsInput{
+	if err := i.ctx.Err(); err != nil {
+		i.err = err
+		return false
+	}
+
+	input := &v3io.GetRecordsInput{
 		Path:     i.request.Proto.Table + i.request.Proto.ShardId,
 		Location: i.nextLocation,
 		Limit:    int(i.request.Proto.MessageLimit),
-	})
+	}
 
-	if err != nil {
+	if _, err := i.container.GetRecords(input, nil, i.responseChan); err != nil {
 		i.err = fmt.Errorf("Error in GetRecords operation (%v)", err)
 		return false
 	}
 
+	var resp *v3io.Response
+	select {
+	case resp = <-i.responseChan:
+	case <-i.ctx.Done():
+		i.err = i.ctx.Err()
+		return false
+	}
+	defer resp.Release()
+
+	if resp.Error != nil {
+		i.err = fmt.Errorf("Error in GetRecords operation (%v)", resp.Error)
+		return false
+	}
+
 	output := resp.Output.(*v3io.GetRecordsOutput)
 	rows := []map[string]interface{}{}
 	var lastSequence int64
@@ -164,7 +197,12 @@ func (i *streamIterator) Next() bool {
 		i.err = fmt.Errorf("Failed to create frame - %v", err)
 		return false
 	}
-	i.currFrame = frame
+
+	i.currFrame, err = frames.WithMarker(frame, output.NextLocation)
+	if err != nil {
+		i.err = err
+		return false
+	}
 
 	i.nextLocation = output.NextLocation
 	i.isLast = i.isLast || (output.RecordsBehindLatest == 0)
@@ -21,6 +21,7 @@ such restriction.
 package stream
 
 import (
+	"context"
 	"encoding/json"
 	"time"
 
@@ -31,13 +32,19 @@ import (
 	"github.com/v3io/v3io-go/pkg/dataplane"
 )
 
-var allowedWriteRequestFields = map[string]bool{
-	"HaveMore": true,
+// WriteContext writes to a stream, frames are not added once ctx is done
+func (b *Backend) WriteContext(ctx context.Context, request *frames.WriteRequest) (frames.FrameAppender, error) {
+	appender, err := b.Write(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return backends.NewContextAppender(ctx, appender), nil
 }
 
 func (b *Backend) Write(request *frames.WriteRequest) (frames.FrameAppender, error) {
 
-	err := backends.ValidateRequest("stream", request, allowedWriteRequestFields)
+	err := backends.ValidateRequest(capabilities, request)
 	if err != nil {
 		return nil, err
 	}
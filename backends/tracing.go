@@ -0,0 +1,105 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package backends
+
+import (
+	"time"
+
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func requestAttributes(backend, table string) []attribute.KeyValue {
+	return []attribute.KeyValue{tracing.BackendKey.String(backend), tracing.TableKey.String(table)}
+}
+
+// tracedIterator ends the read span when the iteration ends
+type tracedIterator struct {
+	frames.FrameIterator
+	span    trace.Span
+	nFrames int
+	nRows   int
+	ended   bool
+}
+
+func (it *tracedIterator) Next() bool {
+	if it.FrameIterator.Next() {
+		it.nFrames++
+		if frame := it.At(); frame != nil {
+			it.nRows += frame.Len()
+		}
+		return true
+	}
+
+	if !it.ended {
+		it.ended = true
+		it.span.SetAttributes(tracing.FramesKey.Int(it.nFrames), tracing.RowsKey.Int(it.nRows))
+		tracing.End(it.span, it.Err())
+	}
+	return false
+}
+
+// tracedAppender ends the write span when the write completes or the appender
+// is closed
+type tracedAppender struct {
+	frames.FrameAppender
+	span    trace.Span
+	nFrames int
+	nRows   int
+	ended   bool
+}
+
+func (a *tracedAppender) Add(frame frames.Frame) error {
+	if err := a.FrameAppender.Add(frame); err != nil {
+		return err
+	}
+
+	a.count(frame)
+	return nil
+}
+
+func (a *tracedAppender) WaitForComplete(timeout time.Duration) error {
+	err := a.FrameAppender.WaitForComplete(timeout)
+	a.end(err)
+	return err
+}
+
+func (a *tracedAppender) Close() {
+	a.FrameAppender.Close()
+	a.end(nil)
+}
+
+func (a *tracedAppender) count(frame frames.Frame) {
+	a.nFrames++
+	a.nRows += frame.Len()
+}
+
+func (a *tracedAppender) end(err error) {
+	if a.ended {
+		return
+	}
+
+	a.ended = true
+	a.span.SetAttributes(tracing.FramesKey.Int(a.nFrames), tracing.RowsKey.Int(a.nRows))
+	tracing.End(a.span, err)
+}
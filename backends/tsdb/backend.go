@@ -21,6 +21,7 @@ such restriction.
 package tsdb
 
 import (
+	"context"
 	"fmt"
 	"hash/fnv"
 	"reflect"
@@ -34,6 +35,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/v3io/frames"
 	"github.com/v3io/frames/backends"
+	"github.com/v3io/frames/metrics"
 	"github.com/v3io/frames/v3ioutils"
 	v3io "github.com/v3io/v3io-go/pkg/dataplane"
 	"github.com/v3io/v3io-tsdb/pkg/config"
@@ -54,6 +56,22 @@ type Backend struct {
 	inactivityTimeout time.Duration
 }
 
+var (
+	// Make sure we're implementing frames.ContextDataBackend and frames.CatalogBackend
+	_ frames.ContextDataBackend = &Backend{}
+	_ frames.CatalogBackend     = &Backend{}
+)
+
+var capabilities = &frames.Capabilities{
+	Type: "tsdb",
+	ReadFields: backends.ReadFields(
+		"MultiIndex", "Query", "GroupBy", "Start", "End", "Step", "Aggregators", "AggregationWindow",
+	),
+	WriteFields:  backends.WriteFields(),
+	CreateFields: backends.CreateFields("Rate", "Aggregates", "AggregationGranularity"),
+	DeleteFields: backends.DeleteFields("Start", "End", "Metrics"),
+}
+
 // NewBackend returns a new TSDB backend
 func NewBackend(logger logger.Logger, v3ioContext v3io.Context, cfg *frames.BackendConfig, framesConfig *frames.Config) (frames.DataBackend, error) {
 
@@ -166,7 +184,10 @@ func (b *Backend) GetQuerier(session *frames.Session, password string, token str
 	b.queriersLock.Lock()
 	defer b.queriersLock.Unlock()
 	qry, found := b.queriers.Get(key)
-	if !found {
+	if found {
+		metrics.QuerierCache.WithLabelValues("hit").Inc()
+	} else {
+		metrics.QuerierCache.WithLabelValues("miss").Inc()
 		var err error
 		adapter, err := b.newAdapter(session, password, token, path)
 		if err != nil {
@@ -184,6 +205,9 @@ func (b *Backend) GetQuerier(session *frames.Session, password string, token str
 
 // Create creates a TSDB table
 func (b *Backend) Create(request *frames.CreateRequest) error {
+	if err := backends.ValidateRequest(capabilities, request.Proto); err != nil {
+		return err
+	}
 
 	rate := request.Proto.Rate
 	if request.Proto.Rate == "" {
@@ -233,16 +257,10 @@ func (b *Backend) Create(request *frames.CreateRequest) error {
 	return err
 }
 
-var allowedDeleteRequestFields = map[string]bool{
-	"Start":   true,
-	"End":     true,
-	"Metrics": true,
-}
-
 // Delete deletes a table or part of it
 func (b *Backend) Delete(request *frames.DeleteRequest) error {
 
-	err := backends.ValidateRequest("tsdb", request.Proto, allowedDeleteRequestFields)
+	err := backends.ValidateRequest(capabilities, request.Proto)
 	if err != nil {
 		return err
 	}
@@ -285,11 +303,21 @@ func (b *Backend) Delete(request *frames.DeleteRequest) error {
 
 }
 
+// Capabilities returns the requests the backend supports, there are no exec commands
+func (b *Backend) Capabilities() *frames.Capabilities {
+	return capabilities
+}
+
 // Exec executes a command
 func (b *Backend) Exec(request *frames.ExecRequest) (frames.Frame, error) {
 	return nil, fmt.Errorf("TSDB backend doesn't support the 'execute' command")
 }
 
+// ExecContext executes a command
+func (b *Backend) ExecContext(ctx context.Context, request *frames.ExecRequest) (frames.Frame, error) {
+	return b.Exec(request)
+}
+
 func (b *Backend) ignoreCreateExists(request *frames.CreateRequest, err error) bool {
 	if request.Proto.IfExists != frames.IgnoreError {
 		return false
@@ -0,0 +1,92 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package tsdb
+
+import (
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/pb"
+	"github.com/v3io/frames/v3ioutils"
+	"github.com/v3io/v3io-tsdb/pkg/config"
+)
+
+// ListTables lists the TSDB tables (directories with a TSDB schema) in a
+// directory
+func (b *Backend) ListTables(request *frames.ListTablesRequest) ([]string, error) {
+	session := frames.InitSessionDefaults(request.Proto.Session, b.framesConfig)
+	containerName, dirPath, err := v3ioutils.ProcessPaths(session, request.Proto.Path, true)
+	if err != nil {
+		return nil, err
+	}
+
+	session.Container = containerName
+	container, err := v3ioutils.NewContainer(b.v3ioContext, session, request.Password.Get(), request.Token.Get(), b.logger)
+	if err != nil {
+		return nil, err
+	}
+
+	return v3ioutils.ListTables(container, dirPath, request.Proto.Path, func(dir string) (bool, error) {
+		return v3ioutils.PathExists(container, path.Join(dir, config.SchemaConfigFileName))
+	})
+}
+
+// DescribeTable returns the table metrics as schema fields, and the partition
+// schema as attributes
+func (b *Backend) DescribeTable(request *frames.DescribeTableRequest) (*pb.DescribeTableResponse, error) {
+	adapter, err := b.GetAdapter(request.Proto.Session, request.Password.Get(), request.Token.Get(), request.Proto.Table)
+	if err != nil {
+		return nil, err
+	}
+
+	querier, err := adapter.QuerierV2()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to initialize querier")
+	}
+
+	metrics, err := querier.LabelValues(config.PrometheusMetricNameAttribute)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read metric names")
+	}
+
+	schema := &frames.TableSchema{}
+	for _, metric := range metrics {
+		schema.Fields = append(schema.Fields, &frames.SchemaField{Name: metric, Type: v3ioutils.DoubleType})
+	}
+
+	tsdbSchema := adapter.GetSchema()
+	partition := tsdbSchema.PartitionSchemaInfo
+	attributes, err := pb.FromGoMap(map[string]interface{}{
+		"aggregates":              strings.Join(partition.Aggregates, ","),
+		"aggregation_granularity": partition.AggregationGranularity,
+		"partitioner_interval":    partition.PartitionerInterval,
+		"chunker_interval":        partition.ChunckerInterval,
+		"sample_retention":        partition.SampleRetention,
+		"partitions":              len(tsdbSchema.Partitions),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.DescribeTableResponse{Schema: schema, Attributes: attributes}, nil
+}
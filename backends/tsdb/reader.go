@@ -21,6 +21,7 @@ such restriction.
 package tsdb
 
 import (
+	"context"
 	"sort"
 	"strings"
 	"time"
@@ -28,12 +29,14 @@ import (
 	"github.com/pkg/errors"
 	"github.com/v3io/frames"
 	"github.com/v3io/frames/backends"
+	"github.com/v3io/frames/tracing"
 	"github.com/v3io/v3io-tsdb/pkg/config"
 	"github.com/v3io/v3io-tsdb/pkg/pquerier"
 	tsdbutils "github.com/v3io/v3io-tsdb/pkg/utils"
 )
 
 type tsdbIterator struct {
+	ctx              context.Context
 	request          *frames.ReadRequest
 	set              pquerier.FrameSet
 	err              error
@@ -43,20 +46,15 @@ type tsdbIterator struct {
 	currTsdbFrame    frames.Frame
 }
 
-var allowedReadRequestFields = map[string]bool{
-	"MultiIndex":        true,
-	"Query":             true,
-	"GroupBy":           true,
-	"Start":             true,
-	"End":               true,
-	"Step":              true,
-	"Aggregators":       true,
-	"AggregationWindow": true,
+func (b *Backend) Read(request *frames.ReadRequest) (frames.FrameIterator, error) {
+	return b.ReadContext(context.Background(), request)
 }
 
-func (b *Backend) Read(request *frames.ReadRequest) (frames.FrameIterator, error) {
+// ReadContext reads from the TSDB, the iterator stops fetching frames from
+// the querier once ctx is done
+func (b *Backend) ReadContext(ctx context.Context, request *frames.ReadRequest) (frames.FrameIterator, error) {
 
-	err := backends.ValidateRequest("tsdb", request.Proto, allowedReadRequestFields)
+	err := backends.ValidateRequest(capabilities, request.Proto)
 	if err != nil {
 		return nil, err
 	}
@@ -104,7 +102,7 @@ func (b *Backend) Read(request *frames.ReadRequest) (frames.FrameIterator, error
 		return nil, errors.Wrap(err, "failed to create adapter")
 	}
 
-	iter := tsdbIterator{request: request}
+	iter := tsdbIterator{ctx: ctx, request: request}
 	name := ""
 	if len(request.Proto.Columns) > 0 {
 		name = strings.Join(request.Proto.Columns, ",")
@@ -127,7 +125,9 @@ func (b *Backend) Read(request *frames.ReadRequest) (frames.FrameIterator, error
 			AggregationWindow: aggregationWindow}
 	}
 
+	_, span := tracing.Start(ctx, "tsdb.SelectDataFrame", tracing.TableKey.String(table))
 	iter.set, err = qry.SelectDataFrame(selectParams)
+	tracing.End(span, err)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed on TSDB Select")
 	}
@@ -145,6 +145,11 @@ func (i *tsdbIterator) Next() bool {
 	}
 
 	for i.currTsdbFrame == nil || i.currTsdbFrame.Len() == 0 {
+		if err := i.ctx.Err(); err != nil {
+			i.err = err
+			return false
+		}
+
 		if i.set.NextFrame() {
 			i.currTsdbFrame, err = i.set.GetFrame()
 			if err != nil {
@@ -21,6 +21,7 @@ such restriction.
 package tsdb
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"strings"
@@ -34,9 +35,19 @@ import (
 	"github.com/v3io/v3io-tsdb/pkg/utils"
 )
 
+// WriteContext writes to the TSDB, frames are not added once ctx is done
+func (b *Backend) WriteContext(ctx context.Context, request *frames.WriteRequest) (frames.FrameAppender, error) {
+	appender, err := b.Write(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return backends.NewContextAppender(ctx, appender), nil
+}
+
 func (b *Backend) Write(request *frames.WriteRequest) (frames.FrameAppender, error) {
 
-	err := backends.ValidateRequest("tsdb", request, nil)
+	err := backends.ValidateRequest(capabilities, request)
 	if err != nil {
 		return nil, err
 	}
@@ -130,20 +141,27 @@ func (a *tsdbAppender) Add(frame frames.Frame) error {
 		}
 
 		switch col.DType() {
-		case frames.FloatType:
+		case frames.FloatType, frames.Float32Type:
 			typed, _ := col.Floats()
 			data := make([]interface{}, len(typed))
 			for i, v := range typed {
 				data[i] = v
 			}
 			values[i] = data
-		case frames.IntType:
+		case frames.IntType, frames.Int32Type:
 			typed, _ := col.Ints()
 			data := make([]interface{}, len(typed))
 			for i, v := range typed {
 				data[i] = float64(v) // TODO: why?
 			}
 			values[i] = data
+		case frames.DecimalType:
+			typed, _ := col.Decimals()
+			data := make([]interface{}, len(typed))
+			for i, v := range typed {
+				data[i] = v.Float64()
+			}
+			values[i] = data
 		case frames.BoolType:
 			typed, _ := col.Bools()
 			data := make([]interface{}, len(typed))
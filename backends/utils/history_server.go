@@ -11,6 +11,8 @@ import (
 	"github.com/nuclio/logger"
 	"github.com/pkg/errors"
 	"github.com/v3io/frames"
+	"github.com/v3io/frames/authz"
+	"github.com/v3io/frames/metrics"
 	"github.com/v3io/frames/pb"
 	"github.com/v3io/frames/v3ioutils"
 	v3io "github.com/v3io/v3io-go/pkg/dataplane"
@@ -35,6 +37,7 @@ const (
 	createType  = "create"
 	deleteType  = "delete"
 	executeType = "execute"
+	deniedType  = "denied"
 
 	logFileTimeFormat = "20060102T150405Z"
 )
@@ -69,6 +72,15 @@ type HistoryServer struct {
 }
 
 func NewHistoryServer(logger logger.Logger, cfg *frames.Config) (*HistoryServer, error) {
+	return newHistoryServer(logger, cfg, nil)
+}
+
+// NewHistoryServerWithContainer returns a history server writing logs to the given container (e.g. a fake one)
+func NewHistoryServerWithContainer(logger logger.Logger, cfg *frames.Config, container v3io.Container) (*HistoryServer, error) {
+	return newHistoryServer(logger, cfg, container)
+}
+
+func newHistoryServer(logger logger.Logger, cfg *frames.Config, container v3io.Container) (*HistoryServer, error) {
 	mon := HistoryServer{
 		logger:   logger,
 		requests: make(chan HistoryEntry, 100),
@@ -80,9 +92,14 @@ func NewHistoryServer(logger logger.Logger, cfg *frames.Config) (*HistoryServer,
 		return nil, err
 	}
 
-	err = mon.createDefaultV3ioClient()
-	if err != nil {
-		return nil, err
+	if container != nil {
+		mon.container = container
+		mon.isActive = true
+	} else {
+		err = mon.createDefaultV3ioClient()
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if mon.isActive {
@@ -191,6 +208,12 @@ func (m *HistoryServer) Start() {
 	}()
 }
 
+// enqueue queues entry to be written, it blocks when the queue is full
+func (m *HistoryServer) enqueue(entry HistoryEntry) {
+	metrics.HistoryQueueLength.Inc()
+	m.requests <- entry
+}
+
 func (m *HistoryServer) createDefaultV3ioClient() error {
 
 	session := &frames.Session{}
@@ -243,7 +266,7 @@ func (m *HistoryServer) AddReadLog(readRequest *frames.ReadRequest, duration tim
 	// append the entry in a different goroutine so that it won't block
 	go func() {
 		entry := HistoryEntry{BackendName: readRequest.Proto.Backend,
-			UserName:       readRequest.Proto.Session.User,
+			UserName:       userName(readRequest.Identity, readRequest.Proto.Session),
 			TableName:      readRequest.Proto.Table,
 			StartTime:      startTime,
 			ActionDuration: duration,
@@ -251,7 +274,7 @@ func (m *HistoryServer) AddReadLog(readRequest *frames.ReadRequest, duration tim
 			AdditionalData: readRequest.ToMap(),
 			Container:      readRequest.Proto.Session.Container}
 
-		m.requests <- entry
+		m.enqueue(entry)
 	}()
 }
 
@@ -263,7 +286,7 @@ func (m *HistoryServer) AddWriteLog(writeRequest *frames.WriteRequest, duration
 	// append the entry in a different goroutine so that it won't block
 	go func() {
 		entry := HistoryEntry{BackendName: writeRequest.Backend,
-			UserName:       writeRequest.Session.User,
+			UserName:       userName(writeRequest.Identity, writeRequest.Session),
 			TableName:      writeRequest.Table,
 			StartTime:      startTime,
 			ActionDuration: duration,
@@ -271,7 +294,7 @@ func (m *HistoryServer) AddWriteLog(writeRequest *frames.WriteRequest, duration
 			AdditionalData: writeRequest.ToMap(),
 			Container:      writeRequest.Session.Container}
 
-		m.requests <- entry
+		m.enqueue(entry)
 	}()
 }
 
@@ -283,7 +306,7 @@ func (m *HistoryServer) AddCreateLog(createRequest *frames.CreateRequest, durati
 	// append the entry in a different goroutine so that it won't block
 	go func() {
 		entry := HistoryEntry{BackendName: createRequest.Proto.Backend,
-			UserName:       createRequest.Proto.Session.User,
+			UserName:       userName(createRequest.Identity, createRequest.Proto.Session),
 			TableName:      createRequest.Proto.Table,
 			StartTime:      startTime,
 			ActionDuration: duration,
@@ -291,7 +314,7 @@ func (m *HistoryServer) AddCreateLog(createRequest *frames.CreateRequest, durati
 			AdditionalData: createRequest.ToMap(),
 			Container:      createRequest.Proto.Session.Container}
 
-		m.requests <- entry
+		m.enqueue(entry)
 	}()
 }
 
@@ -303,7 +326,7 @@ func (m *HistoryServer) AddDeleteLog(deleteRequest *frames.DeleteRequest, durati
 	// append the entry in a different goroutine so that it won't block
 	go func() {
 		entry := HistoryEntry{BackendName: deleteRequest.Proto.Backend,
-			UserName:       deleteRequest.Proto.Session.User,
+			UserName:       userName(deleteRequest.Identity, deleteRequest.Proto.Session),
 			TableName:      deleteRequest.Proto.Table,
 			StartTime:      startTime,
 			ActionDuration: duration,
@@ -311,7 +334,7 @@ func (m *HistoryServer) AddDeleteLog(deleteRequest *frames.DeleteRequest, durati
 			AdditionalData: deleteRequest.ToMap(),
 			Container:      deleteRequest.Proto.Session.Container}
 
-		m.requests <- entry
+		m.enqueue(entry)
 	}()
 }
 
@@ -323,7 +346,7 @@ func (m *HistoryServer) AddExecuteLog(execRequest *frames.ExecRequest, duration
 	// append the entry in a different goroutine so that it won't block
 	go func() {
 		entry := HistoryEntry{BackendName: execRequest.Proto.Backend,
-			UserName:       execRequest.Proto.Session.User,
+			UserName:       userName(execRequest.Identity, execRequest.Proto.Session),
 			TableName:      execRequest.Proto.Table,
 			StartTime:      startTime,
 			ActionDuration: duration,
@@ -331,10 +354,52 @@ func (m *HistoryServer) AddExecuteLog(execRequest *frames.ExecRequest, duration
 			AdditionalData: execRequest.ToMap(),
 			Container:      execRequest.Proto.Session.Container}
 
-		m.requests <- entry
+		m.enqueue(entry)
+	}()
+}
+
+// AddDeniedLog logs a request the authorization policy denied, the denied
+// action is in the "action" additional data
+func (m *HistoryServer) AddDeniedLog(denied *authz.DeniedError, session *frames.Session) {
+	if !m.isActive {
+		return
+	}
+
+	if session == nil {
+		session = &frames.Session{}
+	}
+
+	request := denied.Request
+	additionalData := map[string]string{"action": request.Action, "reason": denied.Error()}
+	if request.Command != "" {
+		additionalData["command"] = request.Command
+	}
+
+	startTime := time.Now()
+	// append the entry in a different goroutine so that it won't block
+	go func() {
+		entry := HistoryEntry{BackendName: request.Backend,
+			UserName:       userName(request.Identity, session),
+			TableName:      request.Table,
+			StartTime:      startTime,
+			ActionType:     deniedType,
+			AdditionalData: additionalData,
+			Container:      request.Container}
+
+		m.enqueue(entry)
 	}()
 }
 
+// userName returns the verified user of a request, the session user is used
+// when the server has no authentication
+func userName(identity *frames.Identity, session *frames.Session) string {
+	if identity != nil {
+		return identity.User
+	}
+
+	return session.User
+}
+
 func (m *HistoryServer) GetLogs(request *frames.HistoryRequest, out chan frames.Frame) error {
 	if request.Proto.MinStartTime == "" {
 		request.Proto.MinStartTime = "0"
@@ -549,10 +614,13 @@ func createDF(userNameColData, backendNameColData, tableNameColData, actionTypeC
 }
 
 func (m *HistoryServer) writeMonitoringBatch(logs []HistoryEntry) {
+	defer metrics.HistoryQueueLength.Sub(float64(len(logs)))
+
 	for _, log := range logs {
 		d, err := json.Marshal(log)
 		if err != nil {
 			m.logger.ErrorWith("Failed to marshal log to json", "error", err, "log", log)
+			metrics.HistoryDropped.Add(float64(len(logs)))
 			return
 		}
 
@@ -581,6 +649,7 @@ func (m *HistoryServer) writeMonitoringBatch(logs []HistoryEntry) {
 
 	if err != nil {
 		m.logger.ErrorWith("Failed to append Frames history logs to file", "error", err, "logs", logs)
+		metrics.HistoryDropped.Add(float64(len(logs)))
 	}
 
 	// reset the slice for future reuse
@@ -0,0 +1,147 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/authz"
+	"github.com/v3io/frames/pb"
+	"github.com/v3io/frames/v3ioutils"
+	v3io "github.com/v3io/v3io-go/pkg/dataplane"
+)
+
+func TestHistoryServerFakeContainer(t *testing.T) {
+	logger, err := frames.NewLogger("info")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v3ioContext, err := v3ioutils.NewFakeContext(v3ioutils.FakeMemoryRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	container, err := v3ioContext.Container("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &frames.Config{PendingLogsBatchSize: 1}
+	historyServer, err := NewHistoryServerWithContainer(logger, cfg, container)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	writeRequest := &frames.WriteRequest{
+		Session: &frames.Session{Container: "bigdata", User: "daffy"},
+		Backend: "kv",
+		Table:   "t1",
+	}
+	historyServer.AddWriteLog(writeRequest, time.Second, time.Now())
+
+	for start := time.Now(); time.Since(start) < 10*time.Second; time.Sleep(10 * time.Millisecond) {
+		if container.CheckPathExistsSync(&v3io.CheckPathExistsInput{Path: historyServer.LogsFolderPath}) == nil {
+			break
+		}
+	}
+
+	out := make(chan frames.Frame, 10)
+	request := &frames.HistoryRequest{Proto: &pb.HistoryRequest{Table: "t1"}}
+	if err := historyServer.GetLogs(request, out); err != nil {
+		t.Fatal(err)
+	}
+	close(out)
+
+	rows := 0
+	for frame := range out {
+		rows += frame.Len()
+	}
+	if rows != 1 {
+		t.Fatalf("wrong number of log entries - %d", rows)
+	}
+}
+
+func TestHistoryUserName(t *testing.T) {
+	session := &frames.Session{User: "daffy"}
+	if user := userName(nil, session); user != "daffy" {
+		t.Fatalf("bad user without identity - %q", user)
+	}
+
+	identity := &frames.Identity{User: "bugs", Provider: "apikey"}
+	if user := userName(identity, session); user != "bugs" {
+		t.Fatalf("bad user with identity - %q", user)
+	}
+}
+
+func TestHistoryDeniedLog(t *testing.T) {
+	logger, err := frames.NewLogger("info")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v3ioContext, err := v3ioutils.NewFakeContext(v3ioutils.FakeMemoryRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	container, err := v3ioContext.Container("users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &frames.Config{PendingLogsBatchSize: 1, LogsFolderPath: "/monitoring/denied"}
+	historyServer, err := NewHistoryServerWithContainer(logger, cfg, container)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	denied := &authz.DeniedError{
+		Request: authz.Request{
+			Identity: &frames.Identity{User: "daffy"},
+			Action:   authz.DeleteAction,
+			Backend:  "tsdb",
+			Table:    "prod/cpu",
+		},
+	}
+	historyServer.AddDeniedLog(denied, &frames.Session{User: "bugs"})
+
+	for start := time.Now(); time.Since(start) < 10*time.Second; time.Sleep(10 * time.Millisecond) {
+		if container.CheckPathExistsSync(&v3io.CheckPathExistsInput{Path: historyServer.LogsFolderPath}) == nil {
+			break
+		}
+	}
+
+	out := make(chan frames.Frame, 10)
+	request := &frames.HistoryRequest{Proto: &pb.HistoryRequest{Action: "denied", User: "daffy"}}
+	if err := historyServer.GetLogs(request, out); err != nil {
+		t.Fatal(err)
+	}
+	close(out)
+
+	rows := 0
+	for frame := range out {
+		rows += frame.Len()
+	}
+	if rows != 1 {
+		t.Fatalf("wrong number of denied entries - %d", rows)
+	}
+}
@@ -110,30 +110,36 @@ func NewColumnFromType(t string, size int) (interface{}, error) {
 		return make([]time.Time, size), nil
 	case v3ioutils.BoolType:
 		return make([]bool, size), nil
+	case v3ioutils.IntType:
+		return make([]int32, size), nil
+	case v3ioutils.FloatType:
+		data := make([]float32, size)
+		for i := range data {
+			data[i] = float32(math.NaN())
+		}
+
+		return data, nil
+	case v3ioutils.BlobType:
+		return make([][]byte, size), nil
+	case v3ioutils.DecimalType:
+		return make([]frames.Decimal, size), nil
+	case v3ioutils.ListType:
+		return make([]frames.Column, size), nil
 	}
 
 	return nil, fmt.Errorf("unknown type - %T", t)
 }
 
-// AppendNil appends an empty value to data
+// AppendNil appends a null to data, the column holds an empty value in its
+// place
 func AppendNil(col frames.Column) error {
-	var value interface{}
 	switch col.DType() {
-	case frames.IntType:
-		value = int64(0)
-	case frames.FloatType:
-		value = math.NaN()
-	case frames.StringType:
-		value = ""
-	case frames.TimeType:
-		value = time.Unix(0, 0)
-	case frames.BoolType:
-		value = false
-	default:
-		return fmt.Errorf("unsupported data type - %d", col.DType())
+	case frames.IntType, frames.FloatType, frames.StringType, frames.TimeType, frames.BoolType,
+		frames.Int32Type, frames.Float32Type, frames.BytesType, frames.DecimalType, frames.ListType:
+		return AppendColumn(col, nil)
 	}
 
-	return AppendColumn(col, value)
+	return fmt.Errorf("unsupported data type - %d", col.DType())
 }
 
 // ColAt return value at index i in column as interface{}
@@ -155,6 +161,8 @@ func ColAt(col frames.Column, i int) (interface{}, error) {
 		return col.TimeAt(i)
 	case frames.BoolType:
 		return col.BoolAt(i)
+	case frames.Int32Type, frames.Float32Type, frames.BytesType, frames.DecimalType, frames.ListType:
+		return frames.ValueAt(col, i)
 	default:
 		return nil, fmt.Errorf("unknown column type - %d", col.DType())
 	}
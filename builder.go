@@ -21,6 +21,7 @@ such restriction.
 package frames
 
 import (
+	"bytes"
 	"fmt"
 	"math"
 	"sort"
@@ -35,6 +36,7 @@ type ColumnBuilder interface {
 	Append(value interface{}) error
 	At(index int) (interface{}, error)
 	Set(index int, value interface{}) error
+	SetNull(index int) error
 	Delete(index int) error
 	Finish() Column
 }
@@ -53,6 +55,7 @@ func NewSliceColumnBuilder(name string, dtype DType, size int) ColumnBuilder {
 		msg:     msg,
 		values:  make(map[int]interface{}),
 		deleted: make(map[int]bool),
+		nulls:   make(map[int]bool),
 	}
 }
 
@@ -60,6 +63,7 @@ type sliceColumBuilder struct {
 	msg     *pb.Column
 	values  map[int]interface{}
 	deleted map[int]bool
+	nulls   map[int]bool
 	index   int // next index for append. TODO: Find a better name
 }
 
@@ -92,12 +96,23 @@ func (b *sliceColumBuilder) Set(index int, value interface{}) error {
 		err = b.setTime(index, value)
 	case pb.DType_BOOLEAN:
 		err = b.setBool(index, value)
+	case pb.DType_INT32:
+		err = b.setInt32(index, value)
+	case pb.DType_FLOAT32:
+		err = b.setFloat32(index, value)
+	case pb.DType_BYTES:
+		err = b.setBytes(index, value)
+	case pb.DType_DECIMAL:
+		err = b.setDecimal(index, value)
+	case pb.DType_LIST:
+		err = b.setList(index, value)
 	default:
 		return fmt.Errorf("unknown dtype - %s", b.msg.Dtype)
 	}
 
 	if err == nil {
 		delete(b.deleted, index) // Undelete
+		delete(b.nulls, index)
 		if index >= b.index {
 			b.index = index + 1
 		}
@@ -105,12 +120,27 @@ func (b *sliceColumBuilder) Set(index int, value interface{}) error {
 	return err
 }
 
+func (b *sliceColumBuilder) SetNull(index int) error {
+	if index < 0 {
+		return fmt.Errorf("negative index - %d", index)
+	}
+
+	delete(b.values, index)
+	delete(b.deleted, index)
+	b.nulls[index] = true
+	if index >= b.index {
+		b.index = index + 1
+	}
+	return nil
+}
+
 func (b *sliceColumBuilder) Delete(index int) error {
 	if index < 0 || index >= b.index {
 		return fmt.Errorf("index out of bounds: [0:%d]", b.index-1)
 	}
 	b.deleted[index] = true
 	delete(b.values, index)
+	delete(b.nulls, index)
 	return nil
 }
 
@@ -186,6 +216,95 @@ func (b *sliceColumBuilder) setBool(index int, value interface{}) error {
 	return nil
 }
 
+func (b *sliceColumBuilder) setInt32(index int, value interface{}) error {
+	ival, ok := pb.AsInt64(value)
+	if !ok {
+		return b.typeError(value)
+	}
+
+	if ival < math.MinInt32 || ival > math.MaxInt32 {
+		return fmt.Errorf("%d out of int32 range", ival)
+	}
+
+	b.values[index] = ival
+	return nil
+}
+
+func (b *sliceColumBuilder) setFloat32(index int, value interface{}) error {
+	switch value.(type) {
+	case float32:
+		b.values[index] = value.(float32)
+		return nil
+	case float64:
+		b.values[index] = float32(value.(float64))
+		return nil
+	}
+
+	return b.typeError(value)
+}
+
+func (b *sliceColumBuilder) setBytes(index int, value interface{}) error {
+	data, ok := value.([]byte)
+	if !ok {
+		return b.typeError(value)
+	}
+
+	b.values[index] = data
+	return nil
+}
+
+// setDecimal sets a decimal value, the column scale is the biggest scale of
+// its values so all values must fit in it
+func (b *sliceColumBuilder) setDecimal(index int, value interface{}) error {
+	d, ok := value.(Decimal)
+	if !ok {
+		ival, ok := pb.AsInt64(value)
+		if !ok {
+			return b.typeError(value)
+		}
+		d = Decimal{Value: ival}
+	}
+
+	scale := b.msg.Scale
+	if d.Scale > scale {
+		scale = d.Scale
+		for _, v := range b.values {
+			if _, err := v.(Decimal).Rescale(scale); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := d.Rescale(scale); err != nil {
+		return err
+	}
+
+	b.msg.Scale = scale
+	b.values[index] = d
+	return nil
+}
+
+// setList sets a list value, the list dtype is set by the first list with a
+// known dtype
+func (b *sliceColumBuilder) setList(index int, value interface{}) error {
+	list, err := listColumn(value, b.msg.ListDtype)
+	if err != nil {
+		return err
+	}
+
+	dtype := list.msg.Dtype
+	switch {
+	case dtype == pb.DType_NULL:
+	case b.msg.ListDtype == pb.DType_NONE || b.msg.ListDtype == pb.DType_NULL:
+		b.msg.ListDtype = dtype
+	case dtype != b.msg.ListDtype:
+		return fmt.Errorf("%s list in %s list column", dtype, b.msg.ListDtype)
+	}
+
+	b.values[index] = list
+	return nil
+}
+
 // TODO: Return error
 func (b *sliceColumBuilder) Finish() Column {
 	size := b.index - len(b.deleted)
@@ -233,6 +352,42 @@ func (b *sliceColumBuilder) Finish() Column {
 			v, _ := value.(bool)
 			b.msg.Bools[i] = v
 		}
+	case pb.DType_INT32:
+		b.msg.Ints = make([]int64, size)
+		set = func(i int, value interface{}) {
+			v, _ := value.(int64)
+			b.msg.Ints[i] = v
+		}
+	case pb.DType_FLOAT32:
+		b.msg.Floats32 = make([]float32, size)
+		set = func(i int, value interface{}) {
+			v, ok := value.(float32)
+			if !ok {
+				v = float32(math.NaN())
+			}
+			b.msg.Floats32[i] = v
+		}
+	case pb.DType_BYTES:
+		b.msg.Blobs = make([][]byte, size)
+		set = func(i int, value interface{}) {
+			v, _ := value.([]byte)
+			b.msg.Blobs[i] = v
+		}
+	case pb.DType_DECIMAL:
+		b.msg.Ints = make([]int64, size)
+		set = func(i int, value interface{}) {
+			v, _ := value.(Decimal)
+			v, _ = v.Rescale(b.msg.Scale) // Checked in setDecimal
+			b.msg.Ints[i] = v.Value
+		}
+	case pb.DType_LIST:
+		b.msg.Lists = make([]*pb.Column, size)
+		set = func(i int, value interface{}) {
+			list, _ := value.(*colImpl)
+			b.msg.Lists[i] = listElement(list, b.msg.ListDtype)
+		}
+	default:
+		set = func(int, interface{}) {}
 	}
 
 	d := 0
@@ -242,6 +397,9 @@ func (b *sliceColumBuilder) Finish() Column {
 		}
 		v := b.values[i+d]
 		set(i, v)
+		if b.nulls[i+d] {
+			b.msg.Nulls = setBitmap(b.msg.Nulls, i)
+		}
 	}
 
 	return &colImpl{msg: b.msg}
@@ -267,6 +425,12 @@ func NewLabelColumnBuilder(name string, dtype DType, size int) ColumnBuilder {
 		msg.Times = make([]int64, 1)
 	case BoolType:
 		msg.Bools = make([]bool, 1)
+	case Int32Type, DecimalType:
+		msg.Ints = make([]int64, 1)
+	case Float32Type:
+		msg.Floats32 = make([]float32, 1)
+	case BytesType:
+		msg.Blobs = make([][]byte, 1)
 	}
 
 	return &labelColumBuilder{
@@ -312,6 +476,14 @@ func (b *labelColumBuilder) Set(index int, value interface{}) error {
 		err = b.setTime(index, value)
 	case pb.DType_BOOLEAN:
 		err = b.setBool(index, value)
+	case pb.DType_INT32:
+		err = b.setInt(index, value)
+	case pb.DType_FLOAT32:
+		err = b.setFloat32(index, value)
+	case pb.DType_BYTES:
+		err = b.setBytes(index, value)
+	case pb.DType_DECIMAL:
+		err = b.setDecimal(index, value)
 	default:
 		return fmt.Errorf("unknown dtype - %s", b.msg.Dtype)
 	}
@@ -326,6 +498,11 @@ func (b *labelColumBuilder) Set(index int, value interface{}) error {
 	return err
 }
 
+// SetNull returns an error, label columns have a single value
+func (b *labelColumBuilder) SetNull(index int) error {
+	return fmt.Errorf("label column %q can't have null values", b.msg.Name)
+}
+
 func (b *labelColumBuilder) Delete(index int) error {
 	b.deleted[index] = true
 	return nil
@@ -443,6 +620,66 @@ func (b *labelColumBuilder) setTime(index int, value interface{}) error {
 
 }
 
+func (b *labelColumBuilder) setFloat32(index int, value interface{}) error {
+	var fval float32
+	switch value.(type) {
+	case float32:
+		fval = value.(float32)
+	case float64:
+		fval = float32(value.(float64))
+	default:
+		return b.typeError(value)
+	}
+
+	if b.empty {
+		b.msg.Floats32[0] = fval
+		b.empty = false
+	} else {
+		if b.msg.Floats32[0] != fval {
+			return b.valueError(b.msg.Floats32[0], fval)
+		}
+	}
+
+	return nil
+}
+
+func (b *labelColumBuilder) setBytes(index int, value interface{}) error {
+	data, ok := value.([]byte)
+	if !ok {
+		return b.typeError(value)
+	}
+
+	if b.empty {
+		b.msg.Blobs[0] = data
+		b.empty = false
+	} else {
+		if !bytes.Equal(b.msg.Blobs[0], data) {
+			return b.valueError(b.msg.Blobs[0], data)
+		}
+	}
+
+	return nil
+}
+
+func (b *labelColumBuilder) setDecimal(index int, value interface{}) error {
+	d, ok := value.(Decimal)
+	if !ok {
+		return b.typeError(value)
+	}
+
+	if b.empty {
+		b.msg.Ints[0], b.msg.Scale = d.Value, d.Scale
+		b.empty = false
+	} else {
+		current := Decimal{Value: b.msg.Ints[0], Scale: b.msg.Scale}
+		if current.Cmp(d) != 0 {
+			return b.valueError(current, d)
+		}
+	}
+
+	return nil
+}
+
 func (b *labelColumBuilder) typeError(value interface{}) error {
 	return fmt.Errorf("unsupported type for %s label column - %T", b.msg.Dtype, value)
 }
@@ -488,6 +725,31 @@ func valueAt(msg *pb.Column, index int) (interface{}, error) {
 			return nil, nil
 		}
 		return msg.Bools[index], nil
+	case pb.DType_INT32:
+		if len(msg.Ints) < index+1 {
+			return nil, nil
+		}
+		return int32(msg.Ints[index]), nil
+	case pb.DType_FLOAT32:
+		if len(msg.Floats32) < index+1 {
+			return nil, nil
+		}
+		return msg.Floats32[index], nil
+	case pb.DType_BYTES:
+		if len(msg.Blobs) < index+1 {
+			return nil, nil
+		}
+		return msg.Blobs[index], nil
+	case pb.DType_DECIMAL:
+		if len(msg.Ints) < index+1 {
+			return nil, nil
+		}
+		return Decimal{Value: msg.Ints[index], Scale: msg.Scale}, nil
+	case pb.DType_LIST:
+		if len(msg.Lists) < index+1 {
+			return nil, nil
+		}
+		return &colImpl{msg: msg.Lists[index]}, nil
 	}
 
 	return nil, nil
@@ -166,3 +166,41 @@ func TestBuilderAppend(t *testing.T) {
 		t.Fatalf("bad size: %d != %d", col.Len(), size)
 	}
 }
+
+func TestBuilderSetNull(t *testing.T) {
+	b := NewSliceColumnBuilder("a", StringType, 1)
+	for i := 0; i < 5; i++ {
+		if err := b.Append("v"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, i := range []int{1, 3, 6} {
+		if err := b.SetNull(i); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := b.Set(3, "x"); err != nil { // un-null
+		t.Fatal(err)
+	}
+
+	if err := b.Delete(0); err != nil {
+		t.Fatal(err)
+	}
+
+	col := b.Finish()
+	if col.Len() != 6 {
+		t.Fatalf("bad size: %d != 6", col.Len())
+	}
+
+	for i, expected := range []bool{true, false, false, false, false, true} {
+		if col.IsNull(i) != expected {
+			t.Fatalf("%d: null mismatch", i)
+		}
+	}
+
+	if err := NewLabelColumnBuilder("l", IntType, 1).SetNull(0); err == nil {
+		t.Fatal("no error on label column null")
+	}
+}
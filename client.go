@@ -41,6 +41,12 @@ type Client interface {
 	Delete(request *pb.DeleteRequest) error
 	// Exec executes a command on the backend
 	Exec(request *pb.ExecRequest) (Frame, error)
+	// ListTables lists the tables in a directory
+	ListTables(request *pb.ListTablesRequest) ([]string, error)
+	// DescribeTable returns a table schema
+	DescribeTable(request *pb.DescribeTableRequest) (*pb.DescribeTableResponse, error)
+	// Capabilities returns the requests backends support, all backends if request.Backend is empty
+	Capabilities(request *pb.CapabilitiesRequest) ([]*pb.Capabilities, error)
 }
 
 // SessionFromEnv return a session from V3IO_SESSION environment variable (JSON encoded)
@@ -21,6 +21,7 @@ such restriction.
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io/ioutil"
@@ -36,6 +37,7 @@ import (
 	"github.com/v3io/frames/backends/utils"
 	"github.com/v3io/frames/grpc"
 	framesHttp "github.com/v3io/frames/http"
+	"github.com/v3io/frames/tracing"
 )
 
 var (
@@ -80,6 +82,19 @@ func main() {
 		log.Fatalf("error: can't create logger - %s", err)
 	}
 
+	if cfg.Tracing != nil {
+		shutdown, err := tracing.Setup(cfg.Tracing)
+		if err != nil {
+			log.Fatalf("error: can't set up tracing - %s", err)
+		}
+		// Export the remaining spans when the servers stop
+		defer func() {
+			if err := shutdown(context.Background()); err != nil {
+				framesLogger.WarnWith("can't export spans", "error", err)
+			}
+		}()
+	}
+
 	var historyServer *utils.HistoryServer
 	if !cfg.DisableHistory {
 		historyServer, err = utils.NewHistoryServer(framesLogger, cfg)
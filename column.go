@@ -21,8 +21,15 @@ such restriction.
 package frames
 
 import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"math"
+	"math/bits"
+	"reflect"
 	"strconv"
+	"strings"
 	"time"
 	"unsafe"
 
@@ -41,16 +48,23 @@ type colImpl struct {
 }
 
 func (c *colImpl) Len() int {
-	if c.msg.Kind == pb.Column_LABEL {
+	// Null slice columns (e.g. lists of nulls) have no values
+	if c.msg.Kind == pb.Column_LABEL || c.msg.Dtype == pb.DType_NULL {
 		return int(c.msg.Size)
 	}
 
 	// Slice column
 	switch c.msg.Dtype {
-	case pb.DType_INTEGER:
+	case pb.DType_INTEGER, pb.DType_INT32, pb.DType_DECIMAL:
 		return len(c.msg.Ints)
 	case pb.DType_FLOAT:
 		return len(c.msg.Floats)
+	case pb.DType_FLOAT32:
+		return len(c.msg.Floats32)
+	case pb.DType_BYTES:
+		return len(c.msg.Blobs)
+	case pb.DType_LIST:
+		return len(c.msg.Lists)
 	case pb.DType_STRING:
 		return len(c.msg.Strings)
 	case pb.DType_TIME:
@@ -72,7 +86,7 @@ func (c *colImpl) DType() DType {
 }
 
 func (c *colImpl) Ints() ([]int64, error) {
-	if err := c.checkDType(pb.DType_INTEGER); err != nil {
+	if err := c.checkDType(pb.DType_INTEGER, pb.DType_INT32); err != nil {
 		return nil, err
 	}
 
@@ -90,7 +104,11 @@ func (c *colImpl) Ints() ([]int64, error) {
 }
 
 func (c *colImpl) IntAt(i int) (int64, error) {
-	if err := c.validateAt(pb.DType_INTEGER, i); err != nil {
+	if err := c.checkDType(pb.DType_INTEGER, pb.DType_INT32); err != nil {
+		return 0, err
+	}
+
+	if err := c.checkInbounds(i); err != nil {
 		return 0, err
 	}
 
@@ -101,6 +119,14 @@ func (c *colImpl) IntAt(i int) (int64, error) {
 }
 
 func (c *colImpl) Floats() ([]float64, error) {
+	if c.msg.Dtype == pb.DType_FLOAT32 {
+		data := make([]float64, c.Len())
+		for i := range data {
+			data[i] = float64(c.msg.Floats32[c.index(i)])
+		}
+		return data, nil
+	}
+
 	if err := c.checkDType(pb.DType_FLOAT); err != nil {
 		return nil, err
 	}
@@ -119,6 +145,13 @@ func (c *colImpl) Floats() ([]float64, error) {
 }
 
 func (c *colImpl) FloatAt(i int) (float64, error) {
+	if c.msg.Dtype == pb.DType_FLOAT32 {
+		if err := c.checkInbounds(i); err != nil {
+			return 0.0, err
+		}
+		return float64(c.msg.Floats32[c.index(i)]), nil
+	}
+
 	if err := c.validateAt(pb.DType_FLOAT, i); err != nil {
 		return 0.0, err
 	}
@@ -129,6 +162,98 @@ func (c *colImpl) FloatAt(i int) (float64, error) {
 	return c.msg.Floats[i], nil
 }
 
+func (c *colImpl) Int32s() ([]int32, error) {
+	if err := c.checkDType(pb.DType_INT32); err != nil {
+		return nil, err
+	}
+
+	data := make([]int32, c.Len())
+	for i := range data {
+		data[i] = int32(c.msg.Ints[c.index(i)])
+	}
+	return data, nil
+}
+
+func (c *colImpl) Float32s() ([]float32, error) {
+	if err := c.checkDType(pb.DType_FLOAT32); err != nil {
+		return nil, err
+	}
+
+	if c.msg.Kind == pb.Column_SLICE {
+		return c.msg.Floats32, nil
+	}
+
+	data := make([]float32, c.Len())
+	for i := range data {
+		data[i] = c.msg.Floats32[0]
+	}
+	return data, nil
+}
+
+func (c *colImpl) Bytes() ([][]byte, error) {
+	if err := c.checkDType(pb.DType_BYTES); err != nil {
+		return nil, err
+	}
+
+	if c.msg.Kind == pb.Column_SLICE {
+		return c.msg.Blobs, nil
+	}
+
+	data := make([][]byte, c.Len())
+	for i := range data {
+		data[i] = c.msg.Blobs[0]
+	}
+	return data, nil
+}
+
+func (c *colImpl) BytesAt(i int) ([]byte, error) {
+	if err := c.validateAt(pb.DType_BYTES, i); err != nil {
+		return nil, err
+	}
+
+	return c.msg.Blobs[c.index(i)], nil
+}
+
+func (c *colImpl) Decimals() ([]Decimal, error) {
+	if err := c.checkDType(pb.DType_DECIMAL); err != nil {
+		return nil, err
+	}
+
+	data := make([]Decimal, c.Len())
+	for i := range data {
+		data[i] = Decimal{Value: c.msg.Ints[c.index(i)], Scale: c.msg.Scale}
+	}
+	return data, nil
+}
+
+func (c *colImpl) DecimalAt(i int) (Decimal, error) {
+	if err := c.validateAt(pb.DType_DECIMAL, i); err != nil {
+		return Decimal{}, err
+	}
+
+	return Decimal{Value: c.msg.Ints[c.index(i)], Scale: c.msg.Scale}, nil
+}
+
+func (c *colImpl) Lists() ([]Column, error) {
+	if err := c.checkDType(pb.DType_LIST); err != nil {
+		return nil, err
+	}
+
+	data := make([]Column, c.Len())
+	for i := range data {
+		data[i] = &colImpl{msg: c.msg.Lists[c.index(i)]}
+	}
+	return data, nil
+}
+
+func (c *colImpl) ListAt(i int) (Column, error) {
+	if err := c.validateAt(pb.DType_LIST, i); err != nil {
+		return nil, err
+	}
+
+	return &colImpl{msg: c.msg.Lists[c.index(i)]}, nil
+}
+
 func (c *colImpl) Strings() []string {
 	if c.msg.Dtype == pb.DType_STRING && c.msg.Kind == pb.Column_SLICE {
 		return c.msg.Strings
@@ -149,7 +274,7 @@ func (c *colImpl) StringAt(i int) (string, error) {
 
 	dtype := c.msg.Dtype
 	switch dtype {
-	case pb.DType_INTEGER:
+	case pb.DType_INTEGER, pb.DType_INT32:
 		val, err := c.IntAt(i)
 		if err != nil {
 			return "", err
@@ -162,6 +287,32 @@ func (c *colImpl) StringAt(i int) (string, error) {
 			return "", err
 		}
 		return strconv.FormatFloat(val, 'f', -1, 64), nil
+	case pb.DType_FLOAT32:
+		return strconv.FormatFloat(float64(c.msg.Floats32[c.index(i)]), 'f', -1, 32), nil
+	case pb.DType_BYTES:
+		return base64.StdEncoding.EncodeToString(c.msg.Blobs[c.index(i)]), nil
+	case pb.DType_DECIMAL:
+		val, err := c.DecimalAt(i)
+		if err != nil {
+			return "", err
+		}
+		return val.String(), nil
+	case pb.DType_LIST:
+		val, err := c.ListAt(i)
+		if err != nil {
+			return "", err
+		}
+
+		values, err := ListValues(val)
+		if err != nil {
+			return "", err
+		}
+
+		data, err := json.Marshal(values)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
 	case pb.DType_STRING:
 		if c.msg.Kind == pb.Column_LABEL {
 			i = 0
@@ -233,7 +384,17 @@ func (c *colImpl) Bools() ([]bool, error) {
 		return nil, err
 	}
 
-	return c.msg.Bools, nil
+	var data []bool
+	if c.msg.Kind == pb.Column_SLICE {
+		data = c.msg.Bools
+	} else {
+		data = make([]bool, c.msg.Size)
+		for i := int64(0); i < c.msg.Size; i++ {
+			data[i] = c.msg.Bools[0]
+		}
+	}
+
+	return data, nil
 }
 
 func (c *colImpl) BoolAt(i int) (bool, error) {
@@ -263,17 +424,19 @@ func (c *colImpl) Slice(start int, end int) (Column, error) {
 	}
 
 	msg := &pb.Column{
-		Kind:  c.msg.Kind,
-		Dtype: c.msg.Dtype,
-		Name:  c.msg.Name,
+		Kind:      c.msg.Kind,
+		Dtype:     c.msg.Dtype,
+		Name:      c.msg.Name,
+		Scale:     c.msg.Scale,
+		ListDtype: c.msg.ListDtype,
 	}
 
-	if c.msg.Kind == pb.Column_LABEL {
+	if c.msg.Kind == pb.Column_LABEL || c.msg.Dtype == pb.DType_NULL {
 		msg.Size = int64(end - start)
 	}
 
 	switch c.msg.Dtype {
-	case pb.DType_INTEGER:
+	case pb.DType_INTEGER, pb.DType_INT32, pb.DType_DECIMAL:
 		data := c.msg.Ints
 		if c.msg.Kind == pb.Column_SLICE {
 			data = data[start:end]
@@ -303,6 +466,28 @@ func (c *colImpl) Slice(start int, end int) (Column, error) {
 			data = data[start:end]
 		}
 		msg.Bools = data
+	case pb.DType_FLOAT32:
+		data := c.msg.Floats32
+		if c.msg.Kind == pb.Column_SLICE {
+			data = data[start:end]
+		}
+		msg.Floats32 = data
+	case pb.DType_BYTES:
+		data := c.msg.Blobs
+		if c.msg.Kind == pb.Column_SLICE {
+			data = data[start:end]
+		}
+		msg.Blobs = data
+	case pb.DType_LIST:
+		data := c.msg.Lists
+		if c.msg.Kind == pb.Column_SLICE {
+			data = data[start:end]
+		}
+		msg.Lists = data
+	}
+
+	if c.msg.Kind == pb.Column_SLICE {
+		msg.Nulls = sliceBitmap(c.msg.Nulls, start, end)
 	}
 
 	col := &colImpl{
@@ -311,6 +496,33 @@ func (c *colImpl) Slice(start int, end int) (Column, error) {
 	return col, nil
 }
 
+// IsNull returns true if the value at index i is null
+func (c *colImpl) IsNull(i int) bool {
+	if c.msg.Dtype == pb.DType_NULL {
+		return true
+	}
+
+	return bitmapIsSet(c.msg.Nulls, i)
+}
+
+// NullCount returns the number of null values
+func (c *colImpl) NullCount() int {
+	if c.msg.Dtype == pb.DType_NULL {
+		return c.Len()
+	}
+
+	count := 0
+	for _, b := range c.msg.Nulls {
+		count += bits.OnesCount8(b)
+	}
+	return count
+}
+
+func (c *colImpl) setNull(i int) {
+	c.msg.Nulls = setBitmap(c.msg.Nulls, i)
+}
+
+// Append appends a value to the column, nil appends a null
 func (c *colImpl) Append(value interface{}) error {
 	if c.msg.Kind == pb.Column_LABEL {
 		return c.appendLabel(value)
@@ -325,10 +537,15 @@ func (c *colImpl) CopyWithName(newName string) Column {
 	newMsg := *c.msg
 	newColImpl.msg = &newMsg
 	newColImpl.msg.Name = newName
+	if c.msg.Nulls != nil {
+		// Don't share the bitmap, setNull changes it in place
+		newColImpl.msg.Nulls = append([]byte(nil), c.msg.Nulls...)
+	}
 	return newCol
 }
 
-// NewSliceColumn returns a new slice column
+// NewSliceColumn returns a new slice column. Slices of slices (or of Column)
+// are LIST columns, the elements of []Decimal are rescaled to the biggest scale
 func NewSliceColumn(name string, data interface{}) (Column, error) {
 	msg := &pb.Column{
 		Kind: pb.Column_SLICE,
@@ -352,6 +569,35 @@ func NewSliceColumn(name string, data interface{}) (Column, error) {
 	case []string:
 		msg.Dtype = pb.DType_STRING
 		msg.Strings = data.([]string)
+	case []int32:
+		msg.Dtype = pb.DType_INT32
+		msg.Ints = make([]int64, len(data.([]int32)))
+		for i, v := range data.([]int32) {
+			msg.Ints[i] = int64(v)
+		}
+	case []float32:
+		msg.Dtype = pb.DType_FLOAT32
+		msg.Floats32 = data.([]float32)
+	case [][]byte:
+		msg.Dtype = pb.DType_BYTES
+		msg.Blobs = data.([][]byte)
+	case []Decimal:
+		msg.Dtype = pb.DType_DECIMAL
+		decimals := data.([]Decimal)
+		for _, d := range decimals {
+			if d.Scale > msg.Scale {
+				msg.Scale = d.Scale
+			}
+		}
+
+		msg.Ints = make([]int64, len(decimals))
+		for i, d := range decimals {
+			d, err := d.Rescale(msg.Scale)
+			if err != nil {
+				return nil, err
+			}
+			msg.Ints[i] = d.Value
+		}
 	case []time.Time:
 		times := data.([]time.Time)
 		msg.Dtype = pb.DType_TIME
@@ -365,7 +611,24 @@ func NewSliceColumn(name string, data interface{}) (Column, error) {
 			}
 		}
 	default:
-		return nil, fmt.Errorf("unknown data type %T", data)
+		values := reflect.ValueOf(data)
+		if values.Kind() != reflect.Slice {
+			return nil, fmt.Errorf("unknown data type %T", data)
+		}
+
+		elemType := values.Type().Elem()
+		if elemType.Kind() != reflect.Slice && elemType != columnType {
+			return nil, fmt.Errorf("unknown data type %T", data)
+		}
+
+		msg.Dtype = pb.DType_LIST
+		col := &colImpl{msg: msg}
+		for i := 0; i < values.Len(); i++ {
+			if err := col.appendSlice(values.Index(i).Interface()); err != nil {
+				return nil, err
+			}
+		}
+		return col, nil
 	}
 
 	col := &colImpl{
@@ -375,6 +638,36 @@ func NewSliceColumn(name string, data interface{}) (Column, error) {
 	return col, nil
 }
 
+var columnType = reflect.TypeOf((*Column)(nil)).Elem()
+
+// NewSliceColumnWithNulls returns a new slice column where the values at
+// indices with true in nulls are null
+func NewSliceColumnWithNulls(name string, data interface{}, nulls []bool) (Column, error) {
+	col, err := NewSliceColumn(name, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nulls) != col.Len() {
+		return nil, fmt.Errorf("%q column has %d values and %d nulls", name, col.Len(), len(nulls))
+	}
+
+	c := col.(*colImpl)
+	for i, isNull := range nulls {
+		if isNull {
+			c.setNull(i)
+		}
+	}
+	return col, nil
+}
+
+// IsLabelColumn returns true if col is a label column (a single value in all
+// rows)
+func IsLabelColumn(col Column) bool {
+	c, ok := col.(*colImpl)
+	return ok && c.msg.Kind == pb.Column_LABEL
+}
+
 // NewLabelColumn returns a new slabel column
 func NewLabelColumn(name string, value interface{}, size int) (Column, error) {
 	msg := &pb.Column{
@@ -402,6 +695,19 @@ func NewLabelColumn(name string, value interface{}, size int) (Column, error) {
 	case time.Time:
 		msg.Dtype = pb.DType_TIME
 		msg.Times = []int64{value.(time.Time).UnixNano()}
+	case int32:
+		msg.Dtype = pb.DType_INT32
+		msg.Ints = []int64{int64(value.(int32))}
+	case float32:
+		msg.Dtype = pb.DType_FLOAT32
+		msg.Floats32 = []float32{value.(float32)}
+	case []byte:
+		msg.Dtype = pb.DType_BYTES
+		msg.Blobs = [][]byte{value.([]byte)}
+	case Decimal:
+		msg.Dtype = pb.DType_DECIMAL
+		msg.Ints = []int64{value.(Decimal).Value}
+		msg.Scale = value.(Decimal).Scale
 	case nil:
 		msg.Dtype = pb.DType_NULL
 		msg.Bools = []bool{false}
@@ -416,6 +722,10 @@ func NewLabelColumn(name string, value interface{}, size int) (Column, error) {
 }
 
 func (c *colImpl) appendSlice(value interface{}) error {
+	if value == nil {
+		return c.appendNull()
+	}
+
 	switch c.msg.Dtype {
 	case pb.DType_INTEGER:
 		v, ok := pb.AsInt64(value)
@@ -457,11 +767,287 @@ func (c *colImpl) appendSlice(value interface{}) error {
 		}
 		c.msg.Bools = append(c.msg.Bools, v)
 		return nil
+	case pb.DType_INT32:
+		v, ok := pb.AsInt64(value)
+		if !ok {
+			return fmt.Errorf("wrong type for int32 - %T", value)
+		}
+		if v < math.MinInt32 || v > math.MaxInt32 {
+			return fmt.Errorf("%d out of int32 range", v)
+		}
+		c.msg.Ints = append(c.msg.Ints, v)
+		return nil
+	case pb.DType_FLOAT32:
+		switch v := value.(type) {
+		case float32:
+			c.msg.Floats32 = append(c.msg.Floats32, v)
+		case float64:
+			c.msg.Floats32 = append(c.msg.Floats32, float32(v))
+		default:
+			iv, ok := pb.AsInt64(value)
+			if !ok {
+				return fmt.Errorf("wrong type for float32 - %T", value)
+			}
+			c.msg.Floats32 = append(c.msg.Floats32, float32(iv))
+		}
+		return nil
+	case pb.DType_BYTES:
+		v, ok := value.([]byte)
+		if !ok {
+			return fmt.Errorf("wrong type for []byte - %T", value)
+		}
+		c.msg.Blobs = append(c.msg.Blobs, v)
+		return nil
+	case pb.DType_DECIMAL:
+		v, ok := value.(Decimal)
+		if !ok {
+			iv, ok := pb.AsInt64(value)
+			if !ok {
+				return fmt.Errorf("wrong type for decimal - %T", value)
+			}
+			v = Decimal{Value: iv}
+		}
+		return c.appendDecimal(v)
+	case pb.DType_LIST:
+		return c.appendList(value)
 	}
 
 	return fmt.Errorf("unknown dtype - %s", c.msg.Dtype)
 }
 
+// appendDecimal appends d, the column scale grows to the scale of d
+func (c *colImpl) appendDecimal(d Decimal) error {
+	if d.Scale > c.msg.Scale {
+		// Don't change values in place, they might be shared with slices
+		values := make([]int64, len(c.msg.Ints), len(c.msg.Ints)+1)
+		for i, value := range c.msg.Ints {
+			rescaled, err := Decimal{Value: value, Scale: c.msg.Scale}.Rescale(d.Scale)
+			if err != nil {
+				return err
+			}
+			values[i] = rescaled.Value
+		}
+		c.msg.Ints, c.msg.Scale = values, d.Scale
+	}
+
+	d, err := d.Rescale(c.msg.Scale)
+	if err != nil {
+		return err
+	}
+	c.msg.Ints = append(c.msg.Ints, d.Value)
+	return nil
+}
+
+// appendList appends a list, which is a Column, a []interface{} or a typed
+// slice. The list dtype is set by the first list with a known dtype
+func (c *colImpl) appendList(value interface{}) error {
+	elem, err := listColumn(value, c.msg.ListDtype)
+	if err != nil {
+		return err
+	}
+
+	dtype := elem.msg.Dtype
+	switch {
+	case dtype == pb.DType_NULL:
+	case c.msg.ListDtype == pb.DType_NONE || c.msg.ListDtype == pb.DType_NULL:
+		// Lists so far had only nulls
+		for i, list := range c.msg.Lists {
+			c.msg.Lists[i] = listElement(&colImpl{msg: list}, dtype)
+		}
+		c.msg.ListDtype = dtype
+	case dtype != c.msg.ListDtype:
+		return fmt.Errorf("%s list in %s list column", dtype, c.msg.ListDtype)
+	}
+
+	c.msg.Lists = append(c.msg.Lists, listElement(elem, c.msg.ListDtype))
+	return nil
+}
+
+// listElement returns the message of list in a column of dtype lists. nil and
+// NULL lists are converted to lists of dtype nulls if dtype is known
+func listElement(list *colImpl, dtype pb.DType) *pb.Column {
+	size := 0
+	if list != nil {
+		if list.msg.Dtype != pb.DType_NULL {
+			return list.msg
+		}
+		size = list.Len()
+	}
+
+	if dtype != pb.DType_NONE && dtype != pb.DType_NULL {
+		if null, err := nullColumn(size, dtype); err == nil {
+			return null.msg
+		}
+	}
+	return &pb.Column{Kind: pb.Column_SLICE, Dtype: pb.DType_NULL, Size: int64(size)}
+}
+
+// listColumn returns value as a list element column of dtype, dtype is
+// inferred from the first value of []interface{} if it's NONE (or NULL). Lists
+// of nulls with an unknown dtype are NULL columns
+func listColumn(value interface{}, dtype pb.DType) (*colImpl, error) {
+	var col Column
+	switch value.(type) {
+	case Column:
+		col = value.(Column)
+	case []interface{}:
+		values := value.([]interface{})
+		if dtype == pb.DType_NONE || dtype == pb.DType_NULL {
+			for _, v := range values {
+				if v == nil {
+					continue
+				}
+
+				first, err := newColumn("", v)
+				if err != nil {
+					return nil, err
+				}
+				dtype = pb.DType(first.DType())
+				break
+			}
+		}
+
+		if dtype == pb.DType_NONE || dtype == pb.DType_NULL {
+			msg := &pb.Column{Kind: pb.Column_SLICE, Dtype: pb.DType_NULL, Size: int64(len(values))}
+			return &colImpl{msg: msg}, nil
+		}
+
+		list := &colImpl{msg: &pb.Column{Kind: pb.Column_SLICE, Dtype: dtype}}
+		for _, v := range values {
+			if err := list.appendSlice(v); err != nil {
+				return nil, err
+			}
+		}
+		return list, nil
+	default:
+		var err error
+		if col, err = NewSliceColumn("", value); err != nil {
+			return nil, err
+		}
+	}
+
+	list, ok := col.(*colImpl)
+	if !ok {
+		return nil, fmt.Errorf("unsupported list column type - %T", col)
+	}
+	return list, nil
+}
+
+// nullColumn returns a slice column of size nulls
+func nullColumn(size int, dtype pb.DType) (*colImpl, error) {
+	col := &colImpl{msg: &pb.Column{Kind: pb.Column_SLICE, Dtype: dtype}}
+	for i := 0; i < size; i++ {
+		if err := col.appendNull(); err != nil {
+			return nil, err
+		}
+	}
+	return col, nil
+}
+
+// ValueAt returns the value of col at index i, values of LIST columns are
+// columns. It doesn't check for nulls
+func ValueAt(col Column, i int) (interface{}, error) {
+	switch col.DType() {
+	case IntType:
+		return col.IntAt(i)
+	case FloatType:
+		return col.FloatAt(i)
+	case StringType:
+		return col.StringAt(i)
+	case TimeType:
+		return col.TimeAt(i)
+	case BoolType:
+		return col.BoolAt(i)
+	case Int32Type:
+		value, err := col.IntAt(i)
+		return int32(value), err
+	case Float32Type:
+		value, err := col.FloatAt(i)
+		return float32(value), err
+	case BytesType:
+		return col.BytesAt(i)
+	case DecimalType:
+		return col.DecimalAt(i)
+	case ListType:
+		return col.ListAt(i)
+	}
+
+	return nil, fmt.Errorf("%q column has unsupported type - %s", col.Name(), pb.DType(col.DType()))
+}
+
+// DecodeJSONList decodes a JSON array to values for a list column, whole
+// numbers are int64 and other numbers are float64
+func DecodeJSONList(text string) ([]interface{}, error) {
+	dec := json.NewDecoder(strings.NewReader(text))
+	dec.UseNumber()
+
+	var list []interface{}
+	if err := dec.Decode(&list); err != nil {
+		return nil, err
+	}
+
+	for i, value := range list {
+		list[i] = jsonValue(value)
+	}
+	return list, nil
+}
+
+// jsonValue converts json.Number in value to int64 or float64
+func jsonValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case json.Number:
+		if i, err := value.Int64(); err == nil {
+			return i
+		}
+		f, _ := value.Float64()
+		return f
+	case []interface{}:
+		for i, v := range value {
+			value[i] = jsonValue(v)
+		}
+	}
+	return value
+}
+
+// ListValues returns the values of a list column, nulls are nil and nested
+// lists are []interface{}
+func ListValues(col Column) ([]interface{}, error) {
+	values := make([]interface{}, col.Len())
+	for i := range values {
+		if col.IsNull(i) {
+			continue
+		}
+
+		value, err := ValueAt(col, i)
+		if err != nil {
+			return nil, err
+		}
+
+		if list, ok := value.(Column); ok {
+			if value, err = ListValues(list); err != nil {
+				return nil, err
+			}
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// appendNull appends a placeholder value and marks it as null
+func (c *colImpl) appendNull() error {
+	value, err := zeroValue(DType(c.msg.Dtype))
+	if err != nil {
+		return err
+	}
+
+	if err := c.appendSlice(value); err != nil {
+		return err
+	}
+
+	c.setNull(c.Len() - 1)
+	return nil
+}
+
 func (c *colImpl) appendLabel(value interface{}) error {
 	if !c.sameLabelValue(value) {
 		return fmt.Errorf("append - wrong type or value mismatch - %v", value)
@@ -503,6 +1089,30 @@ func (c *colImpl) sameLabelValue(value interface{}) bool {
 			return false
 		}
 		return v == c.msg.Bools[0]
+	case pb.DType_INT32:
+		v, ok := pb.AsInt64(value)
+		if !ok {
+			return false
+		}
+		return v == c.msg.Ints[0]
+	case pb.DType_FLOAT32:
+		v, ok := value.(float32)
+		if !ok {
+			return false
+		}
+		return v == c.msg.Floats32[0]
+	case pb.DType_BYTES:
+		v, ok := value.([]byte)
+		if !ok {
+			return false
+		}
+		return bytes.Equal(v, c.msg.Blobs[0])
+	case pb.DType_DECIMAL:
+		v, ok := value.(Decimal)
+		if !ok {
+			return false
+		}
+		return v.Cmp(Decimal{Value: c.msg.Ints[0], Scale: c.msg.Scale}) == 0
 	}
 
 	return false
@@ -523,12 +1133,22 @@ func (c *colImpl) checkInbounds(i int) error {
 	return fmt.Errorf("index %d out of bounds [0:%d]", i, c.Len())
 }
 
-func (c *colImpl) checkDType(dtype pb.DType) error {
-	if c.msg.Dtype != dtype {
-		return fmt.Errorf("wrong dtype")
+func (c *colImpl) checkDType(dtypes ...pb.DType) error {
+	for _, dtype := range dtypes {
+		if c.msg.Dtype == dtype {
+			return nil
+		}
 	}
 
-	return nil
+	return fmt.Errorf("wrong dtype")
+}
+
+// index returns the index of element i in the value arrays
+func (c *colImpl) index(i int) int {
+	if c.msg.Kind == pb.Column_LABEL {
+		return 0
+	}
+	return i
 }
 
 func intToInt64(arr []int) []int64 {
@@ -546,3 +1166,33 @@ func intToInt64(arr []int) []int64 {
 	}
 	return out
 }
+
+// Null bitmaps are LSB first, a bitmap shorter than the column means the
+// missing elements are not null
+
+func bitmapIsSet(bitmap []byte, i int) bool {
+	if i < 0 || i/8 >= len(bitmap) {
+		return false
+	}
+	return bitmap[i/8]&(1<<uint(i%8)) != 0
+}
+
+func setBitmap(bitmap []byte, i int) []byte {
+	for len(bitmap) <= i/8 {
+		bitmap = append(bitmap, 0)
+	}
+	bitmap[i/8] |= 1 << uint(i%8)
+	return bitmap
+}
+
+// sliceBitmap returns the bitmap of elements [start:end], nil if there are
+// no nulls in range
+func sliceBitmap(bitmap []byte, start int, end int) []byte {
+	var out []byte
+	for i := start; i < end && i/8 < len(bitmap); i++ {
+		if bitmapIsSet(bitmap, i) {
+			out = setBitmap(out, i-start)
+		}
+	}
+	return out
+}
@@ -47,3 +47,228 @@ func TestLabelColTimeAt(t *testing.T) {
 		t.Fatalf("bad time %v != %v", ts1, ts)
 	}
 }
+
+func TestLabelColBools(t *testing.T) {
+	col, err := NewLabelColumn("b", true, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !IsLabelColumn(col) {
+		t.Fatal("not a label column")
+	}
+
+	bools, err := col.Bools()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(bools) != 3 || !bools[2] {
+		t.Fatalf("bad bools - %v", bools)
+	}
+}
+
+func TestColumnNulls(t *testing.T) {
+	col, err := NewSliceColumn("f", []float64{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ca := col.(*colImpl)
+	for i := 0; i < 10; i++ {
+		var value interface{} = float64(i)
+		if i%3 == 0 {
+			value = nil
+		}
+		if err := ca.Append(value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if count := col.NullCount(); count != 4 {
+		t.Fatalf("bad null count: %d != 4", count)
+	}
+
+	// Appended rows start at 2, nulls at 2, 5, 8, 11
+	for i := 0; i < col.Len(); i++ {
+		expected := i >= 2 && (i-2)%3 == 0
+		if col.IsNull(i) != expected {
+			t.Fatalf("%d: null mismatch", i)
+		}
+	}
+
+	slice, err := col.Slice(5, 12)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count := slice.NullCount(); count != 3 {
+		t.Fatalf("bad slice null count: %d != 3", count)
+	}
+
+	for _, i := range []int{0, 3, 6} {
+		if !slice.IsNull(i) {
+			t.Fatalf("slice %d: not null", i)
+		}
+	}
+
+	if slice.IsNull(1) || col.IsNull(100) {
+		t.Fatal("bad null")
+	}
+
+	col, err = NewSliceColumnWithNulls("s", []string{"a", "", "c"}, []bool{false, true, false})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if col.NullCount() != 1 || !col.IsNull(1) || IsLabelColumn(col) {
+		t.Fatal("bad nulls in new column")
+	}
+
+	if _, err := NewSliceColumnWithNulls("s", []string{"a"}, nil); err == nil {
+		t.Fatal("no error for nulls size mismatch")
+	}
+}
+
+func TestColumnExtendedDtypes(t *testing.T) {
+	ints, err := NewSliceColumn("i", []int32{1, -2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ints.DType() != Int32Type || ints.Len() != 3 {
+		t.Fatalf("bad int32 column: %v (%d)", ints.DType(), ints.Len())
+	}
+
+	if i, err := ints.IntAt(1); err != nil || i != -2 {
+		t.Fatalf("bad int32 value: %v (%v)", i, err)
+	}
+
+	if err := ints.(*colImpl).Append(int64(1) << 40); err == nil {
+		t.Fatal("no error on int32 overflow")
+	}
+
+	floats, err := NewSliceColumn("f", []float32{1.5, 2.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f, err := floats.FloatAt(1); err != nil || f != 2.5 {
+		t.Fatalf("bad float32 value: %v (%v)", f, err)
+	}
+
+	blobs, err := NewSliceColumn("b", [][]byte{[]byte("hi"), {0xff}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s, err := blobs.StringAt(1); err != nil || s != "/w==" {
+		t.Fatalf("bad bytes string: %q (%v)", s, err)
+	}
+
+	decimals, err := NewSliceColumn("d", []Decimal{{15, 1}, {-3, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Appending a value with a larger scale rescales the column
+	if err := decimals.(*colImpl).Append(Decimal{Value: 125, Scale: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"1.50", "-3.00", "1.25"}
+	for i, s := range expected {
+		value, err := decimals.StringAt(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != s {
+			t.Fatalf("%d: bad decimal %q != %q", i, value, s)
+		}
+	}
+
+	label, err := NewLabelColumn("l", Decimal{Value: 7, Scale: 1}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if d, err := label.DecimalAt(2); err != nil || d.Cmp(Decimal{7, 1}) != 0 {
+		t.Fatalf("bad label decimal: %v (%v)", d, err)
+	}
+}
+
+func TestListColumn(t *testing.T) {
+	col, err := NewSliceColumn("l", [][]int64{{1, 2}, {}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if col.DType() != ListType || col.Len() != 2 {
+		t.Fatalf("bad list column: %v (%d)", col.DType(), col.Len())
+	}
+
+	ca := col.(*colImpl)
+	for _, value := range []interface{}{[]interface{}{nil, int64(3)}, nil} {
+		if err := ca.Append(value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := ca.Append([]string{"a"}); err == nil {
+		t.Fatal("no error on list dtype mismatch")
+	}
+
+	expected := []string{"[1,2]", "[]", "[null,3]"}
+	for i, s := range expected {
+		value, err := col.StringAt(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != s {
+			t.Fatalf("%d: bad list %q != %q", i, value, s)
+		}
+	}
+
+	if !col.IsNull(3) {
+		t.Fatal("appended nil list is not null")
+	}
+
+	list, err := col.ListAt(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if list.DType() != IntType || list.Len() != 2 || !list.IsNull(0) {
+		t.Fatalf("bad list element: %v (%d)", list.DType(), list.Len())
+	}
+
+	slice, err := col.Slice(2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s, err := slice.StringAt(0); err != nil || s != "[null,3]" {
+		t.Fatalf("bad slice: %q (%v)", s, err)
+	}
+
+	// Element dtype is set by the first list with a value
+	nested, err := NewSliceColumn("n", []Column{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := DecodeJSONList(`[[], [[1.5], null]]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, value := range values {
+		if err := nested.(*colImpl).Append(value); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if s, err := nested.StringAt(1); err != nil || s != "[[1.5],null]" {
+		t.Fatalf("bad nested list: %q (%v)", s, err)
+	}
+}
@@ -35,7 +35,7 @@ type LogConfig struct {
 type Config struct {
 	Log            LogConfig `json:"log"`
 	DefaultLimit   int       `json:"limit,omitempty"`
-	DefaultTimeout int       `json:"timeout,omitempty"`
+	DefaultTimeout int       `json:"timeout,omitempty"` // Exec and write completion deadline in seconds, negative for none
 
 	// default V3IO connection details
 	WebAPIEndpoint string `json:"webApiEndpoint"`
@@ -61,9 +61,104 @@ type Config struct {
 	HistoryFileNum                    int    `json:historyFileNum`
 	DisableHistory                    bool   `json:disableHistory`
 
+	// Memory cap of the right side of a read join, negative for none
+	JoinMaxBytes int64 `json:"joinMaxBytes,omitempty"`
+
 	Backends []*BackendConfig `json:"backends,omitempty"`
 
 	DisableProfiling bool `json:"disableProfiling,omitempty"`
+
+	// TLS of the HTTP and gRPC listeners, plain TCP if nil
+	TLS *TLSConfig `json:"tls,omitempty"`
+	// Authentication of requests, none if nil
+	Auth *AuthConfig `json:"auth,omitempty"`
+	// Authorization policy of requests, everything is allowed if nil
+	Authorization *AuthorizationConfig `json:"authorization,omitempty"`
+	// Export of OpenTelemetry spans, not exported if nil
+	Tracing *TracingConfig `json:"tracing,omitempty"`
+}
+
+// TLSConfig is server TLS configuration, files are reloaded when they change
+type TLSConfig struct {
+	CertFile string `json:"certFile"`
+	KeyFile  string `json:"keyFile"`
+	// CA of client certificates (mutual TLS), the certificate subject is the
+	// caller identity
+	ClientCAFile string `json:"clientCAFile,omitempty"`
+	// Accept clients without a certificate (e.g. with an API key)
+	ClientCertOptional bool `json:"clientCertOptional,omitempty"`
+}
+
+// ClientTLSConfig is client TLS configuration
+type ClientTLSConfig struct {
+	// CA of the server certificate, the system CAs are used if empty
+	CAFile string
+	// Client certificate for mutual TLS
+	CertFile string
+	KeyFile  string
+	// Server name to verify, taken from the address if empty
+	ServerName         string
+	InsecureSkipVerify bool
+}
+
+// TracingConfig is the export of OpenTelemetry spans with OTLP over HTTP
+type TracingConfig struct {
+	// Collector address (host:port)
+	Endpoint string `json:"endpoint"`
+	// Default is /v1/traces
+	URLPath string `json:"urlPath,omitempty"`
+	// Use HTTP instead of HTTPS
+	Insecure bool              `json:"insecure,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	// Default is framesd
+	ServiceName string `json:"serviceName,omitempty"`
+	// Fraction of traces sampled when there's no sampled parent span, default
+	// is 1 (all)
+	SampleRatio float64 `json:"sampleRatio,omitempty"`
+}
+
+// AuthConfig is authentication configuration, requests must pass one of the
+// configured providers
+type AuthConfig struct {
+	// YAML (or JSON) file of API keys and their users
+	APIKeysFile string     `json:"apiKeysFile,omitempty"`
+	JWT         *JWTConfig `json:"jwt,omitempty"`
+}
+
+// JWTConfig is configuration of bearer JWT authentication
+type JWTConfig struct {
+	// JWKS file with the keys that verify tokens, "oct" keys for HS256 and
+	// "RSA" keys for RS256
+	JWKSFile string `json:"jwksFile"`
+	// Required "iss" and "aud" claims, not checked if empty
+	Issuer   string `json:"issuer,omitempty"`
+	Audience string `json:"audience,omitempty"`
+	// Claims of the user name (default "sub") and groups (default "groups")
+	UserClaim   string `json:"userClaim,omitempty"`
+	GroupsClaim string `json:"groupsClaim,omitempty"`
+}
+
+// AuthorizationConfig is an authorization policy. A request is denied if a
+// "deny" rule matches it, otherwise it's allowed if an "allow" rule matches it
+type AuthorizationConfig struct {
+	// Effect when no rule matches, "deny" (default) or "allow"
+	DefaultEffect string               `json:"defaultEffect,omitempty"`
+	Rules         []*AuthorizationRule `json:"rules,omitempty"`
+}
+
+// AuthorizationRule matches requests, an empty list matches everything
+type AuthorizationRule struct {
+	Effect     string   `json:"effect"` // "allow" or "deny"
+	Users      []string `json:"users,omitempty"`
+	Groups     []string `json:"groups,omitempty"`
+	Backends   []string `json:"backends,omitempty"` // Backend names
+	Containers []string `json:"containers,omitempty"`
+	// Table globs, "*" doesn't match "/" and "**" matches anything
+	Tables []string `json:"tables,omitempty"`
+	// "read", "write", "create", "delete" or "exec"
+	Actions []string `json:"actions,omitempty"`
+	// Exec commands, a rule with commands matches only exec requests
+	Commands []string `json:"commands,omitempty"`
 }
 
 // InitDefaults initializes the defaults for configuration
@@ -72,6 +167,10 @@ func (c *Config) InitDefaults() error {
 		c.DefaultTimeout = 300
 	}
 
+	if c.JoinMaxBytes == 0 {
+		c.JoinMaxBytes = 256 * 1024 * 1024
+	}
+
 	for _, backendConfig := range c.Backends {
 		initBackendDefaults(backendConfig, c)
 	}
@@ -147,8 +246,17 @@ type BackendConfig struct {
 	// backend specific options
 	Options map[string]interface{} `json:"options"`
 
-	// CSV backend
+	// CSV and Parquet backends
 	RootDir string `json:"rootdir,omitempty"`
+
+	// Use a local fake of v3io instead of the web API, kept under this
+	// directory (or in memory if ":memory:")
+	FakeV3ioRoot string `json:"fakeV3ioRoot,omitempty"`
+
+	// Plugin backend, the address of a running plugin or a command (and
+	// arguments) that starts one
+	PluginAddress string   `json:"pluginAddress,omitempty"`
+	PluginCommand []string `json:"pluginCommand,omitempty"`
 }
 
 // NewSession will create a new session. It will populate missing values from
@@ -223,7 +331,7 @@ func initBackendDefaults(cfg *BackendConfig, framesConfig *Config) {
 
 	if cfg.V3ioGoWorkers == 0 {
 		switch cfg.Name {
-		case "csv", "stream":
+		case "csv", "parquet", "stream", "memory":
 			cfg.V3ioGoWorkers = 256
 		default:
 			cfg.V3ioGoWorkers = 1024
@@ -0,0 +1,173 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package frames
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MaxDecimalScale is the maximal number of digits after the decimal point
+const MaxDecimalScale = 18
+
+// Decimal is a fixed point number, its value is Value / 10^Scale
+type Decimal struct {
+	Value int64
+	Scale int32
+}
+
+// ParseDecimal parses a decimal number (e.g. "-12.30"), the scale is the
+// number of digits after the decimal point
+func ParseDecimal(s string) (Decimal, error) {
+	text := strings.TrimSpace(s)
+	digits, fraction := text, ""
+	if i := strings.IndexByte(text, '.'); i >= 0 {
+		digits, fraction = text[:i], text[i+1:]
+	}
+
+	if len(fraction) > MaxDecimalScale || strings.ContainsAny(fraction, "+-") || !strings.ContainsAny(text, "0123456789") {
+		return Decimal{}, fmt.Errorf("bad decimal - %q", s)
+	}
+
+	if digits == "" || digits == "-" || digits == "+" {
+		digits += "0"
+	}
+
+	value, err := strconv.ParseInt(digits+fraction, 10, 64)
+	if err != nil {
+		return Decimal{}, fmt.Errorf("bad decimal - %q", s)
+	}
+
+	return Decimal{Value: value, Scale: int32(len(fraction))}, nil
+}
+
+// String returns the decimal with Scale digits after the decimal point
+func (d Decimal) String() string {
+	if d.Scale <= 0 {
+		return strconv.FormatInt(d.Value, 10)
+	}
+
+	sign, digits := "", strconv.FormatInt(d.Value, 10)
+	if d.Value < 0 {
+		sign, digits = "-", digits[1:]
+	}
+
+	scale := int(d.Scale)
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+
+	point := len(digits) - scale
+	return sign + digits[:point] + "." + digits[point:]
+}
+
+// Float64 returns the (possibly rounded) float value of the decimal
+func (d Decimal) Float64() float64 {
+	return float64(d.Value) / math.Pow10(int(d.Scale))
+}
+
+// Rescale returns the decimal with scale digits after the decimal point, it
+// fails if the value overflows or if digits are lost
+func (d Decimal) Rescale(scale int32) (Decimal, error) {
+	if scale < 0 || scale > MaxDecimalScale {
+		return Decimal{}, fmt.Errorf("decimal scale %d out of range [0:%d]", scale, MaxDecimalScale)
+	}
+
+	value := d.Value
+	for s := d.Scale; s < scale; s++ {
+		if value > math.MaxInt64/10 || value < math.MinInt64/10 {
+			return Decimal{}, fmt.Errorf("decimal %s overflows with scale %d", d, scale)
+		}
+		value *= 10
+	}
+
+	for s := d.Scale; s > scale; s-- {
+		if value%10 != 0 {
+			return Decimal{}, fmt.Errorf("decimal %s loses digits with scale %d", d, scale)
+		}
+		value /= 10
+	}
+
+	return Decimal{Value: value, Scale: scale}, nil
+}
+
+// Cmp compares two decimals, it returns -1 if d < other, 0 if they're equal
+// and 1 if d > other
+func (d Decimal) Cmp(other Decimal) int {
+	scale := d.Scale
+	if other.Scale > scale {
+		scale = other.Scale
+	}
+
+	a, errA := d.Rescale(scale)
+	b, errB := other.Rescale(scale)
+	if errA == nil && errB == nil {
+		switch {
+		case a.Value < b.Value:
+			return -1
+		case a.Value > b.Value:
+			return 1
+		}
+		return 0
+	}
+
+	// Values too big to rescale differ enough for floats
+	switch x, y := d.Float64(), other.Float64(); {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+// Normalize returns the decimal with the smallest scale that keeps its value
+// (e.g. 1.50 -> 1.5), equal decimals have the same normalized form
+func (d Decimal) Normalize() Decimal {
+	for d.Scale > 0 && d.Value%10 == 0 {
+		d.Value /= 10
+		d.Scale--
+	}
+	return d
+}
+
+// MarshalJSON encodes the decimal as a JSON string, so it's not read as a
+// float
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON decodes a decimal from a JSON string or number
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	text := string(data)
+	if len(text) > 0 && text[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	*d, err = ParseDecimal(text)
+	return err
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package frames
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseDecimal(t *testing.T) {
+	cases := []struct {
+		text     string
+		expected Decimal
+		str      string
+	}{
+		{"12.34", Decimal{1234, 2}, "12.34"},
+		{"-0.05", Decimal{-5, 2}, "-0.05"},
+		{"7", Decimal{7, 0}, "7"},
+		{"1.50", Decimal{150, 2}, "1.50"},
+	}
+
+	for _, tc := range cases {
+		d, err := ParseDecimal(tc.text)
+		if err != nil {
+			t.Fatalf("%s: %s", tc.text, err)
+		}
+
+		if d != tc.expected {
+			t.Fatalf("%s: %+v != %+v", tc.text, d, tc.expected)
+		}
+
+		if s := d.String(); s != tc.str {
+			t.Fatalf("%s: bad string - %q", tc.text, s)
+		}
+	}
+
+	for _, text := range []string{"", "1.2.3", "abc", "1.1234567890123456789"} {
+		if _, err := ParseDecimal(text); err == nil {
+			t.Fatalf("%q: no error", text)
+		}
+	}
+}
+
+func TestDecimalRescale(t *testing.T) {
+	d := Decimal{Value: 150, Scale: 2}
+	up, err := d.Rescale(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if up != (Decimal{15000, 4}) {
+		t.Fatalf("bad rescale: %+v", up)
+	}
+
+	down, err := d.Rescale(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if down != (Decimal{15, 1}) {
+		t.Fatalf("bad rescale: %+v", down)
+	}
+
+	if _, err := (Decimal{Value: 155, Scale: 2}).Rescale(1); err == nil {
+		t.Fatal("no error on lost digits")
+	}
+
+	if _, err := (Decimal{Value: 1 << 60, Scale: 0}).Rescale(5); err == nil {
+		t.Fatal("no error on overflow")
+	}
+
+	if n := (Decimal{Value: 1500, Scale: 3}).Normalize(); n != (Decimal{15, 1}) {
+		t.Fatalf("bad normalize: %+v", n)
+	}
+}
+
+func TestDecimalCmp(t *testing.T) {
+	cases := []struct {
+		a, b     Decimal
+		expected int
+	}{
+		{Decimal{150, 2}, Decimal{15, 1}, 0},
+		{Decimal{1, 0}, Decimal{101, 2}, -1},
+		{Decimal{-1, 1}, Decimal{-2, 1}, 1},
+		{Decimal{1 << 60, 0}, Decimal{1, 5}, 1},
+	}
+
+	for _, tc := range cases {
+		if cmp := tc.a.Cmp(tc.b); cmp != tc.expected {
+			t.Fatalf("%s cmp %s: %d != %d", tc.a, tc.b, cmp, tc.expected)
+		}
+	}
+}
+
+func TestDecimalJSON(t *testing.T) {
+	data, err := json.Marshal(Decimal{Value: 1234, Scale: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(data) != `"12.34"` {
+		t.Fatalf("bad JSON: %s", data)
+	}
+
+	for _, text := range []string{`"12.34"`, `12.34`} {
+		var d Decimal
+		if err := json.Unmarshal([]byte(text), &d); err != nil {
+			t.Fatal(err)
+		}
+		if d != (Decimal{1234, 2}) {
+			t.Fatalf("%s: bad decimal - %+v", text, d)
+		}
+	}
+}
@@ -43,6 +43,26 @@ func (fr *frameImpl) NullValuesMap() []*pb.NullValuesMap {
 	return fr.msg.NullValues
 }
 
+// Marker returns the read continuation marker of the frame
+func (fr *frameImpl) Marker() string {
+	return fr.msg.Marker
+}
+
+// WithMarker returns a copy of frame with a read continuation marker, reads
+// with the marker resume after this frame
+func WithMarker(frame Frame, marker string) (Frame, error) {
+	fr, ok := frame.(*frameImpl)
+	if !ok {
+		return nil, fmt.Errorf("unsupported frame type - %T", frame)
+	}
+
+	newFrame := *fr
+	msg := *fr.msg
+	msg.Marker = marker
+	newFrame.msg = &msg
+	return &newFrame, nil
+}
+
 // NewFrame returns a new Frame
 func NewFrame(columns []Column, indices []Column, labels map[string]interface{}) (Frame, error) {
 	return NewFrameWithNullValues(columns, indices, labels, nil)
@@ -54,6 +74,7 @@ func NewFrameWithNullValues(columns []Column, indices []Column, labels map[strin
 	}
 
 	msg := &pb.Frame{}
+	columns, msg.NullValues = syncNullValues(columns, nullValues)
 
 	var err error
 	msg.Columns, err = cols2PB(columns)
@@ -71,8 +92,6 @@ func NewFrameWithNullValues(columns []Column, indices []Column, labels map[strin
 		return nil, err
 	}
 
-	msg.NullValues = nullValues
-
 	byName := make(map[string]Column)
 	for _, col := range columns {
 		byName[col.Name()] = col
@@ -226,6 +245,10 @@ func (fr *frameImpl) Proto() *pb.Frame {
 }
 
 func (fr *frameImpl) IsNull(index int, colName string) bool {
+	if col, ok := fr.byName[colName]; ok && col.IsNull(index) {
+		return true
+	}
+
 	if len(fr.msg.NullValues) == 0 {
 		return false
 	}
@@ -236,13 +259,18 @@ func (fr *frameImpl) IsNull(index int, colName string) bool {
 
 // NewFrameFromProto return a new frame from protobuf message
 func NewFrameFromProto(msg *pb.Frame) Frame {
-	byName := make(map[string]Column)
 	columns := make([]Column, len(msg.Columns))
 	for i, colMsg := range msg.Columns {
-		col := &colImpl{msg: colMsg}
-		columns[i] = col
-		byName[colMsg.Name] = col
+		columns[i] = &colImpl{msg: colMsg}
 	}
+	columns, msg.NullValues = syncNullValues(columns, msg.NullValues)
+
+	byName := make(map[string]Column)
+	for i, col := range columns {
+		msg.Columns[i] = col.(*colImpl).msg
+		byName[col.Name()] = col
+	}
+
 	indices := make([]Column, len(msg.Indices))
 	for i, colMsg := range msg.Indices {
 		indices[i] = &colImpl{msg: colMsg}
@@ -256,6 +284,66 @@ func NewFrameFromProto(msg *pb.Frame) Frame {
 	}
 }
 
+// syncNullValues keeps the column null bitmaps and the per row null values
+// (used by older clients) in sync. Bitmaps are marked from nullValues, and if
+// nullValues is empty it's built from the bitmaps. Columns are shared between
+// frames, marked columns are copies
+func syncNullValues(columns []Column, nullValues []*pb.NullValuesMap) ([]Column, []*pb.NullValuesMap) {
+	if len(nullValues) > 0 {
+		byName := make(map[string]int)
+		for i, col := range columns {
+			if c, ok := col.(*colImpl); ok && c.msg.Kind == pb.Column_SLICE {
+				byName[col.Name()] = i
+			}
+		}
+
+		copied := make(map[int]bool)
+		for row, nulls := range nullValues {
+			if nulls == nil {
+				continue
+			}
+			for name := range nulls.NullColumns {
+				i, ok := byName[name]
+				if !ok || row >= columns[i].Len() || columns[i].IsNull(row) {
+					continue
+				}
+
+				if !copied[i] {
+					if len(copied) == 0 {
+						columns = append([]Column(nil), columns...)
+					}
+					columns[i] = columns[i].CopyWithName(name)
+					copied[i] = true
+				}
+				columns[i].(*colImpl).setNull(row)
+			}
+		}
+		return columns, nullValues
+	}
+
+	var nullCols []Column
+	for _, col := range columns {
+		if c, ok := col.(*colImpl); ok && len(c.msg.Nulls) > 0 {
+			nullCols = append(nullCols, col)
+		}
+	}
+
+	if len(nullCols) == 0 {
+		return columns, nullValues
+	}
+
+	nullValues = make([]*pb.NullValuesMap, nullCols[0].Len())
+	for row := range nullValues {
+		nullValues[row] = &pb.NullValuesMap{NullColumns: make(map[string]bool)}
+		for _, col := range nullCols {
+			if col.IsNull(row) {
+				nullValues[row].NullColumns[col.Name()] = true
+			}
+		}
+	}
+	return columns, nullValues
+}
+
 func validateSlice(start int, end int, size int) error {
 	if start < 0 || end < 0 {
 		return fmt.Errorf("negative indexing not supported")
@@ -324,24 +412,25 @@ func zeroValue(dtype DType) (interface{}, error) {
 		return time.Unix(0, 0), nil
 	case BoolType:
 		return false, nil
+	case Int32Type:
+		return int32(0), nil
+	case Float32Type:
+		return float32(math.NaN()), nil
+	case BytesType:
+		return []byte{}, nil
+	case DecimalType:
+		return Decimal{}, nil
+	case ListType:
+		return []interface{}{}, nil
 	}
 
 	return nil, fmt.Errorf("unsupported data type - %d", dtype)
 }
 
-// TODO: Unite with backend/utils.AppendNil
+// extendCol appends nulls to col up to size
 func extendCol(col Column, size int) error {
-	if col.Len() >= size {
-		return nil
-	}
-
-	value, err := zeroValue(col.DType())
-	if err != nil {
-		return err
-	}
-
 	for col.Len() < size {
-		if err := colAppend(col, value); err != nil {
+		if err := colAppend(col, nil); err != nil {
 			return err
 		}
 	}
@@ -363,6 +452,12 @@ func newColumn(name string, value interface{}) (Column, error) {
 		data = []time.Time{}
 	case bool:
 		data = []bool{}
+	case []byte:
+		data = [][]byte{}
+	case Decimal:
+		data = []Decimal{}
+	case []interface{}, Column:
+		data = []Column{}
 	default:
 		return nil, fmt.Errorf("unsupported type %T", value)
 	}
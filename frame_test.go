@@ -23,6 +23,8 @@ package frames
 import (
 	"fmt"
 	"testing"
+
+	"github.com/v3io/frames/pb"
 )
 
 func TestFrameNew(t *testing.T) {
@@ -176,3 +178,157 @@ func newIntCols(t *testing.T, numCols int, size int) []Column {
 
 	return cols
 }
+
+func TestFrameNullValues(t *testing.T) {
+	col, err := NewSliceColumn("x", []int64{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nulls := []*pb.NullValuesMap{
+		{NullColumns: map[string]bool{}},
+		{NullColumns: map[string]bool{"x": true}},
+		{NullColumns: map[string]bool{}},
+	}
+
+	frame, err := NewFrameWithNullValues([]Column{col}, nil, nil, nulls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	col, err = frame.Column("x")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !col.IsNull(1) || col.NullCount() != 1 {
+		t.Fatal("null values not in column bitmap")
+	}
+
+	// Frame from bitmaps, NullValuesMap is built for older clients
+	msg := frame.(*frameImpl).Proto()
+	msg.NullValues = nil
+	frame = NewFrameFromProto(msg)
+	if nulls := frame.NullValuesMap(); len(nulls) != 3 || !nulls[1].NullColumns["x"] || len(nulls[0].NullColumns) != 0 {
+		t.Fatalf("bad null values: %v", nulls)
+	}
+
+	if !frame.IsNull(1, "x") || frame.IsNull(2, "x") {
+		t.Fatal("bad frame nulls")
+	}
+}
+
+func TestNewFrameFromRowsNulls(t *testing.T) {
+	rows := []map[string]interface{}{
+		{"x": 1, "y": "a"},
+		{"x": 2, "z": 1.0},
+	}
+
+	frame, err := NewFrameFromRows(rows, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !frame.IsNull(1, "y") || !frame.IsNull(0, "z") || frame.IsNull(0, "x") {
+		t.Fatal("missing values are not null")
+	}
+}
+
+func TestLegacyFrame(t *testing.T) {
+	ints, err := NewSliceColumn("i", []int32{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decimals, err := NewSliceColumn("d", []Decimal{{15, 1}, {2, 0}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lists, err := NewSliceColumn("l", [][]string{{"a"}, {}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nulls := []*pb.NullValuesMap{
+		{NullColumns: map[string]bool{}},
+		{NullColumns: map[string]bool{"d": true}},
+	}
+	frame, err := NewFrameWithNullValues([]Column{ints, decimals, lists}, nil, nil, nulls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	legacy, err := LegacyFrame(frame)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]DType{"i": IntType, "d": StringType, "l": StringType}
+	for name, dtype := range expected {
+		col, err := legacy.Column(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if col.DType() != dtype {
+			t.Fatalf("%s: bad dtype %v != %v", name, col.DType(), dtype)
+		}
+	}
+
+	col, err := legacy.Column("d")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s, err := col.StringAt(0); err != nil || s != "1.5" {
+		t.Fatalf("bad decimal string: %q (%v)", s, err)
+	}
+
+	if !legacy.IsNull(1, "d") || legacy.IsNull(0, "d") {
+		t.Fatal("bad nulls")
+	}
+
+	col, err = legacy.Column("l")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s, err := col.StringAt(0); err != nil || s != `["a"]` {
+		t.Fatalf("bad list string: %q (%v)", s, err)
+	}
+
+	// Frames with old dtypes are returned as is
+	old := newArrowTestFrame(t, 3)
+	if out, err := LegacyFrame(old); err != nil || out != old {
+		t.Fatalf("old frame converted (%v)", err)
+	}
+}
+
+func TestFrameNullValuesSharedColumn(t *testing.T) {
+	col, err := NewSliceColumn("x", []int64{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nulls := []*pb.NullValuesMap{
+		{NullColumns: map[string]bool{"x": true}},
+		{NullColumns: map[string]bool{}},
+	}
+	frame1, err := NewFrameWithNullValues([]Column{col}, nil, nil, nulls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frame2, err := NewFrame([]Column{col}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !frame1.IsNull(0, "x") {
+		t.Fatal("null not set")
+	}
+
+	if col.IsNull(0) || frame2.IsNull(0, "x") {
+		t.Fatal("null set in shared column")
+	}
+}
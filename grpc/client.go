@@ -30,23 +30,36 @@ import (
 	"github.com/nuclio/logger"
 	"github.com/pkg/errors"
 	"github.com/v3io/frames"
+	"github.com/v3io/frames/auth"
 	"github.com/v3io/frames/pb"
+	"github.com/v3io/frames/tlsconfig"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
 )
 
 // Client is frames gRPC client
 type Client struct {
 	client  pb.FramesClient
 	session *frames.Session
+	creds   auth.Credentials
 }
 
 var (
 	// Make sure we're implementing frames.Client
 	_ frames.Client = &Client{}
+	// Callers resume reads with the iterator marker
+	_ frames.MarkerIterator = &frameIterator{}
 )
 
 // NewClient returns a new gRPC client
 func NewClient(address string, session *frames.Session, logger logger.Logger) (*Client, error) {
+	return NewClientWithTLS(address, session, logger, nil)
+}
+
+// NewClientWithTLS returns a new gRPC client that connects with TLS, the
+// connection is insecure if tlsConfig is nil
+func NewClientWithTLS(address string, session *frames.Session, logger logger.Logger, tlsConfig *frames.ClientTLSConfig) (*Client, error) {
 	if address == "" {
 		address = os.Getenv("V3IO_URL")
 	}
@@ -55,9 +68,25 @@ func NewClient(address string, session *frames.Session, logger logger.Logger) (*
 		return nil, fmt.Errorf("empty address")
 	}
 
+	transport := grpc.WithInsecure()
+	if tlsConfig != nil {
+		if logger == nil {
+			var err error
+			if logger, err = frames.NewLogger("info"); err != nil {
+				return nil, errors.Wrap(err, "can't create logger")
+			}
+		}
+
+		config, err := tlsconfig.NewClient(tlsConfig, logger)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't create TLS configuration")
+		}
+		transport = grpc.WithTransportCredentials(credentials.NewTLS(config))
+	}
+
 	conn, err := grpc.Dial(
 		address,
-		grpc.WithInsecure(),
+		transport,
 		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(grpcMsgSize)),
 	)
 	if err != nil {
@@ -80,12 +109,31 @@ func NewClient(address string, session *frames.Session, logger logger.Logger) (*
 	return client, nil
 }
 
+// SetCredentials sets the credentials sent to authenticate requests
+func (c *Client) SetCredentials(creds auth.Credentials) {
+	c.creds = creds
+}
+
+// context returns a call context with the credentials metadata
+func (c *Client) context() context.Context {
+	md := metadata.MD{}
+	if c.creds.APIKey != "" {
+		md.Set(auth.APIKeyHeader, c.creds.APIKey)
+	}
+	if c.creds.BearerToken != "" {
+		md.Set(auth.AuthorizationHeader, "Bearer "+c.creds.BearerToken)
+	}
+
+	return metadata.NewOutgoingContext(context.Background(), md)
+}
+
 func (c *Client) Read(request *pb.ReadRequest) (frames.FrameIterator, error) {
 	if request.Session == nil {
 		request.Session = c.session
 	}
+	request.ExtendedDtypes = true // The client decodes every dtype
 
-	stream, err := c.client.Read(context.Background(), request)
+	stream, err := c.client.Read(c.context(), request)
 	if err != nil {
 		return nil, err
 	}
@@ -111,7 +159,7 @@ func (c *Client) Write(request *frames.WriteRequest) (frames.FrameAppender, erro
 		frame = proto.Proto()
 	}
 
-	stream, err := c.client.Write(context.Background())
+	stream, err := c.client.Write(c.context())
 	if err != nil {
 		return nil, err
 	}
@@ -153,7 +201,7 @@ func (c *Client) Create(request *pb.CreateRequest) error {
 		request.Session = c.session
 	}
 
-	_, err := c.client.Create(context.Background(), request)
+	_, err := c.client.Create(c.context(), request)
 	return err
 }
 
@@ -163,7 +211,7 @@ func (c *Client) Delete(request *pb.DeleteRequest) error {
 		request.Session = c.session
 	}
 
-	_, err := c.client.Delete(context.Background(), request)
+	_, err := c.client.Delete(c.context(), request)
 	return err
 }
 
@@ -173,7 +221,7 @@ func (c *Client) Exec(request *pb.ExecRequest) (frames.Frame, error) {
 		request.Session = c.session
 	}
 
-	msg, err := c.client.Exec(context.Background(), request)
+	msg, err := c.client.Exec(c.context(), request)
 	if err != nil {
 		return nil, err
 	}
@@ -186,9 +234,47 @@ func (c *Client) Exec(request *pb.ExecRequest) (frames.Frame, error) {
 	return frame, nil
 }
 
+// ListTables lists the tables in a directory
+func (c *Client) ListTables(request *pb.ListTablesRequest) ([]string, error) {
+	if request.Session == nil {
+		request.Session = c.session
+	}
+
+	resp, err := c.client.ListTables(c.context(), request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Tables, nil
+}
+
+// DescribeTable returns a table schema
+func (c *Client) DescribeTable(request *pb.DescribeTableRequest) (*pb.DescribeTableResponse, error) {
+	if request.Session == nil {
+		request.Session = c.session
+	}
+
+	return c.client.DescribeTable(c.context(), request)
+}
+
+// Capabilities returns the requests backends support
+func (c *Client) Capabilities(request *pb.CapabilitiesRequest) ([]*pb.Capabilities, error) {
+	if request.Session == nil {
+		request.Session = c.session
+	}
+
+	resp, err := c.client.Capabilities(c.context(), request)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Backends, nil
+}
+
 type frameIterator struct {
 	stream pb.Frames_ReadClient
 	frame  frames.Frame
+	marker string
 	err    error
 	done   bool
 }
@@ -207,7 +293,21 @@ func (it *frameIterator) Next() bool {
 		return false
 	}
 
-	it.frame = frames.NewFrameFromProto(msg)
+	if len(msg.Arrow) > 0 {
+		it.frame, it.err = frames.UnmarshalArrow(msg.Arrow)
+		if it.err == nil && msg.Marker != "" {
+			it.frame, it.err = frames.WithMarker(it.frame, msg.Marker)
+		}
+		if it.err != nil {
+			return false
+		}
+	} else {
+		it.frame = frames.NewFrameFromProto(msg)
+	}
+
+	if marker := it.frame.Marker(); marker != "" {
+		it.marker = marker
+	}
 	return true
 }
 
@@ -219,6 +319,11 @@ func (it *frameIterator) At() frames.Frame {
 	return it.frame
 }
 
+// Marker returns the marker of the last frame that had one
+func (it *frameIterator) Marker() string {
+	return it.marker
+}
+
 type frameAppender struct {
 	stream pb.Frames_WriteClient
 	closed bool
@@ -21,16 +21,30 @@ such restriction.
 package grpc_test
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net"
+	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
 
 	"github.com/v3io/frames"
+	"github.com/v3io/frames/auth"
 	"github.com/v3io/frames/grpc"
 	"github.com/v3io/frames/pb"
+	"github.com/v3io/frames/tlsconfig/tlstest"
+	"github.com/v3io/frames/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	ggrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func TestEnd2End(t *testing.T) {
@@ -127,6 +141,29 @@ func TestEnd2End(t *testing.T) {
 		t.Fatalf("# of rows mismatch - %d != %d", nRows, frame.Len())
 	}
 
+	readReq.DataFormat = frames.ArrowDataFormat
+	it, err = client.Read(readReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nRows = 0
+	for it.Next() {
+		iFrame := it.At()
+		if !reflect.DeepEqual(iFrame.Names(), frame.Names()) {
+			t.Fatalf("arrow columns mismatch: %v != %v", iFrame.Names(), frame.Names())
+		}
+		nRows += iFrame.Len()
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if nRows != frame.Len() {
+		t.Fatalf("# of arrow rows mismatch - %d != %d", nRows, frame.Len())
+	}
+
 	// Exec
 	execReq := &pb.ExecRequest{
 		Backend: backendName,
@@ -175,3 +212,364 @@ func freePort() (int, error) {
 	l.Close()
 	return l.Addr().(*net.TCPAddr).Port, nil
 }
+
+const apiKeys = `
+keys:
+  - user: daffy
+    key: daffy-key
+  - user: bugs
+    groups: [readers]
+    key: bugs-key
+`
+
+func TestAuth(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "frames-grpc-auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	keysFile := filepath.Join(tmpDir, "keys.yml")
+	if err := ioutil.WriteFile(keysFile, []byte(apiKeys), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	backendName := "auth-backend"
+	cfg := &frames.Config{
+		Backends: []*frames.BackendConfig{
+			{
+				Name:    backendName,
+				Type:    "csv",
+				RootDir: tmpDir,
+			},
+		},
+		Auth: &frames.AuthConfig{APIKeysFile: keysFile},
+		Authorization: &frames.AuthorizationConfig{
+			Rules: []*frames.AuthorizationRule{
+				{Effect: "allow", Users: []string{"daffy"}},
+				{Effect: "allow", Groups: []string{"readers"}, Actions: []string{"read"}},
+			},
+		},
+	}
+
+	port, err := freePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv, err := grpc.NewServer(cfg, fmt.Sprintf(":%d", port), nil, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(100 * time.Millisecond) // Let server start
+
+	client, err := grpc.NewClient(fmt.Sprintf("localhost:%d", port), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	execReq := &pb.ExecRequest{Backend: backendName, Table: "t1", Command: "ping"}
+	if _, err := client.Exec(execReq); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("exec without credentials - %v", err)
+	}
+
+	it, err := client.Read(&pb.ReadRequest{Backend: backendName, Table: "t1"})
+	if err == nil && !it.Next() {
+		err = it.Err()
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("read without credentials - %v", err)
+	}
+
+	client.SetCredentials(auth.Credentials{APIKey: "elmer-key"})
+	if _, err := client.Exec(execReq); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("exec with bad credentials - %v", err)
+	}
+
+	client.SetCredentials(auth.Credentials{APIKey: "bugs-key"})
+	if _, err := client.Exec(execReq); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("exec without permission - %v", err)
+	}
+
+	client.SetCredentials(auth.Credentials{APIKey: "daffy-key"})
+	if _, err := client.Exec(execReq); err != nil {
+		t.Fatalf("exec with credentials - %v", err)
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "frames-grpc-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	files, err := tlstest.WriteFiles(tmpDir, "daffy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backendName := "tls-backend"
+	cfg := &frames.Config{
+		Backends: []*frames.BackendConfig{
+			{
+				Name:    backendName,
+				Type:    "csv",
+				RootDir: tmpDir,
+			},
+		},
+		TLS: &frames.TLSConfig{
+			CertFile:     files.ServerCertFile,
+			KeyFile:      files.ServerKeyFile,
+			ClientCAFile: files.CAFile,
+		},
+		Authorization: &frames.AuthorizationConfig{
+			Rules: []*frames.AuthorizationRule{
+				{Effect: "allow", Users: []string{"daffy"}},
+			},
+		},
+	}
+
+	port, err := freePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv, err := grpc.NewServer(cfg, fmt.Sprintf(":%d", port), nil, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(100 * time.Millisecond) // Let server start
+
+	address := fmt.Sprintf("localhost:%d", port)
+	execReq := &pb.ExecRequest{Backend: backendName, Table: "t1", Command: "ping"}
+
+	noCertConfig := &frames.ClientTLSConfig{CAFile: files.CAFile}
+	client, err := grpc.NewClientWithTLS(address, nil, nil, noCertConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Exec(execReq); err == nil {
+		t.Fatal("exec without client certificate")
+	}
+
+	tlsConfig := &frames.ClientTLSConfig{
+		CAFile:   files.CAFile,
+		CertFile: files.ClientCertFile,
+		KeyFile:  files.ClientKeyFile,
+	}
+	client, err = grpc.NewClientWithTLS(address, nil, nil, tlsConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Exec(execReq); err != nil {
+		t.Fatalf("exec with client certificate - %v", err)
+	}
+}
+
+func TestTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider, err := tracing.NewProvider(&frames.TracingConfig{}, exporter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	tmpDir, err := ioutil.TempDir("", "frames-grpc-tracing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	backendName := "tracing-backend"
+	cfg := &frames.Config{
+		Backends: []*frames.BackendConfig{
+			{
+				Name:    backendName,
+				Type:    "csv",
+				RootDir: tmpDir,
+			},
+		},
+	}
+
+	port, err := freePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv, err := grpc.NewServer(cfg, fmt.Sprintf(":%d", port), nil, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(100 * time.Millisecond) // Let server start
+
+	frame, err := makeFrame()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	address := fmt.Sprintf("localhost:%d", port)
+	client, err := grpc.NewClient(address, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appender, err := client.Write(&frames.WriteRequest{Backend: backendName, Table: "t1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := appender.Add(frame); err != nil {
+		t.Fatal(err)
+	}
+	if err := appender.WaitForComplete(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := ggrpc.Dial(address, ggrpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	traceParent := fmt.Sprintf("00-%s-00f067aa0ba902b7-01", traceID)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "traceparent", traceParent)
+	readReq := &pb.ReadRequest{Backend: backendName, Table: "t1", Session: &pb.Session{}}
+	stream, err := pb.NewFramesClient(conn).Read(ctx, readReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		if _, err := stream.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The server span ends after the reply is sent
+	var spans map[string]tracetest.SpanStub
+	for i := 0; i < 100; i++ {
+		spans = traceSpans(exporter, traceID)
+		if len(spans) == 3 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	serverSpan, ok := spans["/pb.Frames/Read"]
+	if !ok {
+		t.Fatalf("no server span in %v", spans)
+	}
+	apiSpan := spans["api.read"]
+	if apiSpan.Parent.SpanID() != serverSpan.SpanContext.SpanID() {
+		t.Fatal("api span is not a child of the server span")
+	}
+	backendSpan := spans["backend.Read"]
+	if backendSpan.Parent.SpanID() != apiSpan.SpanContext.SpanID() {
+		t.Fatal("backend span is not a child of the api span")
+	}
+
+	expected := tracing.RowsKey.Int(frame.Len())
+	found := false
+	for _, attr := range backendSpan.Attributes {
+		found = found || attr == expected
+	}
+	if !found {
+		t.Fatalf("no %v in backend span attributes %v", expected, backendSpan.Attributes)
+	}
+}
+
+func TestWriteStreamError(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "frames-grpc-write")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	// Parquet files are replaced only when the write completes
+	backendName := "write-backend"
+	cfg := &frames.Config{
+		Backends: []*frames.BackendConfig{
+			{
+				Name:    backendName,
+				Type:    "parquet",
+				RootDir: tmpDir,
+			},
+		},
+	}
+
+	port, err := freePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv, err := grpc.NewServer(cfg, fmt.Sprintf(":%d", port), nil, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(100 * time.Millisecond) // Let server start
+
+	frame, err := makeFrame()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conn, err := ggrpc.Dial(fmt.Sprintf("localhost:%d", port), ggrpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	stream, err := pb.NewFramesClient(conn).Write(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	initial := &pb.InitialWriteRequest{Backend: backendName, Table: "t1", Session: &pb.Session{}}
+	if err := stream.Send(&pb.WriteRequest{Type: &pb.WriteRequest_Request{Request: initial}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&pb.WriteRequest{Type: &pb.WriteRequest_Frame{Frame: frame.(pb.Framed).Proto()}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := stream.Send(&pb.WriteRequest{}); err != nil { // No frame
+		t.Fatal(err)
+	}
+
+	if _, err := stream.CloseAndRecv(); err == nil {
+		t.Fatal("no error on write without frame")
+	}
+
+	if _, err := os.Stat(filepath.Join(tmpDir, "t1")); !os.IsNotExist(err) {
+		t.Fatalf("failed write was completed - %v", err)
+	}
+}
+
+// traceSpans returns the exported spans of the trace by name
+func traceSpans(exporter *tracetest.InMemoryExporter, traceID string) map[string]tracetest.SpanStub {
+	spans := make(map[string]tracetest.SpanStub)
+	for _, span := range exporter.GetSpans() {
+		if span.SpanContext.TraceID().String() == traceID {
+			spans[span.Name] = span
+		}
+	}
+	return spans
+}
@@ -26,14 +26,22 @@ import (
 	"io"
 	"net"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/nuclio/logger"
 	"github.com/pkg/errors"
 	"github.com/v3io/frames"
 	"github.com/v3io/frames/api"
+	"github.com/v3io/frames/auth"
+	"github.com/v3io/frames/authz"
 	"github.com/v3io/frames/backends/utils"
+	"github.com/v3io/frames/metrics"
 	"github.com/v3io/frames/pb"
+	"github.com/v3io/frames/tlsconfig"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
 )
 
 const (
@@ -44,12 +52,13 @@ const (
 type Server struct {
 	frames.ServerBase
 
-	address string
-	api     *api.API
-	config  *frames.Config
-	logger  logger.Logger
-	server  *grpc.Server
-	version string
+	address       string
+	api           *api.API
+	authenticator auth.Authenticator // nil if there's no authentication
+	config        *frames.Config
+	logger        logger.Logger
+	server        *grpc.Server
+	version       string
 }
 
 // NewServer returns a new gRPC server
@@ -75,24 +84,51 @@ func NewServer(config *frames.Config, addr string, logger logger.Logger, history
 		return nil, errors.Wrap(err, "can't create API")
 	}
 
+	authenticator, err := auth.New(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't create authenticator")
+	}
+
 	server := &Server{
 		ServerBase: *frames.NewServerBase(),
 
-		address: addr,
-		api:     api,
-		config:  config,
-		logger:  logger,
-		server: grpc.NewServer(
-			grpc.MaxRecvMsgSize(grpcMsgSize),
-			grpc.MaxSendMsgSize(grpcMsgSize),
-		),
-		version: version,
+		address:       addr,
+		api:           api,
+		authenticator: authenticator,
+		config:        config,
+		logger:        logger,
+		version:       version,
 	}
 
+	options := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(grpcMsgSize),
+		grpc.MaxSendMsgSize(grpcMsgSize),
+	}
+	if config.TLS != nil {
+		tlsConfig, err := tlsconfig.NewServer(config.TLS, logger, "h2")
+		if err != nil {
+			return nil, errors.Wrap(err, "can't create TLS configuration")
+		}
+		options = append(options, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	unaryInterceptors := []grpc.UnaryServerInterceptor{unaryTracingInterceptor}
+	streamInterceptors := []grpc.StreamServerInterceptor{streamTracingInterceptor}
+	if authenticator != nil {
+		unaryInterceptors = append(unaryInterceptors, server.unaryAuthInterceptor)
+		streamInterceptors = append(streamInterceptors, server.streamAuthInterceptor)
+	}
+	options = append(
+		options,
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
+	server.server = grpc.NewServer(options...)
+
 	pb.RegisterFramesServer(server.server, server)
 	reflection.Register(server.server)
 
-	server.logger.InfoWith("GRPC server started", "address", server.address)
+	server.logger.InfoWith("GRPC server started", "address", server.address, "tls", config.TLS != nil)
 
 	return server, nil
 }
@@ -127,30 +163,67 @@ func (s *Server) Read(request *pb.ReadRequest, stream pb.Frames_ReadServer) erro
 		Proto:    request,
 		Password: password,
 		Token:    token,
+		Identity: auth.FromContext(stream.Context()),
 	}
 
+	switch request.DataFormat {
+	case frames.FramesDataFormat, frames.ArrowDataFormat:
+	default:
+		return fmt.Errorf("unsupported data format - %q", request.DataFormat)
+	}
+
+	// Cancelled when the client goes away or when we return on a send error
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
 	var apiError error
 	go func() {
 		defer close(ch)
-		apiError = s.api.Read(&req, ch)
+		apiError = s.api.Read(ctx, &req, ch)
 		if apiError != nil {
 			s.logger.ErrorWith("API error reading", "error", apiError)
 		}
 	}()
 
 	for frame := range ch {
+		if request.DataFormat == frames.ArrowDataFormat {
+			data, err := frames.MarshalArrow(frame)
+			if err != nil {
+				return err
+			}
+
+			if err := sendFrame(stream, &pb.Frame{Arrow: data, Marker: frame.Marker()}); err != nil {
+				return err
+			}
+			continue
+		}
+
 		fpb, ok := frame.(pb.Framed)
 		if !ok {
 			s.logger.Error("unknown frame type")
 			return errors.New("unknown frame type")
 		}
 
-		if err := stream.Send(fpb.Proto()); err != nil {
+		if err := sendFrame(stream, fpb.Proto()); err != nil {
 			return err
 		}
 	}
 
-	return apiError
+	return apiStatus(apiError)
+}
+
+type frameSender interface {
+	Send(*pb.Frame) error
+}
+
+// sendFrame sends msg and adds its size to the encoded bytes metric
+func sendFrame(stream frameSender, msg *pb.Frame) error {
+	if err := stream.Send(msg); err != nil {
+		return err
+	}
+
+	metrics.EncodedBytes.Add(float64(proto.Size(msg)))
+	return nil
 }
 
 // Write write data to table
@@ -182,6 +255,7 @@ func (s *Server) Write(stream pb.Frames_WriteServer) error {
 		Session:       pbReq.Session,
 		Password:      password,
 		Token:         token,
+		Identity:      auth.FromContext(stream.Context()),
 		Backend:       pbReq.Backend,
 		Expression:    pbReq.Expression,
 		Condition:     pbReq.Condition,
@@ -200,38 +274,51 @@ func (s *Server) Write(stream pb.Frames_WriteServer) error {
 		done           = make(chan bool)
 	)
 
+	writeCtx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
 	go func() {
 		defer close(done)
-		nFrames, nRows, writeError = s.api.Write(req, ch)
+		nFrames, nRows, writeError = s.api.Write(writeCtx, req, ch)
 	}()
 
-	for writeError == nil {
-		msg, err := stream.Recv()
-		if err != nil {
-			if err != io.EOF {
-				s.logger.ErrorWith("stream error", "error", err)
-				return err
+	// ch is closed on every return so the API drains and stops, errors cancel
+	// the write first so it's not completed
+	err = func() error {
+		defer close(ch)
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				if err != io.EOF {
+					s.logger.ErrorWith("stream error", "error", err)
+					cancel()
+					return err
+				}
+				return nil
 			}
-			break
-		}
-
-		frameMessage := msg.GetFrame()
-		if frameMessage == nil {
-			s.logger.ErrorWith("nil frame", "message", msg)
-			return fmt.Errorf("nil frame")
-		}
 
-		frame := frames.NewFrameFromProto(frameMessage)
-		ch <- frame
-	}
+			frameMessage := msg.GetFrame()
+			if frameMessage == nil {
+				s.logger.ErrorWith("nil frame", "message", msg)
+				cancel()
+				return fmt.Errorf("nil frame")
+			}
 
-	close(ch)
+			select {
+			case ch <- frames.NewFrameFromProto(frameMessage):
+			case <-done: // Write failed, writeError has the reason
+				return nil
+			}
+		}
+	}()
 	<-done
+	if err != nil {
+		return err
+	}
 
 	// We can't handle writeError right after .Write since it's done in a goroutine
 	if writeError != nil {
 		s.logger.ErrorWith("write error", "error", writeError)
-		return writeError
+		return apiStatus(writeError)
 	}
 
 	resp := &pb.WriteRespose{
@@ -252,11 +339,12 @@ func (s *Server) Create(ctx context.Context, req *pb.CreateRequest) (*pb.CreateR
 		Proto:    req,
 		Password: password,
 		Token:    token,
+		Identity: auth.FromContext(ctx),
 	}
 
 	// TODO: Use ctx for timeout
-	if err := s.api.Create(&request); err != nil {
-		return nil, err
+	if err := s.api.Create(ctx, &request); err != nil {
+		return nil, apiStatus(err)
 	}
 
 	return &pb.CreateResponse{}, nil
@@ -272,10 +360,11 @@ func (s *Server) Delete(ctx context.Context, req *pb.DeleteRequest) (*pb.DeleteR
 		Proto:    req,
 		Password: password,
 		Token:    token,
+		Identity: auth.FromContext(ctx),
 	}
 
-	if err := s.api.Delete(&request); err != nil {
-		return nil, err
+	if err := s.api.Delete(ctx, &request); err != nil {
+		return nil, apiStatus(err)
 	}
 
 	return &pb.DeleteResponse{}, nil
@@ -291,11 +380,12 @@ func (s *Server) Exec(ctx context.Context, req *pb.ExecRequest) (*pb.ExecRespons
 		Proto:    req,
 		Password: password,
 		Token:    token,
+		Identity: auth.FromContext(ctx),
 	}
 
-	frame, err := s.api.Exec(&request)
+	frame, err := s.api.Exec(ctx, &request)
 	if err != nil {
-		return nil, err
+		return nil, apiStatus(err)
 	}
 
 	resp := &pb.ExecResponse{}
@@ -312,6 +402,75 @@ func (s *Server) Exec(ctx context.Context, req *pb.ExecRequest) (*pb.ExecRespons
 	return resp, nil
 }
 
+// ListTables lists the tables in a directory
+func (s *Server) ListTables(ctx context.Context, req *pb.ListTablesRequest) (*pb.ListTablesResponse, error) {
+	password, token := s.takeCredentials(req.Session)
+	request := frames.ListTablesRequest{
+		Proto:    req,
+		Password: password,
+		Token:    token,
+		Identity: auth.FromContext(ctx),
+	}
+
+	tables, err := s.api.ListTables(ctx, &request)
+	if err != nil {
+		return nil, apiStatus(err)
+	}
+
+	return &pb.ListTablesResponse{Tables: tables}, nil
+}
+
+// DescribeTable returns a table schema
+func (s *Server) DescribeTable(ctx context.Context, req *pb.DescribeTableRequest) (*pb.DescribeTableResponse, error) {
+	password, token := s.takeCredentials(req.Session)
+	request := frames.DescribeTableRequest{
+		Proto:    req,
+		Password: password,
+		Token:    token,
+		Identity: auth.FromContext(ctx),
+	}
+
+	description, err := s.api.DescribeTable(ctx, &request)
+	if err != nil {
+		return nil, apiStatus(err)
+	}
+
+	return description, nil
+}
+
+// Capabilities returns the requests backends support
+func (s *Server) Capabilities(ctx context.Context, req *pb.CapabilitiesRequest) (*pb.CapabilitiesResponse, error) {
+	caps, err := s.api.Capabilities(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CapabilitiesResponse{Backends: caps}, nil
+}
+
+// takeCredentials removes the password and token from session and returns them
+func (s *Server) takeCredentials(session *frames.Session) (frames.SecretString, frames.SecretString) {
+	if session == nil {
+		return frames.InitSecretString(""), frames.InitSecretString("")
+	}
+
+	password := frames.InitSecretString(session.Password)
+	token := frames.InitSecretString(session.Token)
+	session.Password = ""
+	session.Token = ""
+	return password, token
+}
+
+// apiStatus returns authorization denials as PermissionDenied status errors,
+// other errors are returned as is
+func apiStatus(err error) error {
+	if authz.IsDenied(err) {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	return err
+}
+
 // History returns framesd history logs
 func (s *Server) History(request *pb.HistoryRequest, stream pb.Frames_HistoryServer) error {
 	ch := make(chan frames.Frame)
@@ -325,6 +484,7 @@ func (s *Server) History(request *pb.HistoryRequest, stream pb.Frames_HistorySer
 		Proto:    request,
 		Password: password,
 		Token:    token,
+		Identity: auth.FromContext(stream.Context()),
 	}
 
 	var apiError error
@@ -343,7 +503,7 @@ func (s *Server) History(request *pb.HistoryRequest, stream pb.Frames_HistorySer
 			return errors.New("unknown frame type")
 		}
 
-		if err := stream.Send(fpb.Proto()); err != nil {
+		if err := sendFrame(stream, fpb.Proto()); err != nil {
 			return err
 		}
 	}
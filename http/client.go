@@ -35,7 +35,9 @@ import (
 	"github.com/nuclio/logger"
 	"github.com/pkg/errors"
 	"github.com/v3io/frames"
+	"github.com/v3io/frames/auth"
 	"github.com/v3io/frames/pb"
+	"github.com/v3io/frames/tlsconfig"
 	"github.com/valyala/fasthttp"
 )
 
@@ -69,15 +71,25 @@ type Client struct {
 	logger     logger.Logger
 	session    *frames.Session
 	httpClient *fasthttp.Client
+	creds      auth.Credentials
 }
 
 var (
 	// Make sure we're implementing frames.Client
 	_ frames.Client = &Client{}
+	// Callers resume reads with the iterator marker
+	_ frames.MarkerIterator = &streamFrameIterator{}
 )
 
-// NewClient returns a new HTTP client
+// NewClient returns a new HTTP client, server certificates of "https" URLs
+// aren't verified
 func NewClient(url string, session *frames.Session, logger logger.Logger) (*Client, error) {
+	return NewClientWithTLS(url, session, logger, nil)
+}
+
+// NewClientWithTLS returns a new HTTP client with TLS configuration for
+// "https" URLs
+func NewClientWithTLS(url string, session *frames.Session, logger logger.Logger, tlsConfig *frames.ClientTLSConfig) (*Client, error) {
 	var err error
 	if logger == nil {
 		logger, err = frames.NewLogger("info")
@@ -116,6 +128,18 @@ func NewClient(url string, session *frames.Session, logger logger.Logger) (*Clie
 			InsecureSkipVerify: true,
 		},
 	}
+	if tlsConfig != nil {
+		if httpClient.TLSConfig, err = tlsconfig.NewClient(tlsConfig, logger); err != nil {
+			return nil, errors.Wrap(err, "can't create TLS configuration")
+		}
+
+		// fasthttp copies only some of the TLS configuration fields, the client
+		// certificate is loaded once
+		if getCert := httpClient.TLSConfig.GetClientCertificate; getCert != nil {
+			cert, _ := getCert(nil)
+			httpClient.TLSConfig.Certificates = []tls.Certificate{*cert}
+		}
+	}
 
 	client := &Client{
 		url:        netURL,
@@ -127,11 +151,31 @@ func NewClient(url string, session *frames.Session, logger logger.Logger) (*Clie
 	return client, nil
 }
 
+// SetCredentials sets the credentials sent to authenticate requests
+func (c *Client) SetCredentials(creds auth.Credentials) {
+	c.creds = creds
+}
+
+func (c *Client) setCredentials(httpRequest *fasthttp.Request) {
+	if c.creds.APIKey != "" {
+		httpRequest.Header.Set(auth.APIKeyHeader, c.creds.APIKey)
+	}
+	if c.creds.BearerToken != "" {
+		httpRequest.Header.Set(auth.AuthorizationHeader, "Bearer "+c.creds.BearerToken)
+	}
+}
+
 // Read runs a query on the client
 func (c *Client) Read(request *pb.ReadRequest) (frames.FrameIterator, error) {
 	if request.Session == nil {
 		request.Session = c.session
 	}
+	request.ExtendedDtypes = true // The client decodes every dtype
+
+	switch request.DataFormat {
+	case frames.JSONLDataFormat, frames.CSVDataFormat:
+		return nil, fmt.Errorf("data format %q isn't supported by the client", request.DataFormat)
+	}
 
 	marshalledRequest, err := json.Marshal(request)
 	if err != nil {
@@ -145,6 +189,7 @@ func (c *Client) Read(request *pb.ReadRequest) (frames.FrameIterator, error) {
 	httpRequest.SetBody(marshalledRequest)
 	httpRequest.Header.SetContentType("application/json")
 	httpRequest.Header.SetMethod("POST")
+	c.setCredentials(httpRequest)
 
 	httpResponse := fasthttp.AcquireResponse()
 
@@ -165,6 +210,15 @@ func (c *Client) Read(request *pb.ReadRequest) (frames.FrameIterator, error) {
 
 	httpResponseReaderCloser := newHTTPResponseReaderCloser(httpResponse)
 
+	if request.DataFormat == frames.ArrowDataFormat {
+		it := &arrowFrameIterator{
+			reader:  &httpResponseReaderCloser,
+			decoder: frames.NewArrowDecoder(&httpResponseReaderCloser),
+			logger:  c.logger,
+		}
+		return it, nil
+	}
+
 	it := &streamFrameIterator{
 		reader:  &httpResponseReaderCloser,
 		decoder: frames.NewDecoder(&httpResponseReaderCloser),
@@ -203,6 +257,7 @@ func (c *Client) Write(request *frames.WriteRequest) (frames.FrameAppender, erro
 	httpRequest.URI().SetPath(c.url.Path + "/write")
 	httpRequest.Header.SetContentType("application/json")
 	httpRequest.Header.SetMethod("POST")
+	c.setCredentials(httpRequest)
 	httpRequest.SetBodyStream(io.MultiReader(&buf, reader), -1)
 
 	appender := &streamFrameAppender{
@@ -278,6 +333,63 @@ func (c *Client) Exec(request *pb.ExecRequest) (frames.Frame, error) {
 	return frames.UnmarshalFrame(data)
 }
 
+// ListTables lists the tables in a directory
+func (c *Client) ListTables(request *pb.ListTablesRequest) ([]string, error) {
+	if request.Session == nil {
+		request.Session = c.session
+	}
+
+	reply := &pb.ListTablesResponse{}
+	if err := c.jsonReply("/tables", request, reply); err != nil {
+		return nil, err
+	}
+
+	return reply.Tables, nil
+}
+
+// DescribeTable returns a table schema
+func (c *Client) DescribeTable(request *pb.DescribeTableRequest) (*pb.DescribeTableResponse, error) {
+	if request.Session == nil {
+		request.Session = c.session
+	}
+
+	reply := &pb.DescribeTableResponse{}
+	if err := c.jsonReply("/describe", request, reply); err != nil {
+		return nil, err
+	}
+
+	return reply, nil
+}
+
+// Capabilities returns the requests backends support
+func (c *Client) Capabilities(request *pb.CapabilitiesRequest) ([]*pb.Capabilities, error) {
+	if request.Session == nil {
+		request.Session = c.session
+	}
+
+	reply := &pb.CapabilitiesResponse{}
+	if err := c.jsonReply("/capabilities", request, reply); err != nil {
+		return nil, err
+	}
+
+	return reply.Backends, nil
+}
+
+// jsonReply calls path and decodes the JSON reply
+func (c *Client) jsonReply(path string, request interface{}, reply interface{}) error {
+	httpResponse, err := c.jsonCall(path, request, true)
+	if err != nil {
+		return err
+	}
+
+	defer fasthttp.ReleaseResponse(httpResponse)
+	if err := json.Unmarshal(httpResponse.Body(), reply); err != nil {
+		return errors.Wrap(err, "bad JSON reply")
+	}
+
+	return nil
+}
+
 func (c *Client) jsonCall(path string, request interface{}, returnResponse bool) (*fasthttp.Response, error) {
 	var buf bytes.Buffer
 
@@ -292,6 +404,7 @@ func (c *Client) jsonCall(path string, request interface{}, returnResponse bool)
 	httpRequest.SetBody(buf.Bytes())
 	httpRequest.Header.SetContentType("application/json")
 	httpRequest.Header.SetMethod("POST")
+	c.setCredentials(httpRequest)
 
 	httpResponse := fasthttp.AcquireResponse()
 
@@ -321,6 +434,7 @@ func (c *Client) jsonCall(path string, request interface{}, returnResponse bool)
 // streamFrameIterator implements FrameIterator over io.Reader
 type streamFrameIterator struct {
 	frame   frames.Frame
+	marker  string
 	err     error
 	reader  io.Reader
 	decoder *frames.Decoder
@@ -338,6 +452,9 @@ func (it *streamFrameIterator) Next() bool {
 	}
 	if err == nil {
 		it.frame = frames.NewFrameFromProto(msg)
+		if marker := it.frame.Marker(); marker != "" {
+			it.marker = marker
+		}
 		return true
 	}
 
@@ -364,6 +481,45 @@ func (it *streamFrameIterator) Err() error {
 	return it.err
 }
 
+// Marker returns the marker of the last frame that had one
+func (it *streamFrameIterator) Marker() string {
+	return it.marker
+}
+
+// arrowFrameIterator implements FrameIterator over Arrow IPC streams
+type arrowFrameIterator struct {
+	frame   frames.Frame
+	err     error
+	reader  io.Closer
+	decoder *frames.ArrowDecoder
+	logger  logger.Logger
+}
+
+func (it *arrowFrameIterator) Next() bool {
+	frame, err := it.decoder.Decode()
+	if err == nil {
+		it.frame = frame
+		return true
+	}
+
+	if err := it.reader.Close(); err != nil {
+		it.logger.WarnWith("can't close reader", "error", err)
+	}
+
+	if err != io.EOF {
+		it.err = err
+	}
+	return false
+}
+
+func (it *arrowFrameIterator) At() frames.Frame {
+	return it.frame
+}
+
+func (it *arrowFrameIterator) Err() error {
+	return it.err
+}
+
 type appenderHTTPResponse struct {
 	httpResponse *fasthttp.Response
 	err          error
@@ -21,18 +21,28 @@ such restriction.
 package http_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net"
 	nhttp "net/http"
+	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/v3io/frames"
+	"github.com/v3io/frames/auth"
 	"github.com/v3io/frames/http"
 	"github.com/v3io/frames/pb"
+	"github.com/v3io/frames/tlsconfig/tlstest"
+	"github.com/v3io/frames/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestEnd2End(t *testing.T) {
@@ -130,6 +140,32 @@ func TestEnd2End(t *testing.T) {
 		t.Fatalf("# of rows mismatch - %d != %d", nRows, frame.Len())
 	}
 
+	readReq.DataFormat = frames.ArrowDataFormat
+	it, err = client.Read(readReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nRows = 0
+	for it.Next() {
+		iFrame := it.At()
+		if !reflect.DeepEqual(iFrame.Names(), frame.Names()) {
+			t.Fatalf("arrow columns mismatch: %v != %v", iFrame.Names(), frame.Names())
+		}
+		nRows += iFrame.Len()
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	if nRows != frame.Len() {
+		t.Fatalf("# of arrow rows mismatch - %d != %d", nRows, frame.Len())
+	}
+
+	testRawRead(t, url, backendName, tableName, frames.JSONLDataFormat, "application/x-ndjson", frame.Len())
+	testRawRead(t, url, backendName, tableName, frames.CSVDataFormat, "text/csv", frame.Len()+1) // + header
+
 	testGrafana(t, url, backendName, tableName)
 
 	// Exec
@@ -142,6 +178,365 @@ func TestEnd2End(t *testing.T) {
 	if _, err := client.Exec(execReq); err != nil {
 		t.Fatalf("can't exec - %s", err)
 	}
+
+	testCapabilities(t, url, backendName)
+	testMetrics(t, url, backendName, frame.Len())
+	testQuery(t, client, backendName, tableName)
+	testJoin(t, client, backendName, tableName)
+}
+
+func testQuery(t *testing.T, client frames.Client, backend string, table string) {
+	readReq := &pb.ReadRequest{
+		Backend: backend,
+		Query:   fmt.Sprintf("SELECT bools, count(*) AS n, max(ints) AS top FROM %s WHERE ints >= 100 GROUP BY bools ORDER BY bools", table),
+	}
+
+	it, err := client.Read(readReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !it.Next() {
+		t.Fatalf("no query result - %v", it.Err())
+	}
+
+	frame := it.At()
+	if !reflect.DeepEqual(frame.Names(), []string{"bools", "n", "top"}) || frame.Len() != 2 {
+		t.Fatalf("bad query result - %v (%d rows)", frame.Names(), frame.Len())
+	}
+
+	for i, expected := range []int64{463, 464} {
+		col, err := frame.Column("n")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if n, _ := col.IntAt(i); n != expected {
+			t.Fatalf("bad count in row %d - %d != %d", i, n, expected)
+		}
+	}
+
+	if it.Next() {
+		t.Fatal("more than one query result frame")
+	}
+}
+
+func testJoin(t *testing.T, client frames.Client, backend string, table string) {
+	names, err := frames.NewFrameFromMap(map[string]interface{}{
+		"ints": []int64{3, 1, 2000},
+		"name": []string{"three", "one", "many"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appender, err := client.Write(&frames.WriteRequest{Backend: backend, Table: "names"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := appender.Add(names); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := appender.WaitForComplete(10 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	readReq := &pb.ReadRequest{
+		Backend:      backend,
+		Table:        table,
+		Columns:      []string{"ints", "strings"},
+		MessageLimit: 100,
+		Join: []*pb.JoinStruct{
+			{Table: "names", LeftOn: []string{"ints"}},
+		},
+	}
+
+	it, err := client.Read(readReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	joined := make(map[string]string)
+	for it.Next() {
+		frame := it.At()
+		for r := frame.IterRows(false); r.Next(); {
+			row := r.Row()
+			joined[fmt.Sprint(row["strings"])] = fmt.Sprint(row["name"])
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]string{"val1": "one", "val3": "three"}
+	if !reflect.DeepEqual(joined, expected) {
+		t.Fatalf("bad join result - %v", joined)
+	}
+}
+
+const apiKeys = `
+keys:
+  - user: daffy
+    key: daffy-key
+  - user: bugs
+    groups: [readers]
+    key: bugs-key
+`
+
+func TestAuth(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "frames-auth")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	keysFile := filepath.Join(tmpDir, "keys.yml")
+	if err := ioutil.WriteFile(keysFile, []byte(apiKeys), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	backendName := "auth-backend"
+	cfg := &frames.Config{
+		Backends: []*frames.BackendConfig{
+			{
+				Name:    backendName,
+				Type:    "csv",
+				RootDir: tmpDir,
+			},
+		},
+		Auth: &frames.AuthConfig{APIKeysFile: keysFile},
+		Authorization: &frames.AuthorizationConfig{
+			Rules: []*frames.AuthorizationRule{
+				{Effect: "allow", Users: []string{"daffy"}},
+				{Effect: "allow", Groups: []string{"readers"}, Actions: []string{"read"}},
+				{Effect: "deny", Tables: []string{"secret"}},
+				{Effect: "allow", Actions: []string{"delete"}, Tables: []string{"scratch"}},
+				{Effect: "deny", Groups: []string{"readers"}, Actions: []string{"delete"}},
+			},
+		},
+	}
+
+	port, err := freePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv, err := http.NewServer(cfg, fmt.Sprintf(":%d", port), nil, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(100 * time.Millisecond) // Let server start
+
+	url := fmt.Sprintf("http://localhost:%d", port)
+
+	// Status is public
+	resp, err := nhttp.Get(url + "/_/status")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != nhttp.StatusOK {
+		t.Fatalf("status: bad status - %d %s", resp.StatusCode, resp.Status)
+	}
+
+	resp, err = nhttp.Get(fmt.Sprintf("%s/capabilities?backend=%s", url, backendName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != nhttp.StatusUnauthorized || resp.Header.Get("WWW-Authenticate") == "" {
+		t.Fatalf("capabilities without credentials: bad reply - %d %v", resp.StatusCode, resp.Header)
+	}
+
+	client, err := http.NewClient(url, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	execReq := &pb.ExecRequest{Backend: backendName, Table: "t1", Command: "ping"}
+	if _, err := client.Exec(execReq); err == nil {
+		t.Fatal("exec without credentials")
+	}
+
+	client.SetCredentials(auth.Credentials{APIKey: "elmer-key"})
+	if _, err := client.Exec(execReq); err == nil {
+		t.Fatal("exec with bad credentials")
+	}
+
+	client.SetCredentials(auth.Credentials{APIKey: "bugs-key"})
+	if _, err := client.Exec(execReq); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Fatalf("exec without permission - %v", err)
+	}
+
+	// Read denials are replied before streaming
+	body := bytes.NewBufferString(fmt.Sprintf(`{"backend": %q, "table": "secret"}`, backendName))
+	readReq, err := nhttp.NewRequest("POST", url+"/read", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	readReq.Header.Set(auth.APIKeyHeader, "bugs-key")
+	resp, err = nhttp.DefaultClient.Do(readReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != nhttp.StatusForbidden {
+		t.Fatalf("read without permission: bad status - %d %s", resp.StatusCode, resp.Status)
+	}
+
+	// Group rules apply to requests without a session
+	body = bytes.NewBufferString(fmt.Sprintf(`{"backend": %q, "table": "scratch"}`, backendName))
+	deleteReq, err := nhttp.NewRequest("POST", url+"/delete", body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleteReq.Header.Set(auth.APIKeyHeader, "bugs-key")
+	resp, err = nhttp.DefaultClient.Do(deleteReq)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != nhttp.StatusForbidden {
+		t.Fatalf("delete without session: bad status - %d %s", resp.StatusCode, resp.Status)
+	}
+
+	client.SetCredentials(auth.Credentials{APIKey: "daffy-key"})
+	if _, err := client.Exec(execReq); err != nil {
+		t.Fatalf("exec with credentials - %s", err)
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "frames-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	files, err := tlstest.WriteFiles(tmpDir, "daffy")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backendName := "tls-backend"
+	cfg := &frames.Config{
+		Backends: []*frames.BackendConfig{
+			{
+				Name:    backendName,
+				Type:    "csv",
+				RootDir: tmpDir,
+			},
+		},
+		TLS: &frames.TLSConfig{
+			CertFile:     files.ServerCertFile,
+			KeyFile:      files.ServerKeyFile,
+			ClientCAFile: files.CAFile,
+		},
+		Authorization: &frames.AuthorizationConfig{
+			Rules: []*frames.AuthorizationRule{
+				{Effect: "allow", Users: []string{"daffy"}},
+			},
+		},
+	}
+
+	port, err := freePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv, err := http.NewServer(cfg, fmt.Sprintf(":%d", port), nil, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(100 * time.Millisecond) // Let server start
+
+	url := fmt.Sprintf("https://localhost:%d", port)
+	execReq := &pb.ExecRequest{Backend: backendName, Table: "t1", Command: "ping"}
+
+	noCertConfig := &frames.ClientTLSConfig{CAFile: files.CAFile}
+	client, err := http.NewClientWithTLS(url, nil, nil, noCertConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Exec(execReq); err == nil {
+		t.Fatal("exec without client certificate")
+	}
+
+	tlsConfig := &frames.ClientTLSConfig{
+		CAFile:   files.CAFile,
+		CertFile: files.ClientCertFile,
+		KeyFile:  files.ClientKeyFile,
+	}
+	client, err = http.NewClientWithTLS(url, nil, nil, tlsConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Exec(execReq); err != nil {
+		t.Fatalf("exec with client certificate - %s", err)
+	}
+}
+
+func testMetrics(t *testing.T, baseURL string, backend string, nRows int) {
+	resp, err := nhttp.Get(baseURL + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != nhttp.StatusOK {
+		t.Fatalf("metrics: bad status - %d %s", resp.StatusCode, data)
+	}
+
+	expected := []string{
+		fmt.Sprintf(`frames_requests_total{backend=%q,operation="exec",status="ok"}`, backend),
+		fmt.Sprintf(`frames_request_duration_seconds_count{backend=%q,operation="write"}`, backend),
+		fmt.Sprintf(`frames_rows_total{backend=%q,operation="write"} %d`, backend, nRows),
+		`frames_inflight_streams{operation="read"} 0`,
+		"frames_encoded_bytes_total",
+	}
+	for _, line := range expected {
+		if !bytes.Contains(data, []byte(line)) {
+			t.Fatalf("metrics: can't find %s in\n%s", line, data)
+		}
+	}
+}
+
+// testCapabilities gets capabilities with a query argument (e.g. as with curl)
+func testCapabilities(t *testing.T, baseURL string, backend string) {
+	resp, err := nhttp.Get(fmt.Sprintf("%s/capabilities?backend=%s", baseURL, backend))
+	if err != nil {
+		t.Fatalf("can't get capabilities - %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != nhttp.StatusOK {
+		t.Fatalf("capabilities: bad status - %d %s", resp.StatusCode, resp.Status)
+	}
+
+	reply := &pb.CapabilitiesResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(reply); err != nil {
+		t.Fatalf("can't decode capabilities - %s", err)
+	}
+
+	if len(reply.Backends) != 1 || reply.Backends[0].Name != backend || reply.Backends[0].Type != "csv" {
+		t.Fatalf("bad capabilities - %+v", reply.Backends)
+	}
 }
 
 func testGrafana(t *testing.T, baseURL string, backend string, table string) {
@@ -165,6 +560,44 @@ func testGrafana(t *testing.T, baseURL string, backend string, table string) {
 	}
 }
 
+// testRawRead reads with a text data format (e.g. as with curl)
+func testRawRead(t *testing.T, baseURL string, backend string, table string, format string, contentType string, nLines int) {
+	request := map[string]interface{}{
+		"backend":       backend,
+		"table":         table,
+		"data_format":   format,
+		"message_limit": 100,
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := nhttp.Post(baseURL+"/read", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("%s: can't read - %s", format, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != nhttp.StatusOK {
+		t.Fatalf("%s: bad status - %d %s", format, resp.StatusCode, resp.Status)
+	}
+
+	if ct := resp.Header.Get("Content-Type"); ct != contentType {
+		t.Fatalf("%s: bad content type - %q", format, ct)
+	}
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := bytes.Count(data, []byte("\n")); n != nLines {
+		t.Fatalf("%s: # of lines mismatch - %d != %d", format, n, nLines)
+	}
+}
+
 func freePort() (int, error) {
 	l, err := net.Listen("tcp", ":0")
 	if err != nil {
@@ -201,3 +634,82 @@ func makeFrame() (frames.Frame, error) {
 	}
 	return frames.NewFrameFromMap(columns, nil)
 }
+
+func TestTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider, err := tracing.NewProvider(&frames.TracingConfig{}, exporter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	tmpDir, err := ioutil.TempDir("", "frames-tracing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	backendName := "tracing-backend"
+	cfg := &frames.Config{
+		Backends: []*frames.BackendConfig{
+			{
+				Name:    backendName,
+				Type:    "csv",
+				RootDir: tmpDir,
+			},
+		},
+	}
+
+	port, err := freePort()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv, err := http.NewServer(cfg, fmt.Sprintf(":%d", port), nil, nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(100 * time.Millisecond) // Let server start
+
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	body := bytes.NewBufferString(fmt.Sprintf(`{"backend": %q, "table": "t1", "command": "ping", "session": {}}`, backendName))
+	req, err := nhttp.NewRequest("POST", fmt.Sprintf("http://localhost:%d/exec", port), body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("traceparent", fmt.Sprintf("00-%s-00f067aa0ba902b7-01", traceID))
+
+	resp, err := nhttp.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != nhttp.StatusOK {
+		t.Fatalf("exec: bad status - %d %s", resp.StatusCode, resp.Status)
+	}
+
+	spans := make(map[string]tracetest.SpanStub)
+	for _, span := range exporter.GetSpans() {
+		if span.SpanContext.TraceID().String() == traceID {
+			spans[span.Name] = span
+		}
+	}
+
+	serverSpan, ok := spans["/exec"]
+	if !ok {
+		t.Fatalf("no server span in %v", spans)
+	}
+	apiSpan := spans["api.exec"]
+	if apiSpan.Parent.SpanID() != serverSpan.SpanContext.SpanID() {
+		t.Fatal("api span is not a child of the server span")
+	}
+	backendSpan := spans["backend.Exec"]
+	if backendSpan.Parent.SpanID() != apiSpan.SpanContext.SpanID() {
+		t.Fatal("backend span is not a child of the api span")
+	}
+}
@@ -0,0 +1,275 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package http
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"mime"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/v3io/frames"
+)
+
+// Content types of /read data formats
+var formatContentTypes = map[string]string{
+	frames.FramesDataFormat: "application/octet-stream",
+	frames.ArrowDataFormat:  "application/vnd.apache.arrow.stream",
+	frames.JSONLDataFormat:  "application/x-ndjson",
+	frames.CSVDataFormat:    "text/csv",
+}
+
+// readFormat returns the data format of a read request, the Accept header is
+// used if the request has no format
+func readFormat(dataFormat string, accept string) (string, error) {
+	if dataFormat != "" {
+		if _, ok := formatContentTypes[dataFormat]; !ok {
+			return "", fmt.Errorf("unsupported data format - %q", dataFormat)
+		}
+		return dataFormat, nil
+	}
+
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(mediaRange)
+		if err != nil {
+			continue
+		}
+
+		for format, contentType := range formatContentTypes {
+			if mediaType == contentType {
+				return format, nil
+			}
+		}
+
+		// Some tools use these for JSON lines
+		if mediaType == "application/jsonl" || mediaType == "application/x-jsonlines" {
+			return frames.JSONLDataFormat, nil
+		}
+	}
+
+	return frames.FramesDataFormat, nil
+}
+
+// frameEncoder encodes frames in /read responses of formats other than the
+// default one
+type frameEncoder interface {
+	Encode(frame frames.Frame) error
+	EncodeError(err error) error // Error at the end of the response
+	Close() error
+}
+
+func newFrameEncoder(format string, w io.Writer) (frameEncoder, error) {
+	switch format {
+	case frames.ArrowDataFormat:
+		return frames.NewArrowEncoder(w), nil
+	case frames.JSONLDataFormat:
+		return &jsonlEncoder{w: w}, nil
+	case frames.CSVDataFormat:
+		return &csvEncoder{w: w, writer: csv.NewWriter(w)}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported data format - %q", format)
+}
+
+// jsonlEncoder writes a JSON object per row. Nulls, NaN and Inf are written as
+// null since JSON can't represent them
+type jsonlEncoder struct {
+	w   io.Writer
+	buf bytes.Buffer
+}
+
+func (e *jsonlEncoder) Encode(frame frames.Frame) error {
+	names := rowNames(frame)
+	it := frame.IterRows(true)
+	for it.Next() {
+		row := it.Row()
+		e.buf.Reset()
+		e.buf.WriteByte('{')
+		for i, name := range names {
+			if i > 0 {
+				e.buf.WriteByte(',')
+			}
+
+			key, err := json.Marshal(name)
+			if err != nil {
+				return err
+			}
+			e.buf.Write(key)
+			e.buf.WriteByte(':')
+
+			value := row[name]
+			if isNullValue(frame, it.RowNum(), name, value) {
+				e.buf.WriteString("null")
+				continue
+			}
+
+			data, err := json.Marshal(value)
+			if err != nil {
+				return errors.Wrapf(err, "%s:%d can't encode value", name, it.RowNum())
+			}
+			e.buf.Write(data)
+		}
+		e.buf.WriteString("}\n")
+
+		if _, err := e.w.Write(e.buf.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return it.Err()
+}
+
+func (e *jsonlEncoder) EncodeError(err error) error {
+	data, jsonErr := json.Marshal(map[string]string{"error": err.Error()})
+	if jsonErr != nil {
+		return jsonErr
+	}
+
+	_, err = fmt.Fprintf(e.w, "%s\n", data)
+	return err
+}
+
+func (e *jsonlEncoder) Close() error {
+	return nil
+}
+
+// csvEncoder writes rows as CSV, a header line is written before the first
+// frame and whenever the columns change. Nulls are empty cells
+type csvEncoder struct {
+	w      io.Writer
+	writer *csv.Writer
+	header []string
+}
+
+func (e *csvEncoder) Encode(frame frames.Frame) error {
+	names := rowNames(frame)
+	if !reflect.DeepEqual(names, e.header) {
+		if err := e.writer.Write(names); err != nil {
+			return err
+		}
+		e.header = names
+	}
+
+	record := make([]string, len(names))
+	it := frame.IterRows(true)
+	for it.Next() {
+		row := it.Row()
+		for i, name := range names {
+			if frame.IsNull(it.RowNum(), name) {
+				record[i] = ""
+				continue
+			}
+			record[i] = csvValue(row[name])
+		}
+
+		if err := e.writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	if err := it.Err(); err != nil {
+		return err
+	}
+
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// EncodeError writes the error in a "#error:" line (skipped by CSV readers
+// that support comments)
+func (e *csvEncoder) EncodeError(err error) error {
+	e.writer.Flush()
+	if err := e.writer.Error(); err != nil {
+		return err
+	}
+
+	msg := strings.Replace(err.Error(), "\n", " ", -1)
+	_, err = fmt.Fprintf(e.w, "#error: %s\n", msg)
+	return err
+}
+
+func (e *csvEncoder) Close() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+// rowNames returns the row keys of frame.IterRows(true) in column order,
+// followed by the indices
+func rowNames(frame frames.Frame) []string {
+	names := make([]string, 0, len(frame.Names())+len(frame.Indices()))
+	for i, name := range frame.Names() {
+		if name == "" {
+			name = fmt.Sprintf("col-%d", i)
+		}
+		names = append(names, name)
+	}
+
+	for i, col := range frame.Indices() {
+		name := col.Name()
+		switch {
+		case name != "":
+		case i == 0:
+			name = "idx"
+		default:
+			name = fmt.Sprintf("idx-%d", i)
+		}
+		names = append(names, name)
+	}
+
+	return names
+}
+
+func isNullValue(frame frames.Frame, row int, name string, value interface{}) bool {
+	if frame.IsNull(row, name) {
+		return true
+	}
+
+	f, ok := value.(float64)
+	if f32, ok32 := value.(float32); ok32 {
+		f, ok = float64(f32), true
+	}
+	return ok && (math.IsNaN(f) || math.IsInf(f, 0))
+}
+
+// csvValue formats a row value, bytes are base64 and lists are JSON
+func csvValue(value interface{}) string {
+	switch value := value.(type) {
+	case time.Time:
+		return value.Format(time.RFC3339Nano)
+	case []byte:
+		return base64.StdEncoding.EncodeToString(value)
+	case []interface{}:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprintf("%v", value)
+		}
+		return string(data)
+	}
+	return fmt.Sprintf("%v", value)
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/pb"
+)
+
+func TestReadFormat(t *testing.T) {
+	testCases := []struct {
+		dataFormat string
+		accept     string
+		expected   string
+	}{
+		{"", "", frames.FramesDataFormat},
+		{"", "*/*", frames.FramesDataFormat},
+		{"csv", "", frames.CSVDataFormat},
+		{"jsonl", "text/csv", frames.JSONLDataFormat},
+		{"", "text/csv; charset=utf-8", frames.CSVDataFormat},
+		{"", "text/html, application/x-ndjson", frames.JSONLDataFormat},
+		{"", "application/vnd.apache.arrow.stream", frames.ArrowDataFormat},
+	}
+
+	for _, tc := range testCases {
+		format, err := readFormat(tc.dataFormat, tc.accept)
+		if err != nil {
+			t.Fatalf("%q, %q: %s", tc.dataFormat, tc.accept, err)
+		}
+
+		if format != tc.expected {
+			t.Fatalf("%q, %q: format mismatch %q != %q", tc.dataFormat, tc.accept, format, tc.expected)
+		}
+	}
+
+	if _, err := readFormat("xml", ""); err == nil {
+		t.Fatal("no error on unknown format")
+	}
+}
+
+func newFormatsFrame(t *testing.T) frames.Frame {
+	ints, err := frames.NewSliceColumn("i", []int64{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	floats, err := frames.NewSliceColumn("f", []float64{math.NaN(), 2.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	strs, err := frames.NewSliceColumn("s", []string{"a,b", ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	times := []time.Time{time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), time.Date(2020, 1, 3, 3, 4, 5, 0, time.UTC)}
+	index, err := frames.NewSliceColumn("t", times)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nulls := []*pb.NullValuesMap{
+		{NullColumns: map[string]bool{}},
+		{NullColumns: map[string]bool{"s": true}},
+	}
+
+	frame, err := frames.NewFrameWithNullValues([]frames.Column{ints, floats, strs}, []frames.Column{index}, nil, nulls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return frame
+}
+
+func TestFrameEncoders(t *testing.T) {
+	testCases := []struct {
+		format   string
+		expected string
+	}{
+		{
+			frames.JSONLDataFormat,
+			`{"i":1,"f":null,"s":"a,b","t":"2020-01-02T03:04:05Z"}
+{"i":2,"f":2.5,"s":null,"t":"2020-01-03T03:04:05Z"}
+{"i":1,"f":null,"s":"a,b","t":"2020-01-02T03:04:05Z"}
+{"i":2,"f":2.5,"s":null,"t":"2020-01-03T03:04:05Z"}
+{"error":"oops"}
+`,
+		},
+		{
+			frames.CSVDataFormat,
+			`i,f,s,t
+1,NaN,"a,b",2020-01-02T03:04:05Z
+2,2.5,,2020-01-03T03:04:05Z
+1,NaN,"a,b",2020-01-02T03:04:05Z
+2,2.5,,2020-01-03T03:04:05Z
+#error: oops
+`,
+		},
+	}
+
+	frame := newFormatsFrame(t)
+	for _, tc := range testCases {
+		var buf bytes.Buffer
+		enc, err := newFrameEncoder(tc.format, &buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i := 0; i < 2; i++ {
+			if err := enc.Encode(frame); err != nil {
+				t.Fatalf("%s: %s", tc.format, err)
+			}
+		}
+
+		if err := enc.EncodeError(fmt.Errorf("oops")); err != nil {
+			t.Fatalf("%s: %s", tc.format, err)
+		}
+
+		if buf.String() != tc.expected {
+			t.Fatalf("%s: bad output:\n%s", tc.format, buf.String())
+		}
+	}
+}
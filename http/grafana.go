@@ -441,7 +441,7 @@ func findColumnOrIndices(frame frames.Frame, col string) (frames.Column, error)
 func prepareKVColumnFormat(column frames.Column, field string) tableColumn {
 	columnTypeStr := "string"
 	switch column.DType() {
-	case frames.FloatType, frames.IntType:
+	case frames.FloatType, frames.IntType, frames.Float32Type, frames.Int32Type:
 		columnTypeStr = "number"
 	case frames.TimeType:
 		columnTypeStr = "time"
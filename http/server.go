@@ -23,10 +23,13 @@ package http
 import (
 	"bufio"
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"path"
 
@@ -34,8 +37,13 @@ import (
 	"github.com/pkg/errors"
 	"github.com/v3io/frames"
 	"github.com/v3io/frames/api"
+	"github.com/v3io/frames/auth"
+	"github.com/v3io/frames/authz"
 	"github.com/v3io/frames/backends/utils"
+	"github.com/v3io/frames/metrics"
 	"github.com/v3io/frames/pb"
+	"github.com/v3io/frames/tlsconfig"
+	"github.com/v3io/frames/tracing"
 	"github.com/valyala/fasthttp"
 )
 
@@ -47,6 +55,17 @@ var (
 
 const AccessKeyUser = "__ACCESS_KEY"
 
+// User value key of the request identity
+const identityKey = "identity"
+
+// Routes that don't require authentication
+var publicRoutes = map[string]bool{
+	"/":         true,
+	"/_/status": true,
+	"/metrics":  true,
+	"/version":  true,
+}
+
 // Server is HTTP server
 type Server struct {
 	*frames.ServerBase
@@ -55,10 +74,12 @@ type Server struct {
 	server  *fasthttp.Server
 	routes  map[string]func(*fasthttp.RequestCtx)
 
-	config  *frames.Config
-	api     *api.API
-	logger  logger.Logger
-	version string
+	config        *frames.Config
+	api           *api.API
+	authenticator auth.Authenticator // nil if there's no authentication
+	tlsConfig     *tls.Config        // nil for plain TCP
+	logger        logger.Logger
+	version       string
 }
 
 // NewServer creates a new server
@@ -85,14 +106,28 @@ func NewServer(config *frames.Config, addr string, logger logger.Logger, history
 		return nil, errors.Wrap(err, "can't create API")
 	}
 
+	authenticator, err := auth.New(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't create authenticator")
+	}
+
+	var tlsConfig *tls.Config
+	if config.TLS != nil {
+		if tlsConfig, err = tlsconfig.NewServer(config.TLS, logger); err != nil {
+			return nil, errors.Wrap(err, "can't create TLS configuration")
+		}
+	}
+
 	srv := &Server{
 		ServerBase: frames.NewServerBase(),
 
-		address: addr,
-		config:  config,
-		logger:  logger,
-		api:     api,
-		version: version,
+		address:       addr,
+		config:        config,
+		logger:        logger,
+		api:           api,
+		authenticator: authenticator,
+		tlsConfig:     tlsConfig,
+		version:       version,
 	}
 
 	srv.initRoutes()
@@ -113,8 +148,18 @@ func (s *Server) Start() error {
 		MaxRequestBodySize: 8 * (1 << 30), // 8GB
 	}
 
+	serve := func() error { return s.server.ListenAndServe(s.address) }
+	if s.tlsConfig != nil {
+		lis, err := net.Listen("tcp4", s.address)
+		if err != nil {
+			s.SetError(err)
+			return err
+		}
+		serve = func() error { return s.server.Serve(tls.NewListener(lis, s.tlsConfig)) }
+	}
+
 	go func() {
-		err := s.server.ListenAndServe(s.address)
+		err := serve()
 		if err != nil {
 			s.logger.ErrorWith("error running HTTP server", "error", err)
 			s.SetError(err)
@@ -122,7 +167,7 @@ func (s *Server) Start() error {
 	}()
 
 	s.SetState(frames.RunningState)
-	s.logger.InfoWith("HTTP server started", "address", s.address)
+	s.logger.InfoWith("HTTP server started", "address", s.address, "tls", s.tlsConfig != nil)
 	return nil
 }
 
@@ -130,16 +175,63 @@ func (s *Server) handler(ctx *fasthttp.RequestCtx) {
 	// Avoid something like a double slash causing a misroute to status due to the fact that ctx.URI() and ctx.Path()
 	// translate a path like //read to /, which in turn causes the plaintext status being returned to a client that is
 	// expecteing a binary response (which currently results in a Python MemoryError on the client side).
-	canonicalPath := path.Clean(string(ctx.Request.Header.RequestURI()))
+	canonicalPath := path.Clean(string(ctx.URI().PathOriginal()))
 	fn, ok := s.routes[canonicalPath]
 	if !ok {
 		ctx.Error(fmt.Sprintf("unknown path - %q", string(ctx.Path())), http.StatusNotFound)
 		return
 	}
 
+	span := startSpan(ctx, canonicalPath)
+	defer endSpan(ctx, span)
+
+	if s.authenticator != nil && !publicRoutes[canonicalPath] {
+		if !s.authenticate(ctx) {
+			return
+		}
+	}
+
 	fn(ctx)
 }
 
+// authenticate verifies the request credentials and sets its identity,
+// replies with an error and returns false if they're missing or bad
+func (s *Server) authenticate(ctx *fasthttp.RequestCtx) bool {
+	creds := auth.Credentials{
+		APIKey:      string(ctx.Request.Header.Peek(auth.APIKeyHeader)),
+		BearerToken: auth.ParseBearer(string(ctx.Request.Header.Peek(auth.AuthorizationHeader))),
+	}
+	if state := ctx.TLSConnectionState(); state != nil && len(state.VerifiedChains) > 0 {
+		creds.Certificate = state.VerifiedChains[0][0]
+	}
+
+	identity, err := s.authenticator.Authenticate(creds)
+	if err != nil {
+		s.logger.WarnWith("authentication failed", "path", string(ctx.Path()), "error", err.Error())
+		ctx.Error(fmt.Sprintf("unauthorized - %s", err), http.StatusUnauthorized)
+		ctx.Response.Header.Set("WWW-Authenticate", `Bearer realm="frames"`)
+		return false
+	}
+
+	ctx.SetUserValue(identityKey, identity)
+	return true
+}
+
+// apiErrorStatus returns the HTTP status of an API error
+func apiErrorStatus(err error) int {
+	if authz.IsDenied(err) {
+		return http.StatusForbidden
+	}
+
+	return http.StatusInternalServerError
+}
+
+// identityOf returns the request identity, nil if there's no authentication
+func identityOf(ctx *fasthttp.RequestCtx) *frames.Identity {
+	identity, _ := ctx.UserValue(identityKey).(*frames.Identity)
+	return identity
+}
+
 func (s *Server) handleStatus(ctx *fasthttp.RequestCtx) {
 	status := map[string]interface{}{
 		"state": s.State(),
@@ -148,6 +240,14 @@ func (s *Server) handleStatus(ctx *fasthttp.RequestCtx) {
 	_ = s.replyJSON(ctx, status)
 }
 
+func (s *Server) handleMetrics(ctx *fasthttp.RequestCtx) {
+	ctx.SetContentType(metrics.ContentType)
+	if err := metrics.Write(ctx); err != nil {
+		s.logger.ErrorWith("can't write metrics", "error", err)
+		ctx.Error(fmt.Sprintf("can't write metrics - %s", err), http.StatusInternalServerError)
+	}
+}
+
 func (s *Server) handleRead(ctx *fasthttp.RequestCtx) {
 	if !ctx.IsPost() { // ctx.PostBody() blocks on GET
 		ctx.Error("unsupported method", http.StatusMethodNotAllowed)
@@ -172,21 +272,51 @@ func (s *Server) handleRead(ctx *fasthttp.RequestCtx) {
 		requestInner.Session.Password = ""
 		requestInner.Session.Token = ""
 	}
+	request.Identity = identityOf(ctx)
 
 	s.logger.DebugWith("read request", "request", request)
 
+	format, err := readFormat(requestInner.DataFormat, string(ctx.Request.Header.Peek("Accept")))
+	if err != nil {
+		ctx.Error(err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Denials are replied before the body is streamed
+	if err := s.api.AuthorizeRead(request); err != nil {
+		ctx.Error(err.Error(), apiErrorStatus(err))
+		return
+	}
+
+	// The body is written after the handler returns, ctx can't be used. The
+	// read is cancelled when the client disconnects (flush fails)
+	readCtx, cancel := context.WithCancel(withSpan(context.Background(), ctx))
 	ch := make(chan frames.Frame)
 	var apiError error
 	go func() {
 		defer close(ch)
-		apiError = s.api.Read(request, ch)
+		apiError = s.api.Read(readCtx, request, ch)
 		if apiError != nil {
 			s.logger.ErrorWith("error reading", "error", apiError)
 		}
 	}()
 
+	if format != frames.FramesDataFormat {
+		ctx.SetContentType(formatContentTypes[format])
+		ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
+			defer cancel()
+			_, span := tracing.Start(readCtx, "http.encode", formatKey.String(format))
+			defer span.End()
+			s.writeFrames(w, format, ch, cancel, func() error { return apiError })
+		})
+		return
+	}
+
 	ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
-		enc := frames.NewEncoder(w)
+		defer cancel()
+		_, span := tracing.Start(readCtx, "http.encode", formatKey.String(format))
+		defer span.End()
+		enc := frames.NewEncoder(countingWriter{w})
 		for frame := range ch {
 			iface, ok := frame.(pb.Framed)
 			if !ok {
@@ -201,6 +331,7 @@ func (s *Server) handleRead(ctx *fasthttp.RequestCtx) {
 
 			if err := w.Flush(); err != nil {
 				s.logger.ErrorWith("can't flush", "error", err)
+				cancel()
 				s.writeError(enc, err)
 			}
 		}
@@ -211,6 +342,54 @@ func (s *Server) handleRead(ctx *fasthttp.RequestCtx) {
 	})
 }
 
+// writeFrames writes the frames in a non default format, the read is
+// cancelled on write errors. apiError is called after ch is closed
+func (s *Server) writeFrames(w *bufio.Writer, format string, ch chan frames.Frame, cancel context.CancelFunc, apiError func() error) {
+	enc, err := newFrameEncoder(format, countingWriter{w})
+	for frame := range ch {
+		if err != nil {
+			continue // Drain the channel so the API won't block
+		}
+
+		if err = enc.Encode(frame); err != nil {
+			s.logger.ErrorWith("can't encode result", "error", err)
+			cancel()
+			continue
+		}
+
+		if err = w.Flush(); err != nil {
+			s.logger.ErrorWith("can't flush", "error", err)
+			cancel()
+		}
+	}
+
+	if enc == nil {
+		return
+	}
+
+	if err == nil {
+		err = apiError()
+	}
+
+	// Errors are written at the end of the response (the status is already sent)
+	if err != nil {
+		_ = enc.EncodeError(err)
+	} else if err := enc.Close(); err != nil {
+		s.logger.ErrorWith("can't close encoder", "error", err)
+	}
+}
+
+// countingWriter adds the bytes written to the encoded bytes metric
+type countingWriter struct {
+	w io.Writer
+}
+
+func (cw countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	metrics.EncodedBytes.Add(float64(n))
+	return n, err
+}
+
 func (s *Server) writeError(enc *frames.Encoder, err error) {
 	msg := &pb.Frame{
 		Error: err.Error(),
@@ -270,17 +449,20 @@ func (s *Server) handleWrite(ctx *fasthttp.RequestCtx) {
 	s.httpAuth(ctx, request.Session)
 	request.Password = frames.InitSecretString(req.Session.Password)
 	request.Token = frames.InitSecretString(req.Session.Token)
+	request.Identity = identityOf(ctx)
 	req.Session.Password = ""
 	req.Session.Token = ""
 
 	var nFrames, nRows int
 	var writeError error
 
+	writeCtx, cancel := context.WithCancel(withSpan(ctx, ctx))
+	defer cancel()
 	ch := make(chan frames.Frame, 1)
 	done := make(chan bool)
 	go func() {
 		defer close(done)
-		nFrames, nRows, writeError = s.api.Write(request, ch)
+		nFrames, nRows, writeError = s.api.Write(writeCtx, request, ch)
 	}()
 
 	for writeError == nil {
@@ -290,6 +472,7 @@ func (s *Server) handleWrite(ctx *fasthttp.RequestCtx) {
 			if err != io.EOF {
 				s.logger.ErrorWith("decode error", "error", err)
 				ctx.Error("decode error", http.StatusInternalServerError)
+				cancel() // Client disconnected or sent bad data
 			}
 			break
 		}
@@ -303,7 +486,7 @@ func (s *Server) handleWrite(ctx *fasthttp.RequestCtx) {
 	// We can't handle writeError right after .Write since it's done in a goroutine
 	if writeError != nil {
 		s.logger.ErrorWith("write error", "error", writeError)
-		ctx.Error("write error: "+writeError.Error(), http.StatusInternalServerError)
+		ctx.Error("write error: "+writeError.Error(), apiErrorStatus(writeError))
 		return
 	}
 
@@ -336,10 +519,11 @@ func (s *Server) handleCreate(ctx *fasthttp.RequestCtx) {
 		requestInner.Session.Password = ""
 		requestInner.Session.Token = ""
 	}
+	request.Identity = identityOf(ctx)
 
 	s.logger.InfoWith("create", "request", request)
-	if err := s.api.Create(request); err != nil {
-		ctx.Error(err.Error(), http.StatusInternalServerError)
+	if err := s.api.Create(withSpan(ctx, ctx), request); err != nil {
+		ctx.Error(err.Error(), apiErrorStatus(err))
 		return
 	}
 
@@ -368,15 +552,105 @@ func (s *Server) handleDelete(ctx *fasthttp.RequestCtx) {
 		requestInner.Session.Password = ""
 		requestInner.Session.Token = ""
 	}
+	request.Identity = identityOf(ctx)
 
-	if err := s.api.Delete(request); err != nil {
-		ctx.Error(err.Error(), http.StatusInternalServerError)
+	if err := s.api.Delete(withSpan(ctx, ctx), request); err != nil {
+		ctx.Error(err.Error(), apiErrorStatus(err))
 		return
 	}
 
 	s.replyOK(ctx)
 }
 
+func (s *Server) handleListTables(ctx *fasthttp.RequestCtx) {
+	if !ctx.IsPost() { // ctx.PostBody() blocks on GET
+		ctx.Error("unsupported method", http.StatusMethodNotAllowed)
+	}
+
+	requestInner := &pb.ListTablesRequest{}
+	if err := json.Unmarshal(ctx.PostBody(), requestInner); err != nil {
+		s.logger.ErrorWith("can't decode request", "error", err)
+		ctx.Error(fmt.Sprintf("bad request - %s", err), http.StatusBadRequest)
+		return
+	}
+	request := &frames.ListTablesRequest{
+		Proto: requestInner,
+	}
+
+	if requestInner.Session != nil {
+		s.httpAuth(ctx, requestInner.Session)
+		request.Password = frames.InitSecretString(requestInner.Session.Password)
+		request.Token = frames.InitSecretString(requestInner.Session.Token)
+		requestInner.Session.Password = ""
+		requestInner.Session.Token = ""
+	}
+	request.Identity = identityOf(ctx)
+
+	tables, err := s.api.ListTables(withSpan(ctx, ctx), request)
+	if err != nil {
+		ctx.Error(err.Error(), apiErrorStatus(err))
+		return
+	}
+
+	_ = s.replyJSON(ctx, &pb.ListTablesResponse{Tables: tables})
+}
+
+func (s *Server) handleDescribe(ctx *fasthttp.RequestCtx) {
+	if !ctx.IsPost() { // ctx.PostBody() blocks on GET
+		ctx.Error("unsupported method", http.StatusMethodNotAllowed)
+	}
+
+	requestInner := &pb.DescribeTableRequest{}
+	if err := json.Unmarshal(ctx.PostBody(), requestInner); err != nil {
+		s.logger.ErrorWith("can't decode request", "error", err)
+		ctx.Error(fmt.Sprintf("bad request - %s", err), http.StatusBadRequest)
+		return
+	}
+	request := &frames.DescribeTableRequest{
+		Proto: requestInner,
+	}
+
+	if requestInner.Session != nil {
+		s.httpAuth(ctx, requestInner.Session)
+		request.Password = frames.InitSecretString(requestInner.Session.Password)
+		request.Token = frames.InitSecretString(requestInner.Session.Token)
+		requestInner.Session.Password = ""
+		requestInner.Session.Token = ""
+	}
+	request.Identity = identityOf(ctx)
+
+	description, err := s.api.DescribeTable(withSpan(ctx, ctx), request)
+	if err != nil {
+		ctx.Error(err.Error(), apiErrorStatus(err))
+		return
+	}
+
+	_ = s.replyJSON(ctx, description)
+}
+
+// handleCapabilities returns backends capabilities, the backend is taken from
+// the "backend" query argument on GET
+func (s *Server) handleCapabilities(ctx *fasthttp.RequestCtx) {
+	request := &pb.CapabilitiesRequest{}
+	if ctx.IsPost() {
+		if err := json.Unmarshal(ctx.PostBody(), request); err != nil {
+			s.logger.ErrorWith("can't decode request", "error", err)
+			ctx.Error(fmt.Sprintf("bad request - %s", err), http.StatusBadRequest)
+			return
+		}
+	} else {
+		request.Backend = string(ctx.QueryArgs().Peek("backend"))
+	}
+
+	caps, err := s.api.Capabilities(request)
+	if err != nil {
+		ctx.Error(err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	_ = s.replyJSON(ctx, &pb.CapabilitiesResponse{Backends: caps})
+}
+
 func (s *Server) handleConfig(ctx *fasthttp.RequestCtx) {
 	_ = s.replyJSON(ctx, s.config)
 }
@@ -415,16 +689,17 @@ func (s *Server) handleExec(ctx *fasthttp.RequestCtx) {
 	s.httpAuth(ctx, request.Proto.Session)
 	request.Password = frames.InitSecretString(request.Proto.Session.Password)
 	request.Token = frames.InitSecretString(request.Proto.Session.Token)
+	request.Identity = identityOf(ctx)
 	request.Proto.Session.Password = ""
 	request.Proto.Session.Token = ""
 
-	frame, err := s.api.Exec(request)
+	frame, err := s.api.Exec(withSpan(ctx, ctx), request)
 	if err != nil {
-		ctx.Error("can't exec", http.StatusInternalServerError)
+		ctx.Error(err.Error(), apiErrorStatus(err))
 		return
 	}
 
-	enc := frames.NewEncoder(ctx)
+	enc := frames.NewEncoder(countingWriter{ctx})
 	var frameData string
 	if frame != nil {
 		data, err := frames.MarshalFrame(frame)
@@ -475,7 +750,7 @@ func (s *Server) handleSimpleJSON(ctx *fasthttp.RequestCtx, method string) {
 		for _, req := range requests {
 			result, err := s.executeSimpleJSONSubRequest(req, ctx)
 			if err != nil {
-				ctx.Error(fmt.Sprintf("Error querying: %s", err.Error()), http.StatusInternalServerError)
+				ctx.Error(fmt.Sprintf("Error querying: %s", err.Error()), apiErrorStatus(err))
 				return
 			}
 			results = appendSimpleJSONResults(results, result)
@@ -507,17 +782,22 @@ func (s *Server) executeSimpleJSONSubRequest(req simpleJSONRequestInterface, ctx
 	s.httpAuth(ctx, request.Proto.Session)
 	request.Password = frames.InitSecretString(request.Proto.Session.Password)
 	request.Token = frames.InitSecretString(request.Proto.Session.Token)
+	request.Identity = identityOf(ctx)
 	request.Proto.Session.Password = ""
 	request.Proto.Session.Token = ""
 
+	readCtx, cancel := context.WithCancel(withSpan(ctx, ctx))
+	defer cancel()
 	ch := make(chan frames.Frame)
 	var apiError error
 	go func() {
 		defer close(ch)
-		apiError = s.api.Read(request, ch)
+		apiError = s.api.Read(readCtx, request, ch)
 	}()
 
+	_, span := tracing.Start(readCtx, "simplejson.CreateResponse")
 	resp, err := CreateResponse(req, ch)
+	tracing.End(span, err)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to build a response")
 	}
@@ -545,6 +825,7 @@ func (s *Server) handleHistory(ctx *fasthttp.RequestCtx) {
 	s.httpAuth(ctx, request.Proto.Session)
 	request.Password = frames.InitSecretString(request.Proto.Session.Password)
 	request.Token = frames.InitSecretString(request.Proto.Session.Token)
+	request.Identity = identityOf(ctx)
 	request.Proto.Session.Password = ""
 	request.Proto.Session.Token = ""
 
@@ -559,7 +840,7 @@ func (s *Server) handleHistory(ctx *fasthttp.RequestCtx) {
 	}()
 
 	ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
-		enc := frames.NewEncoder(w)
+		enc := frames.NewEncoder(countingWriter{w})
 		for frame := range ch {
 			iface, ok := frame.(pb.Framed)
 			if !ok {
@@ -586,16 +867,20 @@ func (s *Server) handleHistory(ctx *fasthttp.RequestCtx) {
 
 // based on https://github.com/buaazp/fasthttprouter/tree/master/examples/auth
 func (s *Server) httpAuth(ctx *fasthttp.RequestCtx, session *frames.Session) {
-	auth := ctx.Request.Header.Peek("Authorization")
-	if auth == nil {
+	header := ctx.Request.Header.Peek("Authorization")
+	if header == nil {
 		return
 	}
 
 	switch {
-	case bytes.HasPrefix(auth, basicAuthPrefix):
-		s.parseBasicAuth(auth, session)
-	case bytes.HasPrefix(auth, bearerAuthPrefix):
-		s.parseBearerAuth(auth, session)
+	case bytes.HasPrefix(header, basicAuthPrefix):
+		s.parseBasicAuth(header, session)
+	case bytes.HasPrefix(header, bearerAuthPrefix):
+		// A JWT used to authenticate the request isn't a v3io token
+		if identity := identityOf(ctx); identity != nil && identity.Provider == auth.JWTProvider {
+			return
+		}
+		s.parseBearerAuth(header, session)
 	default:
 		s.logger.WarnWith("unknown auth scheme")
 	}
@@ -632,17 +917,21 @@ func (s *Server) parseBearerAuth(auth []byte, session *frames.Session) {
 
 func (s *Server) initRoutes() {
 	s.routes = map[string]func(*fasthttp.RequestCtx){
-		"/_/config": s.handleConfig,
-		"/_/status": s.handleStatus,
-		"/create":   s.handleCreate,
-		"/delete":   s.handleDelete,
-		"/read":     s.handleRead,
-		"/write":    s.handleWrite,
-		"/exec":     s.handleExec,
-		"/history":  s.handleHistory,
-		"/":         s.handleStatus,
-		"/query":    s.handleSimpleJSONQuery,
-		"/search":   s.handleSimpleJSONSearch,
-		"/version":  s.handleVersion,
+		"/_/config":     s.handleConfig,
+		"/_/status":     s.handleStatus,
+		"/capabilities": s.handleCapabilities,
+		"/create":       s.handleCreate,
+		"/delete":       s.handleDelete,
+		"/describe":     s.handleDescribe,
+		"/read":         s.handleRead,
+		"/write":        s.handleWrite,
+		"/exec":         s.handleExec,
+		"/history":      s.handleHistory,
+		"/metrics":      s.handleMetrics,
+		"/tables":       s.handleListTables,
+		"/":             s.handleStatus,
+		"/query":        s.handleSimpleJSONQuery,
+		"/search":       s.handleSimpleJSONSearch,
+		"/version":      s.handleVersion,
 	}
 }
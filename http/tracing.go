@@ -0,0 +1,93 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package http
+
+import (
+	"context"
+
+	"github.com/v3io/frames/tracing"
+	"github.com/valyala/fasthttp"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// User value key of the request span
+const spanKey = "span"
+
+// Reply format of encoding spans
+const formatKey = attribute.Key("frames.format")
+
+// headerCarrier carries trace context in request headers
+type headerCarrier struct {
+	header *fasthttp.RequestHeader
+}
+
+func (c headerCarrier) Get(key string) string {
+	return string(c.header.Peek(key))
+}
+
+func (c headerCarrier) Set(key string, value string) {
+	c.header.Set(key, value)
+}
+
+func (c headerCarrier) Keys() []string {
+	var keys []string
+	c.header.VisitAll(func(key, _ []byte) {
+		keys = append(keys, string(key))
+	})
+	return keys
+}
+
+// startSpan starts the span of a request to route, a child of the span in the
+// request headers
+func startSpan(ctx *fasthttp.RequestCtx, route string) trace.Span {
+	parent := tracing.Propagator.Extract(context.Background(), headerCarrier{&ctx.Request.Header})
+	_, span := tracing.StartServer(
+		parent,
+		route,
+		semconv.HTTPMethodKey.String(string(ctx.Method())),
+		semconv.HTTPRouteKey.String(route),
+	)
+
+	ctx.SetUserValue(spanKey, span)
+	return span
+}
+
+// endSpan ends the span of a request after the handler returns, bodies that
+// are streamed later aren't included
+func endSpan(ctx *fasthttp.RequestCtx, span trace.Span) {
+	status := ctx.Response.StatusCode()
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(status))
+	span.SetStatus(semconv.SpanStatusFromHTTPStatusCode(status))
+	span.End()
+}
+
+// withSpan returns parent with the span of the request, spans started with it
+// are children of the request span
+func withSpan(parent context.Context, ctx *fasthttp.RequestCtx) context.Context {
+	span, ok := ctx.UserValue(spanKey).(trace.Span)
+	if !ok {
+		return parent
+	}
+
+	return trace.ContextWithSpan(parent, span)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package frames
+
+import (
+	"fmt"
+
+	"github.com/v3io/frames/pb"
+)
+
+// LegacyFrame returns frame with the dtypes older clients know. int32 and
+// float32 columns are widened to int64 and float64, bytes (base64), decimal
+// and list (JSON) columns are converted to strings. Nulls stay nulls
+func LegacyFrame(frame Frame) (Frame, error) {
+	columns := make([]Column, len(frame.Names()))
+	changed := false
+	for i, name := range frame.Names() {
+		col, err := frame.Column(name)
+		if err != nil {
+			return nil, err
+		}
+
+		columns[i], err = legacyColumn(col)
+		if err != nil {
+			return nil, err
+		}
+		changed = changed || columns[i] != col
+	}
+
+	indices := make([]Column, len(frame.Indices()))
+	for i, col := range frame.Indices() {
+		var err error
+		indices[i], err = legacyColumn(col)
+		if err != nil {
+			return nil, err
+		}
+		changed = changed || indices[i] != col
+	}
+
+	if !changed {
+		return frame, nil
+	}
+
+	out, err := NewFrameWithNullValues(columns, indices, frame.Labels(), frame.NullValuesMap())
+	if err != nil {
+		return nil, err
+	}
+
+	if frame.Marker() == "" {
+		return out, nil
+	}
+	return WithMarker(out, frame.Marker())
+}
+
+// legacyColumn returns col with an older dtype, or col if its dtype is old
+func legacyColumn(col Column) (Column, error) {
+	c, ok := col.(*colImpl)
+	if !ok {
+		return nil, fmt.Errorf("unsupported column type - %T", col)
+	}
+
+	msg := &pb.Column{
+		Kind:  c.msg.Kind,
+		Name:  c.msg.Name,
+		Size:  c.msg.Size,
+		Nulls: c.msg.Nulls,
+	}
+
+	// Convert the stored values, label columns have one
+	values := *c.msg
+	values.Kind = pb.Column_SLICE
+	src := &colImpl{msg: &values}
+
+	switch c.msg.Dtype {
+	case pb.DType_INT32:
+		msg.Dtype = pb.DType_INTEGER
+		msg.Ints = c.msg.Ints
+	case pb.DType_FLOAT32:
+		msg.Dtype = pb.DType_FLOAT
+		msg.Floats = make([]float64, len(c.msg.Floats32))
+		for i, v := range c.msg.Floats32 {
+			msg.Floats[i] = float64(v)
+		}
+	case pb.DType_BYTES, pb.DType_DECIMAL, pb.DType_LIST:
+		msg.Dtype = pb.DType_STRING
+		msg.Strings = make([]string, src.Len())
+		for i := range msg.Strings {
+			var err error
+			if msg.Strings[i], err = src.StringAt(i); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return col, nil
+	}
+
+	return &colImpl{msg: msg}, nil
+}
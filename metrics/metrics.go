@@ -0,0 +1,149 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+// Package metrics has the Prometheus metrics of the server, they are
+// registered in the default Prometheus registry (together with the Go
+// runtime and process metrics)
+package metrics
+
+import (
+	"io"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+)
+
+const namespace = "frames"
+
+// ContentType is the content type of Write
+const ContentType = string(expfmt.FmtText)
+
+// Request status label values
+const (
+	StatusOK     = "ok"
+	StatusError  = "error"
+	StatusDenied = "denied"
+)
+
+var (
+	// Requests counts API requests by operation (read, write, create,
+	// delete, exec ...), backend and status
+	Requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "requests_total",
+		Help:      "Number of requests by operation, backend and status.",
+	}, []string{"operation", "backend", "status"})
+
+	// RequestDuration is API request latency by operation and backend
+	RequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "request_duration_seconds",
+		Help:      "Request latency by operation and backend.",
+		Buckets:   []float64{.005, .01, .05, .1, .5, 1, 5, 10, 30, 60, 300},
+	}, []string{"operation", "backend"})
+
+	// Rows counts rows read and written by backend
+	Rows = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rows_total",
+		Help:      "Number of rows read and written by operation and backend.",
+	}, []string{"operation", "backend"})
+
+	// Frames counts frames read and written by backend
+	Frames = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "frames_total",
+		Help:      "Number of frames read and written by operation and backend.",
+	}, []string{"operation", "backend"})
+
+	// InflightStreams is the number of read and write streams in progress
+	InflightStreams = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "inflight_streams",
+		Help:      "Number of read and write streams in progress.",
+	}, []string{"operation"})
+
+	// EncodedBytes counts bytes of responses written by the servers
+	EncodedBytes = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "encoded_bytes_total",
+		Help:      "Number of bytes of encoded response messages.",
+	})
+
+	// HistoryQueueLength is the number of history entries not written yet
+	HistoryQueueLength = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "history_queue_length",
+		Help:      "Number of history entries waiting to be written.",
+	})
+
+	// HistoryDropped counts history entries that failed to be written
+	HistoryDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "history_dropped_entries_total",
+		Help:      "Number of history entries that failed to be written.",
+	})
+
+	// QuerierCache counts TSDB querier cache lookups by result (hit or miss)
+	QuerierCache = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tsdb_querier_cache_requests_total",
+		Help:      "Number of TSDB querier cache lookups by result (hit or miss).",
+	}, []string{"result"})
+)
+
+func init() {
+	prometheus.MustRegister(
+		Requests,
+		RequestDuration,
+		Rows,
+		Frames,
+		InflightStreams,
+		EncodedBytes,
+		HistoryQueueLength,
+		HistoryDropped,
+		QuerierCache,
+	)
+}
+
+// ObserveRequest records a request of operation to backend that started at
+// start, status is one of the Status constants
+func ObserveRequest(operation, backend, status string, start time.Time) {
+	Requests.WithLabelValues(operation, backend, status).Inc()
+	RequestDuration.WithLabelValues(operation, backend).Observe(time.Since(start).Seconds())
+}
+
+// Write writes the metrics in Prometheus text format to w
+func Write(w io.Writer) error {
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		return err
+	}
+
+	encoder := expfmt.NewEncoder(w, expfmt.FmtText)
+	for _, family := range families {
+		if err := encoder.Encode(family); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
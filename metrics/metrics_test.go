@@ -0,0 +1,51 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWrite(t *testing.T) {
+	ObserveRequest("read", "kv", StatusOK, time.Now().Add(-time.Second))
+	ObserveRequest("read", "kv", StatusDenied, time.Now())
+
+	var buf bytes.Buffer
+	if err := Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	text := buf.String()
+	expected := []string{
+		`frames_requests_total{backend="kv",operation="read",status="ok"} 1`,
+		`frames_requests_total{backend="kv",operation="read",status="denied"} 1`,
+		`frames_request_duration_seconds_count{backend="kv",operation="read"} 2`,
+		"go_goroutines",
+	}
+	for _, line := range expected {
+		if !strings.Contains(text, line) {
+			t.Fatalf("can't find %s in\n%s", line, text)
+		}
+	}
+}
@@ -0,0 +1,644 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package ops
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/pb"
+)
+
+// accumulator is the per group state of an aggregation. Nulls and NaN floats
+// are skipped
+type accumulator interface {
+	// dtype is the type of the values added so far
+	dtype() frames.DType
+	// add adds the rows of col to groups, there are size groups
+	add(col frames.Column, groups []int, size int) error
+	// merge adds group i of other to groups[i], there are size groups
+	merge(other accumulator, groups []int, size int) error
+	// column returns the result column of size groups
+	column(name string, size int) (frames.Column, error)
+}
+
+var accumulators = map[string]func(dtype frames.DType) accumulator{
+	"count":          func(frames.DType) accumulator { return &countAccumulator{} },
+	"sum":            newSumAccumulator,
+	"avg":            func(frames.DType) accumulator { return &avgAccumulator{} },
+	"mean":           func(frames.DType) accumulator { return &avgAccumulator{} },
+	"stddev":         func(frames.DType) accumulator { return &stddevAccumulator{} },
+	"count_distinct": func(frames.DType) accumulator { return &distinctAccumulator{} },
+	"min": func(dtype frames.DType) accumulator {
+		return &pickAccumulator{typ: widenType(dtype), pick: func(current, value interface{}) bool {
+			return current == nil || compareValues(value, current) < 0
+		}}
+	},
+	"max": func(dtype frames.DType) accumulator {
+		return &pickAccumulator{typ: widenType(dtype), pick: func(current, value interface{}) bool {
+			return current == nil || compareValues(value, current) > 0
+		}}
+	},
+	"first": func(dtype frames.DType) accumulator {
+		return &pickAccumulator{typ: widenType(dtype), pick: func(current, value interface{}) bool {
+			return current == nil
+		}}
+	},
+	"last": func(dtype frames.DType) accumulator {
+		return &pickAccumulator{typ: widenType(dtype), pick: func(current, value interface{}) bool {
+			return true
+		}}
+	},
+}
+
+// present returns a function that reports if row i of col has a value
+func present(col frames.Column, data interface{}) func(i int) bool {
+	switch data := data.(type) {
+	case []float64:
+		return func(i int) bool { return !math.IsNaN(data[i]) && !col.IsNull(i) }
+	case []float32:
+		return func(i int) bool { return !math.IsNaN(float64(data[i])) && !col.IsNull(i) }
+	}
+	return func(i int) bool { return !col.IsNull(i) }
+}
+
+// numbers returns the values of a numeric column as floats
+func numbers(col frames.Column) ([]float64, error) {
+	data, err := widenedValues(col)
+	if err != nil {
+		return nil, err
+	}
+
+	switch data := data.(type) {
+	case []float64:
+		return data, nil
+	case []int64:
+		floats := make([]float64, len(data))
+		for i, value := range data {
+			floats[i] = float64(value)
+		}
+		return floats, nil
+	}
+
+	return nil, fmt.Errorf("%q column is not numeric (%s)", col.Name(), pb.DType(col.DType()))
+}
+
+type countAccumulator struct {
+	counts []int64
+}
+
+func (a *countAccumulator) dtype() frames.DType { return frames.IntType }
+
+func (a *countAccumulator) add(col frames.Column, groups []int, size int) error {
+	data, err := values(col)
+	if err != nil {
+		return err
+	}
+
+	a.counts = growInts(a.counts, size)
+	ok := present(col, data)
+	for i, group := range groups {
+		if ok(i) {
+			a.counts[group]++
+		}
+	}
+	return nil
+}
+
+func (a *countAccumulator) merge(other accumulator, groups []int, size int) error {
+	a.counts = growInts(a.counts, size)
+	for i, count := range other.(*countAccumulator).counts {
+		a.counts[groups[i]] += count
+	}
+	return nil
+}
+
+func (a *countAccumulator) column(name string, size int) (frames.Column, error) {
+	return frames.NewSliceColumn(name, growInts(a.counts, size))
+}
+
+// sumAccumulator sums integers as long as there are no floats
+type sumAccumulator struct {
+	isFloat bool
+	ints    []int64
+	floats  []float64
+	has     []bool
+}
+
+func newSumAccumulator(dtype frames.DType) accumulator {
+	switch dtype {
+	case frames.FloatType, frames.Float32Type, frames.DecimalType:
+		return &sumAccumulator{isFloat: true}
+	}
+	return &sumAccumulator{}
+}
+
+func (a *sumAccumulator) dtype() frames.DType {
+	if a.isFloat {
+		return frames.FloatType
+	}
+	return frames.IntType
+}
+
+func (a *sumAccumulator) toFloat() {
+	if a.isFloat {
+		return
+	}
+
+	a.floats = make([]float64, len(a.ints))
+	for i, value := range a.ints {
+		a.floats[i] = float64(value)
+	}
+	a.ints, a.isFloat = nil, true
+}
+
+func (a *sumAccumulator) grow(size int) {
+	if a.isFloat {
+		a.floats = growFloats(a.floats, size)
+	} else {
+		a.ints = growInts(a.ints, size)
+	}
+	a.has = growBools(a.has, size)
+}
+
+func (a *sumAccumulator) add(col frames.Column, groups []int, size int) error {
+	data, err := widenedValues(col)
+	if err != nil {
+		return err
+	}
+
+	switch data.(type) {
+	case []int64:
+	case []float64:
+		a.toFloat()
+	default:
+		return fmt.Errorf("%q column is not numeric (%s)", col.Name(), pb.DType(col.DType()))
+	}
+
+	a.grow(size)
+	ok := present(col, data)
+	for i, group := range groups {
+		if !ok(i) {
+			continue
+		}
+
+		switch data := data.(type) {
+		case []int64:
+			if a.isFloat {
+				a.floats[group] += float64(data[i])
+			} else {
+				a.ints[group] += data[i]
+			}
+		case []float64:
+			a.floats[group] += data[i]
+		}
+		a.has[group] = true
+	}
+	return nil
+}
+
+func (a *sumAccumulator) merge(other accumulator, groups []int, size int) error {
+	o := other.(*sumAccumulator)
+	if o.isFloat {
+		a.toFloat()
+	}
+
+	a.grow(size)
+	for i, has := range o.has {
+		if !has {
+			continue
+		}
+
+		group := groups[i]
+		switch {
+		case !a.isFloat:
+			a.ints[group] += o.ints[i]
+		case o.isFloat:
+			a.floats[group] += o.floats[i]
+		default:
+			a.floats[group] += float64(o.ints[i])
+		}
+		a.has[group] = true
+	}
+	return nil
+}
+
+func (a *sumAccumulator) column(name string, size int) (frames.Column, error) {
+	a.grow(size)
+	if a.isFloat {
+		return newColumn(name, a.floats, nullsOf(a.has))
+	}
+	return newColumn(name, a.ints, nullsOf(a.has))
+}
+
+type avgAccumulator struct {
+	sums   []float64
+	counts []int64
+}
+
+func (a *avgAccumulator) dtype() frames.DType { return frames.FloatType }
+
+func (a *avgAccumulator) add(col frames.Column, groups []int, size int) error {
+	data, err := numbers(col)
+	if err != nil {
+		return err
+	}
+
+	a.sums, a.counts = growFloats(a.sums, size), growInts(a.counts, size)
+	ok := present(col, data)
+	for i, group := range groups {
+		if ok(i) {
+			a.sums[group] += data[i]
+			a.counts[group]++
+		}
+	}
+	return nil
+}
+
+func (a *avgAccumulator) merge(other accumulator, groups []int, size int) error {
+	o := other.(*avgAccumulator)
+	a.sums, a.counts = growFloats(a.sums, size), growInts(a.counts, size)
+	for i, count := range o.counts {
+		a.sums[groups[i]] += o.sums[i]
+		a.counts[groups[i]] += count
+	}
+	return nil
+}
+
+func (a *avgAccumulator) column(name string, size int) (frames.Column, error) {
+	a.sums, a.counts = growFloats(a.sums, size), growInts(a.counts, size)
+	avgs := make([]float64, size)
+	has := make([]bool, size)
+	for i, count := range a.counts {
+		if count > 0 {
+			avgs[i], has[i] = a.sums[i]/float64(count), true
+		}
+	}
+	return newColumn(name, avgs, nullsOf(has))
+}
+
+// stddevAccumulator is a sample standard deviation using Welford's algorithm,
+// partial results are merged with Chan's formula
+type stddevAccumulator struct {
+	counts []int64
+	means  []float64
+	m2s    []float64
+}
+
+func (a *stddevAccumulator) dtype() frames.DType { return frames.FloatType }
+
+func (a *stddevAccumulator) grow(size int) {
+	a.counts = growInts(a.counts, size)
+	a.means, a.m2s = growFloats(a.means, size), growFloats(a.m2s, size)
+}
+
+func (a *stddevAccumulator) add(col frames.Column, groups []int, size int) error {
+	data, err := numbers(col)
+	if err != nil {
+		return err
+	}
+
+	a.grow(size)
+	ok := present(col, data)
+	for i, group := range groups {
+		if !ok(i) {
+			continue
+		}
+
+		a.counts[group]++
+		delta := data[i] - a.means[group]
+		a.means[group] += delta / float64(a.counts[group])
+		a.m2s[group] += delta * (data[i] - a.means[group])
+	}
+	return nil
+}
+
+func (a *stddevAccumulator) merge(other accumulator, groups []int, size int) error {
+	o := other.(*stddevAccumulator)
+	a.grow(size)
+	for i, count := range o.counts {
+		if count == 0 {
+			continue
+		}
+
+		group := groups[i]
+		n, m := float64(a.counts[group]), float64(count)
+		total := n + m
+		delta := o.means[i] - a.means[group]
+		a.means[group] += delta * m / total
+		a.m2s[group] += o.m2s[i] + delta*delta*n*m/total
+		a.counts[group] += count
+	}
+	return nil
+}
+
+func (a *stddevAccumulator) column(name string, size int) (frames.Column, error) {
+	a.grow(size)
+	stddevs := make([]float64, size)
+	has := make([]bool, size)
+	for i, count := range a.counts {
+		if count > 1 {
+			stddevs[i], has[i] = math.Sqrt(a.m2s[i]/float64(count-1)), true
+		}
+	}
+	return newColumn(name, stddevs, nullsOf(has))
+}
+
+// pickAccumulator keeps a value per group, pick reports if value replaces the
+// current one (nil if there's none)
+type pickAccumulator struct {
+	typ    frames.DType
+	values []interface{}
+	pick   func(current, value interface{}) bool
+}
+
+func (a *pickAccumulator) dtype() frames.DType { return a.typ }
+
+func (a *pickAccumulator) setType(dtype frames.DType) error {
+	typ, err := commonType(a.typ, dtype)
+	if err != nil {
+		return err
+	}
+
+	if typ == frames.FloatType && a.typ == frames.IntType {
+		for i, value := range a.values {
+			if value, ok := value.(int64); ok {
+				a.values[i] = float64(value)
+			}
+		}
+	}
+	a.typ = typ
+	return nil
+}
+
+func (a *pickAccumulator) set(group int, value interface{}) {
+	if value, ok := value.(int64); ok && a.typ == frames.FloatType {
+		a.values[group] = float64(value)
+		return
+	}
+	a.values[group] = value
+}
+
+func (a *pickAccumulator) add(col frames.Column, groups []int, size int) error {
+	data, err := values(col)
+	if err != nil {
+		return err
+	}
+
+	if err := a.setType(widenType(col.DType())); err != nil {
+		return err
+	}
+
+	a.values = growValues(a.values, size)
+	ok := present(col, data)
+	for i, group := range groups {
+		if !ok(i) {
+			continue
+		}
+
+		value, err := valueAt(col, i)
+		if err != nil {
+			return err
+		}
+
+		value = widenValue(value)
+		if a.pick(a.values[group], value) {
+			a.set(group, value)
+		}
+	}
+	return nil
+}
+
+func (a *pickAccumulator) merge(other accumulator, groups []int, size int) error {
+	o := other.(*pickAccumulator)
+	if err := a.setType(o.typ); err != nil {
+		return err
+	}
+
+	a.values = growValues(a.values, size)
+	for i, value := range o.values {
+		if value != nil && a.pick(a.values[groups[i]], value) {
+			a.set(groups[i], value)
+		}
+	}
+	return nil
+}
+
+func (a *pickAccumulator) column(name string, size int) (frames.Column, error) {
+	return newBoxedColumn(name, a.typ, growValues(a.values, size))
+}
+
+// timeKey is the distinct value key of a time
+type timeKey int64
+
+// bytesKey is the distinct value key of bytes
+type bytesKey string
+
+// floatKey returns the distinct value key of a float, integral floats are the
+// same as ints
+func floatKey(value float64) interface{} {
+	if value == math.Trunc(value) && math.Abs(value) < 1<<53 {
+		return int64(value)
+	}
+	return value
+}
+
+type distinctAccumulator struct {
+	sets []map[interface{}]bool
+}
+
+func (a *distinctAccumulator) dtype() frames.DType { return frames.IntType }
+
+func (a *distinctAccumulator) grow(size int) {
+	for len(a.sets) < size {
+		a.sets = append(a.sets, make(map[interface{}]bool))
+	}
+}
+
+func (a *distinctAccumulator) add(col frames.Column, groups []int, size int) error {
+	data, err := values(col)
+	if err != nil {
+		return err
+	}
+
+	a.grow(size)
+	ok := present(col, data)
+	for i, group := range groups {
+		if !ok(i) {
+			continue
+		}
+
+		var key interface{}
+		switch data := data.(type) {
+		case []int64:
+			key = data[i]
+		case []float64:
+			key = floatKey(data[i])
+		case []string:
+			key = data[i]
+		case []time.Time:
+			key = timeKey(data[i].UnixNano())
+		case []bool:
+			key = data[i]
+		case []int32:
+			key = int64(data[i])
+		case []float32:
+			key = floatKey(float64(data[i]))
+		case [][]byte:
+			key = bytesKey(data[i])
+		case []frames.Decimal:
+			key = data[i].Normalize()
+		default:
+			return fmt.Errorf("%q column values can't be counted (%s)", col.Name(), pb.DType(col.DType()))
+		}
+		a.sets[group][key] = true
+	}
+	return nil
+}
+
+func (a *distinctAccumulator) merge(other accumulator, groups []int, size int) error {
+	a.grow(size)
+	for i, set := range other.(*distinctAccumulator).sets {
+		for key := range set {
+			a.sets[groups[i]][key] = true
+		}
+	}
+	return nil
+}
+
+func (a *distinctAccumulator) column(name string, size int) (frames.Column, error) {
+	a.grow(size)
+	counts := make([]int64, size)
+	for i, set := range a.sets {
+		counts[i] = int64(len(set))
+	}
+	return frames.NewSliceColumn(name, counts)
+}
+
+// commonType returns the type of values of both types, ints and floats are
+// floats
+func commonType(a frames.DType, b frames.DType) (frames.DType, error) {
+	switch {
+	case a == b:
+		return a, nil
+	case a == frames.NullType:
+		return b, nil
+	case b == frames.NullType:
+		return a, nil
+	case (a == frames.IntType && b == frames.FloatType) || (a == frames.FloatType && b == frames.IntType):
+		return frames.FloatType, nil
+	}
+
+	return a, fmt.Errorf("mixed %s and %s values", pb.DType(a), pb.DType(b))
+}
+
+// compareValues compares two values of the same type (or an int and a float)
+func compareValues(a interface{}, b interface{}) int {
+	switch a := a.(type) {
+	case int64:
+		if b, ok := b.(int64); ok {
+			return compareInts(a, b)
+		}
+		return compareFloats(float64(a), b.(float64))
+	case float64:
+		if b, ok := b.(int64); ok {
+			return compareFloats(a, float64(b))
+		}
+		return compareFloats(a, b.(float64))
+	case string:
+		return strings.Compare(a, b.(string))
+	case time.Time:
+		return compareInts(a.UnixNano(), b.(time.Time).UnixNano())
+	case bool:
+		return compareInts(boolInt(a), boolInt(b.(bool)))
+	case []byte:
+		return bytes.Compare(a, b.([]byte))
+	case frames.Decimal:
+		return a.Cmp(b.(frames.Decimal))
+	}
+	return 0
+}
+
+func compareFloats(a float64, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func boolInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// nullsOf returns the nulls of values where has is false, nil if there are
+// none
+func nullsOf(has []bool) []bool {
+	var nulls []bool
+	for i, ok := range has {
+		if ok {
+			continue
+		}
+
+		if nulls == nil {
+			nulls = make([]bool, len(has))
+		}
+		nulls[i] = true
+	}
+	return nulls
+}
+
+func growInts(data []int64, size int) []int64 {
+	for len(data) < size {
+		data = append(data, 0)
+	}
+	return data
+}
+
+func growFloats(data []float64, size int) []float64 {
+	for len(data) < size {
+		data = append(data, 0)
+	}
+	return data
+}
+
+func growBools(data []bool, size int) []bool {
+	for len(data) < size {
+		data = append(data, false)
+	}
+	return data
+}
+
+func growValues(data []interface{}, size int) []interface{} {
+	for len(data) < size {
+		data = append(data, nil)
+	}
+	return data
+}
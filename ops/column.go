@@ -0,0 +1,306 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package ops
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/pb"
+)
+
+// Column data is handled as typed slices ([]int64, []float64, []string,
+// []time.Time, []bool, []int32, []float32, [][]byte, []frames.Decimal or
+// []frames.Column)
+
+// values returns the values of col, label columns are expanded
+func values(col frames.Column) (interface{}, error) {
+	switch col.DType() {
+	case frames.IntType:
+		return col.Ints()
+	case frames.FloatType:
+		return col.Floats()
+	case frames.StringType:
+		return col.Strings(), nil
+	case frames.TimeType:
+		return col.Times()
+	case frames.BoolType:
+		return col.Bools()
+	case frames.Int32Type:
+		return col.Int32s()
+	case frames.Float32Type:
+		return col.Float32s()
+	case frames.BytesType:
+		return col.Bytes()
+	case frames.DecimalType:
+		return col.Decimals()
+	case frames.ListType:
+		return col.Lists()
+	}
+
+	return nil, fmt.Errorf("%q column has unsupported type - %s", col.Name(), pb.DType(col.DType()))
+}
+
+// widenedValues returns the values of col with int32 as int64, float32 as
+// float64 and decimals as (possibly rounded) float64
+func widenedValues(col frames.Column) (interface{}, error) {
+	switch col.DType() {
+	case frames.Int32Type:
+		return col.Ints()
+	case frames.Float32Type:
+		return col.Floats()
+	case frames.DecimalType:
+		decimals, err := col.Decimals()
+		if err != nil {
+			return nil, err
+		}
+
+		floats := make([]float64, len(decimals))
+		for i, d := range decimals {
+			floats[i] = d.Float64()
+		}
+		return floats, nil
+	}
+
+	return values(col)
+}
+
+// takeValues returns the values in data at rows
+func takeValues(data interface{}, rows []int) interface{} {
+	switch data := data.(type) {
+	case []int64:
+		out := make([]int64, len(rows))
+		for i, r := range rows {
+			out[i] = data[r]
+		}
+		return out
+	case []float64:
+		out := make([]float64, len(rows))
+		for i, r := range rows {
+			out[i] = data[r]
+		}
+		return out
+	case []string:
+		out := make([]string, len(rows))
+		for i, r := range rows {
+			out[i] = data[r]
+		}
+		return out
+	case []time.Time:
+		out := make([]time.Time, len(rows))
+		for i, r := range rows {
+			out[i] = data[r]
+		}
+		return out
+	case []bool:
+		out := make([]bool, len(rows))
+		for i, r := range rows {
+			out[i] = data[r]
+		}
+		return out
+	case []int32:
+		out := make([]int32, len(rows))
+		for i, r := range rows {
+			out[i] = data[r]
+		}
+		return out
+	case []float32:
+		out := make([]float32, len(rows))
+		for i, r := range rows {
+			out[i] = data[r]
+		}
+		return out
+	case [][]byte:
+		out := make([][]byte, len(rows))
+		for i, r := range rows {
+			out[i] = data[r]
+		}
+		return out
+	case []frames.Decimal:
+		out := make([]frames.Decimal, len(rows))
+		for i, r := range rows {
+			out[i] = data[r]
+		}
+		return out
+	case []frames.Column:
+		out := make([]frames.Column, len(rows))
+		for i, r := range rows {
+			out[i] = data[r]
+		}
+		return out
+	}
+
+	return nil
+}
+
+// appendValues appends src to dst, dst is nil or of the same type as src
+func appendValues(dst interface{}, src interface{}) interface{} {
+	switch src := src.(type) {
+	case []int64:
+		out, _ := dst.([]int64)
+		return append(out, src...)
+	case []float64:
+		out, _ := dst.([]float64)
+		return append(out, src...)
+	case []string:
+		out, _ := dst.([]string)
+		return append(out, src...)
+	case []time.Time:
+		out, _ := dst.([]time.Time)
+		return append(out, src...)
+	case []bool:
+		out, _ := dst.([]bool)
+		return append(out, src...)
+	case []int32:
+		out, _ := dst.([]int32)
+		return append(out, src...)
+	case []float32:
+		out, _ := dst.([]float32)
+		return append(out, src...)
+	case [][]byte:
+		out, _ := dst.([][]byte)
+		return append(out, src...)
+	case []frames.Decimal:
+		out, _ := dst.([]frames.Decimal)
+		return append(out, src...)
+	case []frames.Column:
+		out, _ := dst.([]frames.Column)
+		return append(out, src...)
+	}
+
+	return nil
+}
+
+// newColumn returns a slice column of data, nulls is nil if there are no
+// nulls
+func newColumn(name string, data interface{}, nulls []bool) (frames.Column, error) {
+	if nulls == nil {
+		return frames.NewSliceColumn(name, data)
+	}
+	return frames.NewSliceColumnWithNulls(name, data, nulls)
+}
+
+// takeColumn returns a column with the values of col at rows
+func takeColumn(col frames.Column, rows []int) (frames.Column, error) {
+	if frames.IsLabelColumn(col) {
+		return resizeLabel(col, len(rows))
+	}
+
+	data, err := values(col)
+	if err != nil {
+		return nil, err
+	}
+
+	var nulls []bool
+	if col.NullCount() > 0 {
+		nulls = make([]bool, len(rows))
+		for i, r := range rows {
+			nulls[i] = col.IsNull(r)
+		}
+	}
+
+	return newColumn(col.Name(), takeValues(data, rows), nulls)
+}
+
+// valueAt returns the value of col at row i, nil if it's null
+func valueAt(col frames.Column, i int) (interface{}, error) {
+	if col.IsNull(i) {
+		return nil, nil
+	}
+
+	return frames.ValueAt(col, i)
+}
+
+// widenValue returns int32 values as int64 and float32 values as float64
+func widenValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case int32:
+		return int64(value)
+	case float32:
+		return float64(value)
+	}
+	return value
+}
+
+// widenType returns the dtype of widenValue values of dtype
+func widenType(dtype frames.DType) frames.DType {
+	switch dtype {
+	case frames.Int32Type:
+		return frames.IntType
+	case frames.Float32Type:
+		return frames.FloatType
+	}
+	return dtype
+}
+
+// labelValue returns the value of a label column, nil if it's null or empty
+func labelValue(col frames.Column) (interface{}, error) {
+	if col.Len() == 0 {
+		return nil, nil
+	}
+	return valueAt(col, 0)
+}
+
+// newBoxedColumn returns a slice column of values, nil values are nulls
+func newBoxedColumn(name string, dtype frames.DType, values []interface{}) (frames.Column, error) {
+	builder := frames.NewSliceColumnBuilder(name, dtype, len(values))
+	for i, value := range values {
+		var err error
+		if value == nil {
+			err = builder.SetNull(i)
+		} else {
+			err = builder.Set(i, value)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%q column - %s", name, err)
+		}
+	}
+
+	return builder.Finish(), nil
+}
+
+// resizeLabel returns label column col with size rows
+func resizeLabel(col frames.Column, size int) (frames.Column, error) {
+	if col.Len() == size {
+		return col, nil
+	}
+
+	// There's no value to read in empty columns
+	if col.Len() == 0 {
+		return frames.NewLabelColumnBuilder(col.Name(), col.DType(), size).Finish(), nil
+	}
+
+	value, err := labelValue(col)
+	if err != nil {
+		return nil, err
+	}
+	return frames.NewLabelColumn(col.Name(), value, size)
+}
+
+func sameValue(a interface{}, b interface{}) bool {
+	if ta, ok := a.(time.Time); ok {
+		tb, ok := b.(time.Time)
+		return ok && ta.Equal(tb)
+	}
+	return a == b
+}
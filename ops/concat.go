@@ -0,0 +1,192 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package ops
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/pb"
+)
+
+// Concat returns a frame with the rows of input frames, which must have the
+// same columns and indices (names and types). Label columns with the same
+// value in all frames stay label columns, and the result has the labels that
+// are the same in all frames
+func Concat(input ...frames.Frame) (frames.Frame, error) {
+	if len(input) == 0 {
+		return nil, fmt.Errorf("no frames to concat")
+	}
+
+	first := input[0]
+	if len(input) == 1 {
+		return first, nil
+	}
+
+	names, indexNames := first.Names(), columnNames(first.Indices())
+	for i, frame := range input[1:] {
+		if !reflect.DeepEqual(frame.Names(), names) {
+			return nil, fmt.Errorf("frame %d columns %v don't match %v", i+1, frame.Names(), names)
+		}
+
+		if other := columnNames(frame.Indices()); !reflect.DeepEqual(other, indexNames) {
+			return nil, fmt.Errorf("frame %d indices %v don't match %v", i+1, other, indexNames)
+		}
+	}
+
+	columns := make([]frames.Column, len(names))
+	for c, name := range names {
+		parts := make([]frames.Column, len(input))
+		for i, frame := range input {
+			col, err := frame.Column(name)
+			if err != nil {
+				return nil, err
+			}
+			parts[i] = col
+		}
+
+		var err error
+		if columns[c], err = concatColumns(parts); err != nil {
+			return nil, err
+		}
+	}
+
+	indices := make([]frames.Column, len(indexNames))
+	for c := range indexNames {
+		parts := make([]frames.Column, len(input))
+		for i, frame := range input {
+			parts[i] = frame.Indices()[c]
+		}
+
+		var err error
+		if indices[c], err = concatColumns(parts); err != nil {
+			return nil, err
+		}
+	}
+
+	return frames.NewFrame(columns, indices, commonLabels(input))
+}
+
+// concatColumns returns a column with the values of parts, nulls included
+func concatColumns(parts []frames.Column) (frames.Column, error) {
+	first := parts[0]
+	size, hasNulls := 0, false
+	for i, col := range parts {
+		if col.DType() != first.DType() {
+			return nil, fmt.Errorf("%q column of frame %d is %s, not %s", col.Name(), i, pb.DType(col.DType()), pb.DType(first.DType()))
+		}
+
+		size += col.Len()
+		hasNulls = hasNulls || col.NullCount() > 0
+	}
+
+	if col, ok, err := concatLabels(parts, size); ok || err != nil {
+		return col, err
+	}
+
+	var data interface{}
+	for _, col := range parts {
+		colValues, err := values(col)
+		if err != nil {
+			return nil, err
+		}
+		data = appendValues(data, colValues)
+	}
+
+	var nulls []bool
+	if hasNulls {
+		nulls = make([]bool, 0, size)
+		for _, col := range parts {
+			for i := 0; i < col.Len(); i++ {
+				nulls = append(nulls, col.IsNull(i))
+			}
+		}
+	}
+
+	return newColumn(first.Name(), data, nulls)
+}
+
+// concatLabels returns a label column if all parts are label columns with the
+// same value, ok is false if they aren't
+func concatLabels(parts []frames.Column, size int) (col frames.Column, ok bool, err error) {
+	var value interface{}
+	found := false
+	for _, col := range parts {
+		if !frames.IsLabelColumn(col) {
+			return nil, false, nil
+		}
+
+		if col.Len() == 0 {
+			continue
+		}
+
+		colValue, err := labelValue(col)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if found && !sameValue(value, colValue) {
+			return nil, false, nil
+		}
+		value, found = colValue, true
+	}
+
+	if !found {
+		col, err = resizeLabel(parts[0], size)
+	} else {
+		col, err = frames.NewLabelColumn(parts[0].Name(), value, size)
+	}
+	return col, err == nil, err
+}
+
+// commonLabels returns the labels with the same value in all frames
+func commonLabels(input []frames.Frame) map[string]interface{} {
+	labels := input[0].Labels()
+	if len(labels) == 0 {
+		return nil
+	}
+
+	common := make(map[string]interface{})
+	for key, value := range labels {
+		same := true
+		for _, frame := range input[1:] {
+			other, ok := frame.Labels()[key]
+			if !ok || !sameValue(value, other) {
+				same = false
+				break
+			}
+		}
+
+		if same {
+			common[key] = value
+		}
+	}
+	return common
+}
+
+func columnNames(columns []frames.Column) []string {
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = col.Name()
+	}
+	return names
+}
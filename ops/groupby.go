@@ -0,0 +1,366 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package ops
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/v3io/frames"
+)
+
+// Aggregation is an aggregate of a column in every group
+type Aggregation struct {
+	Func   string // count, sum, avg (or mean), min, max, first, last, count_distinct or stddev
+	Column string // All the columns that aren't group keys if empty
+}
+
+// ParseAggregations parses a comma separated list of aggregations, each is a
+// function of all the columns (e.g. "sum") or of one column (e.g. "sum(x)")
+func ParseAggregations(spec string) ([]Aggregation, error) {
+	var aggregations []Aggregation
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		aggregation := Aggregation{Func: item}
+		if i := strings.Index(item, "("); i >= 0 {
+			if !strings.HasSuffix(item, ")") {
+				return nil, fmt.Errorf("bad aggregation - %q", item)
+			}
+
+			aggregation.Func = strings.TrimSpace(item[:i])
+			aggregation.Column = strings.TrimSpace(item[i+1 : len(item)-1])
+			if aggregation.Column == "" {
+				return nil, fmt.Errorf("bad aggregation - %q", item)
+			}
+		}
+
+		aggregation.Func = strings.ToLower(aggregation.Func)
+		if _, ok := accumulators[aggregation.Func]; !ok {
+			return nil, fmt.Errorf("unknown aggregation function - %q", aggregation.Func)
+		}
+		aggregations = append(aggregations, aggregation)
+	}
+
+	if len(aggregations) == 0 {
+		return nil, fmt.Errorf("no aggregations")
+	}
+	return aggregations, nil
+}
+
+// GroupBy is a hash group by of frames. Group bys of parts of the same frames
+// can be merged, and the result has a row per group by order of appearance
+// with the group keys as indices and a "func(column)" column per aggregate
+type GroupBy struct {
+	keys         []string
+	aggregations []Aggregation
+
+	// Set by the first frame, key types are widened by later frames
+	resolved bool
+	keyTypes []frames.DType
+	columns  []*aggregateColumn
+
+	groups    map[string]int
+	groupKeys []string        // By group
+	keyValues [][]interface{} // By group, then key
+}
+
+// aggregateColumn is an aggregate of a column in the result
+type aggregateColumn struct {
+	name   string
+	column string
+	fn     string
+	acc    accumulator
+}
+
+// NewGroupBy returns a group by of the key columns (or indices), the result
+// has a single group if there are no keys (even if there are no rows)
+func NewGroupBy(keys []string, aggregations []Aggregation) (*GroupBy, error) {
+	if len(aggregations) == 0 {
+		return nil, fmt.Errorf("no aggregations")
+	}
+
+	for _, aggregation := range aggregations {
+		if _, ok := accumulators[aggregation.Func]; !ok {
+			return nil, fmt.Errorf("unknown aggregation function - %q", aggregation.Func)
+		}
+	}
+
+	groupBy := &GroupBy{
+		keys:         keys,
+		aggregations: aggregations,
+		groups:       make(map[string]int),
+	}
+	return groupBy, nil
+}
+
+// Len returns the number of groups
+func (g *GroupBy) Len() int {
+	return len(g.keyValues)
+}
+
+// Add adds the rows of frame to their groups
+func (g *GroupBy) Add(frame frames.Frame) error {
+	if !g.resolved {
+		if err := g.resolve(frame); err != nil {
+			return err
+		}
+	}
+
+	groups, err := g.groupRows(frame)
+	if err != nil {
+		return err
+	}
+
+	for _, col := range g.columns {
+		values, err := lookup(frame, col.column)
+		if err != nil {
+			return err
+		}
+
+		// Columns of nulls have nothing to add
+		if values.DType() == frames.NullType {
+			continue
+		}
+
+		if col.acc == nil {
+			col.acc = accumulators[col.fn](values.DType())
+		}
+
+		if err := col.acc.add(values, groups, g.Len()); err != nil {
+			return fmt.Errorf("%s - %s", col.name, err)
+		}
+	}
+
+	return nil
+}
+
+// Merge adds the groups of other, which is a group by with the same keys and
+// aggregations, other can't be used after it's merged
+func (g *GroupBy) Merge(other *GroupBy) error {
+	if !other.resolved {
+		return nil
+	}
+
+	if !g.resolved {
+		*g = *other
+		return nil
+	}
+
+	if !reflect.DeepEqual(columnNamesOf(g.columns), columnNamesOf(other.columns)) {
+		return fmt.Errorf("group by columns mismatch (%v != %v)", columnNamesOf(g.columns), columnNamesOf(other.columns))
+	}
+
+	for k, dtype := range other.keyTypes {
+		var err error
+		if g.keyTypes[k], err = commonType(g.keyTypes[k], dtype); err != nil {
+			return fmt.Errorf("%q key - %s", g.keys[k], err)
+		}
+	}
+
+	groups := make([]int, other.Len())
+	for i, key := range other.groupKeys {
+		groups[i] = g.group(key, other.keyValues[i])
+	}
+
+	for i, col := range g.columns {
+		otherAcc := other.columns[i].acc
+		switch {
+		case otherAcc == nil:
+			continue
+		case col.acc == nil:
+			col.acc = accumulators[col.fn](otherAcc.dtype())
+		}
+
+		if err := col.acc.merge(otherAcc, groups, g.Len()); err != nil {
+			return fmt.Errorf("%s - %s", col.name, err)
+		}
+	}
+
+	return nil
+}
+
+// Frame returns the groups frame
+func (g *GroupBy) Frame() (frames.Frame, error) {
+	if g.resolved && len(g.keys) == 0 && g.Len() == 0 {
+		g.group("", nil)
+	}
+
+	size := g.Len()
+	indices := make([]frames.Column, len(g.keys))
+	for k, name := range g.keys {
+		dtype := g.keyTypes[k]
+		if dtype == frames.NullType {
+			dtype = frames.FloatType
+		}
+
+		values := make([]interface{}, size)
+		for i, keyValues := range g.keyValues {
+			values[i] = keyValues[k]
+			// Keys of ints and floats are floats
+			if value, ok := values[i].(int64); ok && dtype == frames.FloatType {
+				values[i] = float64(value)
+			}
+		}
+
+		var err error
+		if indices[k], err = newBoxedColumn(name, dtype, values); err != nil {
+			return nil, err
+		}
+	}
+
+	var columns []frames.Column
+	for _, col := range g.columns {
+		// No values were added
+		if col.acc == nil {
+			col.acc = accumulators[col.fn](frames.FloatType)
+		}
+
+		out, err := col.acc.column(col.name, size)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, out)
+	}
+
+	return frames.NewFrame(columns, indices, nil)
+}
+
+// resolve sets the key types and aggregate columns from the first frame
+func (g *GroupBy) resolve(frame frames.Frame) error {
+	for range g.keys {
+		g.keyTypes = append(g.keyTypes, frames.NullType)
+	}
+
+	isKey := make(map[string]bool)
+	for _, name := range g.keys {
+		isKey[name] = true
+	}
+
+	seen := make(map[string]bool)
+	add := func(fn string, column string) {
+		name := fmt.Sprintf("%s(%s)", fn, column)
+		if !seen[name] {
+			seen[name] = true
+			g.columns = append(g.columns, &aggregateColumn{name: name, column: column, fn: fn})
+		}
+	}
+
+	for _, aggregation := range g.aggregations {
+		if aggregation.Column != "" {
+			add(aggregation.Func, aggregation.Column)
+			continue
+		}
+
+		for _, name := range frame.Names() {
+			if !isKey[name] {
+				add(aggregation.Func, name)
+			}
+		}
+	}
+
+	if len(g.columns) == 0 {
+		return fmt.Errorf("no columns to aggregate")
+	}
+
+	g.resolved = true
+	return nil
+}
+
+// groupRows returns the group of every row in frame, new groups are added
+func (g *GroupBy) groupRows(frame frames.Frame) ([]int, error) {
+	builders := make([]strings.Builder, frame.Len())
+	keyColumns := make([]frames.Column, len(g.keys))
+	for k, name := range g.keys {
+		col, err := lookup(frame, name)
+		if err != nil {
+			return nil, err
+		}
+
+		// Keys of only nulls don't have a type
+		if col.NullCount() < col.Len() {
+			if g.keyTypes[k], err = commonType(g.keyTypes[k], col.DType()); err != nil {
+				return nil, fmt.Errorf("%q key - %s", name, err)
+			}
+		}
+
+		if err := writeKeys(builders, col); err != nil {
+			return nil, err
+		}
+		keyColumns[k] = col
+	}
+
+	groups := make([]int, frame.Len())
+	for i := range builders {
+		key := builders[i].String()
+		group, ok := g.groups[key]
+		if !ok {
+			keyValues := make([]interface{}, len(keyColumns))
+			for k, col := range keyColumns {
+				var err error
+				if keyValues[k], err = valueAt(col, i); err != nil {
+					return nil, err
+				}
+			}
+			group = g.group(key, keyValues)
+		}
+		groups[i] = group
+	}
+
+	return groups, nil
+}
+
+// group returns the group of key, adding it if it's new
+func (g *GroupBy) group(key string, keyValues []interface{}) int {
+	group, ok := g.groups[key]
+	if !ok {
+		group = len(g.keyValues)
+		g.groups[key] = group
+		g.groupKeys = append(g.groupKeys, key)
+		g.keyValues = append(g.keyValues, keyValues)
+	}
+	return group
+}
+
+// Aggregate returns the aggregations of frame grouped by keys
+func Aggregate(frame frames.Frame, keys []string, aggregations []Aggregation) (frames.Frame, error) {
+	groupBy, err := NewGroupBy(keys, aggregations)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := groupBy.Add(frame); err != nil {
+		return nil, err
+	}
+	return groupBy.Frame()
+}
+
+func columnNamesOf(columns []*aggregateColumn) []string {
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = col.name
+	}
+	return names
+}
@@ -0,0 +1,326 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package ops
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/v3io/frames"
+)
+
+// rowsOf returns the rows of frame as maps of column (and index) name to
+// value, nulls are nil
+func rowsOf(t *testing.T, frame frames.Frame) []map[string]interface{} {
+	columns, err := frameColumns(frame)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows := make([]map[string]interface{}, frame.Len())
+	columns = append(frame.Indices(), columns...)
+	for i := range rows {
+		rows[i] = make(map[string]interface{})
+		for _, col := range columns {
+			value, err := valueAt(col, i)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rows[i][col.Name()] = value
+		}
+	}
+	return rows
+}
+
+func checkRows(t *testing.T, frame frames.Frame, expected []map[string]interface{}) {
+	rows := rowsOf(t, frame)
+	if len(rows) != len(expected) {
+		t.Fatalf("bad number of rows - %d != %d", len(rows), len(expected))
+	}
+
+	for i, row := range rows {
+		if len(row) != len(expected[i]) {
+			t.Fatalf("bad row %d - %v != %v", i, row, expected[i])
+		}
+
+		for name, value := range expected[i] {
+			out := row[name]
+			if f, ok := value.(float64); ok {
+				if of, ok := out.(float64); ok && math.Abs(f-of) < 1e-9 {
+					continue
+				}
+			}
+
+			if !reflect.DeepEqual(out, value) {
+				t.Fatalf("bad %s in row %d - %v != %v", name, i, out, value)
+			}
+		}
+	}
+}
+
+func TestGroupBy(t *testing.T) {
+	frame := testFrame(t, "d1", nil)
+	aggregations, err := ParseAggregations("count(x), sum(x),AVG(score),min(score),max(x),first(x),last(score),count_distinct(x),stddev(score)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := Aggregate(frame, []string{"dept"}, aggregations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []map[string]interface{}{
+		{
+			"dept": "ops", "count(x)": int64(2), "sum(x)": int64(4), "avg(score)": 1.0,
+			"min(score)": 0.5, "max(x)": int64(2), "first(x)": int64(2), "last(score)": 0.5,
+			"count_distinct(x)": int64(1), "stddev(score)": math.Sqrt(0.5),
+		},
+		{
+			"dept": "eng", "count(x)": int64(1), "sum(x)": int64(1), "avg(score)": 3.5,
+			"min(score)": 3.5, "max(x)": int64(1), "first(x)": int64(1), "last(score)": 3.5,
+			"count_distinct(x)": int64(1), "stddev(score)": nil,
+		},
+	}
+	checkRows(t, out, expected)
+
+	out, err = Aggregate(frame, nil, []Aggregation{{Func: "count"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkRows(t, out, []map[string]interface{}{
+		{"count(dept)": int64(4), "count(x)": int64(3), "count(score)": int64(3), "count(device)": int64(4)},
+	})
+
+	// Null keys are a group
+	out, err = Aggregate(frame, []string{"x", "device"}, []Aggregation{{Func: "sum", Column: "score"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkRows(t, out, []map[string]interface{}{
+		{"x": int64(2), "device": "d1", "sum(score)": 2.0},
+		{"x": int64(1), "device": "d1", "sum(score)": nil},
+		{"x": nil, "device": "d1", "sum(score)": 3.5},
+	})
+}
+
+func TestGroupByMerge(t *testing.T) {
+	aggregations, err := ParseAggregations("count,sum,mean(score),min,max,first,last,count_distinct,stddev(score)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Aggregate(testFrame(t, "d1", nil), []string{"dept"}, aggregations); err == nil {
+		t.Fatal("no error for sum of strings")
+	}
+
+	frame, err := Select(testFrame(t, "d1", nil), "dept", "x", "score")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := Aggregate(frame, []string{"dept"}, aggregations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Partials of consecutive rows, merged in order
+	var partials []*GroupBy
+	for _, rows := range [][]int{{0}, {1, 2}, {}, {3}} {
+		part, err := Take(frame, rows)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		partial, err := NewGroupBy([]string{"dept"}, aggregations)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := partial.Add(part); err != nil {
+			t.Fatal(err)
+		}
+		partials = append(partials, partial)
+	}
+
+	merged, err := NewGroupBy([]string{"dept"}, aggregations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, partial := range partials {
+		if err := merged.Merge(partial); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := merged.Frame()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRows(t, out, rowsOf(t, expected))
+}
+
+func TestGroupByKeyTypes(t *testing.T) {
+	groupBy, err := NewGroupBy([]string{"k"}, []Aggregation{{Func: "count", Column: "x"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Keys of only nulls don't set the key type, ints and floats are floats
+	builder := frames.NewSliceColumnBuilder("k", frames.StringType, 1)
+	if err := builder.SetNull(0); err != nil {
+		t.Fatal(err)
+	}
+	x, err := frames.NewSliceColumn("x", []int64{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	nulls, err := frames.NewFrame([]frames.Column{builder.Finish(), x}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ints, err := frames.NewFrameFromMap(map[string]interface{}{"k": []int64{1}, "x": []int64{1}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	floats, err := frames.NewFrameFromMap(map[string]interface{}{"k": []float64{1, 1.5}, "x": []int64{1, 1}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, frame := range []frames.Frame{nulls, ints, floats} {
+		if err := groupBy.Add(frame); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := groupBy.Frame()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []map[string]interface{}{
+		{"k": nil, "count(x)": int64(1)},
+		{"k": 1.0, "count(x)": int64(2)},
+		{"k": 1.5, "count(x)": int64(1)},
+	}
+	checkRows(t, out, expected)
+
+	// No keys and no rows is a single group
+	empty, err := frames.NewFrameFromMap(map[string]interface{}{"x": []int64{}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err = Aggregate(empty, nil, []Aggregation{{Func: "count", Column: "x"}, {Func: "sum", Column: "x"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkRows(t, out, []map[string]interface{}{{"count(x)": int64(0), "sum(x)": nil}})
+}
+
+func TestParseAggregations(t *testing.T) {
+	aggregations, err := ParseAggregations("count, Sum(x)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Aggregation{{Func: "count"}, {Func: "sum", Column: "x"}}
+	if !reflect.DeepEqual(aggregations, expected) {
+		t.Fatalf("bad aggregations - %v", aggregations)
+	}
+
+	for _, spec := range []string{"", "median", "sum(", "sum()", "count,nope(x)"} {
+		if _, err := ParseAggregations(spec); err == nil {
+			t.Fatalf("no error for %q", spec)
+		}
+	}
+}
+
+func TestGroupByExtendedDtypes(t *testing.T) {
+	key, err := frames.NewSliceColumn("key", [][]byte{[]byte("a"), []byte("b"), []byte("a")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := frames.NewSliceColumn("n", []int32{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := frames.NewSliceColumn("f", []float32{0.5, float32(math.NaN()), 1.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	price, err := frames.NewSliceColumn("price", []frames.Decimal{{Value: 150, Scale: 2}, {Value: 2}, {Value: 15, Scale: 1}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frame, err := frames.NewFrame([]frames.Column{key, n, f, price}, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	aggregations, err := ParseAggregations("sum(n), max(n), sum(f), min(price), count_distinct(price)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := Aggregate(frame, []string{"key"}, aggregations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkRows(t, out, []map[string]interface{}{
+		{
+			"key": []byte("a"), "sum(n)": int64(4), "max(n)": int64(3), "sum(f)": 2.0,
+			"min(price)": frames.Decimal{Value: 150, Scale: 2}, "count_distinct(price)": int64(1),
+		},
+		{
+			"key": []byte("b"), "sum(n)": int64(2), "max(n)": int64(2), "sum(f)": nil,
+			"min(price)": frames.Decimal{Value: 200, Scale: 2}, "count_distinct(price)": int64(1),
+		},
+	})
+
+	sorted, err := Sort(frame, SortKey{Name: "price", Descending: true}, SortKey{Name: "n"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	col, err := sorted.Column("n")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := col.Int32s()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(data, []int32{2, 1, 3}) {
+		t.Fatalf("bad sort - %v", data)
+	}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+// Package ops has frame transformations. They return new frames and keep the
+// indices and labels of the input frames, label columns stay label columns.
+package ops
+
+import (
+	"fmt"
+
+	"github.com/v3io/frames"
+)
+
+// Take returns a frame with the rows of frame at rows, rows can repeat
+func Take(frame frames.Frame, rows []int) (frames.Frame, error) {
+	size := frame.Len()
+	for _, r := range rows {
+		if r < 0 || r >= size {
+			return nil, fmt.Errorf("row %d out of bounds [0:%d]", r, size)
+		}
+	}
+
+	return takeRows(frame, rows)
+}
+
+// Filter returns a frame with the rows of frame where mask is true
+func Filter(frame frames.Frame, mask []bool) (frames.Frame, error) {
+	if len(mask) != frame.Len() {
+		return nil, fmt.Errorf("mask size mismatch (%d != %d)", len(mask), frame.Len())
+	}
+
+	var rows []int
+	for i, keep := range mask {
+		if keep {
+			rows = append(rows, i)
+		}
+	}
+
+	return takeRows(frame, rows)
+}
+
+// FilterFunc returns a frame with the rows of frame where keep returns true
+func FilterFunc(frame frames.Frame, keep func(row int) (bool, error)) (frames.Frame, error) {
+	var rows []int
+	for i := 0; i < frame.Len(); i++ {
+		ok, err := keep(i)
+		if err != nil {
+			return nil, err
+		}
+
+		if ok {
+			rows = append(rows, i)
+		}
+	}
+
+	return takeRows(frame, rows)
+}
+
+// Select returns a frame with the columns names of frame, in order
+func Select(frame frames.Frame, names ...string) (frames.Frame, error) {
+	seen := make(map[string]bool)
+	columns := make([]frames.Column, len(names))
+	for i, name := range names {
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate column %q", name)
+		}
+		seen[name] = true
+
+		col, err := frame.Column(name)
+		if err != nil {
+			return nil, err
+		}
+		columns[i] = col
+	}
+
+	return frames.NewFrame(columns, frame.Indices(), frame.Labels())
+}
+
+// Drop returns a frame without the columns names of frame
+func Drop(frame frames.Frame, names ...string) (frames.Frame, error) {
+	dropped := make(map[string]bool)
+	for _, name := range names {
+		if _, err := frame.Column(name); err != nil {
+			return nil, err
+		}
+		dropped[name] = true
+	}
+
+	var columns []frames.Column
+	for _, name := range frame.Names() {
+		if dropped[name] {
+			continue
+		}
+
+		col, err := frame.Column(name)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, col)
+	}
+
+	return frames.NewFrame(columns, frame.Indices(), frame.Labels())
+}
+
+// Rename returns frame with the columns and indices in names (old -> new)
+// renamed
+func Rename(frame frames.Frame, names map[string]string) (frames.Frame, error) {
+	found := make(map[string]bool)
+	rename := func(cols []frames.Column) ([]frames.Column, error) {
+		seen := make(map[string]bool)
+		out := make([]frames.Column, len(cols))
+		for i, col := range cols {
+			name, ok := names[col.Name()]
+			if ok {
+				found[col.Name()] = true
+				col = col.CopyWithName(name)
+			}
+
+			if seen[col.Name()] && col.Name() != "" {
+				return nil, fmt.Errorf("duplicate column %q", col.Name())
+			}
+			seen[col.Name()] = true
+			out[i] = col
+		}
+		return out, nil
+	}
+
+	columns, err := frameColumns(frame)
+	if err != nil {
+		return nil, err
+	}
+
+	if columns, err = rename(columns); err != nil {
+		return nil, err
+	}
+
+	indices, err := rename(frame.Indices())
+	if err != nil {
+		return nil, err
+	}
+
+	for name := range names {
+		if !found[name] {
+			return nil, fmt.Errorf("column %q not found", name)
+		}
+	}
+
+	return frames.NewFrame(columns, indices, frame.Labels())
+}
+
+// takeRows returns a frame with the rows of frame at rows, which are in range
+func takeRows(frame frames.Frame, rows []int) (frames.Frame, error) {
+	columns, err := frameColumns(frame)
+	if err != nil {
+		return nil, err
+	}
+
+	if columns, err = takeColumns(columns, rows); err != nil {
+		return nil, err
+	}
+
+	indices, err := takeColumns(frame.Indices(), rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return frames.NewFrame(columns, indices, frame.Labels())
+}
+
+func takeColumns(columns []frames.Column, rows []int) ([]frames.Column, error) {
+	out := make([]frames.Column, len(columns))
+	for i, col := range columns {
+		var err error
+		if out[i], err = takeColumn(col, rows); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func frameColumns(frame frames.Frame) ([]frames.Column, error) {
+	columns := make([]frames.Column, len(frame.Names()))
+	for i, name := range frame.Names() {
+		var err error
+		if columns[i], err = frame.Column(name); err != nil {
+			return nil, err
+		}
+	}
+	return columns, nil
+}
+
+// lookup returns the column or index name of frame, columns take precedence
+// over indices with the same name
+func lookup(frame frames.Frame, name string) (frames.Column, error) {
+	if col, err := frame.Column(name); err == nil {
+		return col, nil
+	}
+
+	for _, col := range frame.Indices() {
+		if col.Name() == name {
+			return col, nil
+		}
+	}
+
+	return nil, fmt.Errorf("column %q not found", name)
+}
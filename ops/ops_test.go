@@ -0,0 +1,301 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package ops
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/v3io/frames"
+)
+
+// testFrame returns a frame with an "id" index, a "device" label column and a
+// "site" label. "x" is null in row 2 and "score" is NaN in row 1
+func testFrame(t *testing.T, device string, labels map[string]interface{}) frames.Frame {
+	dept, err := frames.NewSliceColumn("dept", []string{"ops", "eng", "eng", "ops"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x, err := frames.NewSliceColumnWithNulls("x", []int64{2, 1, 0, 2}, []bool{false, false, true, false})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	score, err := frames.NewSliceColumn("score", []float64{1.5, math.NaN(), 3.5, 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	label, err := frames.NewLabelColumn("device", device, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := frames.NewSliceColumn("id", []int64{10, 11, 12, 13})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	frame, err := frames.NewFrame([]frames.Column{dept, x, score, label}, []frames.Column{id}, labels)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return frame
+}
+
+func ids(t *testing.T, frame frames.Frame) []int64 {
+	col, err := lookup(frame, "id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := col.Ints()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// checkFrame checks the row ids of frame and that its device label column and
+// site label are kept
+func checkFrame(t *testing.T, frame frames.Frame, expected ...int64) {
+	out := ids(t, frame)
+	if len(out) != len(expected) || (len(out) > 0 && !reflect.DeepEqual(out, expected)) {
+		t.Fatalf("bad rows - %v != %v", out, expected)
+	}
+
+	col, err := frame.Column("device")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !frames.IsLabelColumn(col) || col.Len() != len(expected) {
+		t.Fatalf("bad device column (size %d)", col.Len())
+	}
+
+	if site := frame.Labels()["site"]; site != "a" {
+		t.Fatalf("bad site label - %v", site)
+	}
+}
+
+func TestSort(t *testing.T) {
+	frame := testFrame(t, "d1", map[string]interface{}{"site": "a"})
+	testCases := []struct {
+		keys     []SortKey
+		expected []int64
+	}{
+		{[]SortKey{{Name: "dept"}, {Name: "x", Descending: true}}, []int64{11, 12, 10, 13}},
+		{[]SortKey{{Name: "x", NullsFirst: true}}, []int64{12, 11, 10, 13}},
+		{[]SortKey{{Name: "score", Descending: true}}, []int64{12, 10, 13, 11}},
+		{[]SortKey{{Name: "device"}, {Name: "id", Descending: true}}, []int64{13, 12, 11, 10}},
+	}
+
+	for _, tc := range testCases {
+		sorted, err := Sort(frame, tc.keys...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkFrame(t, sorted, tc.expected...)
+	}
+
+	sorted, err := Sort(frame, SortKey{Name: "x", NullsFirst: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !sorted.IsNull(0, "x") || sorted.IsNull(1, "x") {
+		t.Fatal("null not moved")
+	}
+
+	if _, err := Sort(frame, SortKey{Name: "nope"}); err == nil {
+		t.Fatal("no error for unknown column")
+	}
+}
+
+func TestFilterAndTake(t *testing.T) {
+	frame := testFrame(t, "d1", map[string]interface{}{"site": "a"})
+
+	filtered, err := Filter(frame, []bool{true, false, true, false})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFrame(t, filtered, 10, 12)
+
+	if !filtered.IsNull(1, "x") || filtered.IsNull(0, "x") {
+		t.Fatal("bad nulls after filter")
+	}
+
+	dept, err := frame.Column("dept")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filtered, err = FilterFunc(frame, func(row int) (bool, error) {
+		value, err := dept.StringAt(row)
+		return value == "ops", err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFrame(t, filtered, 10, 13)
+
+	filtered, err = Filter(frame, make([]bool, 4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFrame(t, filtered)
+
+	taken, err := Take(frame, []int{3, 3, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFrame(t, taken, 13, 13, 10)
+
+	if _, err := Take(frame, []int{4}); err == nil {
+		t.Fatal("no error for row out of bounds")
+	}
+
+	if _, err := Filter(frame, []bool{true}); err == nil {
+		t.Fatal("no error for bad mask")
+	}
+}
+
+func TestConcat(t *testing.T) {
+	first := testFrame(t, "d1", map[string]interface{}{"site": "a"})
+	second := testFrame(t, "d1", map[string]interface{}{"site": "a", "zone": "z"})
+
+	frame, err := Concat(first, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFrame(t, frame, 10, 11, 12, 13, 10, 11, 12, 13)
+
+	if _, ok := frame.Labels()["zone"]; ok {
+		t.Fatal("label of one frame in result")
+	}
+
+	nulls := frame.NullValuesMap()
+	if len(nulls) != 8 || !nulls[2].NullColumns["x"] || !nulls[6].NullColumns["x"] || len(nulls[5].NullColumns) != 0 {
+		t.Fatalf("bad null values - %v", nulls)
+	}
+
+	// Label columns with different values become slice columns
+	other := testFrame(t, "d2", nil)
+	frame, err = Concat(first, other)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	device, err := frame.Column("device")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if frames.IsLabelColumn(device) || len(frame.Labels()) != 0 {
+		t.Fatal("label column with different values")
+	}
+
+	if value, _ := device.StringAt(5); value != "d2" {
+		t.Fatalf("bad device - %q", value)
+	}
+
+	partial, err := Select(first, "dept")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Concat(first, partial); err == nil {
+		t.Fatal("no error for different columns")
+	}
+}
+
+func TestDedupe(t *testing.T) {
+	frame := testFrame(t, "d1", map[string]interface{}{"site": "a"})
+	testCases := []struct {
+		keys     []string
+		expected []int64
+	}{
+		{[]string{"dept"}, []int64{10, 11}},
+		{[]string{"x"}, []int64{10, 11, 12}},
+		{[]string{"device"}, []int64{10}},
+		{nil, []int64{10, 11, 12, 13}},
+	}
+
+	for _, tc := range testCases {
+		deduped, err := Dedupe(frame, tc.keys...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkFrame(t, deduped, tc.expected...)
+	}
+}
+
+func TestColumns(t *testing.T) {
+	frame := testFrame(t, "d1", map[string]interface{}{"site": "a"})
+
+	selected, err := Select(frame, "score", "device")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(selected.Names(), []string{"score", "device"}) {
+		t.Fatalf("bad select - %v", selected.Names())
+	}
+	checkFrame(t, selected, 10, 11, 12, 13)
+
+	dropped, err := Drop(frame, "x", "score")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(dropped.Names(), []string{"dept", "device"}) {
+		t.Fatalf("bad drop - %v", dropped.Names())
+	}
+
+	renamed, err := Rename(frame, map[string]string{"x": "y", "id": "key"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(renamed.Names(), []string{"dept", "y", "score", "device"}) || renamed.Indices()[0].Name() != "key" {
+		t.Fatalf("bad rename - %v", renamed.Names())
+	}
+
+	if !renamed.IsNull(2, "y") {
+		t.Fatal("null lost in rename")
+	}
+
+	for _, names := range []map[string]string{{"nope": "a"}, {"x": "dept"}} {
+		if _, err := Rename(frame, names); err == nil {
+			t.Fatalf("no error for %v", names)
+		}
+	}
+
+	if _, err := Select(frame, "x", "x"); err == nil {
+		t.Fatal("no error for duplicate select")
+	}
+
+	if _, err := Drop(frame, "nope"); err == nil {
+		t.Fatal("no error for unknown drop")
+	}
+}
@@ -0,0 +1,254 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package ops
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/pb"
+)
+
+// SortKey is a column (or index) to sort by
+type SortKey struct {
+	Name       string
+	Descending bool
+	NullsFirst bool // Nulls (and NaN floats) are last by default
+}
+
+// sortColumn compares the rows of a sort key column
+type sortColumn struct {
+	key     SortKey
+	compare func(i, j int) int
+	isNull  func(i int) bool
+}
+
+// Sort returns frame sorted by keys, rows with equal keys keep their order
+func Sort(frame frames.Frame, keys ...SortKey) (frames.Frame, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no sort keys")
+	}
+
+	columns := make([]*sortColumn, len(keys))
+	for i, key := range keys {
+		col, err := lookup(frame, key.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		if columns[i], err = newSortColumn(col, key); err != nil {
+			return nil, err
+		}
+	}
+
+	rows := make([]int, frame.Len())
+	for i := range rows {
+		rows[i] = i
+	}
+
+	sort.SliceStable(rows, func(a, b int) bool {
+		i, j := rows[a], rows[b]
+		for _, col := range columns {
+			iNull, jNull := col.isNull(i), col.isNull(j)
+			switch {
+			case iNull && jNull:
+				continue
+			case iNull != jNull:
+				return iNull == col.key.NullsFirst
+			}
+
+			cmp := col.compare(i, j)
+			if cmp == 0 {
+				continue
+			}
+
+			if col.key.Descending {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+
+	return takeRows(frame, rows)
+}
+
+func newSortColumn(col frames.Column, key SortKey) (*sortColumn, error) {
+	sc := &sortColumn{key: key, isNull: col.IsNull}
+
+	// All the values of a label column are equal
+	if frames.IsLabelColumn(col) {
+		sc.compare = func(i, j int) int { return 0 }
+		return sc, nil
+	}
+
+	data, err := values(col)
+	if err != nil {
+		return nil, err
+	}
+
+	switch data := data.(type) {
+	case []int64:
+		sc.compare = func(i, j int) int { return compareInts(data[i], data[j]) }
+	case []float64:
+		sc.compare = func(i, j int) int {
+			switch {
+			case data[i] < data[j]:
+				return -1
+			case data[i] > data[j]:
+				return 1
+			}
+			return 0
+		}
+		sc.isNull = func(i int) bool { return math.IsNaN(data[i]) || col.IsNull(i) }
+	case []string:
+		sc.compare = func(i, j int) int { return strings.Compare(data[i], data[j]) }
+	case []time.Time:
+		sc.compare = func(i, j int) int { return compareInts(data[i].UnixNano(), data[j].UnixNano()) }
+	case []bool:
+		sc.compare = func(i, j int) int {
+			switch {
+			case data[i] == data[j]:
+				return 0
+			case data[j]:
+				return -1
+			}
+			return 1
+		}
+	case []int32:
+		sc.compare = func(i, j int) int { return compareInts(int64(data[i]), int64(data[j])) }
+	case []float32:
+		sc.compare = func(i, j int) int {
+			switch {
+			case data[i] < data[j]:
+				return -1
+			case data[i] > data[j]:
+				return 1
+			}
+			return 0
+		}
+		sc.isNull = func(i int) bool { return math.IsNaN(float64(data[i])) || col.IsNull(i) }
+	case [][]byte:
+		sc.compare = func(i, j int) int { return bytes.Compare(data[i], data[j]) }
+	case []frames.Decimal:
+		sc.compare = func(i, j int) int { return data[i].Cmp(data[j]) }
+	default:
+		return nil, fmt.Errorf("can't sort by %q column (%s)", col.Name(), pb.DType(col.DType()))
+	}
+
+	return sc, nil
+}
+
+func compareInts(a int64, b int64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// Dedupe returns frame with the first row of every value of the key columns
+// (or indices), all the columns are keys if keys is empty
+func Dedupe(frame frames.Frame, keys ...string) (frames.Frame, error) {
+	if len(keys) == 0 {
+		keys = frame.Names()
+	}
+
+	builders := make([]strings.Builder, frame.Len())
+	for _, name := range keys {
+		col, err := lookup(frame, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := writeKeys(builders, col); err != nil {
+			return nil, err
+		}
+	}
+
+	seen := make(map[string]bool)
+	var rows []int
+	for i := range builders {
+		key := builders[i].String()
+		if !seen[key] {
+			seen[key] = true
+			rows = append(rows, i)
+		}
+	}
+
+	return takeRows(frame, rows)
+}
+
+// writeKeys writes the values of col to the row keys in builders, values are
+// written so that keys of different values differ
+func writeKeys(builders []strings.Builder, col frames.Column) error {
+	data, err := values(col)
+	if err != nil {
+		return err
+	}
+
+	for i := range builders {
+		buf := &builders[i]
+		if col.IsNull(i) {
+			buf.WriteString("n\x00")
+			continue
+		}
+
+		switch data := data.(type) {
+		case []int64:
+			buf.WriteString(strconv.FormatInt(data[i], 10))
+		case []float64:
+			buf.WriteString(strconv.FormatFloat(data[i], 'g', -1, 64))
+		case []string:
+			// Length prefix so the string can't contain the next key
+			buf.WriteString(strconv.Itoa(len(data[i])))
+			buf.WriteByte(':')
+			buf.WriteString(data[i])
+		case []time.Time:
+			buf.WriteString(strconv.FormatInt(data[i].UnixNano(), 10))
+		case []bool:
+			buf.WriteString(strconv.FormatBool(data[i]))
+		case []int32:
+			buf.WriteString(strconv.FormatInt(int64(data[i]), 10))
+		case []float32:
+			buf.WriteString(strconv.FormatFloat(float64(data[i]), 'g', -1, 32))
+		case [][]byte:
+			buf.WriteString(strconv.Itoa(len(data[i])))
+			buf.WriteByte(':')
+			buf.Write(data[i])
+		case []frames.Decimal:
+			buf.WriteString(data[i].Normalize().String())
+		default:
+			return fmt.Errorf("can't use %q column as a key (%s)", col.Name(), pb.DType(col.DType()))
+		}
+		buf.WriteByte(0)
+	}
+
+	return nil
+}
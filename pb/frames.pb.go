@@ -33,16 +33,26 @@ const (
 	DType_TIME    DType = 4
 	DType_BOOLEAN DType = 5
 	DType_NULL    DType = 6
+	DType_INT32   DType = 7
+	DType_FLOAT32 DType = 8
+	DType_BYTES   DType = 9
+	DType_DECIMAL DType = 10
+	DType_LIST    DType = 11
 )
 
 var DType_name = map[int32]string{
-	0: "NONE",
-	1: "INTEGER",
-	2: "FLOAT",
-	3: "STRING",
-	4: "TIME",
-	5: "BOOLEAN",
-	6: "NULL",
+	0:  "NONE",
+	1:  "INTEGER",
+	2:  "FLOAT",
+	3:  "STRING",
+	4:  "TIME",
+	5:  "BOOLEAN",
+	6:  "NULL",
+	7:  "INT32",
+	8:  "FLOAT32",
+	9:  "BYTES",
+	10: "DECIMAL",
+	11: "LIST",
 }
 var DType_value = map[string]int32{
 	"NONE":    0,
@@ -52,13 +62,18 @@ var DType_value = map[string]int32{
 	"TIME":    4,
 	"BOOLEAN": 5,
 	"NULL":    6,
+	"INT32":   7,
+	"FLOAT32": 8,
+	"BYTES":   9,
+	"DECIMAL": 10,
+	"LIST":    11,
 }
 
 func (x DType) String() string {
 	return proto.EnumName(DType_name, int32(x))
 }
 func (DType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{0}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{0}
 }
 
 type ErrorOptions int32
@@ -81,7 +96,7 @@ func (x ErrorOptions) String() string {
 	return proto.EnumName(ErrorOptions_name, int32(x))
 }
 func (ErrorOptions) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{1}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{1}
 }
 
 type Column_Kind int32
@@ -104,7 +119,7 @@ func (x Column_Kind) String() string {
 	return proto.EnumName(Column_Kind_name, int32(x))
 }
 func (Column_Kind) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{0, 0}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{0, 0}
 }
 
 type Column struct {
@@ -113,21 +128,31 @@ type Column struct {
 	Dtype DType       `protobuf:"varint,3,opt,name=dtype,proto3,enum=pb.DType" json:"dtype,omitempty"`
 	Size  int64       `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
 	// In slice columns these arrays will be of length 1
-	Ints                 []int64   `protobuf:"varint,5,rep,packed,name=ints,proto3" json:"ints,omitempty"`
-	Floats               []float64 `protobuf:"fixed64,6,rep,packed,name=floats,proto3" json:"floats,omitempty"`
-	Strings              []string  `protobuf:"bytes,7,rep,name=strings,proto3" json:"strings,omitempty"`
-	Times                []int64   `protobuf:"varint,8,rep,packed,name=times,proto3" json:"times,omitempty"`
-	Bools                []bool    `protobuf:"varint,9,rep,packed,name=bools,proto3" json:"bools,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Ints    []int64   `protobuf:"varint,5,rep,packed,name=ints,proto3" json:"ints,omitempty"`
+	Floats  []float64 `protobuf:"fixed64,6,rep,packed,name=floats,proto3" json:"floats,omitempty"`
+	Strings []string  `protobuf:"bytes,7,rep,name=strings,proto3" json:"strings,omitempty"`
+	Times   []int64   `protobuf:"varint,8,rep,packed,name=times,proto3" json:"times,omitempty"`
+	Bools   []bool    `protobuf:"varint,9,rep,packed,name=bools,proto3" json:"bools,omitempty"`
+	// Null bitmap, bit i (LSB first) is set if element i is null. Missing
+	// trailing bytes mean no nulls
+	Nulls    []byte    `protobuf:"bytes,10,opt,name=nulls,proto3" json:"nulls,omitempty"`
+	Floats32 []float32 `protobuf:"fixed32,11,rep,packed,name=floats32,proto3" json:"floats32,omitempty"`
+	Blobs    [][]byte  `protobuf:"bytes,12,rep,name=blobs,proto3" json:"blobs,omitempty"`
+	// A slice column per list, null lists are empty
+	Lists []*Column `protobuf:"bytes,13,rep,name=lists,proto3" json:"lists,omitempty"`
+	// Digits after the decimal point in DECIMAL columns
+	Scale                int32    `protobuf:"varint,14,opt,name=scale,proto3" json:"scale,omitempty"`
+	ListDtype            DType    `protobuf:"varint,15,opt,name=list_dtype,json=listDtype,proto3,enum=pb.DType" json:"list_dtype,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Column) Reset()         { *m = Column{} }
 func (m *Column) String() string { return proto.CompactTextString(m) }
 func (*Column) ProtoMessage()    {}
 func (*Column) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{0}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{0}
 }
 func (m *Column) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Column.Unmarshal(m, b)
@@ -210,6 +235,48 @@ func (m *Column) GetBools() []bool {
 	return nil
 }
 
+func (m *Column) GetNulls() []byte {
+	if m != nil {
+		return m.Nulls
+	}
+	return nil
+}
+
+func (m *Column) GetFloats32() []float32 {
+	if m != nil {
+		return m.Floats32
+	}
+	return nil
+}
+
+func (m *Column) GetBlobs() [][]byte {
+	if m != nil {
+		return m.Blobs
+	}
+	return nil
+}
+
+func (m *Column) GetLists() []*Column {
+	if m != nil {
+		return m.Lists
+	}
+	return nil
+}
+
+func (m *Column) GetScale() int32 {
+	if m != nil {
+		return m.Scale
+	}
+	return 0
+}
+
+func (m *Column) GetListDtype() DType {
+	if m != nil {
+		return m.ListDtype
+	}
+	return DType_NONE
+}
+
 // Union of values
 type Value struct {
 	// Types that are valid to be assigned to Value:
@@ -228,7 +295,7 @@ func (m *Value) Reset()         { *m = Value{} }
 func (m *Value) String() string { return proto.CompactTextString(m) }
 func (*Value) ProtoMessage()    {}
 func (*Value) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{1}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{1}
 }
 func (m *Value) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Value.Unmarshal(m, b)
@@ -446,7 +513,7 @@ func (m *NullValuesMap) Reset()         { *m = NullValuesMap{} }
 func (m *NullValuesMap) String() string { return proto.CompactTextString(m) }
 func (*NullValuesMap) ProtoMessage()    {}
 func (*NullValuesMap) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{2}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{2}
 }
 func (m *NullValuesMap) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_NullValuesMap.Unmarshal(m, b)
@@ -479,6 +546,8 @@ type Frame struct {
 	Labels               map[string]*Value `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	Error                string            `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
 	NullValues           []*NullValuesMap  `protobuf:"bytes,5,rep,name=null_values,json=nullValues,proto3" json:"null_values,omitempty"`
+	Arrow                []byte            `protobuf:"bytes,6,opt,name=arrow,proto3" json:"arrow,omitempty"`
+	Marker               string            `protobuf:"bytes,7,opt,name=marker,proto3" json:"marker,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -488,7 +557,7 @@ func (m *Frame) Reset()         { *m = Frame{} }
 func (m *Frame) String() string { return proto.CompactTextString(m) }
 func (*Frame) ProtoMessage()    {}
 func (*Frame) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{3}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{3}
 }
 func (m *Frame) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Frame.Unmarshal(m, b)
@@ -543,6 +612,20 @@ func (m *Frame) GetNullValues() []*NullValuesMap {
 	return nil
 }
 
+func (m *Frame) GetArrow() []byte {
+	if m != nil {
+		return m.Arrow
+	}
+	return nil
+}
+
+func (m *Frame) GetMarker() string {
+	if m != nil {
+		return m.Marker
+	}
+	return ""
+}
+
 // TODO: Place these under TableSchema
 type SchemaField struct {
 	Name                 string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -559,7 +642,7 @@ func (m *SchemaField) Reset()         { *m = SchemaField{} }
 func (m *SchemaField) String() string { return proto.CompactTextString(m) }
 func (*SchemaField) ProtoMessage()    {}
 func (*SchemaField) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{4}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{4}
 }
 func (m *SchemaField) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SchemaField.Unmarshal(m, b)
@@ -626,7 +709,7 @@ func (m *SchemaKey) Reset()         { *m = SchemaKey{} }
 func (m *SchemaKey) String() string { return proto.CompactTextString(m) }
 func (*SchemaKey) ProtoMessage()    {}
 func (*SchemaKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{5}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{5}
 }
 func (m *SchemaKey) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_SchemaKey.Unmarshal(m, b)
@@ -678,7 +761,7 @@ func (m *TableSchema) Reset()         { *m = TableSchema{} }
 func (m *TableSchema) String() string { return proto.CompactTextString(m) }
 func (*TableSchema) ProtoMessage()    {}
 func (*TableSchema) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{6}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{6}
 }
 func (m *TableSchema) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TableSchema.Unmarshal(m, b)
@@ -747,7 +830,17 @@ func (m *TableSchema) GetKey() *SchemaKey {
 	return nil
 }
 
+// JoinStruct joins the rows of a read (left side) with the rows of another
+// table (right side) that have the same keys
 type JoinStruct struct {
+	Backend              string   `protobuf:"bytes,1,opt,name=backend,proto3" json:"backend,omitempty"`
+	Table                string   `protobuf:"bytes,2,opt,name=table,proto3" json:"table,omitempty"`
+	Columns              []string `protobuf:"bytes,3,rep,name=columns,proto3" json:"columns,omitempty"`
+	Filter               string   `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	LeftOn               []string `protobuf:"bytes,5,rep,name=left_on,json=leftOn,proto3" json:"left_on,omitempty"`
+	RightOn              []string `protobuf:"bytes,6,rep,name=right_on,json=rightOn,proto3" json:"right_on,omitempty"`
+	How                  string   `protobuf:"bytes,7,opt,name=how,proto3" json:"how,omitempty"`
+	Suffix               string   `protobuf:"bytes,8,opt,name=suffix,proto3" json:"suffix,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -757,7 +850,7 @@ func (m *JoinStruct) Reset()         { *m = JoinStruct{} }
 func (m *JoinStruct) String() string { return proto.CompactTextString(m) }
 func (*JoinStruct) ProtoMessage()    {}
 func (*JoinStruct) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{7}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{7}
 }
 func (m *JoinStruct) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_JoinStruct.Unmarshal(m, b)
@@ -777,6 +870,62 @@ func (m *JoinStruct) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_JoinStruct proto.InternalMessageInfo
 
+func (m *JoinStruct) GetBackend() string {
+	if m != nil {
+		return m.Backend
+	}
+	return ""
+}
+
+func (m *JoinStruct) GetTable() string {
+	if m != nil {
+		return m.Table
+	}
+	return ""
+}
+
+func (m *JoinStruct) GetColumns() []string {
+	if m != nil {
+		return m.Columns
+	}
+	return nil
+}
+
+func (m *JoinStruct) GetFilter() string {
+	if m != nil {
+		return m.Filter
+	}
+	return ""
+}
+
+func (m *JoinStruct) GetLeftOn() []string {
+	if m != nil {
+		return m.LeftOn
+	}
+	return nil
+}
+
+func (m *JoinStruct) GetRightOn() []string {
+	if m != nil {
+		return m.RightOn
+	}
+	return nil
+}
+
+func (m *JoinStruct) GetHow() string {
+	if m != nil {
+		return m.How
+	}
+	return ""
+}
+
+func (m *JoinStruct) GetSuffix() string {
+	if m != nil {
+		return m.Suffix
+	}
+	return ""
+}
+
 type Session struct {
 	Url                  string   `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
 	Container            string   `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"`
@@ -794,7 +943,7 @@ func (m *Session) Reset()         { *m = Session{} }
 func (m *Session) String() string { return proto.CompactTextString(m) }
 func (*Session) ProtoMessage()    {}
 func (*Session) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{8}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{8}
 }
 func (m *Session) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_Session.Unmarshal(m, b)
@@ -880,6 +1029,9 @@ type ReadRequest struct {
 	MessageLimit int64         `protobuf:"varint,14,opt,name=message_limit,json=messageLimit,proto3" json:"message_limit,omitempty"`
 	Marker       string        `protobuf:"bytes,15,opt,name=marker,proto3" json:"marker,omitempty"`
 	ResetIndex   bool          `protobuf:"varint,29,opt,name=reset_index,json=resetIndex,proto3" json:"reset_index,omitempty"`
+	// The client decodes the dtypes after NULL, columns of these dtypes are
+	// sent as older dtypes otherwise
+	ExtendedDtypes bool `protobuf:"varint,30,opt,name=extended_dtypes,json=extendedDtypes,proto3" json:"extended_dtypes,omitempty"`
 	// NoSQL
 	Segments          []int64  `protobuf:"varint,16,rep,packed,name=segments,proto3" json:"segments,omitempty"`
 	TotalSegments     int64    `protobuf:"varint,17,opt,name=total_segments,json=totalSegments,proto3" json:"total_segments,omitempty"`
@@ -905,7 +1057,7 @@ func (m *ReadRequest) Reset()         { *m = ReadRequest{} }
 func (m *ReadRequest) String() string { return proto.CompactTextString(m) }
 func (*ReadRequest) ProtoMessage()    {}
 func (*ReadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{9}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{9}
 }
 func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ReadRequest.Unmarshal(m, b)
@@ -1037,6 +1189,13 @@ func (m *ReadRequest) GetResetIndex() bool {
 	return false
 }
 
+func (m *ReadRequest) GetExtendedDtypes() bool {
+	if m != nil {
+		return m.ExtendedDtypes
+	}
+	return false
+}
+
 func (m *ReadRequest) GetSegments() []int64 {
 	if m != nil {
 		return m.Segments
@@ -1147,7 +1306,7 @@ func (m *InitialWriteRequest) Reset()         { *m = InitialWriteRequest{} }
 func (m *InitialWriteRequest) String() string { return proto.CompactTextString(m) }
 func (*InitialWriteRequest) ProtoMessage()    {}
 func (*InitialWriteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{10}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{10}
 }
 func (m *InitialWriteRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_InitialWriteRequest.Unmarshal(m, b)
@@ -1244,7 +1403,7 @@ func (m *WriteRequest) Reset()         { *m = WriteRequest{} }
 func (m *WriteRequest) String() string { return proto.CompactTextString(m) }
 func (*WriteRequest) ProtoMessage()    {}
 func (*WriteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{11}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{11}
 }
 func (m *WriteRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_WriteRequest.Unmarshal(m, b)
@@ -1387,7 +1546,7 @@ func (m *WriteRespose) Reset()         { *m = WriteRespose{} }
 func (m *WriteRespose) String() string { return proto.CompactTextString(m) }
 func (*WriteRespose) ProtoMessage()    {}
 func (*WriteRespose) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{12}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{12}
 }
 func (m *WriteRespose) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_WriteRespose.Unmarshal(m, b)
@@ -1444,7 +1603,7 @@ func (m *CreateRequest) Reset()         { *m = CreateRequest{} }
 func (m *CreateRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateRequest) ProtoMessage()    {}
 func (*CreateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{13}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{13}
 }
 func (m *CreateRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateRequest.Unmarshal(m, b)
@@ -1544,7 +1703,7 @@ func (m *CreateResponse) Reset()         { *m = CreateResponse{} }
 func (m *CreateResponse) String() string { return proto.CompactTextString(m) }
 func (*CreateResponse) ProtoMessage()    {}
 func (*CreateResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{14}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{14}
 }
 func (m *CreateResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_CreateResponse.Unmarshal(m, b)
@@ -1584,7 +1743,7 @@ func (m *DeleteRequest) Reset()         { *m = DeleteRequest{} }
 func (m *DeleteRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteRequest) ProtoMessage()    {}
 func (*DeleteRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{15}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{15}
 }
 func (m *DeleteRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteRequest.Unmarshal(m, b)
@@ -1670,7 +1829,7 @@ func (m *DeleteResponse) Reset()         { *m = DeleteResponse{} }
 func (m *DeleteResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteResponse) ProtoMessage()    {}
 func (*DeleteResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{16}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{16}
 }
 func (m *DeleteResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_DeleteResponse.Unmarshal(m, b)
@@ -1700,7 +1859,7 @@ func (m *VersionRequest) Reset()         { *m = VersionRequest{} }
 func (m *VersionRequest) String() string { return proto.CompactTextString(m) }
 func (*VersionRequest) ProtoMessage()    {}
 func (*VersionRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{17}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{17}
 }
 func (m *VersionRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VersionRequest.Unmarshal(m, b)
@@ -1732,7 +1891,7 @@ func (m *ExecResponse) Reset()         { *m = ExecResponse{} }
 func (m *ExecResponse) String() string { return proto.CompactTextString(m) }
 func (*ExecResponse) ProtoMessage()    {}
 func (*ExecResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{18}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{18}
 }
 func (m *ExecResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ExecResponse.Unmarshal(m, b)
@@ -1782,7 +1941,7 @@ func (m *ExecRequest) Reset()         { *m = ExecRequest{} }
 func (m *ExecRequest) String() string { return proto.CompactTextString(m) }
 func (*ExecRequest) ProtoMessage()    {}
 func (*ExecRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{19}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{19}
 }
 func (m *ExecRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_ExecRequest.Unmarshal(m, b)
@@ -1855,7 +2014,7 @@ func (m *VersionResponse) Reset()         { *m = VersionResponse{} }
 func (m *VersionResponse) String() string { return proto.CompactTextString(m) }
 func (*VersionResponse) ProtoMessage()    {}
 func (*VersionResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{20}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{20}
 }
 func (m *VersionResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_VersionResponse.Unmarshal(m, b)
@@ -1902,7 +2061,7 @@ func (m *HistoryRequest) Reset()         { *m = HistoryRequest{} }
 func (m *HistoryRequest) String() string { return proto.CompactTextString(m) }
 func (*HistoryRequest) ProtoMessage()    {}
 func (*HistoryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_frames_e3d1b436579e21b2, []int{21}
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{21}
 }
 func (m *HistoryRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_HistoryRequest.Unmarshal(m, b)
@@ -1992,149 +2151,731 @@ func (m *HistoryRequest) GetMaxDuration() int64 {
 	return 0
 }
 
-func init() {
-	proto.RegisterType((*Column)(nil), "pb.Column")
-	proto.RegisterType((*Value)(nil), "pb.Value")
-	proto.RegisterType((*NullValuesMap)(nil), "pb.NullValuesMap")
-	proto.RegisterMapType((map[string]bool)(nil), "pb.NullValuesMap.NullColumnsEntry")
-	proto.RegisterType((*Frame)(nil), "pb.Frame")
-	proto.RegisterMapType((map[string]*Value)(nil), "pb.Frame.LabelsEntry")
-	proto.RegisterType((*SchemaField)(nil), "pb.SchemaField")
-	proto.RegisterMapType((map[string]*Value)(nil), "pb.SchemaField.PropertiesEntry")
-	proto.RegisterType((*SchemaKey)(nil), "pb.SchemaKey")
-	proto.RegisterType((*TableSchema)(nil), "pb.TableSchema")
-	proto.RegisterType((*JoinStruct)(nil), "pb.JoinStruct")
-	proto.RegisterType((*Session)(nil), "pb.Session")
-	proto.RegisterType((*ReadRequest)(nil), "pb.ReadRequest")
-	proto.RegisterType((*InitialWriteRequest)(nil), "pb.InitialWriteRequest")
-	proto.RegisterType((*WriteRequest)(nil), "pb.WriteRequest")
-	proto.RegisterType((*WriteRespose)(nil), "pb.WriteRespose")
-	proto.RegisterType((*CreateRequest)(nil), "pb.CreateRequest")
-	proto.RegisterType((*CreateResponse)(nil), "pb.CreateResponse")
-	proto.RegisterType((*DeleteRequest)(nil), "pb.DeleteRequest")
-	proto.RegisterType((*DeleteResponse)(nil), "pb.DeleteResponse")
-	proto.RegisterType((*VersionRequest)(nil), "pb.VersionRequest")
-	proto.RegisterType((*ExecResponse)(nil), "pb.ExecResponse")
-	proto.RegisterType((*ExecRequest)(nil), "pb.ExecRequest")
-	proto.RegisterMapType((map[string]*Value)(nil), "pb.ExecRequest.ArgsEntry")
-	proto.RegisterType((*VersionResponse)(nil), "pb.VersionResponse")
-	proto.RegisterType((*HistoryRequest)(nil), "pb.HistoryRequest")
-	proto.RegisterEnum("pb.DType", DType_name, DType_value)
-	proto.RegisterEnum("pb.ErrorOptions", ErrorOptions_name, ErrorOptions_value)
-	proto.RegisterEnum("pb.Column_Kind", Column_Kind_name, Column_Kind_value)
+type ListTablesRequest struct {
+	Session              *Session `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	Backend              string   `protobuf:"bytes,2,opt,name=backend,proto3" json:"backend,omitempty"`
+	Path                 string   `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// FramesClient is the client API for Frames service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type FramesClient interface {
-	Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (Frames_ReadClient, error)
-	Write(ctx context.Context, opts ...grpc.CallOption) (Frames_WriteClient, error)
-	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
-	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
-	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error)
-	History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (Frames_HistoryClient, error)
-	Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error)
+func (m *ListTablesRequest) Reset()         { *m = ListTablesRequest{} }
+func (m *ListTablesRequest) String() string { return proto.CompactTextString(m) }
+func (*ListTablesRequest) ProtoMessage()    {}
+func (*ListTablesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{22}
 }
-
-type framesClient struct {
-	cc *grpc.ClientConn
+func (m *ListTablesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTablesRequest.Unmarshal(m, b)
 }
-
-func NewFramesClient(cc *grpc.ClientConn) FramesClient {
-	return &framesClient{cc}
+func (m *ListTablesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTablesRequest.Marshal(b, m, deterministic)
+}
+func (dst *ListTablesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTablesRequest.Merge(dst, src)
+}
+func (m *ListTablesRequest) XXX_Size() int {
+	return xxx_messageInfo_ListTablesRequest.Size(m)
+}
+func (m *ListTablesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTablesRequest.DiscardUnknown(m)
 }
 
-func (c *framesClient) Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (Frames_ReadClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Frames_serviceDesc.Streams[0], "/pb.Frames/Read", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &framesReadClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+var xxx_messageInfo_ListTablesRequest proto.InternalMessageInfo
+
+func (m *ListTablesRequest) GetSession() *Session {
+	if m != nil {
+		return m.Session
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	return nil
+}
+
+func (m *ListTablesRequest) GetBackend() string {
+	if m != nil {
+		return m.Backend
 	}
-	return x, nil
+	return ""
 }
 
-type Frames_ReadClient interface {
-	Recv() (*Frame, error)
-	grpc.ClientStream
+func (m *ListTablesRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
 }
 
-type framesReadClient struct {
-	grpc.ClientStream
+type ListTablesResponse struct {
+	Tables               []string `protobuf:"bytes,1,rep,name=tables,proto3" json:"tables,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (x *framesReadClient) Recv() (*Frame, error) {
-	m := new(Frame)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *ListTablesResponse) Reset()         { *m = ListTablesResponse{} }
+func (m *ListTablesResponse) String() string { return proto.CompactTextString(m) }
+func (*ListTablesResponse) ProtoMessage()    {}
+func (*ListTablesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{23}
+}
+func (m *ListTablesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListTablesResponse.Unmarshal(m, b)
+}
+func (m *ListTablesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListTablesResponse.Marshal(b, m, deterministic)
+}
+func (dst *ListTablesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListTablesResponse.Merge(dst, src)
+}
+func (m *ListTablesResponse) XXX_Size() int {
+	return xxx_messageInfo_ListTablesResponse.Size(m)
+}
+func (m *ListTablesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListTablesResponse.DiscardUnknown(m)
 }
 
-func (c *framesClient) Write(ctx context.Context, opts ...grpc.CallOption) (Frames_WriteClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Frames_serviceDesc.Streams[1], "/pb.Frames/Write", opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ListTablesResponse proto.InternalMessageInfo
+
+func (m *ListTablesResponse) GetTables() []string {
+	if m != nil {
+		return m.Tables
 	}
-	x := &framesWriteClient{stream}
-	return x, nil
+	return nil
 }
 
-type Frames_WriteClient interface {
-	Send(*WriteRequest) error
-	CloseAndRecv() (*WriteRespose, error)
-	grpc.ClientStream
+type DescribeTableRequest struct {
+	Session              *Session `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	Backend              string   `protobuf:"bytes,2,opt,name=backend,proto3" json:"backend,omitempty"`
+	Table                string   `protobuf:"bytes,3,opt,name=table,proto3" json:"table,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type framesWriteClient struct {
-	grpc.ClientStream
+func (m *DescribeTableRequest) Reset()         { *m = DescribeTableRequest{} }
+func (m *DescribeTableRequest) String() string { return proto.CompactTextString(m) }
+func (*DescribeTableRequest) ProtoMessage()    {}
+func (*DescribeTableRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{24}
 }
-
-func (x *framesWriteClient) Send(m *WriteRequest) error {
-	return x.ClientStream.SendMsg(m)
+func (m *DescribeTableRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DescribeTableRequest.Unmarshal(m, b)
 }
-
-func (x *framesWriteClient) CloseAndRecv() (*WriteRespose, error) {
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	m := new(WriteRespose)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *DescribeTableRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DescribeTableRequest.Marshal(b, m, deterministic)
+}
+func (dst *DescribeTableRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DescribeTableRequest.Merge(dst, src)
+}
+func (m *DescribeTableRequest) XXX_Size() int {
+	return xxx_messageInfo_DescribeTableRequest.Size(m)
+}
+func (m *DescribeTableRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DescribeTableRequest.DiscardUnknown(m)
 }
 
-func (c *framesClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
-	out := new(CreateResponse)
-	err := c.cc.Invoke(ctx, "/pb.Frames/Create", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_DescribeTableRequest proto.InternalMessageInfo
+
+func (m *DescribeTableRequest) GetSession() *Session {
+	if m != nil {
+		return m.Session
 	}
-	return out, nil
+	return nil
 }
 
-func (c *framesClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
-	out := new(DeleteResponse)
-	err := c.cc.Invoke(ctx, "/pb.Frames/Delete", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *DescribeTableRequest) GetBackend() string {
+	if m != nil {
+		return m.Backend
 	}
-	return out, nil
+	return ""
+}
+
+func (m *DescribeTableRequest) GetTable() string {
+	if m != nil {
+		return m.Table
+	}
+	return ""
+}
+
+type DescribeTableResponse struct {
+	Schema               *TableSchema      `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	Attributes           map[string]*Value `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *DescribeTableResponse) Reset()         { *m = DescribeTableResponse{} }
+func (m *DescribeTableResponse) String() string { return proto.CompactTextString(m) }
+func (*DescribeTableResponse) ProtoMessage()    {}
+func (*DescribeTableResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{25}
+}
+func (m *DescribeTableResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DescribeTableResponse.Unmarshal(m, b)
+}
+func (m *DescribeTableResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DescribeTableResponse.Marshal(b, m, deterministic)
+}
+func (dst *DescribeTableResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DescribeTableResponse.Merge(dst, src)
+}
+func (m *DescribeTableResponse) XXX_Size() int {
+	return xxx_messageInfo_DescribeTableResponse.Size(m)
+}
+func (m *DescribeTableResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_DescribeTableResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DescribeTableResponse proto.InternalMessageInfo
+
+func (m *DescribeTableResponse) GetSchema() *TableSchema {
+	if m != nil {
+		return m.Schema
+	}
+	return nil
+}
+
+func (m *DescribeTableResponse) GetAttributes() map[string]*Value {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+// ExecArgument describes an argument of an exec command
+type ExecArgument struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Required             bool     `protobuf:"varint,3,opt,name=required,proto3" json:"required,omitempty"`
+	Doc                  string   `protobuf:"bytes,4,opt,name=doc,proto3" json:"doc,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ExecArgument) Reset()         { *m = ExecArgument{} }
+func (m *ExecArgument) String() string { return proto.CompactTextString(m) }
+func (*ExecArgument) ProtoMessage()    {}
+func (*ExecArgument) Descriptor() ([]byte, []int) {
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{26}
+}
+func (m *ExecArgument) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecArgument.Unmarshal(m, b)
+}
+func (m *ExecArgument) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecArgument.Marshal(b, m, deterministic)
+}
+func (dst *ExecArgument) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecArgument.Merge(dst, src)
+}
+func (m *ExecArgument) XXX_Size() int {
+	return xxx_messageInfo_ExecArgument.Size(m)
+}
+func (m *ExecArgument) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecArgument.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecArgument proto.InternalMessageInfo
+
+func (m *ExecArgument) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ExecArgument) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *ExecArgument) GetRequired() bool {
+	if m != nil {
+		return m.Required
+	}
+	return false
+}
+
+func (m *ExecArgument) GetDoc() string {
+	if m != nil {
+		return m.Doc
+	}
+	return ""
+}
+
+// ExecCommand describes an exec command of a backend
+type ExecCommand struct {
+	Name                 string          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Doc                  string          `protobuf:"bytes,2,opt,name=doc,proto3" json:"doc,omitempty"`
+	Args                 []*ExecArgument `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *ExecCommand) Reset()         { *m = ExecCommand{} }
+func (m *ExecCommand) String() string { return proto.CompactTextString(m) }
+func (*ExecCommand) ProtoMessage()    {}
+func (*ExecCommand) Descriptor() ([]byte, []int) {
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{27}
+}
+func (m *ExecCommand) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExecCommand.Unmarshal(m, b)
+}
+func (m *ExecCommand) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExecCommand.Marshal(b, m, deterministic)
+}
+func (dst *ExecCommand) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExecCommand.Merge(dst, src)
+}
+func (m *ExecCommand) XXX_Size() int {
+	return xxx_messageInfo_ExecCommand.Size(m)
+}
+func (m *ExecCommand) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExecCommand.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ExecCommand proto.InternalMessageInfo
+
+func (m *ExecCommand) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ExecCommand) GetDoc() string {
+	if m != nil {
+		return m.Doc
+	}
+	return ""
+}
+
+func (m *ExecCommand) GetArgs() []*ExecArgument {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+// Capabilities describes the requests a backend supports. Fields are the
+// names of request fields in Go (e.g. "ShardingKeys")
+type Capabilities struct {
+	Name                 string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 string         `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	ReadFields           []string       `protobuf:"bytes,3,rep,name=read_fields,json=readFields,proto3" json:"read_fields,omitempty"`
+	WriteFields          []string       `protobuf:"bytes,4,rep,name=write_fields,json=writeFields,proto3" json:"write_fields,omitempty"`
+	CreateFields         []string       `protobuf:"bytes,5,rep,name=create_fields,json=createFields,proto3" json:"create_fields,omitempty"`
+	DeleteFields         []string       `protobuf:"bytes,6,rep,name=delete_fields,json=deleteFields,proto3" json:"delete_fields,omitempty"`
+	Commands             []*ExecCommand `protobuf:"bytes,7,rep,name=commands,proto3" json:"commands,omitempty"`
+	SaveModes            []string       `protobuf:"bytes,8,rep,name=save_modes,json=saveModes,proto3" json:"save_modes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Capabilities) Reset()         { *m = Capabilities{} }
+func (m *Capabilities) String() string { return proto.CompactTextString(m) }
+func (*Capabilities) ProtoMessage()    {}
+func (*Capabilities) Descriptor() ([]byte, []int) {
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{28}
+}
+func (m *Capabilities) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Capabilities.Unmarshal(m, b)
+}
+func (m *Capabilities) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Capabilities.Marshal(b, m, deterministic)
+}
+func (dst *Capabilities) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Capabilities.Merge(dst, src)
+}
+func (m *Capabilities) XXX_Size() int {
+	return xxx_messageInfo_Capabilities.Size(m)
+}
+func (m *Capabilities) XXX_DiscardUnknown() {
+	xxx_messageInfo_Capabilities.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Capabilities proto.InternalMessageInfo
+
+func (m *Capabilities) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Capabilities) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *Capabilities) GetReadFields() []string {
+	if m != nil {
+		return m.ReadFields
+	}
+	return nil
+}
+
+func (m *Capabilities) GetWriteFields() []string {
+	if m != nil {
+		return m.WriteFields
+	}
+	return nil
+}
+
+func (m *Capabilities) GetCreateFields() []string {
+	if m != nil {
+		return m.CreateFields
+	}
+	return nil
+}
+
+func (m *Capabilities) GetDeleteFields() []string {
+	if m != nil {
+		return m.DeleteFields
+	}
+	return nil
+}
+
+func (m *Capabilities) GetCommands() []*ExecCommand {
+	if m != nil {
+		return m.Commands
+	}
+	return nil
+}
+
+func (m *Capabilities) GetSaveModes() []string {
+	if m != nil {
+		return m.SaveModes
+	}
+	return nil
+}
+
+type CapabilitiesRequest struct {
+	Session              *Session `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	Backend              string   `protobuf:"bytes,2,opt,name=backend,proto3" json:"backend,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CapabilitiesRequest) Reset()         { *m = CapabilitiesRequest{} }
+func (m *CapabilitiesRequest) String() string { return proto.CompactTextString(m) }
+func (*CapabilitiesRequest) ProtoMessage()    {}
+func (*CapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{29}
+}
+func (m *CapabilitiesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CapabilitiesRequest.Unmarshal(m, b)
+}
+func (m *CapabilitiesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CapabilitiesRequest.Marshal(b, m, deterministic)
+}
+func (dst *CapabilitiesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CapabilitiesRequest.Merge(dst, src)
+}
+func (m *CapabilitiesRequest) XXX_Size() int {
+	return xxx_messageInfo_CapabilitiesRequest.Size(m)
+}
+func (m *CapabilitiesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CapabilitiesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CapabilitiesRequest proto.InternalMessageInfo
+
+func (m *CapabilitiesRequest) GetSession() *Session {
+	if m != nil {
+		return m.Session
+	}
+	return nil
+}
+
+func (m *CapabilitiesRequest) GetBackend() string {
+	if m != nil {
+		return m.Backend
+	}
+	return ""
+}
+
+type CapabilitiesResponse struct {
+	Backends             []*Capabilities `protobuf:"bytes,1,rep,name=backends,proto3" json:"backends,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CapabilitiesResponse) Reset()         { *m = CapabilitiesResponse{} }
+func (m *CapabilitiesResponse) String() string { return proto.CompactTextString(m) }
+func (*CapabilitiesResponse) ProtoMessage()    {}
+func (*CapabilitiesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{30}
+}
+func (m *CapabilitiesResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CapabilitiesResponse.Unmarshal(m, b)
+}
+func (m *CapabilitiesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CapabilitiesResponse.Marshal(b, m, deterministic)
+}
+func (dst *CapabilitiesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CapabilitiesResponse.Merge(dst, src)
+}
+func (m *CapabilitiesResponse) XXX_Size() int {
+	return xxx_messageInfo_CapabilitiesResponse.Size(m)
+}
+func (m *CapabilitiesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CapabilitiesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CapabilitiesResponse proto.InternalMessageInfo
+
+func (m *CapabilitiesResponse) GetBackends() []*Capabilities {
+	if m != nil {
+		return m.Backends
+	}
+	return nil
+}
+
+// PluginConfigureRequest is sent to a backend plugin once it's connected
+type PluginConfigureRequest struct {
+	Config               []byte   `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PluginConfigureRequest) Reset()         { *m = PluginConfigureRequest{} }
+func (m *PluginConfigureRequest) String() string { return proto.CompactTextString(m) }
+func (*PluginConfigureRequest) ProtoMessage()    {}
+func (*PluginConfigureRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{31}
+}
+func (m *PluginConfigureRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PluginConfigureRequest.Unmarshal(m, b)
+}
+func (m *PluginConfigureRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PluginConfigureRequest.Marshal(b, m, deterministic)
+}
+func (dst *PluginConfigureRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PluginConfigureRequest.Merge(dst, src)
+}
+func (m *PluginConfigureRequest) XXX_Size() int {
+	return xxx_messageInfo_PluginConfigureRequest.Size(m)
+}
+func (m *PluginConfigureRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PluginConfigureRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PluginConfigureRequest proto.InternalMessageInfo
+
+func (m *PluginConfigureRequest) GetConfig() []byte {
+	if m != nil {
+		return m.Config
+	}
+	return nil
+}
+
+type PluginConfigureResponse struct {
+	Capabilities         *Capabilities `protobuf:"bytes,1,opt,name=capabilities,proto3" json:"capabilities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *PluginConfigureResponse) Reset()         { *m = PluginConfigureResponse{} }
+func (m *PluginConfigureResponse) String() string { return proto.CompactTextString(m) }
+func (*PluginConfigureResponse) ProtoMessage()    {}
+func (*PluginConfigureResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_frames_6bd8bbce0d73c49c, []int{32}
+}
+func (m *PluginConfigureResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PluginConfigureResponse.Unmarshal(m, b)
+}
+func (m *PluginConfigureResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PluginConfigureResponse.Marshal(b, m, deterministic)
+}
+func (dst *PluginConfigureResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PluginConfigureResponse.Merge(dst, src)
+}
+func (m *PluginConfigureResponse) XXX_Size() int {
+	return xxx_messageInfo_PluginConfigureResponse.Size(m)
+}
+func (m *PluginConfigureResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PluginConfigureResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PluginConfigureResponse proto.InternalMessageInfo
+
+func (m *PluginConfigureResponse) GetCapabilities() *Capabilities {
+	if m != nil {
+		return m.Capabilities
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Column)(nil), "pb.Column")
+	proto.RegisterType((*Value)(nil), "pb.Value")
+	proto.RegisterType((*NullValuesMap)(nil), "pb.NullValuesMap")
+	proto.RegisterMapType((map[string]bool)(nil), "pb.NullValuesMap.NullColumnsEntry")
+	proto.RegisterType((*Frame)(nil), "pb.Frame")
+	proto.RegisterMapType((map[string]*Value)(nil), "pb.Frame.LabelsEntry")
+	proto.RegisterType((*SchemaField)(nil), "pb.SchemaField")
+	proto.RegisterMapType((map[string]*Value)(nil), "pb.SchemaField.PropertiesEntry")
+	proto.RegisterType((*SchemaKey)(nil), "pb.SchemaKey")
+	proto.RegisterType((*TableSchema)(nil), "pb.TableSchema")
+	proto.RegisterType((*JoinStruct)(nil), "pb.JoinStruct")
+	proto.RegisterType((*Session)(nil), "pb.Session")
+	proto.RegisterType((*ReadRequest)(nil), "pb.ReadRequest")
+	proto.RegisterType((*InitialWriteRequest)(nil), "pb.InitialWriteRequest")
+	proto.RegisterType((*WriteRequest)(nil), "pb.WriteRequest")
+	proto.RegisterType((*WriteRespose)(nil), "pb.WriteRespose")
+	proto.RegisterType((*CreateRequest)(nil), "pb.CreateRequest")
+	proto.RegisterType((*CreateResponse)(nil), "pb.CreateResponse")
+	proto.RegisterType((*DeleteRequest)(nil), "pb.DeleteRequest")
+	proto.RegisterType((*DeleteResponse)(nil), "pb.DeleteResponse")
+	proto.RegisterType((*VersionRequest)(nil), "pb.VersionRequest")
+	proto.RegisterType((*ExecResponse)(nil), "pb.ExecResponse")
+	proto.RegisterType((*ExecRequest)(nil), "pb.ExecRequest")
+	proto.RegisterMapType((map[string]*Value)(nil), "pb.ExecRequest.ArgsEntry")
+	proto.RegisterType((*VersionResponse)(nil), "pb.VersionResponse")
+	proto.RegisterType((*HistoryRequest)(nil), "pb.HistoryRequest")
+	proto.RegisterType((*ListTablesRequest)(nil), "pb.ListTablesRequest")
+	proto.RegisterType((*ListTablesResponse)(nil), "pb.ListTablesResponse")
+	proto.RegisterType((*DescribeTableRequest)(nil), "pb.DescribeTableRequest")
+	proto.RegisterType((*DescribeTableResponse)(nil), "pb.DescribeTableResponse")
+	proto.RegisterMapType((map[string]*Value)(nil), "pb.DescribeTableResponse.AttributesEntry")
+	proto.RegisterType((*ExecArgument)(nil), "pb.ExecArgument")
+	proto.RegisterType((*ExecCommand)(nil), "pb.ExecCommand")
+	proto.RegisterType((*Capabilities)(nil), "pb.Capabilities")
+	proto.RegisterType((*CapabilitiesRequest)(nil), "pb.CapabilitiesRequest")
+	proto.RegisterType((*CapabilitiesResponse)(nil), "pb.CapabilitiesResponse")
+	proto.RegisterType((*PluginConfigureRequest)(nil), "pb.PluginConfigureRequest")
+	proto.RegisterType((*PluginConfigureResponse)(nil), "pb.PluginConfigureResponse")
+	proto.RegisterEnum("pb.DType", DType_name, DType_value)
+	proto.RegisterEnum("pb.ErrorOptions", ErrorOptions_name, ErrorOptions_value)
+	proto.RegisterEnum("pb.Column_Kind", Column_Kind_name, Column_Kind_value)
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// FramesClient is the client API for Frames service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type FramesClient interface {
+	Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (Frames_ReadClient, error)
+	Write(ctx context.Context, opts ...grpc.CallOption) (Frames_WriteClient, error)
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error)
+	History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (Frames_HistoryClient, error)
+	Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error)
+	ListTables(ctx context.Context, in *ListTablesRequest, opts ...grpc.CallOption) (*ListTablesResponse, error)
+	DescribeTable(ctx context.Context, in *DescribeTableRequest, opts ...grpc.CallOption) (*DescribeTableResponse, error)
+	Capabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*CapabilitiesResponse, error)
+}
+
+type framesClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewFramesClient(cc *grpc.ClientConn) FramesClient {
+	return &framesClient{cc}
+}
+
+func (c *framesClient) Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (Frames_ReadClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Frames_serviceDesc.Streams[0], "/pb.Frames/Read", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &framesReadClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Frames_ReadClient interface {
+	Recv() (*Frame, error)
+	grpc.ClientStream
+}
+
+type framesReadClient struct {
+	grpc.ClientStream
+}
+
+func (x *framesReadClient) Recv() (*Frame, error) {
+	m := new(Frame)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *framesClient) Write(ctx context.Context, opts ...grpc.CallOption) (Frames_WriteClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Frames_serviceDesc.Streams[1], "/pb.Frames/Write", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &framesWriteClient{stream}
+	return x, nil
+}
+
+type Frames_WriteClient interface {
+	Send(*WriteRequest) error
+	CloseAndRecv() (*WriteRespose, error)
+	grpc.ClientStream
+}
+
+type framesWriteClient struct {
+	grpc.ClientStream
+}
+
+func (x *framesWriteClient) Send(m *WriteRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *framesWriteClient) CloseAndRecv() (*WriteRespose, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(WriteRespose)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *framesClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
+	out := new(CreateResponse)
+	err := c.cc.Invoke(ctx, "/pb.Frames/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *framesClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, "/pb.Frames/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
 func (c *framesClient) Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error) {
@@ -2146,12 +2887,374 @@ func (c *framesClient) Exec(ctx context.Context, in *ExecRequest, opts ...grpc.C
 	return out, nil
 }
 
-func (c *framesClient) History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (Frames_HistoryClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Frames_serviceDesc.Streams[2], "/pb.Frames/History", opts...)
+func (c *framesClient) History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (Frames_HistoryClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Frames_serviceDesc.Streams[2], "/pb.Frames/History", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &framesHistoryClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Frames_HistoryClient interface {
+	Recv() (*Frame, error)
+	grpc.ClientStream
+}
+
+type framesHistoryClient struct {
+	grpc.ClientStream
+}
+
+func (x *framesHistoryClient) Recv() (*Frame, error) {
+	m := new(Frame)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *framesClient) Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error) {
+	out := new(VersionResponse)
+	err := c.cc.Invoke(ctx, "/pb.Frames/Version", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *framesClient) ListTables(ctx context.Context, in *ListTablesRequest, opts ...grpc.CallOption) (*ListTablesResponse, error) {
+	out := new(ListTablesResponse)
+	err := c.cc.Invoke(ctx, "/pb.Frames/ListTables", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *framesClient) DescribeTable(ctx context.Context, in *DescribeTableRequest, opts ...grpc.CallOption) (*DescribeTableResponse, error) {
+	out := new(DescribeTableResponse)
+	err := c.cc.Invoke(ctx, "/pb.Frames/DescribeTable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *framesClient) Capabilities(ctx context.Context, in *CapabilitiesRequest, opts ...grpc.CallOption) (*CapabilitiesResponse, error) {
+	out := new(CapabilitiesResponse)
+	err := c.cc.Invoke(ctx, "/pb.Frames/Capabilities", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FramesServer is the server API for Frames service.
+type FramesServer interface {
+	Read(*ReadRequest, Frames_ReadServer) error
+	Write(Frames_WriteServer) error
+	Create(context.Context, *CreateRequest) (*CreateResponse, error)
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
+	Exec(context.Context, *ExecRequest) (*ExecResponse, error)
+	History(*HistoryRequest, Frames_HistoryServer) error
+	Version(context.Context, *VersionRequest) (*VersionResponse, error)
+	ListTables(context.Context, *ListTablesRequest) (*ListTablesResponse, error)
+	DescribeTable(context.Context, *DescribeTableRequest) (*DescribeTableResponse, error)
+	Capabilities(context.Context, *CapabilitiesRequest) (*CapabilitiesResponse, error)
+}
+
+func RegisterFramesServer(s *grpc.Server, srv FramesServer) {
+	s.RegisterService(&_Frames_serviceDesc, srv)
+}
+
+func _Frames_Read_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FramesServer).Read(m, &framesReadServer{stream})
+}
+
+type Frames_ReadServer interface {
+	Send(*Frame) error
+	grpc.ServerStream
+}
+
+type framesReadServer struct {
+	grpc.ServerStream
+}
+
+func (x *framesReadServer) Send(m *Frame) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Frames_Write_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(FramesServer).Write(&framesWriteServer{stream})
+}
+
+type Frames_WriteServer interface {
+	SendAndClose(*WriteRespose) error
+	Recv() (*WriteRequest, error)
+	grpc.ServerStream
+}
+
+type framesWriteServer struct {
+	grpc.ServerStream
+}
+
+func (x *framesWriteServer) SendAndClose(m *WriteRespose) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *framesWriteServer) Recv() (*WriteRequest, error) {
+	m := new(WriteRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Frames_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FramesServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Frames/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FramesServer).Create(ctx, req.(*CreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Frames_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FramesServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Frames/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FramesServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Frames_Exec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FramesServer).Exec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Frames/Exec",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FramesServer).Exec(ctx, req.(*ExecRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Frames_History_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(HistoryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FramesServer).History(m, &framesHistoryServer{stream})
+}
+
+type Frames_HistoryServer interface {
+	Send(*Frame) error
+	grpc.ServerStream
+}
+
+type framesHistoryServer struct {
+	grpc.ServerStream
+}
+
+func (x *framesHistoryServer) Send(m *Frame) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Frames_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FramesServer).Version(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Frames/Version",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FramesServer).Version(ctx, req.(*VersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Frames_ListTables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTablesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FramesServer).ListTables(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Frames/ListTables",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FramesServer).ListTables(ctx, req.(*ListTablesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Frames_DescribeTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FramesServer).DescribeTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Frames/DescribeTable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FramesServer).DescribeTable(ctx, req.(*DescribeTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Frames_Capabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FramesServer).Capabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Frames/Capabilities",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FramesServer).Capabilities(ctx, req.(*CapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _Frames_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.Frames",
+	HandlerType: (*FramesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Create",
+			Handler:    _Frames_Create_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _Frames_Delete_Handler,
+		},
+		{
+			MethodName: "Exec",
+			Handler:    _Frames_Exec_Handler,
+		},
+		{
+			MethodName: "Version",
+			Handler:    _Frames_Version_Handler,
+		},
+		{
+			MethodName: "ListTables",
+			Handler:    _Frames_ListTables_Handler,
+		},
+		{
+			MethodName: "DescribeTable",
+			Handler:    _Frames_DescribeTable_Handler,
+		},
+		{
+			MethodName: "Capabilities",
+			Handler:    _Frames_Capabilities_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Read",
+			Handler:       _Frames_Read_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Write",
+			Handler:       _Frames_Write_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "History",
+			Handler:       _Frames_History_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "frames.proto",
+}
+
+// BackendPluginClient is the client API for BackendPlugin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BackendPluginClient interface {
+	Configure(ctx context.Context, in *PluginConfigureRequest, opts ...grpc.CallOption) (*PluginConfigureResponse, error)
+	Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (BackendPlugin_ReadClient, error)
+	Write(ctx context.Context, opts ...grpc.CallOption) (BackendPlugin_WriteClient, error)
+	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
+	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error)
+}
+
+type backendPluginClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewBackendPluginClient(cc *grpc.ClientConn) BackendPluginClient {
+	return &backendPluginClient{cc}
+}
+
+func (c *backendPluginClient) Configure(ctx context.Context, in *PluginConfigureRequest, opts ...grpc.CallOption) (*PluginConfigureResponse, error) {
+	out := new(PluginConfigureResponse)
+	err := c.cc.Invoke(ctx, "/pb.BackendPlugin/Configure", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backendPluginClient) Read(ctx context.Context, in *ReadRequest, opts ...grpc.CallOption) (BackendPlugin_ReadClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BackendPlugin_serviceDesc.Streams[0], "/pb.BackendPlugin/Read", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &framesHistoryClient{stream}
+	x := &backendPluginReadClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -2161,16 +3264,16 @@ func (c *framesClient) History(ctx context.Context, in *HistoryRequest, opts ...
 	return x, nil
 }
 
-type Frames_HistoryClient interface {
+type BackendPlugin_ReadClient interface {
 	Recv() (*Frame, error)
 	grpc.ClientStream
 }
 
-type framesHistoryClient struct {
+type backendPluginReadClient struct {
 	grpc.ClientStream
 }
 
-func (x *framesHistoryClient) Recv() (*Frame, error) {
+func (x *backendPluginReadClient) Recv() (*Frame, error) {
 	m := new(Frame)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
@@ -2178,70 +3281,139 @@ func (x *framesHistoryClient) Recv() (*Frame, error) {
 	return m, nil
 }
 
-func (c *framesClient) Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error) {
-	out := new(VersionResponse)
-	err := c.cc.Invoke(ctx, "/pb.Frames/Version", in, out, opts...)
+func (c *backendPluginClient) Write(ctx context.Context, opts ...grpc.CallOption) (BackendPlugin_WriteClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_BackendPlugin_serviceDesc.Streams[1], "/pb.BackendPlugin/Write", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &backendPluginWriteClient{stream}
+	return x, nil
+}
+
+type BackendPlugin_WriteClient interface {
+	Send(*WriteRequest) error
+	CloseAndRecv() (*WriteRespose, error)
+	grpc.ClientStream
+}
+
+type backendPluginWriteClient struct {
+	grpc.ClientStream
+}
+
+func (x *backendPluginWriteClient) Send(m *WriteRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *backendPluginWriteClient) CloseAndRecv() (*WriteRespose, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(WriteRespose)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *backendPluginClient) Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error) {
+	out := new(CreateResponse)
+	err := c.cc.Invoke(ctx, "/pb.BackendPlugin/Create", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// FramesServer is the server API for Frames service.
-type FramesServer interface {
-	Read(*ReadRequest, Frames_ReadServer) error
-	Write(Frames_WriteServer) error
+func (c *backendPluginClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, "/pb.BackendPlugin/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backendPluginClient) Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error) {
+	out := new(ExecResponse)
+	err := c.cc.Invoke(ctx, "/pb.BackendPlugin/Exec", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BackendPluginServer is the server API for BackendPlugin service.
+type BackendPluginServer interface {
+	Configure(context.Context, *PluginConfigureRequest) (*PluginConfigureResponse, error)
+	Read(*ReadRequest, BackendPlugin_ReadServer) error
+	Write(BackendPlugin_WriteServer) error
 	Create(context.Context, *CreateRequest) (*CreateResponse, error)
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
 	Exec(context.Context, *ExecRequest) (*ExecResponse, error)
-	History(*HistoryRequest, Frames_HistoryServer) error
-	Version(context.Context, *VersionRequest) (*VersionResponse, error)
 }
 
-func RegisterFramesServer(s *grpc.Server, srv FramesServer) {
-	s.RegisterService(&_Frames_serviceDesc, srv)
+func RegisterBackendPluginServer(s *grpc.Server, srv BackendPluginServer) {
+	s.RegisterService(&_BackendPlugin_serviceDesc, srv)
 }
 
-func _Frames_Read_Handler(srv interface{}, stream grpc.ServerStream) error {
+func _BackendPlugin_Configure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PluginConfigureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackendPluginServer).Configure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.BackendPlugin/Configure",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackendPluginServer).Configure(ctx, req.(*PluginConfigureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackendPlugin_Read_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(ReadRequest)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(FramesServer).Read(m, &framesReadServer{stream})
+	return srv.(BackendPluginServer).Read(m, &backendPluginReadServer{stream})
 }
 
-type Frames_ReadServer interface {
+type BackendPlugin_ReadServer interface {
 	Send(*Frame) error
 	grpc.ServerStream
 }
 
-type framesReadServer struct {
+type backendPluginReadServer struct {
 	grpc.ServerStream
 }
 
-func (x *framesReadServer) Send(m *Frame) error {
+func (x *backendPluginReadServer) Send(m *Frame) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _Frames_Write_Handler(srv interface{}, stream grpc.ServerStream) error {
-	return srv.(FramesServer).Write(&framesWriteServer{stream})
+func _BackendPlugin_Write_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BackendPluginServer).Write(&backendPluginWriteServer{stream})
 }
 
-type Frames_WriteServer interface {
+type BackendPlugin_WriteServer interface {
 	SendAndClose(*WriteRespose) error
 	Recv() (*WriteRequest, error)
 	grpc.ServerStream
 }
 
-type framesWriteServer struct {
+type backendPluginWriteServer struct {
 	grpc.ServerStream
 }
 
-func (x *framesWriteServer) SendAndClose(m *WriteRespose) error {
+func (x *backendPluginWriteServer) SendAndClose(m *WriteRespose) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func (x *framesWriteServer) Recv() (*WriteRequest, error) {
+func (x *backendPluginWriteServer) Recv() (*WriteRequest, error) {
 	m := new(WriteRequest)
 	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
@@ -2249,266 +3421,260 @@ func (x *framesWriteServer) Recv() (*WriteRequest, error) {
 	return m, nil
 }
 
-func _Frames_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _BackendPlugin_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FramesServer).Create(ctx, in)
+		return srv.(BackendPluginServer).Create(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.Frames/Create",
+		FullMethod: "/pb.BackendPlugin/Create",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FramesServer).Create(ctx, req.(*CreateRequest))
+		return srv.(BackendPluginServer).Create(ctx, req.(*CreateRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Frames_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _BackendPlugin_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FramesServer).Delete(ctx, in)
+		return srv.(BackendPluginServer).Delete(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.Frames/Delete",
+		FullMethod: "/pb.BackendPlugin/Delete",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FramesServer).Delete(ctx, req.(*DeleteRequest))
+		return srv.(BackendPluginServer).Delete(ctx, req.(*DeleteRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _Frames_Exec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _BackendPlugin_Exec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ExecRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(FramesServer).Exec(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/pb.Frames/Exec",
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FramesServer).Exec(ctx, req.(*ExecRequest))
-	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _Frames_History_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(HistoryRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(FramesServer).History(m, &framesHistoryServer{stream})
-}
-
-type Frames_HistoryServer interface {
-	Send(*Frame) error
-	grpc.ServerStream
-}
-
-type framesHistoryServer struct {
-	grpc.ServerStream
-}
-
-func (x *framesHistoryServer) Send(m *Frame) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-func _Frames_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VersionRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(FramesServer).Version(ctx, in)
+		return srv.(BackendPluginServer).Exec(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/pb.Frames/Version",
+		FullMethod: "/pb.BackendPlugin/Exec",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(FramesServer).Version(ctx, req.(*VersionRequest))
+		return srv.(BackendPluginServer).Exec(ctx, req.(*ExecRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-var _Frames_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "pb.Frames",
-	HandlerType: (*FramesServer)(nil),
+var _BackendPlugin_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.BackendPlugin",
+	HandlerType: (*BackendPluginServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Configure",
+			Handler:    _BackendPlugin_Configure_Handler,
+		},
 		{
 			MethodName: "Create",
-			Handler:    _Frames_Create_Handler,
+			Handler:    _BackendPlugin_Create_Handler,
 		},
 		{
 			MethodName: "Delete",
-			Handler:    _Frames_Delete_Handler,
+			Handler:    _BackendPlugin_Delete_Handler,
 		},
 		{
 			MethodName: "Exec",
-			Handler:    _Frames_Exec_Handler,
-		},
-		{
-			MethodName: "Version",
-			Handler:    _Frames_Version_Handler,
+			Handler:    _BackendPlugin_Exec_Handler,
 		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
 			StreamName:    "Read",
-			Handler:       _Frames_Read_Handler,
+			Handler:       _BackendPlugin_Read_Handler,
 			ServerStreams: true,
 		},
 		{
 			StreamName:    "Write",
-			Handler:       _Frames_Write_Handler,
+			Handler:       _BackendPlugin_Write_Handler,
 			ClientStreams: true,
 		},
-		{
-			StreamName:    "History",
-			Handler:       _Frames_History_Handler,
-			ServerStreams: true,
-		},
 	},
 	Metadata: "frames.proto",
 }
 
-func init() { proto.RegisterFile("frames.proto", fileDescriptor_frames_e3d1b436579e21b2) }
-
-var fileDescriptor_frames_e3d1b436579e21b2 = []byte{
-	// 1969 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0xcd, 0x72, 0x1b, 0xc7,
-	0x11, 0xe6, 0xe2, 0x7f, 0x1b, 0x20, 0x08, 0x8f, 0x65, 0x79, 0x05, 0xcb, 0x11, 0xb5, 0x92, 0x63,
-	0x96, 0x65, 0x51, 0x09, 0x9d, 0xaa, 0xa4, 0x7c, 0x48, 0x4a, 0x14, 0x41, 0x91, 0x11, 0x44, 0xa6,
-	0x96, 0x8c, 0x7c, 0x44, 0x0d, 0xb1, 0x03, 0x68, 0xc2, 0xfd, 0x81, 0x66, 0x06, 0x22, 0x91, 0x43,
-	0x8e, 0xc9, 0x39, 0xa9, 0xca, 0x13, 0xe4, 0x05, 0xf2, 0x0e, 0x39, 0xe5, 0x0d, 0xf2, 0x10, 0xb9,
-	0xe4, 0x94, 0x6b, 0xaa, 0x7b, 0x66, 0x81, 0x05, 0xa5, 0xe4, 0xe0, 0x8a, 0x6e, 0xd3, 0x5f, 0xf7,
-	0xcc, 0x74, 0x7f, 0xdb, 0xdd, 0xd3, 0x00, 0x74, 0x26, 0x8a, 0xa7, 0x42, 0xef, 0xce, 0x54, 0x6e,
-	0x72, 0x56, 0x99, 0x5d, 0x84, 0xbf, 0xaf, 0x40, 0xe3, 0x59, 0x9e, 0xcc, 0xd3, 0x8c, 0x3d, 0x80,
-	0xda, 0xa5, 0xcc, 0xe2, 0xc0, 0xdb, 0xf6, 0x76, 0xba, 0x7b, 0x5b, 0xbb, 0xb3, 0x8b, 0x5d, 0xab,
-	0xd9, 0x7d, 0x21, 0xb3, 0x38, 0x22, 0x25, 0x63, 0x50, 0xcb, 0x78, 0x2a, 0x82, 0xca, 0xb6, 0xb7,
-	0xe3, 0x47, 0xb4, 0x66, 0xf7, 0xa0, 0x1e, 0x9b, 0xc5, 0x4c, 0x04, 0x55, 0xda, 0xe9, 0xe3, 0xce,
-	0x83, 0xf3, 0xc5, 0x4c, 0x44, 0x16, 0xc7, 0x4d, 0x5a, 0xfe, 0x56, 0x04, 0xb5, 0x6d, 0x6f, 0xa7,
-	0x1a, 0xd1, 0x1a, 0x31, 0x99, 0x19, 0x1d, 0xd4, 0xb7, 0xab, 0x88, 0xe1, 0x9a, 0xdd, 0x86, 0xc6,
-	0x24, 0xc9, 0xb9, 0xd1, 0x41, 0x63, 0xbb, 0xba, 0xe3, 0x45, 0x4e, 0x62, 0x01, 0x34, 0xb5, 0x51,
-	0x32, 0x9b, 0xea, 0xa0, 0xb9, 0x5d, 0xdd, 0xf1, 0xa3, 0x42, 0x64, 0xb7, 0xa0, 0x6e, 0x64, 0x2a,
-	0x74, 0xd0, 0xa2, 0x63, 0xac, 0x80, 0xe8, 0x45, 0x9e, 0x27, 0x3a, 0xf0, 0xb7, 0xab, 0x3b, 0xad,
-	0xc8, 0x0a, 0xe1, 0x5d, 0xa8, 0x61, 0x20, 0xcc, 0x87, 0xfa, 0xd9, 0xf0, 0xf8, 0xd9, 0xa0, 0xb7,
-	0x81, 0xcb, 0xe1, 0xd3, 0xfd, 0xc1, 0xb0, 0xe7, 0x85, 0xbf, 0x83, 0xfa, 0x2b, 0x9e, 0xcc, 0x05,
-	0xbb, 0x05, 0x35, 0xf9, 0x96, 0x27, 0x44, 0x43, 0xf5, 0x68, 0x23, 0x22, 0x09, 0xd1, 0x09, 0xa2,
-	0x18, 0xb7, 0x87, 0xe8, 0xc4, 0xa1, 0x1a, 0x51, 0x0c, 0xdc, 0x47, 0x54, 0x3b, 0xd4, 0x20, 0x5a,
-	0x2b, 0x4e, 0x30, 0x0e, 0xbd, 0x40, 0xb4, 0xbe, 0xed, 0xed, 0xb4, 0x10, 0x45, 0x69, 0xbf, 0x09,
-	0xf5, 0xb7, 0x78, 0x6d, 0xf8, 0x67, 0x0f, 0x36, 0x4f, 0xe6, 0x49, 0x42, 0x4e, 0xe8, 0x97, 0x7c,
-	0xc6, 0x0e, 0xa0, 0x9d, 0xcd, 0x93, 0xc4, 0x7e, 0x03, 0x1d, 0x78, 0xdb, 0xd5, 0x9d, 0xf6, 0x5e,
-	0x88, 0xe4, 0xae, 0xd9, 0x91, 0xe4, 0x8c, 0x06, 0x99, 0x51, 0x8b, 0xa8, 0xbc, 0xad, 0xff, 0x73,
-	0xe8, 0xdd, 0x34, 0x60, 0x3d, 0xa8, 0x5e, 0x8a, 0x05, 0x45, 0xe8, 0x47, 0xb8, 0x44, 0xc6, 0xc8,
-	0x0d, 0x8a, 0xaf, 0x15, 0x59, 0xe1, 0xdb, 0xca, 0xcf, 0xbc, 0xf0, 0x4f, 0x15, 0xa8, 0x1f, 0x62,
-	0xd6, 0xb0, 0x87, 0xd0, 0x1c, 0xaf, 0xf9, 0x02, 0xab, 0x14, 0x89, 0x0a, 0x15, 0x5a, 0xc9, 0x2c,
-	0x96, 0x63, 0xa1, 0x83, 0xca, 0xbb, 0x56, 0x4e, 0xc5, 0x1e, 0x43, 0x23, 0xe1, 0x17, 0x22, 0xd1,
-	0x41, 0x95, 0x8c, 0x3e, 0x41, 0x23, 0xba, 0x66, 0x77, 0x48, 0xb8, 0x8d, 0xc4, 0x19, 0xa1, 0x7b,
-	0x42, 0xa9, 0x5c, 0x11, 0xa5, 0x7e, 0x64, 0x05, 0xb6, 0x67, 0x09, 0x1a, 0x91, 0xb3, 0x36, 0x93,
-	0xda, 0x7b, 0x1f, 0xbd, 0x43, 0x50, 0x04, 0xd9, 0x52, 0xec, 0x1f, 0x40, 0xbb, 0x74, 0xc1, 0x7b,
-	0x98, 0xb8, 0x57, 0x66, 0xa2, 0x6d, 0x93, 0x99, 0xf6, 0x96, 0x49, 0xf9, 0xb7, 0x07, 0xed, 0xb3,
-	0xf1, 0x6b, 0x91, 0xf2, 0x43, 0x29, 0x92, 0x55, 0x55, 0x78, 0xa5, 0xaa, 0xe8, 0x41, 0x35, 0xce,
-	0xc7, 0xae, 0x50, 0x70, 0xc9, 0x1e, 0x40, 0x33, 0x16, 0x13, 0x3e, 0x4f, 0x0c, 0x25, 0xcc, 0xda,
-	0xe1, 0x85, 0x06, 0x8f, 0xa2, 0x5a, 0xb2, 0x91, 0xd2, 0x9a, 0xfd, 0x02, 0x60, 0xa6, 0xf2, 0x99,
-	0x50, 0x46, 0x2e, 0xe3, 0xbc, 0x87, 0x7b, 0x4b, 0x3e, 0xec, 0xfe, 0x6a, 0x69, 0x61, 0xb9, 0x2b,
-	0x6d, 0xe9, 0x1f, 0xc1, 0xd6, 0x0d, 0xf5, 0xf7, 0x8d, 0xfc, 0x14, 0x7c, 0x7b, 0xe9, 0x0b, 0xb1,
-	0x60, 0xf7, 0xa1, 0xa3, 0x5f, 0x73, 0x15, 0xcb, 0x6c, 0x3a, 0xb2, 0x87, 0x61, 0x71, 0xb6, 0x0b,
-	0xec, 0x05, 0x1d, 0xda, 0xd6, 0xb9, 0x32, 0x85, 0x45, 0x85, 0x2c, 0xc0, 0x41, 0x2f, 0xc4, 0x22,
-	0xfc, 0xbb, 0x07, 0xed, 0x73, 0x7e, 0x91, 0x08, 0x7b, 0xec, 0x32, 0x7e, 0xaf, 0x14, 0xff, 0x5d,
-	0xf0, 0x91, 0x52, 0x3d, 0xe3, 0xe3, 0xa2, 0xf3, 0xac, 0x80, 0x25, 0xf9, 0xd5, 0x77, 0xc9, 0xaf,
-	0xad, 0xc8, 0x0f, 0xa0, 0xc9, 0x13, 0xc9, 0xb5, 0x23, 0xd0, 0x8f, 0x0a, 0x91, 0x7d, 0x09, 0x8d,
-	0x09, 0x32, 0x68, 0xbb, 0x4e, 0xdb, 0x76, 0xbe, 0x12, 0xb3, 0x91, 0x53, 0xb3, 0x7b, 0x96, 0xb2,
-	0x26, 0xd1, 0xb3, 0xb9, 0xb2, 0x7a, 0x21, 0x16, 0xc4, 0x60, 0xd8, 0x01, 0xf8, 0x65, 0x2e, 0xb3,
-	0x33, 0xa3, 0xe6, 0x63, 0x13, 0xfe, 0xc5, 0x83, 0xe6, 0x99, 0xd0, 0x5a, 0xe6, 0x19, 0xfa, 0x33,
-	0x57, 0x49, 0xc1, 0xf6, 0x5c, 0x25, 0x18, 0xd3, 0x38, 0xcf, 0x0c, 0x97, 0x99, 0x50, 0x45, 0x4c,
-	0x4b, 0x00, 0x63, 0x9a, 0x71, 0xf3, 0xba, 0x88, 0x09, 0xd7, 0x88, 0xcd, 0xb5, 0x28, 0x6a, 0x80,
-	0xd6, 0xac, 0x0f, 0xad, 0x19, 0xd7, 0xfa, 0x2a, 0x57, 0x31, 0x35, 0x16, 0x3f, 0x5a, 0xca, 0xd4,
-	0x1b, 0xf3, 0x4b, 0x91, 0x05, 0x0d, 0x5b, 0x34, 0x24, 0xb0, 0x2e, 0x54, 0x64, 0x4c, 0x31, 0xf8,
-	0x51, 0x45, 0xc6, 0xe1, 0x1f, 0x9a, 0xd0, 0x8e, 0x04, 0x8f, 0x23, 0xf1, 0x66, 0x2e, 0xb4, 0x61,
-	0x5f, 0x40, 0x53, 0x5b, 0xa7, 0xc9, 0xdb, 0xf6, 0x5e, 0x9b, 0x02, 0xb5, 0x50, 0x54, 0xe8, 0x90,
-	0xce, 0x0b, 0x3e, 0xbe, 0x14, 0x59, 0xec, 0x9c, 0x2f, 0x44, 0xa4, 0x53, 0x13, 0x2d, 0x2e, 0xc9,
-	0x89, 0xce, 0xd2, 0x17, 0x8e, 0x9c, 0x1a, 0x53, 0x23, 0xe6, 0x86, 0x8f, 0x26, 0xb9, 0x4a, 0xb9,
-	0x71, 0x61, 0x01, 0x42, 0x87, 0x84, 0xb0, 0xcf, 0x01, 0x54, 0x7e, 0x35, 0x4a, 0xf8, 0x22, 0x9f,
-	0x1b, 0xdb, 0x37, 0x23, 0x5f, 0xe5, 0x57, 0x43, 0x02, 0x70, 0x7f, 0x3a, 0x4f, 0x8c, 0x1c, 0xc9,
-	0x2c, 0x16, 0xd7, 0x14, 0x65, 0x2b, 0x02, 0x82, 0x8e, 0x11, 0x41, 0x02, 0xde, 0xcc, 0x85, 0x5a,
-	0xb8, 0x68, 0xad, 0x40, 0xb4, 0xa0, 0x37, 0x41, 0xcb, 0xd1, 0x82, 0x02, 0xc6, 0x53, 0x34, 0x37,
-	0xdf, 0xa6, 0x47, 0xd1, 0xd0, 0xf0, 0x51, 0x92, 0x89, 0x11, 0x2a, 0x00, 0xda, 0xe0, 0x24, 0x76,
-	0x07, 0x5a, 0x53, 0x95, 0xcf, 0x67, 0xa3, 0x8b, 0x45, 0xd0, 0xb6, 0x14, 0x90, 0xbc, 0xbf, 0x60,
-	0x21, 0xd4, 0x7e, 0x93, 0xcb, 0x2c, 0xe8, 0x50, 0x3e, 0x75, 0x91, 0x80, 0x55, 0x5e, 0x44, 0xa4,
-	0x43, 0x37, 0x12, 0x99, 0x4a, 0x13, 0x6c, 0xd2, 0xa3, 0x68, 0x05, 0xf6, 0x00, 0x36, 0x53, 0xa1,
-	0x35, 0x9f, 0x8a, 0x91, 0xd5, 0x76, 0x49, 0xdb, 0x71, 0xe0, 0x90, 0x8c, 0x6e, 0x43, 0x23, 0xe5,
-	0xea, 0x52, 0xa8, 0x60, 0xcb, 0x7a, 0x64, 0x25, 0x24, 0x44, 0x09, 0x2d, 0x8c, 0x23, 0xe4, 0x73,
-	0x4b, 0x08, 0x41, 0x96, 0x90, 0x3e, 0xb4, 0xb4, 0x98, 0xa6, 0x02, 0xdf, 0xdd, 0x1e, 0x3d, 0x98,
-	0x4b, 0x99, 0x7d, 0x01, 0x5d, 0x93, 0x1b, 0x9e, 0x8c, 0x96, 0x16, 0x1f, 0xd1, 0xd5, 0x9b, 0x84,
-	0x9e, 0x15, 0x66, 0x0f, 0x60, 0xb3, 0x5c, 0xf2, 0x3a, 0x60, 0xc4, 0x56, 0xa7, 0x54, 0xf3, 0x9a,
-	0x3d, 0x81, 0x5b, 0x58, 0xe1, 0x68, 0x30, 0x52, 0x3c, 0x9b, 0x8a, 0x91, 0x36, 0x5c, 0x99, 0xe0,
-	0x63, 0x72, 0xf7, 0x23, 0xd4, 0x61, 0xcd, 0xa0, 0xe6, 0x0c, 0x15, 0xec, 0x11, 0xb0, 0x1b, 0x1b,
-	0x30, 0xb1, 0x6e, 0x91, 0xf9, 0x56, 0xd9, 0x7c, 0x90, 0x51, 0x5e, 0xdb, 0xe3, 0x3e, 0xb1, 0x1f,
-	0x90, 0x04, 0xac, 0x30, 0xdc, 0x73, 0xdb, 0x56, 0x98, 0xb0, 0xa3, 0x8a, 0x36, 0x62, 0x16, 0x7c,
-	0x6a, 0xeb, 0x05, 0xd7, 0x6c, 0x1b, 0xda, 0x7c, 0x3a, 0x55, 0x62, 0xca, 0x4d, 0xae, 0x74, 0x10,
-	0x90, 0xaa, 0x0c, 0xb1, 0xc7, 0xc0, 0x0a, 0x51, 0xe6, 0xd9, 0xe8, 0x4a, 0x66, 0x71, 0x7e, 0x15,
-	0xdc, 0xb5, 0x9e, 0x97, 0x34, 0xdf, 0x91, 0x82, 0x2e, 0x11, 0xe2, 0x32, 0xb8, 0xe3, 0x2e, 0x11,
-	0xe2, 0x12, 0x33, 0x83, 0xe8, 0x18, 0xc9, 0x38, 0xe8, 0xdb, 0xcc, 0x20, 0xf9, 0x38, 0xb6, 0x5f,
-	0xe0, 0xcd, 0x5c, 0x64, 0x63, 0x11, 0x7c, 0x46, 0xfc, 0x2e, 0xe5, 0xf0, 0xaf, 0x15, 0xf8, 0xf8,
-	0x38, 0x93, 0x46, 0xf2, 0xe4, 0x3b, 0x25, 0x8d, 0xf8, 0xbf, 0x55, 0xe4, 0x32, 0xe3, 0xab, 0xe5,
-	0x8c, 0xff, 0x1a, 0x3a, 0xd2, 0xde, 0x36, 0xc2, 0x9a, 0xa3, 0xfa, 0x73, 0x5d, 0x9f, 0x1e, 0xe2,
-	0xa8, 0xed, 0xd4, 0x07, 0xdc, 0x70, 0xf6, 0x03, 0x00, 0x71, 0x3d, 0x53, 0xce, 0x0f, 0xdb, 0x6a,
-	0x4a, 0x08, 0xf2, 0x90, 0xe6, 0x4a, 0xb8, 0x2a, 0xa4, 0x35, 0xa6, 0xd4, 0x8c, 0x2b, 0x23, 0x89,
-	0x48, 0x4a, 0x16, 0x3b, 0xbd, 0x6d, 0x2e, 0x51, 0xca, 0x16, 0xdb, 0x09, 0x63, 0x02, 0x5c, 0x51,
-	0xae, 0x00, 0xf6, 0x19, 0xf8, 0x9a, 0xbf, 0x15, 0xa3, 0x34, 0x8f, 0x45, 0xe0, 0xdb, 0x16, 0x87,
-	0xc0, 0xcb, 0x3c, 0x16, 0x61, 0x06, 0x9d, 0x35, 0xaa, 0xbe, 0x81, 0xa6, 0xb2, 0x4b, 0x47, 0xd5,
-	0xa7, 0x18, 0xce, 0x7b, 0x48, 0x3d, 0xda, 0x88, 0x0a, 0x4b, 0x76, 0x1f, 0xea, 0x34, 0x16, 0x97,
-	0xdf, 0x3d, 0x62, 0xe0, 0x68, 0x23, 0xb2, 0x9a, 0xfd, 0x86, 0x7d, 0x94, 0xc2, 0x6f, 0x97, 0xf7,
-	0xe9, 0x59, 0xae, 0x05, 0xf5, 0x06, 0x9a, 0xa8, 0xed, 0xb4, 0x18, 0x39, 0x09, 0xd9, 0x50, 0xf9,
-	0x95, 0xa6, 0x13, 0xab, 0x11, 0xad, 0xc3, 0x7f, 0x56, 0x60, 0xf3, 0x99, 0x12, 0xfc, 0x83, 0x7f,
-	0xd8, 0x55, 0x03, 0xae, 0xfd, 0xef, 0x06, 0xfc, 0x18, 0x7c, 0x39, 0x19, 0x89, 0x6b, 0xa9, 0x69,
-	0x0e, 0xc7, 0xd9, 0xbd, 0x87, 0xb6, 0x03, 0x9c, 0xae, 0x4e, 0x67, 0x48, 0xbf, 0x8e, 0x5a, 0x72,
-	0x32, 0x20, 0x0b, 0x0a, 0x8a, 0x1b, 0xe1, 0x9e, 0x13, 0x5a, 0x63, 0x5a, 0x14, 0x35, 0x21, 0xb4,
-	0xeb, 0xb3, 0x25, 0x84, 0xfd, 0x14, 0x3e, 0x2d, 0x57, 0xd3, 0x54, 0xf1, 0x6c, 0x9e, 0x70, 0x25,
-	0xcd, 0xc2, 0x7d, 0xe9, 0xdb, 0x25, 0xf5, 0xf3, 0x95, 0x16, 0x99, 0xa5, 0x9a, 0xd1, 0xf4, 0xcd,
-	0xab, 0x91, 0x93, 0xd8, 0x97, 0xb0, 0xa5, 0x84, 0x11, 0x19, 0x1d, 0xf7, 0x3a, 0x9f, 0x2b, 0x4d,
-	0x6d, 0xb9, 0x1a, 0x75, 0x97, 0xf0, 0x11, 0xa2, 0x61, 0x0f, 0xba, 0x05, 0xdb, 0x7a, 0x96, 0x67,
-	0x5a, 0x84, 0xff, 0xf2, 0x60, 0xf3, 0x40, 0x24, 0xe2, 0x83, 0x7f, 0x80, 0xd5, 0x8b, 0x51, 0x5b,
-	0x7b, 0x31, 0x9e, 0x00, 0xc8, 0xc9, 0x28, 0x95, 0x5a, 0xcb, 0x6c, 0xfa, 0x5f, 0x09, 0xf7, 0xe5,
-	0xe4, 0xa5, 0x35, 0x59, 0x75, 0xba, 0xc6, 0x7b, 0x3a, 0x5d, 0x73, 0xd5, 0xe9, 0x02, 0x68, 0xa6,
-	0xc2, 0x28, 0x39, 0xb6, 0xbf, 0x83, 0xfc, 0xa8, 0x10, 0x91, 0x85, 0x22, 0x64, 0xc7, 0x42, 0x0f,
-	0xba, 0xaf, 0x84, 0xa2, 0x00, 0x2d, 0x0b, 0xe1, 0x33, 0xe8, 0x0c, 0xae, 0xc5, 0xb8, 0xb0, 0xc0,
-	0x39, 0xd0, 0xd6, 0x83, 0x77, 0xb3, 0x23, 0x58, 0xfc, 0xbd, 0xd9, 0xfd, 0xc7, 0x0a, 0xb4, 0xed,
-	0x29, 0x1f, 0x94, 0x5a, 0x7a, 0xa6, 0xd3, 0x94, 0x67, 0xb1, 0xe3, 0xb6, 0x10, 0xd9, 0x63, 0xa8,
-	0x71, 0x35, 0x2d, 0xa6, 0xe3, 0x3b, 0x44, 0xeb, 0xca, 0x9f, 0xdd, 0xa7, 0x6a, 0xea, 0xe6, 0x62,
-	0x32, 0xbb, 0xd1, 0xcf, 0x1a, 0x37, 0xfb, 0x59, 0x7f, 0x1f, 0xfc, 0xe5, 0x96, 0xef, 0x3b, 0x2b,
-	0x3f, 0x82, 0xad, 0x25, 0xd5, 0x8e, 0xdb, 0x00, 0x9a, 0x6f, 0x2d, 0xe4, 0x4e, 0x2b, 0xc4, 0xf0,
-	0x6f, 0x15, 0xe8, 0x1e, 0x49, 0x6d, 0x72, 0xb5, 0xf8, 0xc0, 0x1c, 0xbe, 0x6f, 0x8e, 0xbc, 0x0d,
-	0x0d, 0x3e, 0x36, 0xab, 0xd6, 0xee, 0x24, 0xf6, 0x10, 0xba, 0xa9, 0xcc, 0xec, 0xf3, 0x3d, 0xc2,
-	0x1f, 0xd7, 0x8e, 0xaa, 0x4e, 0x8a, 0xe3, 0x0c, 0x57, 0xe6, 0x5c, 0xd2, 0x2f, 0xc3, 0x6e, 0xca,
-	0xaf, 0xcb, 0x56, 0x4d, 0x67, 0xc5, 0xaf, 0x57, 0x56, 0x6b, 0x13, 0x6f, 0xeb, 0xe6, 0xc4, 0x7b,
-	0x1f, 0xf0, 0xcc, 0x51, 0x3c, 0x57, 0xd4, 0x0b, 0x5c, 0xd9, 0xb7, 0x53, 0x99, 0x1d, 0x38, 0x88,
-	0x4c, 0xf8, 0xf5, 0xca, 0x04, 0x9c, 0x09, 0xbf, 0x2e, 0x4c, 0xbe, 0x7a, 0x05, 0x75, 0xfa, 0xe7,
-	0x81, 0xb5, 0xa0, 0x76, 0x72, 0x7a, 0x82, 0xbf, 0xf1, 0xdb, 0xd0, 0x3c, 0x3e, 0x39, 0x1f, 0x3c,
-	0x1f, 0x44, 0x3d, 0x0f, 0x7f, 0xf0, 0x1f, 0x0e, 0x4f, 0x9f, 0x9e, 0xf7, 0x2a, 0x0c, 0xa0, 0x71,
-	0x76, 0x1e, 0x1d, 0x9f, 0x3c, 0xef, 0x55, 0xd1, 0xfa, 0xfc, 0xf8, 0xe5, 0xa0, 0x57, 0x43, 0xeb,
-	0xfd, 0xd3, 0xd3, 0xe1, 0xe0, 0xe9, 0x49, 0xaf, 0x4e, 0x87, 0xfc, 0x7a, 0x38, 0xec, 0x35, 0xbe,
-	0x7a, 0x08, 0x9d, 0x72, 0x91, 0xa2, 0xe6, 0xf0, 0xe9, 0xf1, 0xb0, 0xb7, 0x81, 0xc7, 0x1c, 0x3f,
-	0x3f, 0x39, 0x8d, 0x06, 0x3d, 0x6f, 0xef, 0x1f, 0x15, 0x68, 0x1c, 0xda, 0x17, 0xe0, 0x87, 0x50,
-	0xc3, 0xa9, 0x9a, 0x51, 0xf3, 0x2d, 0xcd, 0xd7, 0xfd, 0x55, 0x39, 0x85, 0x1b, 0x3f, 0xf2, 0xd8,
-	0x13, 0xa8, 0xd3, 0x8b, 0xc2, 0xa8, 0x11, 0x94, 0x9f, 0xa8, 0x7e, 0x19, 0xa1, 0xe7, 0x26, 0xdc,
-	0xd8, 0xf1, 0xd8, 0x8f, 0xa1, 0x61, 0xfb, 0x1a, 0xa3, 0x5f, 0xba, 0x6b, 0x2f, 0x4a, 0x9f, 0x95,
-	0x21, 0x57, 0xf0, 0x1b, 0xb8, 0xc5, 0x36, 0x01, 0xbb, 0x65, 0xad, 0x07, 0xda, 0x2d, 0x37, 0x7a,
-	0xc4, 0x06, 0x7b, 0x04, 0x35, 0xac, 0x1e, 0xeb, 0x7e, 0xa9, 0x8e, 0xac, 0x53, 0xe5, 0x76, 0x11,
-	0x6e, 0xb0, 0xaf, 0xa1, 0xe9, 0x32, 0x97, 0xd1, 0x69, 0xeb, 0x69, 0x7c, 0x33, 0xe2, 0x9f, 0x40,
-	0xd3, 0x55, 0x85, 0xb5, 0x5e, 0xef, 0x46, 0xfd, 0x8f, 0xd7, 0xb0, 0xe2, 0x8e, 0x8b, 0x06, 0xfd,
-	0x65, 0xf5, 0xcd, 0x7f, 0x02, 0x00, 0x00, 0xff, 0xff, 0x12, 0xa6, 0xdd, 0xce, 0xc2, 0x12, 0x00,
-	0x00,
+func init() { proto.RegisterFile("frames.proto", fileDescriptor_frames_6bd8bbce0d73c49c) }
+
+var fileDescriptor_frames_6bd8bbce0d73c49c = []byte{
+	// 2592 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe4, 0x59, 0xcd, 0x72, 0x1b, 0xc7,
+	0x11, 0xe6, 0xe2, 0x1f, 0x0d, 0x90, 0x84, 0x46, 0x32, 0xb5, 0x82, 0x7f, 0x04, 0xaf, 0xe4, 0x98,
+	0xb1, 0x24, 0xda, 0xa1, 0x5c, 0x95, 0x94, 0xab, 0x92, 0x14, 0x7f, 0x40, 0x89, 0x11, 0x44, 0xba,
+	0x96, 0x8c, 0x5d, 0x3e, 0xa1, 0x86, 0xd8, 0x01, 0x38, 0xe1, 0x62, 0x17, 0x9e, 0x59, 0x88, 0x44,
+	0x0e, 0x79, 0x06, 0xe7, 0x90, 0x27, 0x48, 0x1e, 0xc0, 0x8f, 0x90, 0x2a, 0x9f, 0x52, 0x95, 0x6b,
+	0xde, 0x22, 0x97, 0x9c, 0x72, 0x4d, 0x75, 0xcf, 0xec, 0x62, 0x01, 0x52, 0x2e, 0x97, 0xcb, 0x3a,
+	0xe5, 0x36, 0xfd, 0x75, 0xcf, 0xdf, 0x37, 0xdd, 0x3d, 0x3d, 0xbb, 0xd0, 0x1c, 0x2a, 0x3e, 0x16,
+	0x7a, 0x6b, 0xa2, 0xe2, 0x24, 0x66, 0x85, 0xc9, 0x99, 0xf7, 0x6d, 0x11, 0x2a, 0x7b, 0x71, 0x38,
+	0x1d, 0x47, 0xec, 0x01, 0x94, 0x2e, 0x64, 0x14, 0xb8, 0x4e, 0xc7, 0xd9, 0x5c, 0xdb, 0x5e, 0xdf,
+	0x9a, 0x9c, 0x6d, 0x19, 0xcd, 0xd6, 0x0b, 0x19, 0x05, 0x3e, 0x29, 0x19, 0x83, 0x52, 0xc4, 0xc7,
+	0xc2, 0x2d, 0x74, 0x9c, 0xcd, 0xba, 0x4f, 0x6d, 0x76, 0x1f, 0xca, 0x41, 0x32, 0x9b, 0x08, 0xb7,
+	0x48, 0x3d, 0xeb, 0xd8, 0x73, 0xff, 0x74, 0x36, 0x11, 0xbe, 0xc1, 0xb1, 0x93, 0x96, 0x7f, 0x14,
+	0x6e, 0xa9, 0xe3, 0x6c, 0x16, 0x7d, 0x6a, 0x23, 0x26, 0xa3, 0x44, 0xbb, 0xe5, 0x4e, 0x11, 0x31,
+	0x6c, 0xb3, 0x0d, 0xa8, 0x0c, 0xc3, 0x98, 0x27, 0xda, 0xad, 0x74, 0x8a, 0x9b, 0x8e, 0x6f, 0x25,
+	0xe6, 0x42, 0x55, 0x27, 0x4a, 0x46, 0x23, 0xed, 0x56, 0x3b, 0xc5, 0xcd, 0xba, 0x9f, 0x8a, 0xec,
+	0x0e, 0x94, 0x13, 0x39, 0x16, 0xda, 0xad, 0xd1, 0x30, 0x46, 0x40, 0xf4, 0x2c, 0x8e, 0x43, 0xed,
+	0xd6, 0x3b, 0xc5, 0xcd, 0x9a, 0x6f, 0x04, 0x44, 0xa3, 0x69, 0x18, 0x6a, 0x17, 0x3a, 0xce, 0x66,
+	0xd3, 0x37, 0x02, 0x6b, 0x43, 0xcd, 0xcc, 0xf2, 0x74, 0xdb, 0x6d, 0x74, 0x8a, 0x9b, 0x05, 0x3f,
+	0x93, 0x69, 0x9c, 0x30, 0x3e, 0xd3, 0x6e, 0xb3, 0x53, 0xc4, 0x1e, 0x24, 0xb0, 0x0e, 0x94, 0x43,
+	0xa9, 0x13, 0xed, 0xae, 0x76, 0x8a, 0x9b, 0x8d, 0x6d, 0x98, 0x13, 0xe5, 0x1b, 0x05, 0xf6, 0xd3,
+	0x03, 0x1e, 0x0a, 0x77, 0xad, 0xe3, 0x6c, 0x96, 0x7d, 0x23, 0xb0, 0x4d, 0x00, 0x54, 0xf7, 0x0d,
+	0x57, 0xeb, 0xcb, 0x5c, 0xd5, 0x51, 0xb9, 0x8f, 0x3a, 0xef, 0x1d, 0x28, 0x21, 0xe5, 0xac, 0x0e,
+	0xe5, 0x93, 0xde, 0xe1, 0x5e, 0xb7, 0xb5, 0x82, 0xcd, 0xde, 0xce, 0x6e, 0xb7, 0xd7, 0x72, 0xbc,
+	0x3f, 0x41, 0xf9, 0x0b, 0x1e, 0x4e, 0x05, 0xbb, 0x03, 0x25, 0xf9, 0x8a, 0x87, 0x74, 0x60, 0xc5,
+	0xe7, 0x2b, 0x3e, 0x49, 0x88, 0x0e, 0x11, 0xc5, 0x13, 0x72, 0x10, 0x1d, 0x5a, 0x54, 0x23, 0x8a,
+	0x47, 0x54, 0x47, 0x54, 0x5b, 0x34, 0x41, 0xb4, 0x94, 0x8e, 0x90, 0x58, 0xf4, 0x0c, 0xd1, 0x72,
+	0xc7, 0xd9, 0xac, 0x21, 0x8a, 0xd2, 0x6e, 0x15, 0xca, 0xaf, 0x70, 0x5a, 0xef, 0x2f, 0x0e, 0xac,
+	0x1e, 0x4d, 0xc3, 0x90, 0x16, 0xa1, 0x5f, 0xf2, 0x09, 0xdb, 0x87, 0x06, 0x92, 0x69, 0x48, 0xd0,
+	0xae, 0x43, 0xbc, 0x78, 0xb8, 0xb5, 0x05, 0xbb, 0xad, 0xa3, 0xb9, 0x51, 0x37, 0x4a, 0xd4, 0xcc,
+	0xcf, 0x77, 0x6b, 0xff, 0x06, 0x5a, 0xcb, 0x06, 0xac, 0x05, 0xc5, 0x0b, 0x31, 0xa3, 0x1d, 0xd6,
+	0x7d, 0x6c, 0xb2, 0x3b, 0x76, 0x19, 0xb4, 0xbf, 0x9a, 0x6f, 0x84, 0xcf, 0x0a, 0xbf, 0x72, 0xbc,
+	0xbf, 0x17, 0xa0, 0x7c, 0x80, 0xfe, 0xcd, 0x1e, 0x42, 0x75, 0xb0, 0xb0, 0x96, 0xfc, 0x19, 0xa5,
+	0x2a, 0xb4, 0x92, 0x51, 0x20, 0x07, 0x42, 0xbb, 0x85, 0xeb, 0x56, 0x56, 0xc5, 0x9e, 0x40, 0x25,
+	0xe4, 0x67, 0x22, 0xd4, 0x6e, 0x91, 0x8c, 0xde, 0x42, 0x23, 0x9a, 0x66, 0xab, 0x47, 0xb8, 0xd9,
+	0x89, 0x35, 0xc2, 0xe5, 0x09, 0xa5, 0x62, 0x45, 0x94, 0xd6, 0x7d, 0x23, 0xb0, 0x6d, 0x43, 0x50,
+	0x9f, 0x16, 0x6b, 0x7c, 0xbe, 0xb1, 0x7d, 0xeb, 0x1a, 0x41, 0x3e, 0x44, 0x99, 0x88, 0x23, 0x71,
+	0xa5, 0xe2, 0x4b, 0xb7, 0x62, 0xdc, 0x95, 0x04, 0x0c, 0x91, 0x31, 0x57, 0x17, 0x42, 0xb9, 0x55,
+	0x9a, 0xc0, 0x4a, 0xed, 0x7d, 0x68, 0xe4, 0x96, 0x73, 0x03, 0x6f, 0xf7, 0xf3, 0xbc, 0x35, 0x8c,
+	0xe3, 0xd1, 0x4c, 0x79, 0x0a, 0xff, 0xeb, 0x40, 0xe3, 0x64, 0x70, 0x2e, 0xc6, 0xfc, 0x40, 0x8a,
+	0x70, 0x1e, 0xed, 0x4e, 0x2e, 0xda, 0x5b, 0x50, 0x0c, 0xe2, 0x81, 0x4d, 0x00, 0xd8, 0x64, 0x0f,
+	0xa0, 0x1a, 0x88, 0x21, 0x9f, 0x86, 0x89, 0x5b, 0x5c, 0x1e, 0x3c, 0xd5, 0xe0, 0x50, 0xe4, 0xf7,
+	0x86, 0x17, 0x6a, 0xb3, 0xdf, 0x02, 0x4c, 0x54, 0x3c, 0x11, 0x2a, 0x91, 0x19, 0x2b, 0xf7, 0xb1,
+	0x6f, 0x6e, 0x0d, 0x5b, 0x9f, 0x67, 0x16, 0x86, 0xe9, 0x5c, 0x97, 0xf6, 0x73, 0x58, 0x5f, 0x52,
+	0xff, 0xd8, 0x9d, 0x1f, 0x43, 0xdd, 0x4c, 0xfa, 0x42, 0xcc, 0xd8, 0xfb, 0xd0, 0xd4, 0xe7, 0x5c,
+	0x05, 0x32, 0x1a, 0xf5, 0xcd, 0x60, 0x98, 0x74, 0x1a, 0x29, 0xf6, 0x82, 0x06, 0x6d, 0xe8, 0x58,
+	0x25, 0xa9, 0x45, 0x81, 0x2c, 0xc0, 0x42, 0x2f, 0xc4, 0xcc, 0xfb, 0x87, 0x03, 0x8d, 0x53, 0x7e,
+	0x16, 0x0a, 0x33, 0x6c, 0xb6, 0x7f, 0x27, 0xb7, 0xff, 0x77, 0xa0, 0x8e, 0x94, 0xea, 0x09, 0x1f,
+	0xa4, 0x19, 0x75, 0x0e, 0x64, 0xe4, 0x17, 0xaf, 0x93, 0x5f, 0x9a, 0x93, 0xef, 0x42, 0x95, 0x87,
+	0x92, 0x6b, 0x4b, 0x60, 0xdd, 0x4f, 0x45, 0xf6, 0x21, 0x54, 0x86, 0xc8, 0xa0, 0xc9, 0xa6, 0x0d,
+	0x93, 0xd1, 0x73, 0xcc, 0xfa, 0x56, 0xcd, 0xee, 0x1b, 0xca, 0xaa, 0x44, 0xcf, 0xea, 0xdc, 0xea,
+	0x85, 0x98, 0x11, 0x83, 0xde, 0x3f, 0x1d, 0x80, 0xdf, 0xc5, 0x32, 0x3a, 0x49, 0xd4, 0x74, 0x90,
+	0xe0, 0x94, 0x67, 0x7c, 0x70, 0x21, 0xec, 0x5d, 0x51, 0xf7, 0x53, 0x91, 0xd2, 0x31, 0xee, 0xd9,
+	0x6e, 0xc6, 0x08, 0x68, 0x9f, 0x86, 0x63, 0xd1, 0x2c, 0xd1, 0x8a, 0x94, 0xf0, 0x65, 0x98, 0x88,
+	0x34, 0x5c, 0xac, 0xc4, 0xee, 0x42, 0x35, 0x14, 0xc3, 0xa4, 0x1f, 0x47, 0x76, 0x53, 0x15, 0x14,
+	0x8f, 0x23, 0x76, 0x0f, 0x6a, 0x4a, 0x8e, 0xce, 0x49, 0x53, 0x31, 0x63, 0x91, 0x7c, 0x1c, 0x21,
+	0x35, 0xe7, 0xf1, 0xa5, 0x0d, 0x0b, 0x6c, 0xe2, 0xe8, 0x7a, 0x3a, 0x1c, 0xca, 0x2b, 0xb7, 0x66,
+	0x46, 0x37, 0x92, 0xf7, 0x57, 0x07, 0xaa, 0x27, 0x42, 0x6b, 0x19, 0x53, 0xaf, 0xa9, 0x0a, 0x53,
+	0x77, 0x99, 0xaa, 0x10, 0x0f, 0x65, 0x10, 0x47, 0x09, 0x97, 0x91, 0x50, 0xe9, 0xa1, 0x64, 0x00,
+	0x1e, 0xca, 0x84, 0x27, 0xe7, 0xe9, 0xa1, 0x60, 0x1b, 0xb1, 0xa9, 0xce, 0xf6, 0x40, 0x6d, 0xbc,
+	0x56, 0x26, 0x5c, 0xeb, 0xcb, 0x58, 0x05, 0x94, 0x47, 0xeb, 0x7e, 0x26, 0x13, 0x4b, 0xf1, 0x85,
+	0x88, 0xdc, 0x8a, 0x65, 0x09, 0x05, 0xb6, 0x06, 0x05, 0x19, 0xd8, 0xe5, 0x17, 0x64, 0xe0, 0x7d,
+	0x5b, 0x85, 0x86, 0x2f, 0x78, 0xe0, 0x8b, 0xaf, 0xa7, 0x42, 0x27, 0xec, 0x03, 0xa8, 0x6a, 0xb3,
+	0x68, 0x5a, 0x6d, 0x63, 0xbb, 0x41, 0x27, 0x65, 0x20, 0x3f, 0xd5, 0xe5, 0x0f, 0xa7, 0xb0, 0x78,
+	0x38, 0x1f, 0x42, 0x45, 0xd3, 0xb9, 0xda, 0x28, 0x25, 0x7f, 0xc8, 0xb9, 0xa8, 0x6f, 0xd5, 0xe8,
+	0xdb, 0x01, 0x4f, 0x78, 0x7f, 0x18, 0xab, 0x31, 0x4f, 0xec, 0xb6, 0x00, 0xa1, 0x03, 0x42, 0xd8,
+	0xbb, 0x00, 0x2a, 0xbe, 0xec, 0x87, 0x7c, 0x16, 0x4f, 0x13, 0x73, 0x4d, 0xf8, 0x75, 0x15, 0x5f,
+	0xf6, 0x08, 0xc0, 0xfe, 0xe3, 0x69, 0x98, 0xc8, 0xbe, 0x8c, 0x02, 0x71, 0x45, 0xbb, 0xac, 0xf9,
+	0x40, 0xd0, 0x21, 0x22, 0x48, 0xc0, 0xd7, 0x53, 0xa1, 0x66, 0x76, 0xb7, 0x46, 0x98, 0x3b, 0x4f,
+	0xed, 0x35, 0xce, 0x53, 0x7f, 0x9d, 0xf3, 0xc0, 0x82, 0xf3, 0xdc, 0x83, 0xda, 0x48, 0xc5, 0xd3,
+	0x49, 0xff, 0x6c, 0xe6, 0x36, 0x0c, 0x05, 0x24, 0xef, 0xce, 0x98, 0x07, 0xa5, 0x3f, 0xc4, 0x32,
+	0xa2, 0xfb, 0xbc, 0xb1, 0xbd, 0x86, 0x04, 0xcc, 0xfd, 0xda, 0x27, 0x1d, 0x2e, 0x23, 0x94, 0x63,
+	0x99, 0xb8, 0xab, 0x54, 0xad, 0x18, 0x81, 0x3d, 0x80, 0xd5, 0xb1, 0xd0, 0x9a, 0x8f, 0x44, 0xdf,
+	0x68, 0xd7, 0x48, 0xdb, 0xb4, 0x60, 0x8f, 0x8c, 0xe6, 0xc9, 0x79, 0x3d, 0x9f, 0x9c, 0x91, 0x10,
+	0x25, 0xb4, 0x48, 0x2c, 0x21, 0xef, 0x1a, 0x42, 0x08, 0x32, 0x84, 0x7c, 0x08, 0xeb, 0xe2, 0x2a,
+	0x11, 0x51, 0x20, 0x02, 0x53, 0x1e, 0x68, 0xf7, 0x3d, 0x32, 0x5a, 0x4b, 0x61, 0x2a, 0x0c, 0xa8,
+	0x5a, 0xd1, 0x62, 0x34, 0x16, 0x58, 0x39, 0xb5, 0xa8, 0xe4, 0xc9, 0x64, 0xf6, 0x01, 0xac, 0x25,
+	0x71, 0xc2, 0xc3, 0x7e, 0x66, 0x71, 0x8b, 0xd6, 0xb8, 0x4a, 0xe8, 0x49, 0x6a, 0xf6, 0x00, 0x56,
+	0xf3, 0xc9, 0x4d, 0xbb, 0x8c, 0x68, 0x6d, 0xe6, 0xb2, 0x9b, 0x66, 0x1f, 0xc3, 0x1d, 0xcc, 0x65,
+	0x68, 0xd0, 0x57, 0x3c, 0x1a, 0x89, 0xbe, 0x4e, 0xb8, 0x4a, 0xdc, 0xdb, 0xb4, 0xaf, 0x5b, 0xa8,
+	0xc3, 0xec, 0x80, 0x9a, 0x13, 0x54, 0xb0, 0x47, 0xc0, 0x96, 0x3a, 0xa0, 0x07, 0xde, 0x21, 0xf3,
+	0xf5, 0xbc, 0x79, 0xd7, 0xa4, 0x09, 0x33, 0xdc, 0x5b, 0xe6, 0xa4, 0x49, 0xc0, 0x50, 0xc4, 0x3e,
+	0x1b, 0x26, 0x14, 0x85, 0x29, 0x36, 0x75, 0x22, 0x26, 0xee, 0x5d, 0x13, 0x58, 0xd8, 0x66, 0x1d,
+	0x68, 0xf0, 0xd1, 0x48, 0x89, 0x11, 0x4f, 0x62, 0xa5, 0x5d, 0x97, 0x54, 0x79, 0x88, 0x3d, 0x01,
+	0x96, 0x8a, 0x32, 0x8e, 0xfa, 0x97, 0x32, 0x0a, 0xe2, 0x4b, 0xf7, 0x1d, 0xb3, 0xf2, 0x9c, 0xe6,
+	0x4b, 0x52, 0xd0, 0x24, 0x42, 0x5c, 0xb8, 0xf7, 0xec, 0x24, 0x42, 0x5c, 0xa0, 0x0b, 0x11, 0x1d,
+	0x7d, 0x19, 0xb8, 0x6d, 0xe3, 0x42, 0x24, 0x1f, 0x06, 0xe6, 0x04, 0xbe, 0x9e, 0x8a, 0x68, 0x20,
+	0xdc, 0xb7, 0x89, 0xdf, 0x4c, 0xf6, 0xbe, 0x2d, 0xc0, 0xed, 0xc3, 0x48, 0x26, 0x92, 0x87, 0x5f,
+	0x2a, 0x99, 0x88, 0x9f, 0x2c, 0x74, 0xb3, 0xd0, 0x28, 0xe6, 0x43, 0xe3, 0x31, 0x34, 0xa5, 0x99,
+	0xad, 0x8f, 0xc1, 0xe9, 0x96, 0xe6, 0xf7, 0x1b, 0x15, 0x28, 0x7e, 0xc3, 0xaa, 0xf7, 0x79, 0xc2,
+	0xd9, 0x7b, 0x00, 0xe2, 0x6a, 0xa2, 0xec, 0x3a, 0x4c, 0x4e, 0xca, 0x21, 0xc8, 0xc3, 0x38, 0x56,
+	0xc2, 0x86, 0x2b, 0xb5, 0xd1, 0xa5, 0x26, 0x5c, 0x25, 0x92, 0x88, 0x24, 0x67, 0x31, 0xf5, 0xf7,
+	0x6a, 0x86, 0x92, 0xb7, 0x98, 0x94, 0x19, 0x10, 0x60, 0xa3, 0x77, 0x0e, 0xb0, 0xb7, 0xa1, 0xae,
+	0xf9, 0x2b, 0xd1, 0x1f, 0xc7, 0x81, 0x70, 0xeb, 0x26, 0x17, 0x22, 0xf0, 0x32, 0x0e, 0x84, 0x17,
+	0x41, 0x73, 0x81, 0xaa, 0xa7, 0x50, 0x55, 0xa6, 0x69, 0xa9, 0xba, 0x8b, 0xdb, 0xb9, 0x81, 0xd4,
+	0xe7, 0x2b, 0x7e, 0x6a, 0xc9, 0xde, 0x87, 0x32, 0x3d, 0x6c, 0xdc, 0xc2, 0x12, 0x03, 0xcf, 0x57,
+	0x7c, 0xa3, 0xd9, 0xad, 0x98, 0xeb, 0xd7, 0xfb, 0x2c, 0x9b, 0x4f, 0x4f, 0x62, 0x2d, 0x28, 0x89,
+	0xa0, 0x81, 0x36, 0x55, 0xb4, 0x6f, 0x25, 0x64, 0x43, 0xc5, 0x97, 0x9a, 0x46, 0x2c, 0xfa, 0xd4,
+	0xf6, 0xfe, 0x5d, 0x80, 0xd5, 0x3d, 0x25, 0xf8, 0x1b, 0x3f, 0xd8, 0x79, 0xa6, 0x2e, 0x7d, 0x7f,
+	0xa6, 0x7e, 0x02, 0x75, 0x39, 0xec, 0x8b, 0x2b, 0x7a, 0x8e, 0x94, 0xe9, 0x45, 0xd1, 0x42, 0xdb,
+	0x2e, 0x56, 0x9d, 0xc7, 0x13, 0xa4, 0x5f, 0xfb, 0x35, 0x39, 0xec, 0x92, 0x05, 0x6d, 0x8a, 0x27,
+	0xc2, 0xde, 0x3b, 0xd4, 0x46, 0xb7, 0x48, 0x63, 0x42, 0x68, 0x9b, 0x90, 0x73, 0x08, 0xfb, 0x25,
+	0xdc, 0xcd, 0x47, 0xd3, 0x48, 0xf1, 0x68, 0x1a, 0x72, 0x25, 0x93, 0x99, 0x3d, 0xe9, 0x8d, 0x9c,
+	0xfa, 0xd9, 0x5c, 0x4b, 0xb7, 0x2f, 0xc6, 0x8c, 0xa6, 0x33, 0x2f, 0xfa, 0x56, 0xc2, 0x5c, 0xa7,
+	0x44, 0x22, 0x22, 0x1a, 0xee, 0x3c, 0x9e, 0x2a, 0xf3, 0x20, 0x2b, 0xfa, 0x6b, 0x19, 0xfc, 0x1c,
+	0x51, 0xaf, 0x05, 0x6b, 0x29, 0xdb, 0x7a, 0x12, 0x47, 0x5a, 0x78, 0xff, 0x71, 0x60, 0x75, 0x5f,
+	0x84, 0xe2, 0x8d, 0x1f, 0xc0, 0xeb, 0xea, 0x92, 0x8f, 0x01, 0xe4, 0xb0, 0x3f, 0x96, 0x5a, 0xcb,
+	0x68, 0xf4, 0x5a, 0xc2, 0xeb, 0x72, 0xf8, 0xd2, 0x98, 0xcc, 0x33, 0x5d, 0xe5, 0x86, 0x4c, 0x57,
+	0x9d, 0x67, 0x3a, 0x17, 0xaa, 0x63, 0x91, 0x28, 0x39, 0x30, 0x2f, 0xd9, 0xba, 0x9f, 0x8a, 0xc8,
+	0x42, 0xba, 0x65, 0xcb, 0x42, 0x0b, 0xd6, 0xbe, 0x10, 0x8a, 0x36, 0x68, 0x58, 0xf0, 0xf6, 0xa0,
+	0xd9, 0xbd, 0x12, 0x83, 0xd4, 0x02, 0x2b, 0x5e, 0x13, 0x0f, 0xce, 0x72, 0x46, 0x30, 0xf8, 0x8d,
+	0xde, 0xfd, 0xe7, 0x02, 0x34, 0xcc, 0x28, 0x6f, 0x94, 0x5a, 0xba, 0xcf, 0xc7, 0x63, 0x1e, 0x05,
+	0x96, 0xdb, 0x54, 0x64, 0x4f, 0xa0, 0xc4, 0xd5, 0x28, 0x7d, 0x07, 0xdc, 0x23, 0x5a, 0xe7, 0xeb,
+	0xd9, 0xda, 0x51, 0x23, 0xfb, 0x02, 0x20, 0xb3, 0xa5, 0x7c, 0x56, 0x59, 0xce, 0x67, 0xed, 0x5d,
+	0xa8, 0x67, 0x5d, 0x7e, 0xec, 0xab, 0xe0, 0x11, 0xac, 0x67, 0x54, 0x5b, 0x6e, 0x5d, 0xa8, 0xbe,
+	0x32, 0x50, 0x5a, 0xfc, 0x5a, 0xd1, 0xfb, 0xae, 0x00, 0x6b, 0xcf, 0xa5, 0x4e, 0x62, 0x35, 0x7b,
+	0xc3, 0x1c, 0xde, 0x54, 0x70, 0x6e, 0x40, 0x85, 0x0f, 0x92, 0x79, 0x6a, 0xb7, 0x12, 0x7b, 0x08,
+	0x6b, 0x63, 0x19, 0x99, 0xeb, 0xbb, 0x8f, 0x9f, 0x47, 0x2c, 0x55, 0xcd, 0x31, 0xd6, 0x3d, 0x5c,
+	0x25, 0xa7, 0x92, 0x5e, 0xcc, 0x6b, 0x63, 0x7e, 0x95, 0xb7, 0xaa, 0x5a, 0x2b, 0x7e, 0x35, 0xb7,
+	0x5a, 0x28, 0x8d, 0x6b, 0xcb, 0xa5, 0xf1, 0xfb, 0x80, 0x63, 0xf6, 0x83, 0xa9, 0xa2, 0x5c, 0x60,
+	0xc3, 0xbe, 0x31, 0x96, 0xd1, 0xbe, 0x85, 0xc8, 0x84, 0x5f, 0xcd, 0x4d, 0xc0, 0x9a, 0xf0, 0xab,
+	0xd4, 0xc4, 0x3b, 0x87, 0x5b, 0x3d, 0xa9, 0x13, 0xca, 0x76, 0xfa, 0x27, 0xe3, 0xf1, 0x86, 0xb2,
+	0xdd, 0x7b, 0x0c, 0x2c, 0x3f, 0x93, 0x3d, 0xdf, 0x0d, 0xa8, 0x10, 0xc9, 0xda, 0xbe, 0xfa, 0xac,
+	0xe4, 0x8d, 0xe1, 0xce, 0xbe, 0xd0, 0x03, 0x25, 0xcf, 0x04, 0xf5, 0x78, 0xb3, 0x47, 0xec, 0xfd,
+	0xcb, 0x81, 0xb7, 0x96, 0xe6, 0xb3, 0x0b, 0x9c, 0x5f, 0x0e, 0xce, 0xf7, 0x5f, 0x0e, 0x87, 0x00,
+	0x3c, 0x49, 0x94, 0x3c, 0x9b, 0x26, 0xd9, 0x27, 0x8e, 0x9f, 0xd3, 0xf7, 0xa6, 0x9b, 0xc6, 0xdd,
+	0xda, 0xc9, 0x6c, 0xed, 0x3b, 0x7b, 0xde, 0x19, 0xdf, 0xd9, 0x4b, 0xea, 0x1f, 0x1b, 0x51, 0x81,
+	0x49, 0x55, 0x3b, 0x6a, 0x34, 0xc5, 0x72, 0xf4, 0xc6, 0x2f, 0x0c, 0xe9, 0x53, 0xb9, 0x90, 0x7b,
+	0x2a, 0xb7, 0xa1, 0x86, 0xb7, 0xbd, 0x54, 0x22, 0x20, 0xa2, 0x6a, 0x7e, 0x26, 0x5f, 0x7f, 0x14,
+	0x7b, 0x5f, 0x99, 0x54, 0xb6, 0x67, 0x33, 0xcb, 0x0f, 0xfb, 0x8c, 0xf1, 0xd0, 0xe6, 0x1f, 0xf3,
+	0x9d, 0xa7, 0x95, 0xe6, 0x9f, 0x74, 0xa9, 0x26, 0xed, 0x78, 0xdf, 0x14, 0xa0, 0xb9, 0xc7, 0x27,
+	0xfc, 0x4c, 0x86, 0x32, 0x91, 0xa6, 0x52, 0xf8, 0x41, 0x3b, 0xa0, 0x47, 0x00, 0x0f, 0xfa, 0xf6,
+	0x4d, 0x6e, 0x5e, 0xc2, 0x80, 0x10, 0xbd, 0xc6, 0x35, 0x06, 0xc7, 0x25, 0x96, 0x26, 0xa9, 0x45,
+	0x89, 0x2c, 0x1a, 0x84, 0x59, 0x93, 0x07, 0xb0, 0x3a, 0xa0, 0x2b, 0x31, 0xb5, 0x31, 0xaf, 0xe3,
+	0xa6, 0x01, 0xe7, 0x46, 0x01, 0xdd, 0x18, 0xfd, 0xdc, 0xf3, 0xbf, 0xee, 0x37, 0x0d, 0x68, 0x8d,
+	0x1e, 0x41, 0xcd, 0xe6, 0x5d, 0x53, 0xd3, 0x59, 0x3f, 0xca, 0xb1, 0xe6, 0x67, 0x06, 0xf8, 0xde,
+	0xcb, 0x2a, 0xb8, 0xf4, 0x82, 0xaa, 0xa7, 0x25, 0x9c, 0xf6, 0xbe, 0x80, 0xdb, 0x79, 0x46, 0x7e,
+	0xaa, 0xc8, 0xf0, 0xf6, 0xe1, 0xce, 0xe2, 0xb8, 0x36, 0x02, 0x1e, 0x43, 0xcd, 0x9a, 0xa4, 0xdf,
+	0xf7, 0xe8, 0xb0, 0x16, 0x6c, 0x33, 0x0b, 0xef, 0x13, 0xd8, 0xf8, 0x3c, 0x9c, 0x8e, 0x64, 0xb4,
+	0x17, 0x47, 0x43, 0x39, 0x9a, 0xaa, 0x2c, 0x74, 0x37, 0xa0, 0x32, 0x20, 0x8c, 0xd6, 0xd7, 0xf4,
+	0xad, 0xe4, 0x1d, 0xc3, 0xdd, 0x6b, 0x3d, 0xec, 0xd4, 0x9f, 0x42, 0x73, 0x90, 0x9b, 0xc6, 0x6e,
+	0xec, 0xfa, 0xf4, 0x0b, 0x56, 0x1f, 0x7d, 0xe3, 0x40, 0x99, 0x3e, 0xf2, 0xb2, 0x1a, 0x94, 0x8e,
+	0x8e, 0x8f, 0xf0, 0x83, 0x6e, 0x03, 0xaa, 0x87, 0x47, 0xa7, 0xdd, 0x67, 0x5d, 0xbf, 0xe5, 0xe0,
+	0xd7, 0xdd, 0x83, 0xde, 0xf1, 0xce, 0x69, 0xab, 0xc0, 0x00, 0x2a, 0x27, 0xa7, 0xfe, 0xe1, 0xd1,
+	0xb3, 0x56, 0x11, 0xad, 0x4f, 0x0f, 0x5f, 0x76, 0x5b, 0x25, 0xb4, 0xde, 0x3d, 0x3e, 0xee, 0x75,
+	0x77, 0x8e, 0x5a, 0x65, 0x1a, 0xe4, 0xf7, 0xbd, 0x5e, 0xab, 0x82, 0xfd, 0x0e, 0x8f, 0x4e, 0x9f,
+	0x6e, 0xb7, 0xaa, 0x68, 0x41, 0x43, 0x3c, 0xdd, 0x6e, 0xd5, 0x10, 0xdf, 0xfd, 0xea, 0xb4, 0x7b,
+	0xd2, 0xaa, 0x23, 0xbe, 0xdf, 0xdd, 0x3b, 0x7c, 0xb9, 0xd3, 0x6b, 0x01, 0xf6, 0xec, 0x1d, 0x9e,
+	0x9c, 0xb6, 0x1a, 0x1f, 0x3d, 0x84, 0x66, 0xbe, 0x66, 0x41, 0xcd, 0xc1, 0xce, 0x61, 0xaf, 0xb5,
+	0x82, 0x0b, 0x38, 0x7c, 0x76, 0x74, 0xec, 0x77, 0x5b, 0xce, 0xf6, 0xdf, 0x4a, 0x50, 0x39, 0x30,
+	0x05, 0xf1, 0xcf, 0xa0, 0x84, 0x5f, 0x23, 0x18, 0xb9, 0x49, 0xee, 0xbb, 0x44, 0x7b, 0x5e, 0x5d,
+	0x78, 0x2b, 0x9f, 0x38, 0xec, 0x63, 0x28, 0x53, 0x81, 0xcd, 0x88, 0x94, 0x7c, 0xc5, 0xde, 0xce,
+	0x23, 0x54, 0x7d, 0x7b, 0x2b, 0x9b, 0x0e, 0xfb, 0x05, 0x54, 0x4c, 0x99, 0xc7, 0xe8, 0x83, 0xe8,
+	0x42, 0x81, 0xdd, 0x66, 0x79, 0xc8, 0xd6, 0x3f, 0x2b, 0xd8, 0xc5, 0xd4, 0x44, 0xa6, 0xcb, 0x42,
+	0x49, 0xd8, 0x66, 0x79, 0x28, 0xeb, 0xf2, 0x08, 0x4a, 0xe8, 0xdb, 0x6c, 0x7d, 0xa9, 0xac, 0x68,
+	0xb7, 0xe6, 0x40, 0x66, 0xfc, 0x18, 0xaa, 0xf6, 0x22, 0x67, 0x34, 0xda, 0xe2, 0xad, 0xbe, 0xbc,
+	0xe3, 0x4f, 0xa1, 0x6a, 0x8b, 0x04, 0x63, 0xbd, 0x58, 0x9c, 0xb5, 0x6f, 0x2f, 0x60, 0xd9, 0x1c,
+	0xbf, 0x06, 0x98, 0xdf, 0x3e, 0x8c, 0xbe, 0x2a, 0x5f, 0xbb, 0xf7, 0xda, 0x1b, 0xcb, 0x70, 0xd6,
+	0xfd, 0x00, 0x56, 0x17, 0xd2, 0x38, 0x73, 0x6f, 0xc8, 0xec, 0x66, 0x90, 0x7b, 0xaf, 0xcd, 0xf9,
+	0xde, 0x0a, 0xdb, 0x5b, 0xca, 0x66, 0x77, 0xaf, 0xb9, 0xb2, 0x1d, 0xc5, 0xbd, 0xae, 0x48, 0x07,
+	0xd9, 0xfe, 0xae, 0x00, 0xab, 0xbb, 0x26, 0xde, 0x4c, 0xe0, 0xb0, 0x03, 0xa8, 0x67, 0xc1, 0xc3,
+	0xda, 0xd8, 0xf5, 0xe6, 0x18, 0x6c, 0xbf, 0x7d, 0xa3, 0x2e, 0x5b, 0xde, 0xff, 0x91, 0xd7, 0x9d,
+	0x55, 0xe8, 0xaf, 0xdc, 0xd3, 0xff, 0x0d, 0x00, 0x3b, 0x07, 0x82, 0x33, 0xa5, 0x1b, 0x00, 0x00,
 }
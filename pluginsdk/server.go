@@ -0,0 +1,372 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+// Package pluginsdk serves a frames backend from its own process, frames
+// uses it through a "plugin" backend.
+//
+// A plugin binary calls Serve with its backend factory:
+//
+//	func main() {
+//		if err := pluginsdk.Serve(mybackend.NewBackend); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+//
+// Plugins don't get a v3io context, the factory is called with a nil one.
+package pluginsdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/nuclio/logger"
+	"github.com/pkg/errors"
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/backends"
+	"github.com/v3io/frames/pb"
+	"google.golang.org/grpc"
+)
+
+const (
+	// Handshake starts the first line a plugin writes to stdout, it's
+	// followed by a space and the plugin address
+	Handshake = "frames-plugin v1"
+	// AddressEnvVar is the address the plugin listens on (default is a
+	// random local port)
+	AddressEnvVar = "FRAMES_PLUGIN_ADDRESS"
+	// LaunchedEnvVar is set when frames starts the plugin, the plugin stops
+	// once its stdin is closed
+	LaunchedEnvVar = "FRAMES_PLUGIN_LAUNCHED"
+	// LogLevelEnvVar is the plugin log level
+	LogLevelEnvVar = "FRAMES_PLUGIN_LOG_LEVEL"
+
+	// MessageSize is the maximal gRPC message size
+	MessageSize = 128 * (1 << 20) // 128MB
+)
+
+// Server is a BackendPlugin gRPC server for a backend
+type Server struct {
+	logger  logger.Logger
+	factory backends.Factory
+
+	lock    sync.Mutex
+	backend frames.DataBackend
+}
+
+var (
+	// Make sure we're implementing pb.BackendPluginServer
+	_ pb.BackendPluginServer = &Server{}
+)
+
+// NewServer returns a new server, the backend is created by factory on the
+// first Configure call
+func NewServer(logger logger.Logger, factory backends.Factory) *Server {
+	return &Server{
+		logger:  logger,
+		factory: factory,
+	}
+}
+
+// Serve serves the backend created by factory. When frames starts the plugin,
+// Serve returns once frames exits
+func Serve(factory backends.Factory) error {
+	logger, err := frames.NewLogger(os.Getenv(LogLevelEnvVar))
+	if err != nil {
+		return errors.Wrap(err, "can't create logger")
+	}
+
+	address := os.Getenv(AddressEnvVar)
+	if address == "" {
+		address = "127.0.0.1:0"
+	}
+
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		return errors.Wrap(err, "can't listen")
+	}
+
+	// Must come before any other output
+	fmt.Printf("%s %s\n", Handshake, lis.Addr())
+
+	grpcServer := grpc.NewServer(
+		grpc.MaxSendMsgSize(MessageSize),
+		grpc.MaxRecvMsgSize(MessageSize),
+	)
+	pb.RegisterBackendPluginServer(grpcServer, NewServer(logger, factory))
+
+	if os.Getenv(LaunchedEnvVar) != "" {
+		go func() {
+			// Frames keeps our stdin open until it exits
+			_, _ = io.Copy(ioutil.Discard, os.Stdin)
+			logger.Info("stdin closed, stopping")
+			grpcServer.Stop()
+		}()
+	}
+
+	logger.InfoWith("plugin started", "address", lis.Addr().String())
+	return grpcServer.Serve(lis)
+}
+
+// Configure creates the backend, later calls (e.g. from other frames servers)
+// use the existing backend
+func (s *Server) Configure(ctx context.Context, req *pb.PluginConfigureRequest) (*pb.PluginConfigureResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.backend == nil {
+		config := &frames.BackendConfig{}
+		if err := json.Unmarshal(req.Config, config); err != nil {
+			return nil, errors.Wrap(err, "bad configuration")
+		}
+
+		framesConfig := &frames.Config{Backends: []*frames.BackendConfig{config}}
+		backend, err := s.factory(s.logger, nil, config, framesConfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't create backend")
+		}
+
+		s.logger.InfoWith("backend configured", "name", config.Name)
+		s.backend = backend
+	}
+
+	return &pb.PluginConfigureResponse{Capabilities: s.backend.Capabilities()}, nil
+}
+
+// Read reads from the backend
+func (s *Server) Read(request *pb.ReadRequest, stream pb.BackendPlugin_ReadServer) error {
+	backend, err := s.getBackend()
+	if err != nil {
+		return err
+	}
+
+	password, token := takeCredentials(request.Session)
+	req := &frames.ReadRequest{
+		Proto:    request,
+		Password: password,
+		Token:    token,
+	}
+
+	it, err := backends.ReadContext(stream.Context(), backend, req)
+	if err != nil {
+		return err
+	}
+
+	for it.Next() {
+		msg, err := frameProto(it.At())
+		if err != nil {
+			return err
+		}
+
+		if err := stream.Send(msg); err != nil {
+			return err
+		}
+	}
+
+	return it.Err()
+}
+
+// Write writes to the backend
+func (s *Server) Write(stream pb.BackendPlugin_WriteServer) error {
+	backend, err := s.getBackend()
+	if err != nil {
+		return err
+	}
+
+	msg, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+
+	pbReq := msg.GetRequest()
+	if pbReq == nil {
+		return fmt.Errorf("stream didn't start with write request")
+	}
+
+	password, token := takeCredentials(pbReq.Session)
+	saveMode, err := frames.SaveModeFromString(pbReq.SaveMode)
+	if err != nil {
+		return err
+	}
+
+	req := &frames.WriteRequest{
+		Session:       pbReq.Session,
+		Password:      password,
+		Token:         token,
+		Backend:       pbReq.Backend,
+		Table:         pbReq.Table,
+		Expression:    pbReq.Expression,
+		Condition:     pbReq.Condition,
+		PartitionKeys: pbReq.PartitionKeys,
+		HaveMore:      pbReq.More,
+		SaveMode:      saveMode,
+	}
+
+	nFrames, nRows := 0, 0
+	if pbReq.InitialData != nil {
+		req.ImmidiateData = frames.NewFrameFromProto(pbReq.InitialData)
+		nFrames, nRows = 1, req.ImmidiateData.Len()
+	}
+
+	appender, err := backends.WriteContext(stream.Context(), backend, req)
+	if err != nil {
+		return err
+	}
+	defer appender.Close()
+
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		frameMessage := msg.GetFrame()
+		if frameMessage == nil {
+			return fmt.Errorf("nil frame")
+		}
+
+		frame := frames.NewFrameFromProto(frameMessage)
+		if err := appender.Add(frame); err != nil {
+			return errors.Wrap(err, "can't add frame")
+		}
+		nFrames++
+		nRows += frame.Len()
+	}
+
+	if nRows > 0 {
+		if err := appender.WaitForComplete(backends.ContextTimeout(stream.Context(), 0)); err != nil {
+			return errors.Wrap(err, "can't wait for completion")
+		}
+	}
+
+	return stream.SendAndClose(&pb.WriteRespose{Frames: int64(nFrames), Rows: int64(nRows)})
+}
+
+// Create creates a table
+func (s *Server) Create(ctx context.Context, request *pb.CreateRequest) (*pb.CreateResponse, error) {
+	backend, err := s.getBackend()
+	if err != nil {
+		return nil, err
+	}
+
+	password, token := takeCredentials(request.Session)
+	req := &frames.CreateRequest{
+		Proto:    request,
+		Password: password,
+		Token:    token,
+	}
+
+	if err := backend.Create(req); err != nil {
+		return nil, err
+	}
+
+	return &pb.CreateResponse{}, nil
+}
+
+// Delete deletes a table or part of it
+func (s *Server) Delete(ctx context.Context, request *pb.DeleteRequest) (*pb.DeleteResponse, error) {
+	backend, err := s.getBackend()
+	if err != nil {
+		return nil, err
+	}
+
+	password, token := takeCredentials(request.Session)
+	req := &frames.DeleteRequest{
+		Proto:    request,
+		Password: password,
+		Token:    token,
+	}
+
+	if err := backend.Delete(req); err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteResponse{}, nil
+}
+
+// Exec executes a command
+func (s *Server) Exec(ctx context.Context, request *pb.ExecRequest) (*pb.ExecResponse, error) {
+	backend, err := s.getBackend()
+	if err != nil {
+		return nil, err
+	}
+
+	password, token := takeCredentials(request.Session)
+	req := &frames.ExecRequest{
+		Proto:    request,
+		Password: password,
+		Token:    token,
+	}
+
+	frame, err := backends.ExecContext(ctx, backend, req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ExecResponse{}
+	if frame != nil {
+		if resp.Frame, err = frameProto(frame); err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *Server) getBackend() (frames.DataBackend, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.backend == nil {
+		return nil, fmt.Errorf("plugin not configured")
+	}
+
+	return s.backend, nil
+}
+
+// takeCredentials removes the password and token from session and returns them
+func takeCredentials(session *frames.Session) (frames.SecretString, frames.SecretString) {
+	if session == nil {
+		return frames.InitSecretString(""), frames.InitSecretString("")
+	}
+
+	password := frames.InitSecretString(session.Password)
+	token := frames.InitSecretString(session.Token)
+	session.Password = ""
+	session.Token = ""
+	return password, token
+}
+
+func frameProto(frame frames.Frame) (*pb.Frame, error) {
+	fpb, ok := frame.(pb.Framed)
+	if !ok {
+		return nil, errors.New("unknown frame type")
+	}
+
+	return fpb.Proto(), nil
+}
@@ -156,6 +156,13 @@ func (it *rowIterator) getRow() (map[string]interface{}, error) {
 			value, err = col.TimeAt(it.rowNum)
 		case BoolType:
 			value, err = col.BoolAt(it.rowNum)
+		case Int32Type, Float32Type, BytesType, DecimalType:
+			value, err = ValueAt(col, it.rowNum)
+		case ListType:
+			var list Column
+			if list, err = col.ListAt(it.rowNum); err == nil {
+				value, err = ListValues(list)
+			}
 		default:
 			err = fmt.Errorf("%s:%d - unknown dtype - %d", col.Name(), it.rowNum, col.DType())
 		}
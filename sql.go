@@ -35,34 +35,45 @@ type Query struct {
 	GroupBy string
 }
 
-// ParseSQL parsers SQL query to a Query struct
-func ParseSQL(sql string) (*Query, error) {
+// ParseSelect parses a SELECT statement from one table, it returns the
+// statement and the table name
+func ParseSelect(sql string) (*sqlparser.Select, string, error) {
 	stmt, err := sqlparser.Parse(sql)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	slct, ok := stmt.(*sqlparser.Select)
 	if !ok {
-		return nil, fmt.Errorf("not a SELECT statement")
+		return nil, "", fmt.Errorf("not a SELECT statement")
 	}
 
 	if nTables := len(slct.From); nTables != 1 {
-		return nil, fmt.Errorf("nan select from only one table (got %d)", nTables)
+		return nil, "", fmt.Errorf("can select from only one table (got %d)", nTables)
 	}
 
 	aliased, ok := slct.From[0].(*sqlparser.AliasedTableExpr)
 	if !ok {
-		return nil, fmt.Errorf("not a table select")
+		return nil, "", fmt.Errorf("not a table select")
 	}
 
 	table, ok := aliased.Expr.(sqlparser.TableName)
 	if !ok {
-		return nil, fmt.Errorf("not a table in FROM field")
+		return nil, "", fmt.Errorf("not a table in FROM field")
+	}
+
+	return slct, table.Name.String(), nil
+}
+
+// ParseSQL parsers SQL query to a Query struct
+func ParseSQL(sql string) (*Query, error) {
+	slct, table, err := ParseSelect(sql)
+	if err != nil {
+		return nil, err
 	}
 
 	query := &Query{
-		Table: table.Name.String(),
+		Table: table,
 	}
 
 	for _, sexpr := range slct.SelectExprs {
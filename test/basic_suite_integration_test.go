@@ -147,6 +147,9 @@ func setupRoot(t testing.TB) string {
 func sessionInfo(t testing.TB) *frames.Session {
 	data := os.Getenv("V3IO_SESSION")
 	if data == "" {
+		if fakeV3ioRoot() != "" {
+			return &frames.Session{Container: "bigdata"}
+		}
 		return nil
 	}
 
@@ -165,6 +168,28 @@ func sessionInfo(t testing.TB) *frames.Session {
 	return &s.Session
 }
 
+// fakeV3ioRoot returns the fake v3io directory from V3IO_FAKE, when set tests
+// run against a local fake of v3io (shared by framesd and the test)
+func fakeV3ioRoot() string {
+	return os.Getenv("V3IO_FAKE")
+}
+
+func newV3ioContext(logger logger.Logger) v3io.Context {
+	if root := fakeV3ioRoot(); root != "" {
+		v3ioContext, _ := v3ioutils.NewFakeContext(root)
+		return v3ioContext
+	}
+
+	newClient := v3iohttp.NewClient(&v3iohttp.NewClientInput{DialTimeout: 0, MaxConnsPerHost: 100})
+	newContextInput := &v3iohttp.NewContextInput{
+		HTTPClient:     newClient,
+		NumWorkers:     8,
+		RequestChanLen: 4096,
+	}
+	v3ioContext, _ := v3iohttp.NewContext(logger, newContextInput)
+	return v3ioContext
+}
+
 func generateConfig(root string, session *frames.Session) *frames.Config {
 	backends := []*frames.BackendConfig{
 		{
@@ -184,6 +209,10 @@ func generateConfig(root string, session *frames.Session) *frames.Config {
 			Type:    "tsdb",
 			Workers: 16,
 		})
+
+		for _, backend := range backends[1:] {
+			backend.FakeV3ioRoot = fakeV3ioRoot()
+		}
 	}
 
 	return &frames.Config{
@@ -292,13 +321,7 @@ func setupTest(t testing.TB, internalLogger logger.Logger) *testInfo {
 	info.grpcAddr = fmt.Sprintf("localhost:%d", grpcPort)
 	info.httpAddr = fmt.Sprintf("http://localhost:%d", httpPort)
 
-	newClient := v3iohttp.NewClient(&v3iohttp.NewClientInput{DialTimeout: 0, MaxConnsPerHost: 100})
-	newContextInput := &v3iohttp.NewContextInput{
-		HTTPClient:     newClient,
-		NumWorkers:     8,
-		RequestChanLen: 4096,
-	}
-	v3ioContext, _ := v3iohttp.NewContext(internalLogger, newContextInput)
+	v3ioContext := newV3ioContext(internalLogger)
 	container, _ := v3ioutils.NewContainer(
 		v3ioContext,
 		info.session,
@@ -324,13 +347,7 @@ func createTestContainer(t testing.TB) v3io.Container {
 	if err != nil {
 		t.Fatal(err)
 	}
-	newClient := v3iohttp.NewClient(&v3iohttp.NewClientInput{DialTimeout: 0, MaxConnsPerHost: 100})
-	newContextInput := &v3iohttp.NewContextInput{
-		HTTPClient:     newClient,
-		NumWorkers:     8,
-		RequestChanLen: 4096,
-	}
-	v3ioContext, _ := v3iohttp.NewContext(logger, newContextInput)
+	v3ioContext := newV3ioContext(logger)
 	container, _ := v3ioutils.NewContainer(
 		v3ioContext,
 		session,
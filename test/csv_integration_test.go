@@ -129,3 +129,35 @@ func (csvSuite *CsvTestSuite) TestAll() {
 	err = csvSuite.client.Delete(dreq)
 	csvSuite.Require().NoError(err)
 }
+
+func (csvSuite *CsvTestSuite) TestCatalog() {
+	table := fmt.Sprintf("csv_test_catalog%d", time.Now().UnixNano())
+
+	frame := csvSuite.generateSampleFrame(csvSuite.T())
+	appender, err := csvSuite.client.Write(&frames.WriteRequest{Backend: csvSuite.backendName, Table: table})
+	csvSuite.Require().NoError(err)
+	csvSuite.Require().NoError(appender.Add(frame))
+	csvSuite.Require().NoError(appender.WaitForComplete(3 * time.Second))
+
+	tables, err := csvSuite.client.ListTables(&pb.ListTablesRequest{Backend: csvSuite.backendName})
+	csvSuite.Require().NoError(err)
+	csvSuite.Require().Contains(tables, table)
+
+	description, err := csvSuite.client.DescribeTable(&pb.DescribeTableRequest{Backend: csvSuite.backendName, Table: table})
+	csvSuite.Require().NoError(err)
+	types := make(map[string]string)
+	for _, field := range description.Schema.Fields {
+		types[field.Name] = field.Type
+	}
+	expected := map[string]string{
+		"bools":   "boolean",
+		"floats":  "double",
+		"ints":    "long",
+		"strings": "string",
+		"times":   "timestamp",
+	}
+	csvSuite.Require().Equal(expected, types)
+
+	err = csvSuite.client.Delete(&pb.DeleteRequest{Backend: csvSuite.backendName, Table: table})
+	csvSuite.Require().NoError(err)
+}
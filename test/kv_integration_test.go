@@ -266,6 +266,59 @@ func (kvSuite *KvTestSuite) TestAll() {
 	kvSuite.Require().NoError(err)
 }
 
+func (kvSuite *KvTestSuite) TestCatalog() {
+	dir := fmt.Sprintf("kv_test_catalog%d", time.Now().UnixNano())
+	table := dir + "/t1"
+
+	frame := kvSuite.generateRandomSampleFrame(5, "idx", []string{"n1", "n2"})
+	appender, err := kvSuite.client.Write(&frames.WriteRequest{Backend: kvSuite.backendName, Table: table})
+	kvSuite.Require().NoError(err)
+	kvSuite.Require().NoError(appender.Add(frame))
+	kvSuite.Require().NoError(appender.WaitForComplete(3 * time.Second))
+
+	tables, err := kvSuite.client.ListTables(&pb.ListTablesRequest{Backend: kvSuite.backendName, Path: dir})
+	kvSuite.Require().NoError(err)
+	kvSuite.Require().Equal([]string{table}, tables)
+
+	description, err := kvSuite.client.DescribeTable(&pb.DescribeTableRequest{Backend: kvSuite.backendName, Table: table})
+	kvSuite.Require().NoError(err)
+	var names []string
+	for _, field := range description.Schema.Fields {
+		names = append(names, field.Name)
+	}
+	kvSuite.Require().ElementsMatch([]string{"idx", "n1", "n2"}, names)
+	kvSuite.Require().Equal([]string{"idx"}, description.Schema.Key.ShardingKey)
+
+	err = kvSuite.client.Delete(&pb.DeleteRequest{Backend: kvSuite.backendName, Table: table})
+	kvSuite.Require().NoError(err)
+}
+
+func (kvSuite *KvTestSuite) TestCapabilities() {
+	caps, err := kvSuite.client.Capabilities(&pb.CapabilitiesRequest{Backend: kvSuite.backendName})
+	kvSuite.Require().NoError(err)
+	kvSuite.Require().Len(caps, 1)
+	kvSuite.Require().Equal(kvSuite.backendName, caps[0].Name)
+	kvSuite.Require().Equal("kv", caps[0].Type)
+	kvSuite.Require().Contains(caps[0].ReadFields, "ShardingKeys")
+	kvSuite.Require().Contains(caps[0].SaveModes, frames.OverwriteItem.String())
+
+	var commands []string
+	for _, cmd := range caps[0].Commands {
+		commands = append(commands, cmd.Name)
+	}
+	kvSuite.Require().Contains(commands, "update")
+
+	all, err := kvSuite.client.Capabilities(&pb.CapabilitiesRequest{})
+	kvSuite.Require().NoError(err)
+	kvSuite.Require().True(len(all) > 1, "got capabilities of %d backends", len(all))
+
+	// Requests are validated with the capabilities
+	args, err := pb.FromGoMap(map[string]interface{}{"key": "k1"})
+	kvSuite.Require().NoError(err)
+	_, err = kvSuite.client.Exec(&pb.ExecRequest{Backend: kvSuite.backendName, Table: "t", Command: "update", Args: args})
+	kvSuite.Require().Error(err, "update without an expression")
+}
+
 func (kvSuite *KvTestSuite) TestRangeScan() {
 	table := fmt.Sprintf("kv_range_scan%d", time.Now().UnixNano())
 
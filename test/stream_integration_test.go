@@ -124,3 +124,28 @@ func (streamSuite *StreamTestSuite) TestAll() {
 	err = streamSuite.client.Delete(dreq)
 	streamSuite.Require().NoError(err)
 }
+
+func (streamSuite *StreamTestSuite) TestCatalog() {
+	dir := fmt.Sprintf("stream_test_catalog%d", time.Now().UnixNano())
+	table := dir + "/s1"
+
+	req := &pb.CreateRequest{
+		Backend:        streamSuite.backendName,
+		Table:          table,
+		RetentionHours: 48,
+		Shards:         2,
+	}
+	streamSuite.Require().NoError(streamSuite.client.Create(req))
+
+	tables, err := streamSuite.client.ListTables(&pb.ListTablesRequest{Backend: streamSuite.backendName, Path: dir})
+	streamSuite.Require().NoError(err)
+	streamSuite.Require().Equal([]string{table}, tables)
+
+	description, err := streamSuite.client.DescribeTable(&pb.DescribeTableRequest{Backend: streamSuite.backendName, Table: table})
+	streamSuite.Require().NoError(err)
+	streamSuite.Require().Equal(int64(2), description.Attributes["shards"].GetIval())
+	streamSuite.Require().Equal(int64(48), description.Attributes["retention_hours"].GetIval())
+
+	err = streamSuite.client.Delete(&pb.DeleteRequest{Backend: streamSuite.backendName, Table: table})
+	streamSuite.Require().NoError(err)
+}
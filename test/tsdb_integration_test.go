@@ -570,3 +570,34 @@ func (tsdbSuite *TsdbTestSuite) TestDeleteAllSamplesButNotTable() {
 
 	tsdbSuite.Require().NoError(it.Err())
 }
+
+func (tsdbSuite *TsdbTestSuite) TestCatalog() {
+	dir := fmt.Sprintf("tsdb_test_catalog%d", time.Now().UnixNano())
+	table := dir + "/t1"
+
+	err := tsdbSuite.client.Create(&pb.CreateRequest{Backend: tsdbSuite.backendName, Table: table, Rate: "1/m"})
+	tsdbSuite.Require().NoError(err)
+
+	anchorTime, _ := time.Parse(time.RFC3339, "2019-12-12T05:00:00Z")
+	frame := tsdbSuite.generateSampleFrame(tsdbSuite.T(), anchorTime)
+	appender, err := tsdbSuite.client.Write(&frames.WriteRequest{Backend: tsdbSuite.backendName, Table: table})
+	tsdbSuite.Require().NoError(err)
+	tsdbSuite.Require().NoError(appender.Add(frame))
+	tsdbSuite.Require().NoError(appender.WaitForComplete(3 * time.Second))
+
+	tables, err := tsdbSuite.client.ListTables(&pb.ListTablesRequest{Backend: tsdbSuite.backendName, Path: dir})
+	tsdbSuite.Require().NoError(err)
+	tsdbSuite.Require().Equal([]string{table}, tables)
+
+	description, err := tsdbSuite.client.DescribeTable(&pb.DescribeTableRequest{Backend: tsdbSuite.backendName, Table: table})
+	tsdbSuite.Require().NoError(err)
+	var metrics []string
+	for _, field := range description.Schema.Fields {
+		metrics = append(metrics, field.Name)
+	}
+	tsdbSuite.Require().Equal([]string{"cpu", "disk", "mem"}, metrics)
+	tsdbSuite.Require().Equal("1h", description.Attributes["aggregation_granularity"].GetSval())
+
+	err = tsdbSuite.client.Delete(&pb.DeleteRequest{Backend: tsdbSuite.backendName, Table: table})
+	tsdbSuite.Require().NoError(err)
+}
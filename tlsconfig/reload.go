@@ -0,0 +1,188 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nuclio/logger"
+	"github.com/pkg/errors"
+)
+
+// How often files are checked for changes
+var checkInterval = 10 * time.Second
+
+// fileWatch tells if files were modified since they were loaded
+type fileWatch struct {
+	paths     []string
+	modTimes  []time.Time
+	lastCheck time.Time
+}
+
+func newFileWatch(paths ...string) *fileWatch {
+	watch := &fileWatch{paths: paths}
+	watch.modTimes = watch.stat()
+	watch.lastCheck = time.Now()
+	return watch
+}
+
+// changed returns true if a file was modified, it checks at most once every
+// checkInterval. Files that can't be read (e.g. in the middle of an update)
+// are ignored
+func (w *fileWatch) changed() bool {
+	now := time.Now()
+	if now.Sub(w.lastCheck) < checkInterval {
+		return false
+	}
+	w.lastCheck = now
+
+	modTimes := w.stat()
+	changed := false
+	for i, modTime := range modTimes {
+		if !modTime.IsZero() && !modTime.Equal(w.modTimes[i]) {
+			changed = true
+		}
+	}
+
+	if changed {
+		w.modTimes = modTimes
+	}
+	return changed
+}
+
+func (w *fileWatch) stat() []time.Time {
+	modTimes := make([]time.Time, len(w.paths))
+	for i, path := range w.paths {
+		if info, err := os.Stat(path); err == nil {
+			modTimes[i] = info.ModTime()
+		}
+	}
+	return modTimes
+}
+
+// keyPair is a certificate and key, reloaded when the files change
+type keyPair struct {
+	certFile string
+	keyFile  string
+	logger   logger.Logger
+
+	lock  sync.Mutex
+	watch *fileWatch
+	cert  *tls.Certificate
+}
+
+func newKeyPair(certFile, keyFile string, logger logger.Logger) (*keyPair, error) {
+	pair := &keyPair{
+		certFile: certFile,
+		keyFile:  keyFile,
+		logger:   logger,
+		watch:    newFileWatch(certFile, keyFile),
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't load certificate")
+	}
+	pair.cert = &cert
+
+	return pair, nil
+}
+
+// get returns the current certificate, a certificate that fails to load is
+// logged and the previous one is kept
+func (k *keyPair) get() *tls.Certificate {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+
+	if k.watch.changed() {
+		cert, err := tls.LoadX509KeyPair(k.certFile, k.keyFile)
+		if err != nil {
+			k.logger.WarnWith("can't reload certificate", "cert", k.certFile, "error", err.Error())
+		} else {
+			k.logger.InfoWith("certificate reloaded", "cert", k.certFile)
+			k.cert = &cert
+		}
+	}
+
+	return k.cert
+}
+
+// certPool is a CA file, reloaded when the file changes
+type certPool struct {
+	path   string
+	logger logger.Logger
+
+	lock  sync.Mutex
+	watch *fileWatch
+	pool  *x509.CertPool
+}
+
+func newCertPool(path string, logger logger.Logger) (*certPool, error) {
+	pool, err := loadCertPool(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &certPool{
+		path:   path,
+		logger: logger,
+		watch:  newFileWatch(path),
+		pool:   pool,
+	}, nil
+}
+
+// get returns the current pool, a CA file that fails to load is logged and
+// the previous pool is kept
+func (c *certPool) get() *x509.CertPool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.watch.changed() {
+		pool, err := loadCertPool(c.path)
+		if err != nil {
+			c.logger.WarnWith("can't reload CA", "path", c.path, "error", err.Error())
+		} else {
+			c.logger.InfoWith("CA reloaded", "path", c.path)
+			c.pool = pool
+		}
+	}
+
+	return c.pool
+}
+
+func loadCertPool(path string) (*x509.CertPool, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't read CA file")
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, errors.Errorf("no certificates in %q", path)
+	}
+
+	return pool, nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+// Package tlsconfig creates the TLS configuration of servers and clients.
+//
+// Certificate files are checked for changes during handshakes and reloaded,
+// so rotated certificates are used without a restart.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+
+	"github.com/nuclio/logger"
+	"github.com/pkg/errors"
+	"github.com/v3io/frames"
+)
+
+// NewServer returns the TLS configuration of a server. nextProtos are the
+// application protocols (e.g. "h2" for gRPC)
+func NewServer(config *frames.TLSConfig, logger logger.Logger, nextProtos ...string) (*tls.Config, error) {
+	if config.CertFile == "" || config.KeyFile == "" {
+		return nil, errors.New("TLS requires a certificate and a key file")
+	}
+
+	pair, err := newKeyPair(config.CertFile, config.KeyFile, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	serverConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		NextProtos: nextProtos,
+		GetCertificate: func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+			return pair.get(), nil
+		},
+	}
+
+	if config.ClientCAFile == "" {
+		return serverConfig, nil
+	}
+
+	pool, err := newCertPool(config.ClientCAFile, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	serverConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	if config.ClientCertOptional {
+		serverConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+	serverConfig.ClientCAs = pool.get()
+
+	// The client CAs are taken from the config of the connection, we return
+	// one with the current CAs
+	outer := serverConfig.Clone()
+	outer.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		connConfig := serverConfig.Clone()
+		connConfig.ClientCAs = pool.get()
+		return connConfig, nil
+	}
+
+	return outer, nil
+}
+
+// NewClient returns the TLS configuration of a client
+func NewClient(config *frames.ClientTLSConfig, logger logger.Logger) (*tls.Config, error) {
+	clientConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         config.ServerName,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+
+	if config.CAFile != "" {
+		// Clients are usually short lived, the CA isn't reloaded
+		data, err := ioutil.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "can't read CA file")
+		}
+
+		clientConfig.RootCAs = x509.NewCertPool()
+		if !clientConfig.RootCAs.AppendCertsFromPEM(data) {
+			return nil, errors.Errorf("no certificates in %q", config.CAFile)
+		}
+	}
+
+	if config.CertFile != "" || config.KeyFile != "" {
+		pair, err := newKeyPair(config.CertFile, config.KeyFile, logger)
+		if err != nil {
+			return nil, err
+		}
+
+		clientConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return pair.get(), nil
+		}
+	}
+
+	return clientConfig, nil
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/v3io/frames"
+	"github.com/v3io/frames/tlsconfig/tlstest"
+)
+
+func writeFiles(t *testing.T) *tlstest.Files {
+	dir, err := ioutil.TempDir("", "frames-tls")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	files, err := tlstest.WriteFiles(dir, "daffy", "analysts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+// handshake connects a client to a server and returns the server certificate
+// and the client certificate the server verified
+func handshake(t *testing.T, serverConfig, clientConfig *tls.Config) (*x509.Certificate, *x509.Certificate, error) {
+	lis, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lis.Close()
+
+	type result struct {
+		state tls.ConnectionState
+		err   error
+	}
+	serverDone := make(chan result, 1)
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			serverDone <- result{err: err}
+			return
+		}
+		defer conn.Close()
+		tlsConn := conn.(*tls.Conn)
+		err = tlsConn.Handshake()
+		serverDone <- result{tlsConn.ConnectionState(), err}
+	}()
+
+	conn, err := tls.Dial("tcp", lis.Addr().String(), clientConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer conn.Close()
+	// With TLS 1.3 client certificate errors are seen on the first read
+	conn.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	conn.Read(make([]byte, 1))
+
+	server := <-serverDone
+	if server.err != nil {
+		return nil, nil, server.err
+	}
+
+	var clientCert *x509.Certificate
+	if chains := server.state.VerifiedChains; len(chains) > 0 {
+		clientCert = chains[0][0]
+	}
+	return conn.ConnectionState().PeerCertificates[0], clientCert, nil
+}
+
+func TestMutualTLS(t *testing.T) {
+	files := writeFiles(t)
+	logger, _ := frames.NewLogger("")
+
+	serverConfig, err := NewServer(&frames.TLSConfig{
+		CertFile:     files.ServerCertFile,
+		KeyFile:      files.ServerKeyFile,
+		ClientCAFile: files.CAFile,
+	}, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientConfig, err := NewClient(&frames.ClientTLSConfig{
+		CAFile:     files.CAFile,
+		CertFile:   files.ClientCertFile,
+		KeyFile:    files.ClientKeyFile,
+		ServerName: "localhost",
+	}, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, clientCert, err := handshake(t, serverConfig, clientConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if clientCert == nil || clientCert.Subject.CommonName != "daffy" {
+		t.Fatalf("bad client certificate - %+v", clientCert)
+	}
+
+	noCertConfig, err := NewClient(&frames.ClientTLSConfig{CAFile: files.CAFile, ServerName: "localhost"}, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := handshake(t, serverConfig, noCertConfig); err == nil {
+		t.Fatal("handshake without client certificate")
+	}
+}
+
+func TestReload(t *testing.T) {
+	files := writeFiles(t)
+	logger, _ := frames.NewLogger("")
+
+	serverConfig, err := NewServer(&frames.TLSConfig{
+		CertFile: files.ServerCertFile,
+		KeyFile:  files.ServerKeyFile,
+	}, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientConfig, err := NewClient(&frames.ClientTLSConfig{CAFile: files.CAFile, ServerName: "localhost"}, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before, _, err := handshake(t, serverConfig, clientConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	oldInterval := checkInterval
+	checkInterval = 0
+	defer func() { checkInterval = oldInterval }()
+
+	// Rotate to a certificate of a new CA
+	ca, err := tlstest.NewCA()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ca.WriteCert(files.ServerCertFile, files.ServerKeyFile, pkix.Name{CommonName: "localhost"}, "localhost"); err != nil {
+		t.Fatal(err)
+	}
+	if err := ca.WriteCA(files.CAFile); err != nil {
+		t.Fatal(err)
+	}
+	// Make sure the modification time changes on file systems with coarse
+	// time resolution
+	future := time.Now().Add(time.Minute)
+	os.Chtimes(files.ServerCertFile, future, future)
+	os.Chtimes(files.ServerKeyFile, future, future)
+
+	if _, _, err := handshake(t, serverConfig, clientConfig); err == nil {
+		t.Fatal("client trusts rotated certificate of new CA")
+	}
+
+	clientConfig, err = NewClient(&frames.ClientTLSConfig{CAFile: files.CAFile, ServerName: "localhost"}, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	after, _, err := handshake(t, serverConfig, clientConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if before.SerialNumber.Cmp(after.SerialNumber) == 0 {
+		t.Fatal("certificate not reloaded")
+	}
+
+	// A bad certificate keeps the previous one
+	if err := ioutil.WriteFile(files.ServerCertFile, []byte("garbage"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	future = future.Add(time.Minute)
+	os.Chtimes(files.ServerCertFile, future, future)
+
+	if _, _, err := handshake(t, serverConfig, clientConfig); err != nil {
+		t.Fatalf("handshake after bad certificate - %s", err)
+	}
+}
+
+func TestBadServerConfig(t *testing.T) {
+	if _, err := NewServer(&frames.TLSConfig{CertFile: "cert.pem"}, nil); err == nil {
+		t.Fatal("no error for missing key file")
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+// Package tracing creates OpenTelemetry spans.
+//
+// Spans are created with the global tracer provider, which doesn't record
+// anything until Setup (or otel.SetTracerProvider in tests) replaces it.
+package tracing
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/v3io/frames"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	tracerName         = "github.com/v3io/frames"
+	defaultServiceName = "framesd"
+)
+
+// Span attributes
+const (
+	BackendKey = attribute.Key("frames.backend")
+	TableKey   = attribute.Key("frames.table")
+	CommandKey = attribute.Key("frames.command")
+	RowsKey    = attribute.Key("frames.rows")
+	FramesKey  = attribute.Key("frames.frames")
+)
+
+// Propagator propagates W3C trace context in HTTP headers and gRPC metadata
+var Propagator propagation.TextMapPropagator = propagation.TraceContext{}
+
+// Start starts a span, it's a child of the span in ctx
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartServer starts the span of a request to a server
+func StartServer(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...), trace.WithSpanKind(trace.SpanKindServer))
+}
+
+// End ends span, err (if not nil) is recorded as the span error
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// NewProvider returns a tracer provider that exports spans to exporter, or
+// with OTLP to the collector of config if exporter is nil
+func NewProvider(config *frames.TracingConfig, exporter sdktrace.SpanExporter) (*sdktrace.TracerProvider, error) {
+	options := []sdktrace.TracerProviderOption{}
+	if exporter == nil {
+		var err error
+		exporter, err = newExporter(config)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, sdktrace.WithBatcher(exporter))
+	} else {
+		options = append(options, sdktrace.WithSyncer(exporter))
+	}
+
+	serviceName := config.ServiceName
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+	res := resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))
+
+	ratio := config.SampleRatio
+	if ratio == 0 {
+		ratio = 1
+	}
+	sampler := sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))
+
+	options = append(options, sdktrace.WithResource(res), sdktrace.WithSampler(sampler))
+	return sdktrace.NewTracerProvider(options...), nil
+}
+
+// Setup sets the global tracer provider to export spans to the collector of
+// config. It returns a function that exports the remaining spans and stops
+// the provider
+func Setup(config *frames.TracingConfig) (func(context.Context) error, error) {
+	provider, err := NewProvider(config, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+func newExporter(config *frames.TracingConfig) (sdktrace.SpanExporter, error) {
+	if config.Endpoint == "" {
+		return nil, errors.New("tracing requires an endpoint")
+	}
+
+	options := []otlptracehttp.Option{otlptracehttp.WithEndpoint(config.Endpoint)}
+	if config.URLPath != "" {
+		options = append(options, otlptracehttp.WithURLPath(config.URLPath))
+	}
+	if config.Insecure {
+		options = append(options, otlptracehttp.WithInsecure())
+	}
+	if len(config.Headers) > 0 {
+		options = append(options, otlptracehttp.WithHeaders(config.Headers))
+	}
+
+	// The exporter connects when spans are exported, New doesn't block
+	exporter, err := otlptracehttp.New(context.Background(), options...)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't create OTLP exporter")
+	}
+
+	return exporter, nil
+}
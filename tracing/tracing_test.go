@@ -0,0 +1,92 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/v3io/frames"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSpans(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider, err := NewProvider(&frames.TracingConfig{ServiceName: "frames-test"}, exporter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	// Parent from a W3C traceparent header
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	carrier := propagation.HeaderCarrier(http.Header{})
+	carrier.Set("traceparent", fmt.Sprintf("00-%s-00f067aa0ba902b7-01", traceID))
+	ctx := Propagator.Extract(context.Background(), carrier)
+
+	ctx, server := StartServer(ctx, "/read")
+	_, child := Start(ctx, "api.read", BackendKey.String("kv"), TableKey.String("t1"))
+	End(child, fmt.Errorf("oops"))
+	End(server, nil)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("wrong number of spans - %d", len(spans))
+	}
+
+	childSpan, serverSpan := spans[0], spans[1]
+	if serverSpan.SpanContext.TraceID().String() != traceID {
+		t.Fatalf("server span not in propagated trace - %s", serverSpan.SpanContext.TraceID())
+	}
+
+	if serverSpan.SpanKind != trace.SpanKindServer {
+		t.Fatalf("bad server span kind - %s", serverSpan.SpanKind)
+	}
+
+	if childSpan.Parent.SpanID() != serverSpan.SpanContext.SpanID() {
+		t.Fatal("api span is not a child of the server span")
+	}
+
+	if childSpan.Status.Code != codes.Error || len(childSpan.Events) != 1 {
+		t.Fatalf("error not recorded - %+v", childSpan.Status)
+	}
+
+	if len(childSpan.Attributes) != 2 || childSpan.Attributes[0] != BackendKey.String("kv") {
+		t.Fatalf("bad attributes - %v", childSpan.Attributes)
+	}
+
+	if name, _ := serverSpan.Resource.Set().Value("service.name"); name.AsString() != "frames-test" {
+		t.Fatalf("bad service name - %q", name.AsString())
+	}
+}
+
+func TestSetupWithoutEndpoint(t *testing.T) {
+	if _, err := Setup(&frames.TracingConfig{}); err == nil {
+		t.Fatal("no error for configuration without endpoint")
+	}
+}
@@ -21,6 +21,7 @@ such restriction.
 package frames
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"time"
@@ -40,6 +41,14 @@ var (
 	StringType = DType(pb.DType_STRING)
 	TimeType   = DType(pb.DType_TIME)
 	NullType   = DType(pb.DType_NULL)
+
+	// Clients get these as older types unless they set
+	// ReadRequest.ExtendedDtypes (see LegacyFrame)
+	Int32Type   = DType(pb.DType_INT32)
+	Float32Type = DType(pb.DType_FLOAT32)
+	BytesType   = DType(pb.DType_BYTES)
+	DecimalType = DType(pb.DType_DECIMAL)
+	ListType    = DType(pb.DType_LIST)
 )
 
 type SaveMode int
@@ -73,6 +82,8 @@ func (mode SaveMode) String() string {
 		return "overwriteItem"
 	case CreateNewItemsOnly:
 		return "createNewItemsOnly"
+	case AppendRows:
+		return "append"
 	default:
 		return ""
 	}
@@ -84,6 +95,15 @@ const (
 	UpdateItem
 	OverwriteItem
 	CreateNewItemsOnly
+	AppendRows // Add rows to a table without keys (e.g. CSV)
+)
+
+// Read data formats (ReadRequest.DataFormat)
+const (
+	FramesDataFormat = ""      // Length prefixed pb.Frame messages (default)
+	ArrowDataFormat  = "arrow" // Apache Arrow IPC stream
+	JSONLDataFormat  = "jsonl" // JSON object per row (HTTP only)
+	CSVDataFormat    = "csv"   // CSV rows with a header line (HTTP only)
 )
 
 // Column is a data column
@@ -91,18 +111,28 @@ type Column interface {
 	Len() int                                 // Number of elements
 	Name() string                             // Column name
 	DType() DType                             // Data type (e.g. IntType, FloatType ...)
-	Ints() ([]int64, error)                   // Data as []int64
+	Ints() ([]int64, error)                   // Data as []int64 (int32 and int64 columns)
 	IntAt(i int) (int64, error)               // Int value at index i
-	Floats() ([]float64, error)               // Data as []float64
+	Int32s() ([]int32, error)                 // Data as []int32
+	Floats() ([]float64, error)               // Data as []float64 (float32 and float64 columns)
 	FloatAt(i int) (float64, error)           // Float value at index i
+	Float32s() ([]float32, error)             // Data as []float32
 	Strings() []string                        // Data as []string
 	StringAt(i int) (string, error)           // String value at index i
 	Times() ([]time.Time, error)              // Data as []time.Time
 	TimeAt(i int) (time.Time, error)          // time.Time value at index i
 	Bools() ([]bool, error)                   // Data as []bool
 	BoolAt(i int) (bool, error)               // bool value at index i
+	Bytes() ([][]byte, error)                 // Data as [][]byte
+	BytesAt(i int) ([]byte, error)            // []byte value at index i
+	Decimals() ([]Decimal, error)             // Data as []Decimal
+	DecimalAt(i int) (Decimal, error)         // Decimal value at index i
+	Lists() ([]Column, error)                 // Data as a column per list
+	ListAt(i int) (Column, error)             // List at index i
 	Slice(start int, end int) (Column, error) // Slice of data
 	CopyWithName(newName string) Column       // Create a copy of the current column
+	IsNull(i int) bool                        // Is the value at index i null
+	NullCount() int                           // Number of null values
 }
 
 // Frame is a collection of columns
@@ -116,6 +146,7 @@ type Frame interface {
 	IterRows(includeIndex bool) RowIterator  // Iterate over rows
 	IsNull(index int, colName string) bool
 	NullValuesMap() []*pb.NullValuesMap
+	Marker() string // Read continuation marker, "" if the read can't resume after this frame
 }
 
 // RowIterator is an iterator over frame rows
@@ -135,6 +166,23 @@ type DataBackend interface {
 	Create(request *CreateRequest) error
 	Delete(request *DeleteRequest) error
 	Exec(request *ExecRequest) (Frame, error)
+	Capabilities() *Capabilities // Used to validate requests
+}
+
+// ContextDataBackend is a DataBackend whose requests stop once ctx is done
+// (e.g. client disconnected or timeout)
+type ContextDataBackend interface {
+	DataBackend
+	ReadContext(ctx context.Context, request *ReadRequest) (FrameIterator, error)
+	WriteContext(ctx context.Context, request *WriteRequest) (FrameAppender, error)
+	ExecContext(ctx context.Context, request *ExecRequest) (Frame, error)
+}
+
+// CatalogBackend is implemented by backends that can list and describe their
+// tables
+type CatalogBackend interface {
+	ListTables(request *ListTablesRequest) ([]string, error)
+	DescribeTable(request *DescribeTableRequest) (*pb.DescribeTableResponse, error)
 }
 
 // FrameIterator iterates over frames
@@ -144,6 +192,12 @@ type FrameIterator interface {
 	At() Frame
 }
 
+// MarkerIterator is a FrameIterator of a read that can be resumed
+type MarkerIterator interface {
+	FrameIterator
+	Marker() string // Marker of the last frame with one, pass in ReadRequest.Marker to resume after it
+}
+
 // FrameAppender appends frames
 type FrameAppender interface {
 	Add(frame Frame) error
@@ -156,6 +210,7 @@ type ReadRequest struct {
 	Proto    *pb.ReadRequest
 	Password SecretString
 	Token    SecretString
+	Identity *Identity
 }
 
 func (readRequest ReadRequest) ToMap() map[string]string {
@@ -227,6 +282,7 @@ type WriteRequest struct {
 	Session  *Session
 	Password SecretString
 	Token    SecretString
+	Identity *Identity
 	Backend  string // backend name
 	Table    string // Table name (path)
 	// Data message sent with the write request (in case of a stream multiple messages can follow)
@@ -266,6 +322,7 @@ type CreateRequest struct {
 	Proto    *pb.CreateRequest
 	Password SecretString
 	Token    SecretString
+	Identity *Identity
 }
 
 func (createRequest CreateRequest) ToMap() map[string]string {
@@ -296,6 +353,7 @@ type DeleteRequest struct {
 	Proto    *pb.DeleteRequest
 	Password SecretString
 	Token    SecretString
+	Identity *Identity
 }
 
 func (deleteRequest DeleteRequest) ToMap() map[string]string {
@@ -323,6 +381,7 @@ type HistoryRequest struct {
 	Proto    *pb.HistoryRequest
 	Password SecretString
 	Token    SecretString
+	Identity *Identity
 }
 
 // TableSchema is a table schema
@@ -337,6 +396,23 @@ type SchemaKey = pb.SchemaKey
 // Session information
 type Session = pb.Session
 
+// Identity is a caller identity verified by the server, requests have a nil
+// Identity when the server has no authentication
+type Identity struct {
+	User     string
+	Groups   []string
+	Provider string // Authentication provider (e.g. "apikey")
+}
+
+// Capabilities are the requests fields, exec commands and save modes a backend supports
+type Capabilities = pb.Capabilities
+
+// ExecCommand is an exec command a backend supports
+type ExecCommand = pb.ExecCommand
+
+// ExecArgument is an argument of an exec command
+type ExecArgument = pb.ExecArgument
+
 // Shortcut for fail/ignore
 const (
 	IgnoreError = pb.ErrorOptions_IGNORE
@@ -349,11 +425,28 @@ type VersionRequest struct {
 	Token    SecretString
 }
 
+// ListTablesRequest is a request to list the tables in a directory
+type ListTablesRequest struct {
+	Proto    *pb.ListTablesRequest
+	Password SecretString
+	Token    SecretString
+	Identity *Identity
+}
+
+// DescribeTableRequest is a request for a table schema
+type DescribeTableRequest struct {
+	Proto    *pb.DescribeTableRequest
+	Password SecretString
+	Token    SecretString
+	Identity *Identity
+}
+
 // ExecRequest is execution request
 type ExecRequest struct {
 	Proto    *pb.ExecRequest
 	Password SecretString
 	Token    SecretString
+	Identity *Identity
 }
 
 func (executeRequest ExecRequest) ToMap() map[string]string {
@@ -385,6 +478,9 @@ func InitSecretString(s string) SecretString {
 }
 
 func (s SecretString) Get() string {
+	if s.s == nil {
+		return ""
+	}
 	return *s.s
 }
 
@@ -400,6 +496,8 @@ func SaveModeFromString(mode string) (SaveMode, error) {
 		return OverwriteItem, nil
 	case "createNewItemsOnly":
 		return CreateNewItemsOnly, nil
+	case "append":
+		return AppendRows, nil
 	default:
 		return -1, errors.Errorf("no save mode named '%v'", mode)
 	}
@@ -21,6 +21,10 @@ such restriction.
 package v3ioutils
 
 import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/nuclio/logger"
@@ -45,6 +49,7 @@ type AsyncItemsCursor struct {
 	items              []v3io.Item
 	input              *v3io.GetItemsInput
 	container          v3io.Container
+	ctx                context.Context
 	logger             logger.Logger
 	numberOfPartitions int
 
@@ -54,6 +59,18 @@ type AsyncItemsCursor struct {
 	lastShards    int
 	Cnt           int
 	limit         int
+
+	// Position per shard/segment request, used for markers
+	streams   map[*v3io.GetItemsInput]int
+	positions []cursorPosition
+	skips     []int // Items to skip in the first response (resumed cursor)
+	stream    int   // Stream of current items
+}
+
+// cursorPosition is the position in the items of a shard/segment
+type cursorPosition struct {
+	Marker string `json:"m,omitempty"` // GetItems marker of the current response
+	Skip   int    `json:"s,omitempty"` // Items read from the current response
 }
 
 // NewAsyncItemsCursor return new AsyncItemsCursor
@@ -61,6 +78,17 @@ func NewAsyncItemsCursor(container v3io.Container, input *v3io.GetItemsInput, wo
 	logger logger.Logger, limit int, partitions []string,
 	sortKeyRangeStart string, sortKeyRangeEnd string) (*AsyncItemsCursor, error) {
 
+	return NewAsyncItemsCursorWithContext(context.Background(), container, input, workers, shardingKeys,
+		logger, limit, partitions, sortKeyRangeStart, sortKeyRangeEnd, "")
+}
+
+// NewAsyncItemsCursorWithContext return new AsyncItemsCursor that stops (and
+// doesn't request more items) once ctx is done. A cursor with a marker (see
+// Marker) resumes after the position it was taken at
+func NewAsyncItemsCursorWithContext(ctx context.Context, container v3io.Container, input *v3io.GetItemsInput, workers int,
+	shardingKeys []string, logger logger.Logger, limit int, partitions []string,
+	sortKeyRangeStart string, sortKeyRangeEnd string, marker string) (*AsyncItemsCursor, error) {
+
 	// TODO: use workers from Context.numWorkers (if no ShardingKey)
 	if workers == 0 || input.ShardingKey != "" {
 		workers = 1
@@ -68,6 +96,7 @@ func NewAsyncItemsCursor(container v3io.Container, input *v3io.GetItemsInput, wo
 
 	newAsyncItemsCursor := &AsyncItemsCursor{
 		container:          container,
+		ctx:                ctx,
 		input:              input,
 		workers:            workers,
 		logger:             logger.GetChild("AsyncItemsCursor"),
@@ -75,25 +104,21 @@ func NewAsyncItemsCursor(container v3io.Container, input *v3io.GetItemsInput, wo
 		numberOfPartitions: len(partitions),
 	}
 
+	var inputs []*v3io.GetItemsInput
 	if len(shardingKeys) > 0 {
 		newAsyncItemsCursor.workers = len(shardingKeys)
 		newAsyncItemsCursor.responseChan = make(chan *v3io.Response, len(partitions)*newAsyncItemsCursor.workers)
 
 		for _, partition := range partitions {
 			for i := 0; i < newAsyncItemsCursor.workers; i++ {
-				input := v3io.GetItemsInput{
+				inputs = append(inputs, &v3io.GetItemsInput{
 					Path:              partition,
 					AttributeNames:    input.AttributeNames,
 					Filter:            input.Filter,
 					ShardingKey:       shardingKeys[i],
 					SortKeyRangeStart: sortKeyRangeStart,
 					SortKeyRangeEnd:   sortKeyRangeEnd,
-				}
-				_, err := container.GetItems(&input, &input, newAsyncItemsCursor.responseChan)
-
-				if err != nil {
-					return nil, err
-				}
+				})
 			}
 		}
 	} else {
@@ -102,26 +127,77 @@ func NewAsyncItemsCursor(container v3io.Container, input *v3io.GetItemsInput, wo
 
 		for _, partition := range partitions {
 			for i := 0; i < newAsyncItemsCursor.workers; i++ {
-				input := v3io.GetItemsInput{
+				inputs = append(inputs, &v3io.GetItemsInput{
 					Path:           partition,
 					AttributeNames: input.AttributeNames,
 					Filter:         input.Filter,
 					TotalSegments:  newAsyncItemsCursor.totalSegments,
 					Segment:        i,
-				}
-				_, err := container.GetItems(&input, &input, newAsyncItemsCursor.responseChan)
-
-				if err != nil {
-					// TODO: proper exit, release requests which passed
-					return nil, err
-				}
+				})
 			}
 		}
 	}
 
+	// TODO: proper exit on error, release requests which passed
+	if err := newAsyncItemsCursor.start(inputs, marker); err != nil {
+		return nil, err
+	}
+
 	return newAsyncItemsCursor, nil
 }
 
+// start sends the first request of every shard/segment, from the marker
+// positions if there's one
+func (ic *AsyncItemsCursor) start(inputs []*v3io.GetItemsInput, marker string) error {
+	ic.positions = make([]cursorPosition, len(inputs))
+	if marker != "" {
+		data, err := base64.RawURLEncoding.DecodeString(marker)
+		if err != nil {
+			return errors.Wrap(err, "bad marker")
+		}
+
+		var positions []cursorPosition
+		if err := json.Unmarshal(data, &positions); err != nil {
+			return errors.Wrap(err, "bad marker")
+		}
+
+		if len(positions) != len(inputs) {
+			return fmt.Errorf("marker doesn't match the request (%d shards != %d)", len(positions), len(inputs))
+		}
+		ic.positions = positions
+	}
+
+	ic.streams = make(map[*v3io.GetItemsInput]int, len(inputs))
+	ic.skips = make([]int, len(inputs))
+	for i, input := range inputs {
+		ic.streams[input] = i
+		ic.skips[i] = ic.positions[i].Skip
+		input.Marker = ic.positions[i].Marker
+		if _, err := ic.container.GetItems(input, input, ic.responseChan); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Marker returns a marker of the current position, a cursor created with it
+// (and the same request) resumes after the last item returned
+func (ic *AsyncItemsCursor) Marker() (string, error) {
+	data, err := json.Marshal(ic.positions)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// Stream returns the stream (shard or segment request) of the current item,
+// the items of a stream are returned in order
+func (ic *AsyncItemsCursor) Stream() int {
+	return ic.stream
+}
+
 // Err returns the last error
 func (ic *AsyncItemsCursor) Err() error {
 	return ic.currentError
@@ -160,6 +236,7 @@ func (ic *AsyncItemsCursor) NextItem() (v3io.Item, error) {
 		// next time we'll give next item
 		ic.itemIndex++
 		ic.Cnt++
+		ic.positions[ic.stream].Skip = ic.itemIndex
 
 		return ic.currentItem, nil
 	}
@@ -170,8 +247,18 @@ func (ic *AsyncItemsCursor) NextItem() (v3io.Item, error) {
 		return nil, nil
 	}
 
+	if err := ic.ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	// Read response from channel
-	resp := <-ic.responseChan
+	var resp *v3io.Response
+	select {
+	case resp = <-ic.responseChan:
+	case <-ic.ctx.Done():
+		// Responses of requests in flight are left in the (buffered) channel
+		return nil, ic.ctx.Err()
+	}
 	resp.Release()
 
 	// Ignore 404s
@@ -186,15 +273,26 @@ func (ic *AsyncItemsCursor) NextItem() (v3io.Item, error) {
 	}
 
 	getItemsResp := resp.Output.(*v3io.GetItemsOutput)
+	input := resp.Context.(*v3io.GetItemsInput)
 
-	// set the cursor items and reset the item index
+	// set the cursor items and reset the item index, items returned before
+	// the marker of a resumed cursor are skipped
+	ic.stream = ic.streams[input]
+	ic.positions[ic.stream] = cursorPosition{Marker: input.Marker}
 	ic.items = getItemsResp.Items
 	ic.itemIndex = 0
+	if skip := ic.skips[ic.stream]; skip > 0 {
+		if skip > len(ic.items) {
+			skip = len(ic.items)
+		}
+		ic.itemIndex = skip
+		ic.positions[ic.stream].Skip = skip
+		ic.skips[ic.stream] = 0
+	}
 
 	if !getItemsResp.Last {
 
 		// if not last, make a new request to that shard
-		input := resp.Context.(*v3io.GetItemsInput)
 
 		// set next marker
 		input.Marker = getItemsResp.NextMarker
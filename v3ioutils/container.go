@@ -23,6 +23,7 @@ package v3ioutils
 import (
 	"encoding/binary"
 	"net/http"
+	"path"
 	"strings"
 
 	"github.com/nuclio/logger"
@@ -171,6 +172,67 @@ func DeleteTable(logger logger.Logger, container v3io.Container, path, filter st
 	return nil
 }
 
+// ListDirectories returns the paths (with a trailing slash) of the
+// directories in dirPath
+func ListDirectories(container v3io.Container, dirPath string) ([]string, error) {
+	input := &v3io.GetContainerContentsInput{Path: dirPath, DirectoriesOnly: true}
+	var dirs []string
+	for {
+		resp, err := container.GetContainerContentsSync(input)
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't list '%s'", dirPath)
+		}
+
+		output := resp.Output.(*v3io.GetContainerContentsOutput)
+		for _, prefix := range output.CommonPrefixes {
+			dirs = append(dirs, prefix.Prefix)
+		}
+		resp.Release()
+
+		if !output.IsTruncated || output.NextMarker == "" {
+			return dirs, nil
+		}
+		input.Marker = output.NextMarker
+	}
+}
+
+// ListTables returns the directories in dirPath that isTable accepts. Table
+// names are joined to prefix, the directory as the user passed it
+func ListTables(container v3io.Container, dirPath string, prefix string, isTable func(dir string) (bool, error)) ([]string, error) {
+	dirs, err := ListDirectories(container, dirPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []string
+	for _, dir := range dirs {
+		ok, err := isTable(dir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't check '%s'", dir)
+		}
+
+		if ok {
+			tables = append(tables, path.Join(prefix, path.Base(dir)))
+		}
+	}
+
+	return tables, nil
+}
+
+// PathExists returns true if there's an object or directory at path
+func PathExists(container v3io.Container, objectPath string) (bool, error) {
+	err := container.CheckPathExistsSync(&v3io.CheckPathExistsInput{Path: objectPath})
+	if err == nil {
+		return true, nil
+	}
+
+	if errorWithStatusCode, ok := err.(v3ioerrors.ErrorWithStatusCode); ok && errorWithStatusCode.StatusCode() == http.StatusNotFound {
+		return false, nil
+	}
+
+	return false, err
+}
+
 func getItemsWorker(container v3io.Container, input *v3io.GetItemsInput, fileNameChan chan<- string, terminationChan chan<- error, onErrorTerminationChannel <-chan struct{}) {
 	for {
 		select {
@@ -0,0 +1,1099 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package v3ioutils
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/v3io/frames/backends/filter"
+	v3io "github.com/v3io/v3io-go/pkg/dataplane"
+	v3ioerrors "github.com/v3io/v3io-go/pkg/errors"
+	"github.com/valyala/fasthttp"
+)
+
+const (
+	// FakeMemoryRoot is the fake container root for containers kept in memory
+	FakeMemoryRoot = ":memory:"
+
+	fakeDefaultGetItemsLimit = 1000
+	fakeDefaultListLimit     = 1000
+	fakeStreamShardCount     = "__fake_shard_count"
+	fakeStreamRetention      = "__fake_retention_hours"
+
+	// Error codes the KV backend checks for
+	fakeFalseConditionError = `{"ErrorCode": -16777244, "ErrorMessage": "Condition evaluated to false"}`
+	fakeItemExistsError     = `{"ErrorCode": -369098809, "ErrorMessage": "Item already exists (ErrorCode -369098809)"}`
+)
+
+var fakeRequestID uint64
+
+// FakeContext is a v3io.Context whose containers are local fakes, kept either
+// in memory or under a root directory (one sub directory per container)
+type FakeContext struct {
+	*FakeContainer // Context doubles as a container, same as in v3io-go
+
+	root       string
+	lock       sync.Mutex
+	containers map[string]*FakeContainer
+}
+
+// NewFakeContext returns a new fake context, root is a directory or FakeMemoryRoot
+func NewFakeContext(root string) (*FakeContext, error) {
+	if root == "" {
+		return nil, fmt.Errorf("empty fake container root")
+	}
+
+	ctx := &FakeContext{
+		root:       root,
+		containers: make(map[string]*FakeContainer),
+	}
+
+	container, err := ctx.container("")
+	if err != nil {
+		return nil, err
+	}
+	ctx.FakeContainer = container
+
+	return ctx, nil
+}
+
+// NewSession returns a new session, credentials are ignored
+func (c *FakeContext) NewSession(*v3io.NewSessionInput) (v3io.Session, error) {
+	return &fakeSession{context: c}, nil
+}
+
+// Container returns the fake container with the given name
+func (c *FakeContext) Container(name string) (*FakeContainer, error) {
+	return c.container(name)
+}
+
+func (c *FakeContext) container(name string) (*FakeContainer, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if container, ok := c.containers[name]; ok {
+		return container, nil
+	}
+
+	var store fakeStore
+	if c.root == FakeMemoryRoot {
+		store = newMemoryFakeStore()
+	} else {
+		dirStore, err := newDirFakeStore(filepath.Join(c.root, name))
+		if err != nil {
+			return nil, err
+		}
+		store = dirStore
+	}
+
+	container := &FakeContainer{name: name, store: store, NumVNs: 1}
+	c.containers[name] = container
+	return container, nil
+}
+
+type fakeSession struct {
+	context *FakeContext
+}
+
+func (s *fakeSession) NewContainer(input *v3io.NewContainerInput) (v3io.Container, error) {
+	return s.context.container(input.ContainerName)
+}
+
+// FakeContainer is a v3io.Container implementing the objects, KV and stream
+// calls used by the backends on top of a local store
+type FakeContainer struct {
+	// Number of VNs reported by GetClusterMD
+	NumVNs int
+
+	name  string
+	lock  sync.Mutex
+	store fakeStore
+}
+
+func fakeError(statusCode int, format string, args ...interface{}) error {
+	return v3ioerrors.NewErrorWithStatusCode(fmt.Errorf(format, args...), statusCode)
+}
+
+func fakeNotFound(p string) error {
+	return fakeError(http.StatusNotFound, "%q not found", p)
+}
+
+// respond sends the result of a synchronous call to an async caller
+func (c *FakeContainer) respond(input interface{}, context interface{}, responseChan chan *v3io.Response, response *v3io.Response, err error) (*v3io.Request, error) {
+	if response == nil {
+		response = c.newResponse(input, nil, nil)
+	}
+	response.Context = context
+	response.Error = err
+	response.RequestResponse.Request.Context = context
+	response.RequestResponse.Request.ResponseChan = responseChan
+
+	// Same as v3io-go, responses are delivered asynchronously
+	go func() { responseChan <- response }()
+	return &response.RequestResponse.Request, nil
+}
+
+func (c *FakeContainer) newResponse(input interface{}, output interface{}, body []byte) *v3io.Response {
+	id := atomic.AddUint64(&fakeRequestID, 1)
+	requestResponse := &v3io.RequestResponse{
+		Request: v3io.Request{
+			ID:                  id,
+			Input:               input,
+			SendTimeNanoseconds: time.Now().UnixNano(),
+		},
+	}
+
+	response := &requestResponse.Response
+	response.ID = id
+	response.Output = output
+	response.RequestResponse = requestResponse
+	response.HTTPResponse = fasthttp.AcquireResponse()
+	response.HTTPResponse.SetBody(body)
+	return response
+}
+
+// GetClusterMD returns the cluster metadata
+func (c *FakeContainer) GetClusterMD(input *v3io.GetClusterMDInput, context interface{}, responseChan chan *v3io.Response) (*v3io.Request, error) {
+	resp, err := c.GetClusterMDSync(input)
+	return c.respond(input, context, responseChan, resp, err)
+}
+
+// GetClusterMDSync returns the cluster metadata
+func (c *FakeContainer) GetClusterMDSync(input *v3io.GetClusterMDInput) (*v3io.Response, error) {
+	return c.newResponse(input, &v3io.GetClusterMDOutput{NumberOfVNs: c.NumVNs}, nil), nil
+}
+
+// GetContainers returns the containers
+func (c *FakeContainer) GetContainers(input *v3io.GetContainersInput, context interface{}, responseChan chan *v3io.Response) (*v3io.Request, error) {
+	resp, err := c.GetContainersSync(input)
+	return c.respond(input, context, responseChan, resp, err)
+}
+
+// GetContainersSync returns the containers, only the current one is reported
+func (c *FakeContainer) GetContainersSync(input *v3io.GetContainersInput) (*v3io.Response, error) {
+	output := &v3io.GetContainersOutput{}
+	output.Results.Containers = []v3io.ContainerInfo{{Name: c.name}}
+	return c.newResponse(input, output, nil), nil
+}
+
+// GetContainerContents lists a directory
+func (c *FakeContainer) GetContainerContents(input *v3io.GetContainerContentsInput, context interface{}, responseChan chan *v3io.Response) (*v3io.Request, error) {
+	resp, err := c.GetContainerContentsSync(input)
+	return c.respond(input, context, responseChan, resp, err)
+}
+
+// GetContainerContentsSync lists a directory
+func (c *FakeContainer) GetContainerContentsSync(input *v3io.GetContainerContentsInput) (*v3io.Response, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	dir := cleanPath(input.Path)
+	obj, err := c.store.load(dir)
+	if err != nil {
+		return nil, err
+	}
+	if obj == nil || !obj.IsDir {
+		return nil, fakeNotFound(input.Path)
+	}
+
+	entries, err := c.store.list(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = fakeDefaultListLimit
+	}
+
+	output := &v3io.GetContainerContentsOutput{Name: c.name}
+	count := 0
+	for _, entry := range entries {
+		if input.Marker != "" && entry.name <= input.Marker {
+			continue
+		}
+		if entry.obj.IsDir {
+			if count == limit {
+				output.IsTruncated = true
+				break
+			}
+			output.CommonPrefixes = append(output.CommonPrefixes, v3io.CommonPrefix{
+				Prefix:       path.Join(dir, entry.name) + "/",
+				LastModified: entry.obj.Mtime.UTC().Format(time.RFC3339),
+			})
+		} else {
+			if input.DirectoriesOnly {
+				continue
+			}
+			if count == limit {
+				output.IsTruncated = true
+				break
+			}
+			content := v3io.Content{Key: path.Join(dir, entry.name)}
+			if input.GetAllAttributes {
+				size := len(entry.obj.Body)
+				content.Size = &size
+				content.LastModified = entry.obj.Mtime.UTC().Format(time.RFC3339)
+			}
+			output.Contents = append(output.Contents, content)
+		}
+		output.NextMarker = entry.name
+		count++
+	}
+
+	if !output.IsTruncated {
+		output.NextMarker = ""
+	}
+
+	return c.newResponse(input, output, nil), nil
+}
+
+// CheckPathExists checks if a path exists
+func (c *FakeContainer) CheckPathExists(input *v3io.CheckPathExistsInput, context interface{}, responseChan chan *v3io.Response) (*v3io.Request, error) {
+	err := c.CheckPathExistsSync(input)
+	return c.respond(input, context, responseChan, nil, err)
+}
+
+// CheckPathExistsSync checks if a path exists
+func (c *FakeContainer) CheckPathExistsSync(input *v3io.CheckPathExistsInput) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	obj, err := c.store.load(cleanPath(input.Path))
+	if err != nil {
+		return err
+	}
+	if obj == nil {
+		return fakeNotFound(input.Path)
+	}
+	return nil
+}
+
+// GetObject reads an object
+func (c *FakeContainer) GetObject(input *v3io.GetObjectInput, context interface{}, responseChan chan *v3io.Response) (*v3io.Request, error) {
+	resp, err := c.GetObjectSync(input)
+	return c.respond(input, context, responseChan, resp, err)
+}
+
+// GetObjectSync reads an object, a partial read returns 206
+func (c *FakeContainer) GetObjectSync(input *v3io.GetObjectInput) (*v3io.Response, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	obj, err := c.store.load(cleanPath(input.Path))
+	if err != nil {
+		return nil, err
+	}
+	if obj == nil || obj.IsDir {
+		return nil, fakeNotFound(input.Path)
+	}
+
+	body := obj.Body
+	start := input.Offset
+	if start > len(body) {
+		start = len(body)
+	}
+	end := len(body)
+	if input.NumBytes > 0 && start+input.NumBytes < end {
+		end = start + input.NumBytes
+	}
+
+	resp := c.newResponse(input, nil, body[start:end])
+	if end < len(body) {
+		resp.HTTPResponse.SetStatusCode(http.StatusPartialContent)
+	}
+	return resp, nil
+}
+
+// PutObject writes an object
+func (c *FakeContainer) PutObject(input *v3io.PutObjectInput, context interface{}, responseChan chan *v3io.Response) (*v3io.Request, error) {
+	err := c.PutObjectSync(input)
+	return c.respond(input, context, responseChan, nil, err)
+}
+
+// PutObjectSync writes an object, or appends to it
+func (c *FakeContainer) PutObjectSync(input *v3io.PutObjectInput) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	p := cleanPath(input.Path)
+	obj, err := c.loadObject(p)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case input.Append:
+		obj.Body = append(obj.Body, input.Body...)
+	case input.Offset > 0:
+		if input.Offset > len(obj.Body) {
+			obj.Body = append(obj.Body, make([]byte, input.Offset-len(obj.Body))...)
+		}
+		end := input.Offset + len(input.Body)
+		if end > len(obj.Body) {
+			obj.Body = append(obj.Body, make([]byte, end-len(obj.Body))...)
+		}
+		copy(obj.Body[input.Offset:], input.Body)
+	default:
+		obj.Body = append([]byte(nil), input.Body...)
+	}
+
+	obj.Mtime = time.Now()
+	return c.store.store(p, obj)
+}
+
+// UpdateObjectSync updates directory attributes, only path existence is checked
+func (c *FakeContainer) UpdateObjectSync(input *v3io.UpdateObjectInput) error {
+	return c.CheckPathExistsSync(&v3io.CheckPathExistsInput{Path: input.Path})
+}
+
+// DeleteObject deletes an object
+func (c *FakeContainer) DeleteObject(input *v3io.DeleteObjectInput, context interface{}, responseChan chan *v3io.Response) (*v3io.Request, error) {
+	err := c.DeleteObjectSync(input)
+	return c.respond(input, context, responseChan, nil, err)
+}
+
+// DeleteObjectSync deletes an object or an empty directory
+func (c *FakeContainer) DeleteObjectSync(input *v3io.DeleteObjectInput) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	p := cleanPath(input.Path)
+	obj, err := c.store.load(p)
+	if err != nil {
+		return err
+	}
+	if obj == nil || p == "" {
+		return fakeNotFound(input.Path)
+	}
+
+	if obj.IsDir {
+		entries, err := c.store.list(p)
+		if err != nil {
+			return err
+		}
+		if len(entries) > 0 {
+			return fakeError(http.StatusConflict, "directory %q is not empty", input.Path)
+		}
+	}
+
+	return c.store.remove(p)
+}
+
+// GetItem reads an item
+func (c *FakeContainer) GetItem(input *v3io.GetItemInput, context interface{}, responseChan chan *v3io.Response) (*v3io.Request, error) {
+	resp, err := c.GetItemSync(input)
+	return c.respond(input, context, responseChan, resp, err)
+}
+
+// GetItemSync reads an item
+func (c *FakeContainer) GetItemSync(input *v3io.GetItemInput) (*v3io.Response, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	p := cleanPath(input.Path)
+	obj, err := c.store.load(p)
+	if err != nil {
+		return nil, err
+	}
+	if obj == nil || obj.IsDir {
+		return nil, fakeNotFound(input.Path)
+	}
+
+	item := fakeItem(path.Base(p), obj, input.AttributeNames)
+	return c.newResponse(input, &v3io.GetItemOutput{Item: item}, nil), nil
+}
+
+// GetItems reads items from a directory
+func (c *FakeContainer) GetItems(input *v3io.GetItemsInput, context interface{}, responseChan chan *v3io.Response) (*v3io.Request, error) {
+	resp, err := c.GetItemsSync(input)
+	return c.respond(input, context, responseChan, resp, err)
+}
+
+// GetItemsSync reads items from a directory. Supports filter, segments,
+// sharding key with sort key ranges, limit and marker
+func (c *FakeContainer) GetItemsSync(input *v3io.GetItemsInput) (*v3io.Response, error) {
+	var expr filter.Expr
+	if strings.TrimSpace(input.Filter) != "" {
+		var err error
+		expr, err = filter.Parse(input.Filter)
+		if err != nil {
+			return nil, fakeError(http.StatusBadRequest, "bad filter %q - %s", input.Filter, err)
+		}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	dir := cleanPath(path.Join(input.Path, input.TableName))
+	obj, err := c.store.load(dir)
+	if err != nil {
+		return nil, err
+	}
+	if obj == nil || !obj.IsDir {
+		return nil, fakeNotFound(input.Path)
+	}
+
+	entries, err := c.store.list(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = fakeDefaultGetItemsLimit
+	}
+
+	output := &v3io.GetItemsOutput{Last: true}
+	for _, entry := range entries {
+		if entry.obj.IsDir || (input.Marker != "" && entry.name <= input.Marker) {
+			continue
+		}
+
+		if !fakeInSegment(entry.name, input.Segment, input.TotalSegments) {
+			continue
+		}
+
+		if input.ShardingKey != "" && !fakeInSortKeyRange(entry.name, input) {
+			continue
+		}
+
+		if expr != nil {
+			match, err := filter.Match(expr, filter.MapRow(fakeItem(entry.name, entry.obj, []string{"**"})))
+			if err != nil {
+				return nil, fakeError(http.StatusBadRequest, "can't evaluate filter %q - %s", input.Filter, err)
+			}
+			if !match {
+				continue
+			}
+		}
+
+		if len(output.Items) == limit {
+			output.Last = false
+			break
+		}
+
+		output.Items = append(output.Items, fakeItem(entry.name, entry.obj, input.AttributeNames))
+		output.NextMarker = entry.name
+	}
+
+	if output.Last {
+		output.NextMarker = ""
+	}
+
+	return c.newResponse(input, output, nil), nil
+}
+
+// PutItem writes an item, replacing all its attributes
+func (c *FakeContainer) PutItem(input *v3io.PutItemInput, context interface{}, responseChan chan *v3io.Response) (*v3io.Request, error) {
+	resp, err := c.PutItemSync(input)
+	return c.respond(input, context, responseChan, resp, err)
+}
+
+// PutItemSync writes an item, replacing all its attributes
+func (c *FakeContainer) PutItemSync(input *v3io.PutItemInput) (*v3io.Response, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.putItem(input.Path, input.Condition, input.Attributes); err != nil {
+		return nil, err
+	}
+
+	return c.newResponse(input, &v3io.PutItemOutput{}, nil), nil
+}
+
+// PutItems writes several items
+func (c *FakeContainer) PutItems(input *v3io.PutItemsInput, context interface{}, responseChan chan *v3io.Response) (*v3io.Request, error) {
+	resp, err := c.PutItemsSync(input)
+	return c.respond(input, context, responseChan, resp, err)
+}
+
+// PutItemsSync writes several items, errors are reported per item
+func (c *FakeContainer) PutItemsSync(input *v3io.PutItemsInput) (*v3io.Response, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	output := &v3io.PutItemsOutput{Success: true, Errors: make(map[string]error)}
+	for key, attrs := range input.Items {
+		if err := c.putItem(path.Join(input.Path, key), input.Condition, attrs); err != nil {
+			output.Success = false
+			output.Errors[key] = err
+		}
+	}
+
+	return c.newResponse(input, output, nil), nil
+}
+
+func (c *FakeContainer) putItem(itemPath string, condition string, attrs map[string]interface{}) error {
+	p := cleanPath(itemPath)
+	obj, err := c.loadObject(p)
+	if err != nil {
+		return err
+	}
+
+	if err := c.checkCondition(path.Base(p), obj, condition); err != nil {
+		return err
+	}
+
+	obj.Attrs = make(map[string]interface{}, len(attrs))
+	if err := setFakeAttrs(obj.Attrs, attrs); err != nil {
+		return err
+	}
+
+	obj.Mtime = time.Now()
+	return c.store.store(p, obj)
+}
+
+// UpdateItem updates an item
+func (c *FakeContainer) UpdateItem(input *v3io.UpdateItemInput, context interface{}, responseChan chan *v3io.Response) (*v3io.Request, error) {
+	resp, err := c.UpdateItemSync(input)
+	return c.respond(input, context, responseChan, resp, err)
+}
+
+// UpdateItemSync updates an item from attributes and/or an update expression
+func (c *FakeContainer) UpdateItemSync(input *v3io.UpdateItemInput) (*v3io.Response, error) {
+	var assignments []*filter.Assignment
+	if input.Expression != nil && strings.TrimSpace(*input.Expression) != "" {
+		var err error
+		assignments, err = filter.ParseUpdate(*input.Expression)
+		if err != nil {
+			return nil, fakeError(http.StatusBadRequest, "bad expression %q - %s", *input.Expression, err)
+		}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	p := cleanPath(input.Path)
+	current, err := c.store.load(p)
+	if err != nil {
+		return nil, err
+	}
+	if current != nil && current.IsDir {
+		return nil, fakeError(http.StatusBadRequest, "%q is a directory", input.Path)
+	}
+
+	if current != nil && input.UpdateMode == "CreateNewItemOnly" {
+		return nil, fakeError(http.StatusBadRequest, "%s", fakeItemExistsError)
+	}
+
+	obj, err := c.loadObject(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.checkCondition(path.Base(p), obj, input.Condition); err != nil {
+		return nil, err
+	}
+
+	if err := setFakeAttrs(obj.Attrs, input.Attributes); err != nil {
+		return nil, err
+	}
+
+	// Expressions see the current attributes, overwrite keeps only the ones set
+	updated := copyObject(obj).Attrs
+	if err := filter.Update(assignments, updated); err != nil {
+		return nil, fakeError(http.StatusBadRequest, "can't apply expression - %s", err)
+	}
+	// Expressions evaluate to int64, the store keeps int (as v3io-go returns)
+	if err := setFakeAttrs(updated, updated); err != nil {
+		return nil, err
+	}
+
+	if input.UpdateMode == "OverWriteAttributes" {
+		obj.Attrs = make(map[string]interface{})
+		if err := setFakeAttrs(obj.Attrs, input.Attributes); err != nil {
+			return nil, err
+		}
+		for _, assignment := range assignments {
+			name := assignment.Name
+			if val, ok := updated[name]; ok {
+				obj.Attrs[name] = val
+			} else {
+				delete(obj.Attrs, name)
+			}
+		}
+	} else {
+		obj.Attrs = updated
+	}
+
+	obj.Mtime = time.Now()
+	if err := c.store.store(p, obj); err != nil {
+		return nil, err
+	}
+
+	return c.newResponse(input, &v3io.UpdateItemOutput{}, nil), nil
+}
+
+// checkCondition evaluates a condition, an empty condition is always true
+func (c *FakeContainer) checkCondition(name string, obj *fakeObject, condition string) error {
+	if strings.TrimSpace(condition) == "" {
+		return nil
+	}
+
+	expr, err := filter.Parse(condition)
+	if err != nil {
+		return fakeError(http.StatusBadRequest, "bad condition %q - %s", condition, err)
+	}
+
+	match, err := filter.Match(expr, filter.MapRow(fakeItem(name, obj, []string{"**"})))
+	if err != nil {
+		return fakeError(http.StatusBadRequest, "bad condition %q - %s", condition, err)
+	}
+
+	if !match {
+		return fakeError(http.StatusBadRequest, "%s", fakeFalseConditionError)
+	}
+
+	return nil
+}
+
+// loadObject loads an object, returning a new empty object if it doesn't exist
+func (c *FakeContainer) loadObject(p string) (*fakeObject, error) {
+	if p == "" {
+		return nil, fakeError(http.StatusBadRequest, "empty object path")
+	}
+
+	obj, err := c.store.load(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if obj == nil {
+		return &fakeObject{Attrs: make(map[string]interface{})}, nil
+	}
+
+	if obj.IsDir {
+		return nil, fakeError(http.StatusBadRequest, "%q is a directory", p)
+	}
+
+	return obj, nil
+}
+
+// CreateStream creates a stream
+func (c *FakeContainer) CreateStream(input *v3io.CreateStreamInput, context interface{}, responseChan chan *v3io.Response) (*v3io.Request, error) {
+	err := c.CreateStreamSync(input)
+	return c.respond(input, context, responseChan, nil, err)
+}
+
+// CreateStreamSync creates a stream, a directory with one object per shard
+func (c *FakeContainer) CreateStreamSync(input *v3io.CreateStreamInput) error {
+	if input.ShardCount <= 0 {
+		return fakeError(http.StatusBadRequest, "bad shard count - %d", input.ShardCount)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	p := cleanPath(input.Path)
+	obj, err := c.store.load(p)
+	if err != nil {
+		return err
+	}
+	if obj != nil {
+		return fakeError(http.StatusConflict, "%q already exists", input.Path)
+	}
+
+	now := time.Now()
+	dir := &fakeObject{
+		IsDir: true,
+		Mtime: now,
+		Attrs: map[string]interface{}{
+			fakeStreamShardCount: input.ShardCount,
+			fakeStreamRetention:  input.RetentionPeriodHours,
+		},
+	}
+	if err := c.store.store(p, dir); err != nil {
+		return err
+	}
+
+	for shard := 0; shard < input.ShardCount; shard++ {
+		if err := c.store.store(path.Join(p, strconv.Itoa(shard)), &fakeObject{Mtime: now}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DescribeStream returns stream information
+func (c *FakeContainer) DescribeStream(input *v3io.DescribeStreamInput, context interface{}, responseChan chan *v3io.Response) (*v3io.Request, error) {
+	resp, err := c.DescribeStreamSync(input)
+	return c.respond(input, context, responseChan, resp, err)
+}
+
+// DescribeStreamSync returns stream information
+func (c *FakeContainer) DescribeStreamSync(input *v3io.DescribeStreamInput) (*v3io.Response, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	shardCount, retention, err := c.streamInfo(cleanPath(input.Path))
+	if err != nil {
+		return nil, err
+	}
+
+	output := &v3io.DescribeStreamOutput{ShardCount: shardCount, RetentionPeriodHours: retention}
+	return c.newResponse(input, output, nil), nil
+}
+
+func (c *FakeContainer) streamInfo(p string) (int, int, error) {
+	obj, err := c.store.load(p)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if obj == nil || !obj.IsDir {
+		return 0, 0, fakeNotFound(p)
+	}
+
+	shardCount, ok := obj.Attrs[fakeStreamShardCount].(int)
+	if !ok {
+		return 0, 0, fakeError(http.StatusBadRequest, "%q is not a stream", p)
+	}
+
+	retention, _ := obj.Attrs[fakeStreamRetention].(int)
+	return shardCount, retention, nil
+}
+
+// DeleteStream deletes a stream
+func (c *FakeContainer) DeleteStream(input *v3io.DeleteStreamInput, context interface{}, responseChan chan *v3io.Response) (*v3io.Request, error) {
+	err := c.DeleteStreamSync(input)
+	return c.respond(input, context, responseChan, nil, err)
+}
+
+// DeleteStreamSync deletes a stream
+func (c *FakeContainer) DeleteStreamSync(input *v3io.DeleteStreamInput) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	p := cleanPath(input.Path)
+	if _, _, err := c.streamInfo(p); err != nil {
+		return err
+	}
+
+	return c.store.remove(p)
+}
+
+// PutRecords adds records to a stream
+func (c *FakeContainer) PutRecords(input *v3io.PutRecordsInput, context interface{}, responseChan chan *v3io.Response) (*v3io.Request, error) {
+	resp, err := c.PutRecordsSync(input)
+	return c.respond(input, context, responseChan, resp, err)
+}
+
+// PutRecordsSync adds records to a stream. Records without a shard ID go to
+// a shard picked by their partition key, or round robin
+func (c *FakeContainer) PutRecordsSync(input *v3io.PutRecordsInput) (*v3io.Response, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	p := cleanPath(input.Path)
+	shardCount, _, err := c.streamInfo(p)
+	if err != nil {
+		return nil, err
+	}
+
+	shards := make(map[int]*fakeObject)
+	output := &v3io.PutRecordsOutput{}
+	now := time.Now()
+	for i, record := range input.Records {
+		var shardID int
+		switch {
+		case record.ShardID != nil:
+			shardID = *record.ShardID
+		case record.PartitionKey != "":
+			hash := fnv.New32a()
+			hash.Write([]byte(record.PartitionKey))
+			shardID = int(hash.Sum32() % uint32(shardCount))
+		default:
+			shardID = i % shardCount
+		}
+
+		result := v3io.PutRecordResult{ShardID: shardID}
+		if shardID < 0 || shardID >= shardCount {
+			result.ErrorCode = http.StatusBadRequest
+			result.ErrorMessage = fmt.Sprintf("bad shard ID - %d", shardID)
+			output.FailedRecordCount++
+			output.Records = append(output.Records, result)
+			continue
+		}
+
+		shard, ok := shards[shardID]
+		if !ok {
+			shard, err = c.loadObject(path.Join(p, strconv.Itoa(shardID)))
+			if err != nil {
+				return nil, err
+			}
+			shards[shardID] = shard
+		}
+
+		result.SequenceNumber = uint64(len(shard.Records) + 1)
+		shard.Records = append(shard.Records, &fakeRecord{
+			SequenceNumber: result.SequenceNumber,
+			Data:           record.Data,
+			ClientInfo:     record.ClientInfo,
+			PartitionKey:   record.PartitionKey,
+			ArrivalTime:    now,
+		})
+		shard.Mtime = now
+		output.Records = append(output.Records, result)
+	}
+
+	for shardID, shard := range shards {
+		if err := c.store.store(path.Join(p, strconv.Itoa(shardID)), shard); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.newResponse(input, output, nil), nil
+}
+
+// SeekShard returns a location in a shard
+func (c *FakeContainer) SeekShard(input *v3io.SeekShardInput, context interface{}, responseChan chan *v3io.Response) (*v3io.Request, error) {
+	resp, err := c.SeekShardSync(input)
+	return c.respond(input, context, responseChan, resp, err)
+}
+
+// SeekShardSync returns a location in a shard, locations are sequence numbers
+func (c *FakeContainer) SeekShardSync(input *v3io.SeekShardInput) (*v3io.Response, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	shard, err := c.loadShard(input.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	next := uint64(len(shard.Records) + 1)
+	var location uint64
+	switch input.Type {
+	case v3io.SeekShardInputTypeEarliest:
+		location = 1
+	case v3io.SeekShardInputTypeLatest:
+		location = next
+	case v3io.SeekShardInputTypeSequence:
+		location = input.StartingSequenceNumber
+	case v3io.SeekShardInputTypeTime:
+		location = next
+		for _, record := range shard.Records {
+			if record.ArrivalTime.Unix() >= int64(input.Timestamp) {
+				location = record.SequenceNumber
+				break
+			}
+		}
+	default:
+		return nil, fakeError(http.StatusBadRequest, "unknown seek type - %d", input.Type)
+	}
+
+	output := &v3io.SeekShardOutput{Location: strconv.FormatUint(location, 10)}
+	return c.newResponse(input, output, nil), nil
+}
+
+// GetRecords reads records from a shard
+func (c *FakeContainer) GetRecords(input *v3io.GetRecordsInput, context interface{}, responseChan chan *v3io.Response) (*v3io.Request, error) {
+	resp, err := c.GetRecordsSync(input)
+	return c.respond(input, context, responseChan, resp, err)
+}
+
+// GetRecordsSync reads records from a shard, starting at a location returned
+// by SeekShard or by a previous GetRecords
+func (c *FakeContainer) GetRecordsSync(input *v3io.GetRecordsInput) (*v3io.Response, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	shard, err := c.loadShard(input.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	location, err := strconv.ParseUint(input.Location, 10, 64)
+	if err != nil || location == 0 {
+		return nil, fakeError(http.StatusBadRequest, "bad location - %q", input.Location)
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = fakeDefaultGetItemsLimit
+	}
+
+	output := &v3io.GetRecordsOutput{}
+	next := location
+	for _, record := range shard.Records {
+		if record.SequenceNumber < location {
+			continue
+		}
+		if len(output.Records) == limit {
+			break
+		}
+		output.Records = append(output.Records, v3io.GetRecordsResult{
+			ArrivalTimeSec:  int(record.ArrivalTime.Unix()),
+			ArrivalTimeNSec: record.ArrivalTime.Nanosecond(),
+			SequenceNumber:  record.SequenceNumber,
+			ClientInfo:      record.ClientInfo,
+			PartitionKey:    record.PartitionKey,
+			Data:            record.Data,
+		})
+		next = record.SequenceNumber + 1
+	}
+
+	output.NextLocation = strconv.FormatUint(next, 10)
+	if last := uint64(len(shard.Records)); last >= next {
+		output.RecordsBehindLatest = int(last - next + 1)
+	}
+
+	return c.newResponse(input, output, nil), nil
+}
+
+func (c *FakeContainer) loadShard(shardPath string) (*fakeObject, error) {
+	p := cleanPath(shardPath)
+	if _, _, err := c.streamInfo(parentPath(p)); err != nil {
+		return nil, err
+	}
+
+	shard, err := c.store.load(p)
+	if err != nil {
+		return nil, err
+	}
+	if shard == nil || shard.IsDir {
+		return nil, fakeNotFound(shardPath)
+	}
+
+	return shard, nil
+}
+
+// PutChunk is not supported
+func (c *FakeContainer) PutChunk(input *v3io.PutChunkInput, context interface{}, responseChan chan *v3io.Response) (*v3io.Request, error) {
+	return nil, errors.New("PutChunk is not supported by the fake container")
+}
+
+// PutChunkSync is not supported
+func (c *FakeContainer) PutChunkSync(input *v3io.PutChunkInput) error {
+	return errors.New("PutChunk is not supported by the fake container")
+}
+
+// PutOOSObject is not supported
+func (c *FakeContainer) PutOOSObject(input *v3io.PutOOSObjectInput, context interface{}, responseChan chan *v3io.Response) (*v3io.Request, error) {
+	return nil, errors.New("PutOOSObject is not supported by the fake container")
+}
+
+// PutOOSObjectSync is not supported
+func (c *FakeContainer) PutOOSObjectSync(input *v3io.PutOOSObjectInput) error {
+	return errors.New("PutOOSObject is not supported by the fake container")
+}
+
+// fakeItem returns the requested attributes of an object as an item. "*"
+// returns all user attributes and "**" adds the system attributes
+func fakeItem(name string, obj *fakeObject, attributeNames []string) v3io.Item {
+	system := map[string]interface{}{
+		"__name":        name,
+		"__size":        len(obj.Body),
+		"__mtime_secs":  int(obj.Mtime.Unix()),
+		"__mtime_nsecs": obj.Mtime.Nanosecond(),
+	}
+
+	item := v3io.Item{}
+	for _, attr := range attributeNames {
+		switch attr {
+		case "**":
+			for key, val := range system {
+				item[key] = val
+			}
+			fallthrough
+		case "*":
+			for key, val := range obj.Attrs {
+				item[key] = val
+			}
+			item["__name"] = name
+		default:
+			if val, ok := obj.Attrs[attr]; ok {
+				item[attr] = val
+			} else if val, ok := system[attr]; ok {
+				item[attr] = val
+			}
+		}
+	}
+
+	return item
+}
+
+// setFakeAttrs sets attributes, normalizing values to the types v3io returns
+func setFakeAttrs(dest map[string]interface{}, attrs map[string]interface{}) error {
+	for name, val := range attrs {
+		switch typed := val.(type) {
+		case int64:
+			val = int(typed)
+		case int32:
+			val = int(typed)
+		case uint64:
+			val = int(typed)
+		case float32:
+			val = float64(typed)
+		case int, float64, string, []byte, bool, time.Time:
+		default:
+			return fakeError(http.StatusBadRequest, "%s: unsupported attribute type %T", name, val)
+		}
+		dest[name] = val
+	}
+
+	return nil
+}
+
+func fakeInSegment(name string, segment, totalSegments int) bool {
+	if totalSegments <= 1 {
+		return true
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(name))
+	return int(hash.Sum32()%uint32(totalSegments)) == segment
+}
+
+// fakeInSortKeyRange checks an item named "<sharding key>.<sort key>" against
+// the sharding key and sort key range (start inclusive, end exclusive)
+func fakeInSortKeyRange(name string, input *v3io.GetItemsInput) bool {
+	prefix := input.ShardingKey + "."
+	if !strings.HasPrefix(name, prefix) {
+		return name == input.ShardingKey && input.SortKeyRangeStart == "" && input.SortKeyRangeEnd == ""
+	}
+
+	sortKey := name[len(prefix):]
+	if input.SortKeyRangeStart != "" && sortKey < input.SortKeyRangeStart {
+		return false
+	}
+	if input.SortKeyRangeEnd != "" && sortKey >= input.SortKeyRangeEnd {
+		return false
+	}
+	return true
+}
+
+var (
+	_ v3io.Context   = &FakeContext{}
+	_ v3io.Container = &FakeContainer{}
+)
@@ -0,0 +1,290 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package v3ioutils
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	v3io "github.com/v3io/v3io-go/pkg/dataplane"
+	v3ioerrors "github.com/v3io/v3io-go/pkg/errors"
+)
+
+func newFakeContainer(t *testing.T, root string) v3io.Container {
+	ctx, err := NewFakeContext(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	session, err := ctx.NewSession(&v3io.NewSessionInput{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	container, err := session.NewContainer(&v3io.NewContainerInput{ContainerName: "bigdata"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return container
+}
+
+func fakeStatusCode(err error) int {
+	if errWithStatus, ok := err.(v3ioerrors.ErrorWithStatusCode); ok {
+		return errWithStatus.StatusCode()
+	}
+	return 0
+}
+
+func getFakeItem(t *testing.T, container v3io.Container, path string) v3io.Item {
+	resp, err := container.GetItemSync(&v3io.GetItemInput{Path: path, AttributeNames: []string{"*"}})
+	if err != nil {
+		t.Fatalf("can't get %q - %s", path, err)
+	}
+	return resp.Output.(*v3io.GetItemOutput).Item
+}
+
+func TestFakeItems(t *testing.T) {
+	container := newFakeContainer(t, FakeMemoryRoot)
+
+	_, err := container.PutItemSync(&v3io.PutItemInput{
+		Path:       "/table/a",
+		Attributes: map[string]interface{}{"x": 1, "s": "hello", "f": 1.5},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item := getFakeItem(t, container, "/table/a")
+	if item["x"] != 1 || item["s"] != "hello" || item["__name"] != "a" {
+		t.Fatalf("bad item - %v", item)
+	}
+
+	expr := "x=x+2; s='bye'; arr=init_array(3,'int'); arr[1]=7; delete(f)"
+	_, err = container.UpdateItemSync(&v3io.UpdateItemInput{Path: "/table/a", Expression: &expr})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	item = getFakeItem(t, container, "/table/a")
+	if item["x"] != 3 || item["s"] != "bye" {
+		t.Fatalf("bad item after update - %v", item)
+	}
+	if _, ok := item["f"]; ok {
+		t.Fatalf("deleted attribute in item - %v", item)
+	}
+	if arr := AsInt64Array(item["arr"].([]byte)); len(arr) != 3 || arr[1] != 7 {
+		t.Fatalf("bad array value - %v", arr)
+	}
+
+	// False condition
+	expr = "x=100"
+	_, err = container.UpdateItemSync(&v3io.UpdateItemInput{Path: "/table/a", Expression: &expr, Condition: "x > 10"})
+	if err == nil || strings.Count(err.Error(), "ErrorCode") != 1 || !strings.Contains(err.Error(), "16777244") {
+		t.Fatalf("bad false condition error - %v", err)
+	}
+
+	// Create only on existing item
+	_, err = container.UpdateItemSync(&v3io.UpdateItemInput{Path: "/table/a", Expression: &expr, UpdateMode: "CreateNewItemOnly"})
+	if err == nil || strings.Count(err.Error(), "ErrorCode") != 2 || !strings.Contains(err.Error(), "369098809") {
+		t.Fatalf("bad create only error - %v", err)
+	}
+
+	_, err = container.GetItemSync(&v3io.GetItemInput{Path: "/table/nope", AttributeNames: []string{"*"}})
+	if fakeStatusCode(err) != http.StatusNotFound {
+		t.Fatalf("bad error for missing item - %v", err)
+	}
+}
+
+func TestFakeGetItems(t *testing.T) {
+	container := newFakeContainer(t, FakeMemoryRoot)
+
+	for i, name := range []string{"a", "b", "c", "d", "e"} {
+		_, err := container.PutItemSync(&v3io.PutItemInput{
+			Path:       "/table/" + name,
+			Attributes: map[string]interface{}{"i": i},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var names []string
+	marker := ""
+	for {
+		resp, err := container.GetItemsSync(&v3io.GetItemsInput{
+			Path:           "/table/",
+			AttributeNames: []string{"__name", "i"},
+			Filter:         "i >= 1 and __name != 'd'",
+			Limit:          2,
+			Marker:         marker,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		output := resp.Output.(*v3io.GetItemsOutput)
+		for _, item := range output.Items {
+			names = append(names, item["__name"].(string))
+		}
+
+		if output.Last {
+			break
+		}
+		marker = output.NextMarker
+	}
+
+	if strings.Join(names, ",") != "b,c,e" {
+		t.Fatalf("bad items - %v", names)
+	}
+}
+
+func TestFakeObjects(t *testing.T) {
+	container := newFakeContainer(t, FakeMemoryRoot)
+
+	if err := container.PutObjectSync(&v3io.PutObjectInput{Path: "/dir/obj", Body: []byte("hello")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := container.PutObjectSync(&v3io.PutObjectInput{Path: "/dir/obj", Body: []byte(" world"), Append: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := container.GetObjectSync(&v3io.GetObjectInput{Path: "/dir/obj", NumBytes: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp.Body()) != "hello" || resp.HTTPResponse.StatusCode() != http.StatusPartialContent {
+		t.Fatalf("bad partial read - %q (%d)", resp.Body(), resp.HTTPResponse.StatusCode())
+	}
+
+	resp, err = container.GetObjectSync(&v3io.GetObjectInput{Path: "/dir/obj", Offset: 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(resp.Body()) != "world" || resp.HTTPResponse.StatusCode() != http.StatusOK {
+		t.Fatalf("bad read - %q (%d)", resp.Body(), resp.HTTPResponse.StatusCode())
+	}
+
+	resp, err = container.GetContainerContentsSync(&v3io.GetContainerContentsInput{Path: "/dir/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	contents := resp.Output.(*v3io.GetContainerContentsOutput)
+	if len(contents.Contents) != 1 || contents.Contents[0].Key != "dir/obj" {
+		t.Fatalf("bad contents - %+v", contents)
+	}
+
+	err = container.DeleteObjectSync(&v3io.DeleteObjectInput{Path: "/dir/"})
+	if fakeStatusCode(err) != http.StatusConflict {
+		t.Fatalf("deleted non empty directory - %v", err)
+	}
+
+	if err := container.DeleteObjectSync(&v3io.DeleteObjectInput{Path: "/dir/obj"}); err != nil {
+		t.Fatal(err)
+	}
+	err = container.CheckPathExistsSync(&v3io.CheckPathExistsInput{Path: "/dir/obj"})
+	if fakeStatusCode(err) != http.StatusNotFound {
+		t.Fatalf("object exists after delete - %v", err)
+	}
+}
+
+func TestFakeStream(t *testing.T) {
+	container := newFakeContainer(t, FakeMemoryRoot)
+
+	err := container.CreateStreamSync(&v3io.CreateStreamInput{Path: "/stream/", ShardCount: 2, RetentionPeriodHours: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	shardID := 1
+	records := []*v3io.StreamRecord{
+		{Data: []byte("r1"), ShardID: &shardID},
+		{Data: []byte("r2"), ShardID: &shardID},
+	}
+	if _, err := container.PutRecordsSync(&v3io.PutRecordsInput{Path: "/stream/", Records: records}); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := container.SeekShardSync(&v3io.SeekShardInput{Path: "/stream/1", Type: v3io.SeekShardInputTypeEarliest})
+	if err != nil {
+		t.Fatal(err)
+	}
+	location := resp.Output.(*v3io.SeekShardOutput).Location
+
+	resp, err = container.GetRecordsSync(&v3io.GetRecordsInput{Path: "/stream/1", Location: location, Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := resp.Output.(*v3io.GetRecordsOutput)
+	if len(output.Records) != 1 || string(output.Records[0].Data) != "r1" || output.RecordsBehindLatest != 1 {
+		t.Fatalf("bad records - %+v", output)
+	}
+
+	resp, err = container.GetRecordsSync(&v3io.GetRecordsInput{Path: "/stream/1", Location: output.NextLocation})
+	if err != nil {
+		t.Fatal(err)
+	}
+	output = resp.Output.(*v3io.GetRecordsOutput)
+	if len(output.Records) != 1 || string(output.Records[0].Data) != "r2" {
+		t.Fatalf("bad records - %+v", output)
+	}
+}
+
+func TestFakeAsync(t *testing.T) {
+	container := newFakeContainer(t, FakeMemoryRoot)
+
+	responseChan := make(chan *v3io.Response, 1)
+	input := &v3io.PutItemInput{Path: "/table/a", Attributes: map[string]interface{}{"x": 1}}
+	if _, err := container.PutItem(input, "ctx", responseChan); err != nil {
+		t.Fatal(err)
+	}
+
+	resp := <-responseChan
+	if resp.Error != nil || resp.Context != "ctx" || resp.Request().Input != input {
+		t.Fatalf("bad response - %+v", resp)
+	}
+}
+
+func TestFakeDirPersistence(t *testing.T) {
+	root, err := ioutil.TempDir("", "fake-v3io")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(root)
+
+	container := newFakeContainer(t, root)
+	_, err = container.PutItemSync(&v3io.PutItemInput{
+		Path:       "/table/a",
+		Attributes: map[string]interface{}{"x": 1, "f": 2.5, "b": []byte("blob"), "ok": true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A new context over the same directory sees the same data
+	item := getFakeItem(t, newFakeContainer(t, root), "/table/a")
+	if item["x"] != 1 || item["f"] != 2.5 || string(item["b"].([]byte)) != "blob" || item["ok"] != true {
+		t.Fatalf("bad item - %v", item)
+	}
+}
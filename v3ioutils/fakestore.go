@@ -0,0 +1,400 @@
+/*
+Copyright 2018 Iguazio Systems Ltd.
+
+Licensed under the Apache License, Version 2.0 (the "License") with
+an addition restriction as set forth herein. You may not use this
+file except in compliance with the License. You may obtain a copy of
+the License at http://www.apache.org/licenses/LICENSE-2.0.
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or
+implied. See the License for the specific language governing
+permissions and limitations under the License.
+
+In addition, you may not use the software for any purposes that are
+illegal under applicable law, and the grant of the foregoing license
+under the Apache 2.0 license is conditioned upon your compliance with
+such restriction.
+*/
+
+package v3ioutils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// Name of the file holding directory attributes in a directory backed store
+	fakeDirAttrsFile = ".#fake-dir"
+)
+
+// fakeObject is a single object (file) or directory in a fake container.
+// Objects have an optional body, KV attributes and (for stream shards) records
+type fakeObject struct {
+	IsDir   bool                   `json:"dir,omitempty"`
+	Body    []byte                 `json:"body,omitempty"`
+	Attrs   map[string]interface{} `json:"-"`
+	Records []*fakeRecord          `json:"records,omitempty"`
+	Mtime   time.Time              `json:"mtime"`
+
+	// Typed attributes, used for serialization
+	TypedAttrs map[string]map[string]interface{} `json:"attrs,omitempty"`
+}
+
+type fakeRecord struct {
+	SequenceNumber uint64    `json:"seq"`
+	Data           []byte    `json:"data,omitempty"`
+	ClientInfo     []byte    `json:"clientInfo,omitempty"`
+	PartitionKey   string    `json:"partitionKey,omitempty"`
+	ArrivalTime    time.Time `json:"arrival"`
+}
+
+type fakeEntry struct {
+	name string
+	obj  *fakeObject
+}
+
+// fakeStore stores objects by their (cleaned, relative) path
+type fakeStore interface {
+	// load returns the object at path, nil if it doesn't exist
+	load(path string) (*fakeObject, error)
+	// store stores an object, creating parent directories as needed
+	store(path string, obj *fakeObject) error
+	// remove removes an object or a directory with all its content
+	remove(path string) error
+	// list returns the entries of a directory sorted by name
+	list(path string) ([]fakeEntry, error)
+}
+
+// cleanPath returns a container path in the form used by the stores ("a/b/c")
+func cleanPath(p string) string {
+	p = path.Clean("/" + p)
+	return strings.TrimPrefix(p, "/")
+}
+
+func parentPath(p string) string {
+	dir := path.Dir(p)
+	if dir == "." {
+		return ""
+	}
+	return dir
+}
+
+func copyObject(obj *fakeObject) *fakeObject {
+	if obj == nil {
+		return nil
+	}
+
+	out := *obj
+	out.Body = append([]byte(nil), obj.Body...)
+	out.Records = append([]*fakeRecord(nil), obj.Records...)
+	out.Attrs = make(map[string]interface{}, len(obj.Attrs))
+	for name, val := range obj.Attrs {
+		out.Attrs[name] = val
+	}
+	return &out
+}
+
+type memoryFakeStore struct {
+	objects map[string]*fakeObject
+}
+
+func newMemoryFakeStore() *memoryFakeStore {
+	return &memoryFakeStore{
+		objects: map[string]*fakeObject{"": {IsDir: true}},
+	}
+}
+
+func (s *memoryFakeStore) load(p string) (*fakeObject, error) {
+	return copyObject(s.objects[p]), nil
+}
+
+func (s *memoryFakeStore) store(p string, obj *fakeObject) error {
+	for dir := parentPath(p); ; dir = parentPath(dir) {
+		current, ok := s.objects[dir]
+		if ok && !current.IsDir {
+			return fmt.Errorf("%q is not a directory", dir)
+		}
+		if !ok {
+			s.objects[dir] = &fakeObject{IsDir: true, Mtime: time.Now()}
+		}
+		if dir == "" {
+			break
+		}
+	}
+
+	s.objects[p] = copyObject(obj)
+	return nil
+}
+
+func (s *memoryFakeStore) remove(p string) error {
+	prefix := p + "/"
+	for key := range s.objects {
+		if key == p || strings.HasPrefix(key, prefix) {
+			delete(s.objects, key)
+		}
+	}
+	return nil
+}
+
+func (s *memoryFakeStore) list(p string) ([]fakeEntry, error) {
+	prefix := p + "/"
+	if p == "" {
+		prefix = ""
+	}
+
+	var entries []fakeEntry
+	for key, obj := range s.objects {
+		if key == "" || !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		name := key[len(prefix):]
+		if name == "" || strings.Contains(name, "/") {
+			continue
+		}
+		entries = append(entries, fakeEntry{name: name, obj: copyObject(obj)})
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+	return entries, nil
+}
+
+// dirFakeStore keeps every object as a JSON file under a root directory, so
+// several processes (e.g. framesd and a test) can share a container
+type dirFakeStore struct {
+	root string
+}
+
+func newDirFakeStore(root string) (*dirFakeStore, error) {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, errors.Wrapf(err, "can't create fake container root %q", root)
+	}
+
+	return &dirFakeStore{root: root}, nil
+}
+
+func (s *dirFakeStore) fsPath(p string) string {
+	return filepath.Join(s.root, filepath.FromSlash(p))
+}
+
+func (s *dirFakeStore) load(p string) (*fakeObject, error) {
+	fsPath := s.fsPath(p)
+	info, err := os.Stat(fsPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		fsPath = filepath.Join(fsPath, fakeDirAttrsFile)
+		if _, err := os.Stat(fsPath); os.IsNotExist(err) {
+			return &fakeObject{IsDir: true, Mtime: info.ModTime(), Attrs: map[string]interface{}{}}, nil
+		}
+	}
+
+	data, err := ioutil.ReadFile(fsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := &fakeObject{}
+	if err := json.Unmarshal(data, obj); err != nil {
+		return nil, errors.Wrapf(err, "can't decode %q", fsPath)
+	}
+
+	obj.Attrs, err = decodeFakeAttrs(obj.TypedAttrs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "can't decode %q attributes", fsPath)
+	}
+	obj.TypedAttrs = nil
+	obj.IsDir = info.IsDir()
+
+	return obj, nil
+}
+
+func (s *dirFakeStore) store(p string, obj *fakeObject) error {
+	fsPath := s.fsPath(p)
+	if obj.IsDir {
+		if err := os.MkdirAll(fsPath, 0755); err != nil {
+			return err
+		}
+		fsPath = filepath.Join(fsPath, fakeDirAttrsFile)
+	} else if err := os.MkdirAll(filepath.Dir(fsPath), 0755); err != nil {
+		return err
+	}
+
+	typedAttrs, err := encodeFakeAttrs(obj.Attrs)
+	if err != nil {
+		return err
+	}
+
+	out := *obj
+	out.TypedAttrs = typedAttrs
+	data, err := json.Marshal(&out)
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file and rename so readers never see partial files
+	tmp, err := ioutil.TempFile(filepath.Dir(fsPath), ".#fake-tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+
+	return os.Rename(tmp.Name(), fsPath)
+}
+
+func (s *dirFakeStore) remove(p string) error {
+	if p == "" {
+		return fmt.Errorf("can't remove container root")
+	}
+
+	return os.RemoveAll(s.fsPath(p))
+}
+
+func (s *dirFakeStore) list(p string) ([]fakeEntry, error) {
+	infos, err := ioutil.ReadDir(s.fsPath(p))
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []fakeEntry
+	for _, info := range infos {
+		if strings.HasPrefix(info.Name(), ".#fake-") {
+			continue
+		}
+
+		obj, err := s.load(path.Join(p, info.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if obj == nil { // Removed while listing
+			continue
+		}
+		entries = append(entries, fakeEntry{name: info.Name(), obj: obj})
+	}
+
+	return entries, nil
+}
+
+// encodeFakeAttrs encodes attributes to JSON friendly typed values, in the
+// same format v3io uses ({"N": "1"}, {"S": "a"} ...)
+func encodeFakeAttrs(attrs map[string]interface{}) (map[string]map[string]interface{}, error) {
+	if len(attrs) == 0 {
+		return nil, nil
+	}
+
+	typed := make(map[string]map[string]interface{}, len(attrs))
+	for name, val := range attrs {
+		switch val := val.(type) {
+		case int:
+			typed[name] = map[string]interface{}{"N": strconv.Itoa(val)}
+		case float64:
+			typed[name] = map[string]interface{}{"N": strconv.FormatFloat(val, 'E', -1, 64)}
+		case string:
+			typed[name] = map[string]interface{}{"S": val}
+		case []byte:
+			typed[name] = map[string]interface{}{"B": val}
+		case bool:
+			typed[name] = map[string]interface{}{"BOOL": val}
+		case time.Time:
+			typed[name] = map[string]interface{}{"TS": fmt.Sprintf("%d:%d", val.Unix(), val.Nanosecond())}
+		default:
+			return nil, fmt.Errorf("%s: unsupported attribute type - %T", name, val)
+		}
+	}
+
+	return typed, nil
+}
+
+func decodeFakeAttrs(typed map[string]map[string]interface{}) (map[string]interface{}, error) {
+	attrs := make(map[string]interface{}, len(typed))
+	for name, typedVal := range typed {
+		for typ, val := range typedVal {
+			str, _ := val.(string)
+			switch typ {
+			case "N":
+				if i, err := strconv.Atoi(str); err == nil {
+					attrs[name] = i
+					continue
+				}
+				f, err := strconv.ParseFloat(str, 64)
+				if err != nil {
+					return nil, errors.Wrapf(err, "%s: bad number", name)
+				}
+				attrs[name] = f
+			case "S":
+				attrs[name] = str
+			case "B":
+				// encoding/json encodes []byte as a base64 string
+				data, err := base64.StdEncoding.DecodeString(str)
+				if err != nil {
+					return nil, errors.Wrapf(err, "%s: bad blob", name)
+				}
+				attrs[name] = data
+			case "BOOL":
+				b, ok := val.(bool)
+				if !ok {
+					return nil, fmt.Errorf("%s: bad bool - %v", name, val)
+				}
+				attrs[name] = b
+			case "TS":
+				t, err := parseFakeTimestamp(str)
+				if err != nil {
+					return nil, errors.Wrapf(err, "%s: bad timestamp", name)
+				}
+				attrs[name] = t
+			default:
+				return nil, fmt.Errorf("%s: unknown type - %q", name, typ)
+			}
+		}
+	}
+
+	return attrs, nil
+}
+
+// parseFakeTimestamp parses v3io "seconds:nanoseconds" timestamps
+func parseFakeTimestamp(str string) (time.Time, error) {
+	parts := strings.Split(str, ":")
+	if len(parts) != 2 {
+		return time.Time{}, fmt.Errorf("bad timestamp - %q", str)
+	}
+
+	secs, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	nsecs, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(secs, nsecs), nil
+}
@@ -28,6 +28,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/v3io/frames"
+	"github.com/v3io/frames/pb"
 	v3io "github.com/v3io/v3io-go/pkg/dataplane"
 )
 
@@ -38,6 +39,12 @@ const (
 	TimeType   = "timestamp"
 	BoolType   = "boolean"
 
+	IntType     = "int"
+	FloatType   = "float"
+	BlobType    = "blob"
+	DecimalType = "decimal"
+	ListType    = "list"
+
 	DefaultKeyColumn = "idx"
 )
 
@@ -72,18 +79,47 @@ type OldV3ioSchema struct {
 	HashingBucketNum int              `json:"hashingBucketNum"`
 }
 
-// OldSchemaField is OldV3ioSchema field
+// OldSchemaField is OldV3ioSchema field. Type is one of the types other v3io
+// tools know (long, double, string, timestamp or boolean), narrower frames
+// types (e.g. int) are kept in Dtype
 type OldSchemaField struct {
 	Name     string `json:"name"`
 	Type     string `json:"type"`
 	Nullable bool   `json:"nullable"`
+	Dtype    string `json:"dtype,omitempty"`
+}
+
+// storedTypes are the schema types of the frames types other v3io tools don't
+// know
+var storedTypes = map[string]string{
+	IntType:     LongType,
+	FloatType:   DoubleType,
+	BlobType:    StringType,
+	DecimalType: StringType,
+}
+
+// newSchemaField returns a field of a frames type
+func newSchemaField(name string, typ string, nullable bool) OldSchemaField {
+	field := OldSchemaField{Name: name, Type: typ, Nullable: nullable}
+	if stored, ok := storedTypes[typ]; ok {
+		field.Type, field.Dtype = stored, typ
+	}
+	return field
+}
+
+// FramesType returns the frames type of the field (e.g. "int" for a "long"
+// field with an "int" dtype)
+func (f OldSchemaField) FramesType() string {
+	if f.Dtype != "" {
+		return f.Dtype
+	}
+	return f.Type
 }
 
 // AddColumn adds a column
 func (s *OldV3ioSchema) AddColumn(name string, col frames.Column, nullable bool) error {
 	if col.DType() != frames.NullType {
-		field := OldSchemaField{Name: name, Type: ConvertDTypeToString(col.DType()), Nullable: nullable}
-		s.Fields = append(s.Fields, field)
+		s.Fields = append(s.Fields, newSchemaField(name, ConvertDTypeToString(col.DType()), nullable))
 	}
 	return nil
 }
@@ -92,10 +128,18 @@ func (s *OldV3ioSchema) AddColumn(name string, col frames.Column, nullable bool)
 func (s *OldV3ioSchema) AddField(name string, val interface{}, nullable bool) error {
 	var ftype string
 	switch val.(type) {
-	case int, int32, int64:
+	case int, int64:
 		ftype = LongType
-	case float32, float64:
+	case float64:
 		ftype = DoubleType
+	case int32:
+		ftype = IntType
+	case float32:
+		ftype = FloatType
+	case []byte:
+		ftype = BlobType
+	case frames.Decimal:
+		ftype = DecimalType
 	case string:
 		ftype = StringType
 	case time.Time:
@@ -104,8 +148,7 @@ func (s *OldV3ioSchema) AddField(name string, val interface{}, nullable bool) er
 		ftype = BoolType
 	}
 
-	field := OldSchemaField{Name: name, Type: ftype, Nullable: nullable}
-	s.Fields = append(s.Fields, field)
+	s.Fields = append(s.Fields, newSchemaField(name, ftype, nullable))
 	return nil
 }
 
@@ -118,6 +161,31 @@ func (s *OldV3ioSchema) GetField(name string) (OldSchemaField, error) {
 	return OldSchemaField{}, fmt.Errorf("no field named %v ", name)
 }
 
+// TableSchema returns the schema as a frames.TableSchema, nullable is kept as
+// a field property
+func (s *OldV3ioSchema) TableSchema() *frames.TableSchema {
+	schema := &frames.TableSchema{Key: &frames.SchemaKey{}}
+	for _, f := range s.Fields {
+		field := &frames.SchemaField{
+			Name: f.Name,
+			Type: f.FramesType(),
+			Properties: map[string]*pb.Value{
+				"nullable": {Value: &pb.Value_Bval{Bval: f.Nullable}},
+			},
+		}
+		schema.Fields = append(schema.Fields, field)
+	}
+
+	if s.Key != "" {
+		schema.Key.ShardingKey = []string{s.Key}
+	}
+	if s.SortingKey != "" {
+		schema.Key.SortingKey = []string{s.SortingKey}
+	}
+
+	return schema
+}
+
 // toJSON retrun JSON representation of schema
 func (s *OldV3ioSchema) toJSON() ([]byte, error) {
 	return json.Marshal(s)
@@ -137,15 +205,16 @@ func (s *OldV3ioSchema) merge(new *OldV3ioSchema) (bool, error) {
 		if index < 0 {
 			s.Fields = append(s.Fields, field)
 			changed = true
-		} else if field.Type != s.Fields[index].Type {
-			if s.Fields[index].Type == DoubleType && field.Type == LongType {
-				continue
-			} else if s.Fields[index].Type == LongType && field.Type == DoubleType {
-				s.Fields[index].Type = DoubleType
-				changed = true
-			} else {
+		} else if oldType, newType := s.Fields[index].FramesType(), field.FramesType(); oldType != newType {
+			typ, ok := widerType(oldType, newType)
+			if !ok {
 				return changed, fmt.Errorf(
-					"schema change for column %v from type %s to %s is not allowed", field.Name, s.Fields[index].Type, field.Type)
+					"schema change for column %v from type %s to %s is not allowed", field.Name, oldType, newType)
+			}
+
+			if typ != oldType {
+				s.Fields[index] = newSchemaField(field.Name, typ, s.Fields[index].Nullable)
+				changed = true
 			}
 		}
 	}
@@ -181,6 +250,23 @@ func (s *OldV3ioSchema) merge(new *OldV3ioSchema) (bool, error) {
 	return changed, nil
 }
 
+// widerType returns the type that holds values of numeric types a and b. int
+// widens to long, float to double and mixed ints and floats to double
+func widerType(a string, b string) (string, bool) {
+	isInt := map[string]bool{IntType: true, LongType: true}
+	isFloat := map[string]bool{FloatType: true, DoubleType: true}
+
+	switch {
+	case isInt[a] && isInt[b]:
+		return LongType, true
+	case isFloat[a] && isFloat[b]:
+		return DoubleType, true
+	case (isInt[a] || isFloat[a]) && (isInt[b] || isFloat[b]):
+		return DoubleType, true
+	}
+	return "", false
+}
+
 // UpdateSchema updates the schema
 func (s *OldV3ioSchema) UpdateSchema(container v3io.Container, tablePath string, newSchema V3ioSchema) error {
 	changed, err := s.merge(newSchema.(*OldV3ioSchema))
@@ -218,10 +304,47 @@ func ConvertDTypeToString(dType frames.DType) string {
 		return TimeType
 	case frames.BoolType:
 		return BoolType
+	case frames.Int32Type:
+		return IntType
+	case frames.Float32Type:
+		return FloatType
+	case frames.BytesType:
+		return BlobType
+	case frames.DecimalType:
+		return DecimalType
+	case frames.ListType:
+		return ListType
 	}
 	return ""
 }
 
+// ConvertStringToDType converts a schema type name to a frames.DType
+func ConvertStringToDType(typ string) (frames.DType, error) {
+	switch typ {
+	case LongType:
+		return frames.IntType, nil
+	case DoubleType:
+		return frames.FloatType, nil
+	case StringType:
+		return frames.StringType, nil
+	case TimeType:
+		return frames.TimeType, nil
+	case BoolType:
+		return frames.BoolType, nil
+	case IntType:
+		return frames.Int32Type, nil
+	case FloatType:
+		return frames.Float32Type, nil
+	case BlobType:
+		return frames.BytesType, nil
+	case DecimalType:
+		return frames.DecimalType, nil
+	case ListType:
+		return frames.ListType, nil
+	}
+	return 0, fmt.Errorf("unknown schema type - %q", typ)
+}
+
 func ContainsField(fields []OldSchemaField, fieldName string) (bool, OldSchemaField) {
 	for _, f := range fields {
 		if f.Name == fieldName {
@@ -23,6 +23,8 @@ package v3ioutils
 import (
 	"reflect"
 	"testing"
+
+	"github.com/v3io/frames"
 )
 
 const schemaTst = `
@@ -158,3 +160,63 @@ func TestMerge(t *testing.T) {
 		t.Fatal("merge with self should not cause any modifications to schema")
 	}
 }
+
+func TestNarrowTypes(t *testing.T) {
+	schema := NewSchema("idx", "").(*OldV3ioSchema)
+	columns := []struct {
+		name string
+		data interface{}
+	}{
+		{"i32", []int32{1}},
+		{"f32", []float32{1}},
+		{"d", []frames.Decimal{{Value: 1, Scale: 1}}},
+	}
+	for _, c := range columns {
+		col, err := frames.NewSliceColumn(c.name, c.data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := schema.AddColumn(c.name, col, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := schema.AddField("blob", []byte("x"), true); err != nil {
+		t.Fatal(err)
+	}
+
+	// Other tools read the schema with the types they know
+	expected := []OldSchemaField{
+		{Name: "i32", Type: LongType, Nullable: true, Dtype: IntType},
+		{Name: "f32", Type: DoubleType, Nullable: true, Dtype: FloatType},
+		{Name: "d", Type: StringType, Nullable: true, Dtype: DecimalType},
+		{Name: "blob", Type: StringType, Nullable: true, Dtype: BlobType},
+	}
+	if !reflect.DeepEqual(schema.Fields, expected) {
+		t.Fatalf("bad fields - %+v", schema.Fields)
+	}
+
+	tableSchema := schema.TableSchema()
+	for i, field := range tableSchema.Fields {
+		if field.Type != expected[i].Dtype {
+			t.Fatalf("%s: bad type %q", field.Name, field.Type)
+		}
+	}
+
+	wider := OldV3ioSchema{Fields: []OldSchemaField{
+		{Name: "i32", Type: LongType, Nullable: true},
+		{Name: "f32", Type: DoubleType, Nullable: true, Dtype: FloatType},
+	}}
+	changed, err := schema.merge(&wider)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !changed {
+		t.Fatal("merge with wider type not reported")
+	}
+	if field := schema.Fields[0]; field.Type != LongType || field.Dtype != "" {
+		t.Fatalf("bad merged field - %+v", field)
+	}
+	if field := schema.Fields[1]; field.Type != DoubleType || field.Dtype != FloatType {
+		t.Fatalf("bad unchanged field - %+v", field)
+	}
+}